@@ -0,0 +1,146 @@
+package inboundgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+// ABTestTag and ABVariantTag are the tags an A/B test's emails carry: the
+// test's name and the variant the recipient got
+const (
+	ABTestTag    = "ab_test"
+	ABVariantTag = "ab_variant"
+)
+
+// ABVariant is one version of an A/B tested email. Empty fields keep the
+// test email's.
+type ABVariant struct {
+	// Name identifies the variant in its tag and the stats, e.g. "A".
+	Name    string
+	Subject string
+	Text    *string
+	HTML    *string
+}
+
+// ABTest sends variants of an email to a split recipient list, to compare
+// how they perform
+type ABTest struct {
+	// Name identifies the test in its tag, e.g. "spring-launch-subject".
+	Name string
+	// Email is what the variants share, such as From and Tags. Its To is
+	// ignored.
+	Email PostEmailsRequest
+	// Variants are the versions to compare, at least two.
+	Variants []ABVariant
+}
+
+// ABTestResult is the outcome of sending an A/B test
+type ABTestResult struct {
+	*BulkResult
+	// Variants is the variant each recipient got, in the order of the
+	// recipients.
+	Variants []string
+}
+
+// EmailIDs returns the IDs of the emails sent, by variant, to save for
+// ABTestStats once events have come in
+func (r *ABTestResult) EmailIDs() map[string][]string {
+	ids := make(map[string][]string)
+	for i, result := range r.Results {
+		if result.Response != nil && result.Response.ID != "" {
+			ids[r.Variants[i]] = append(ids[r.Variants[i]], result.Response.ID)
+		}
+	}
+	return ids
+}
+
+// Variant returns the variant recipient gets. The split is by a hash of the
+// test name and address, so it is even over a large list, independent of
+// the list's order, and the same on every run.
+func (t *ABTest) Variant(recipient string) *ABVariant {
+	addr := strings.ToLower(strings.TrimSpace(recipient))
+	if local, domain, ok := splitAddress(recipient); ok {
+		addr = local + "@" + domain
+	}
+	h := fnv.New32a()
+	h.Write([]byte(t.Name + "\x00" + addr))
+	return &t.Variants[h.Sum32()%uint32(len(t.Variants))]
+}
+
+// validate checks the test can be sent
+func (t *ABTest) validate() error {
+	if t.Name == "" {
+		return errors.New("A/B test name is required")
+	}
+	if len(t.Variants) < 2 {
+		return errors.New("A/B test needs at least two variants")
+	}
+	names := make(map[string]bool, len(t.Variants))
+	for _, v := range t.Variants {
+		if v.Name == "" {
+			return errors.New("A/B test variant name is required")
+		}
+		if names[v.Name] {
+			return fmt.Errorf("A/B test variant %q is repeated", v.Name)
+		}
+		names[v.Name] = true
+	}
+	return nil
+}
+
+// SendABTest splits recipients between the test's variants and sends each
+// their variant through BulkSend, tagged with ABTestTag and ABVariantTag.
+// Since the split does not change between runs, a test stopped part way
+// resumes like any bulk send, with the same RunID and Store.
+func (c *Inbound) SendABTest(ctx context.Context, test *ABTest, recipients []string, opts BulkOptions) (*ABTestResult, error) {
+	if err := test.validate(); err != nil {
+		return nil, err
+	}
+	reqs := make([]*PostEmailsRequest, len(recipients))
+	variants := make([]string, len(recipients))
+	for i, to := range recipients {
+		v := test.Variant(to)
+		req := test.Email
+		req.To = to
+		if v.Subject != "" {
+			req.Subject = v.Subject
+		}
+		if v.Text != nil {
+			req.Text = v.Text
+		}
+		if v.HTML != nil {
+			req.HTML = v.HTML
+		}
+		req.Tags = make([]EmailTag, 0, len(test.Email.Tags)+2)
+		for _, tag := range test.Email.Tags {
+			if tag.Name != ABTestTag && tag.Name != ABVariantTag {
+				req.Tags = append(req.Tags, tag)
+			}
+		}
+		req.Tags = append(req.Tags, EmailTag{Name: ABTestTag, Value: test.Name}, EmailTag{Name: ABVariantTag, Value: v.Name})
+		reqs[i] = &req
+		variants[i] = v.Name
+	}
+	result, err := c.BulkSend(ctx, reqs, opts)
+	if result == nil {
+		return nil, err
+	}
+	return &ABTestResult{BulkResult: result, Variants: variants}, err
+}
+
+// ABTestStats counts the events of an A/B test's emails by variant, from
+// the IDs saved from ABTestResult.EmailIDs
+func (s *EmailService) ABTestStats(ctx context.Context, emailIDs map[string][]string) (map[string]*EmailStats, error) {
+	stats := make(map[string]*EmailStats, len(emailIDs))
+	for variant, ids := range emailIDs {
+		variantStats, err := s.Stats(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get stats of variant %s: %w", variant, err)
+		}
+		stats[variant] = variantStats
+	}
+	return stats, nil
+}
@@ -0,0 +1,89 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestABTest(t *testing.T) {
+	var mu sync.Mutex
+	var sent []inboundgo.PostEmailsRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			// Every third email opened, variant B's clicked as well
+			var n int
+			fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/emails/email-"), "%d", &n)
+			events := `{"type":"delivered","timestamp":"2025-01-16T10:00:00Z"}`
+			if n%3 == 0 {
+				events += `,{"type":"opened","timestamp":"2025-01-16T10:05:00Z"},{"type":"opened","timestamp":"2025-01-16T11:00:00Z"}`
+			}
+			if sent[n].Subject == "B" {
+				events += `,{"type":"clicked","timestamp":"2025-01-16T10:06:00Z"}`
+			}
+			w.Write([]byte(`{"email_id":"x","events":[` + events + `]}`))
+			return
+		}
+		var req inboundgo.PostEmailsRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		id := len(sent)
+		sent = append(sent, req)
+		mu.Unlock()
+		fmt.Fprintf(w, `{"id":"email-%d"}`, id)
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+
+	test := &inboundgo.ABTest{
+		Name:  "launch-subject",
+		Email: inboundgo.PostEmailsRequest{From: "news@example.com", Subject: "A", Text: inboundgo.String("Hello"), Tags: []inboundgo.EmailTag{{Name: "campaign", Value: "launch"}}},
+		Variants: []inboundgo.ABVariant{
+			{Name: "A"},
+			{Name: "B", Subject: "B"},
+		},
+	}
+	recipients := make([]string, 200)
+	for i := range recipients {
+		recipients[i] = fmt.Sprintf("user%d@example.com", i)
+	}
+	result, err := client.SendABTest(context.Background(), test, recipients, inboundgo.BulkOptions{Concurrency: 1})
+	if err != nil {
+		t.Fatalf("SendABTest failed: %v", err)
+	}
+	ids := result.EmailIDs()
+	if len(ids["A"]) < 70 || len(ids["B"]) < 70 || len(ids["A"])+len(ids["B"]) != 200 {
+		t.Errorf("Expected an even split, got %d and %d", len(ids["A"]), len(ids["B"]))
+	}
+	for i, req := range sent {
+		if len(req.Tags) != 3 || req.Tags[0].Name != "campaign" || req.Tags[2] != (inboundgo.EmailTag{Name: inboundgo.ABVariantTag, Value: req.Subject}) {
+			t.Fatalf("Unexpected tags on email %d: %v", i, req.Tags)
+		}
+	}
+	if v := test.Variant("USER7@Example.com").Name; v != result.Variants[7] {
+		t.Errorf("Expected the split to ignore case, got %s and %s", v, result.Variants[7])
+	}
+
+	stats, err := client.Email().ABTestStats(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("ABTestStats failed: %v", err)
+	}
+	a, b := stats["A"], stats["B"]
+	if a.Emails != len(ids["A"]) || a.Delivered != a.Emails || a.Clicked != 0 || b.Clicked != b.Emails {
+		t.Errorf("Unexpected stats %+v %+v", a, b)
+	}
+	if a.Opened+b.Opened != 67 || a.OpenRate() <= 0.2 || a.OpenRate() >= 0.5 {
+		t.Errorf("Expected each open counted once, got %+v %+v", a, b)
+	}
+
+	if _, err := client.SendABTest(context.Background(), &inboundgo.ABTest{Name: "x", Variants: []inboundgo.ABVariant{{Name: "A"}}}, recipients, inboundgo.BulkOptions{}); err == nil {
+		t.Error("Expected a test with one variant to be refused")
+	}
+}
@@ -0,0 +1,179 @@
+package inboundgo
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/mail"
+	"strings"
+	"sync"
+)
+
+// builtinDisposableDomains is a small seed list of widely used disposable
+// mail providers. Load a maintained list with LoadDisposableDomains or
+// UpdateFrom for production use.
+var builtinDisposableDomains = []string{
+	"10minutemail.com", "20minutemail.com", "33mail.com", "dispostable.com",
+	"emailondeck.com", "fakeinbox.com", "getairmail.com", "getnada.com",
+	"guerrillamail.com", "guerrillamail.net", "guerrillamailblock.com",
+	"maildrop.cc", "mailinator.com", "mailnesia.com", "mintemail.com",
+	"mohmal.com", "mytemp.email", "sharklasers.com", "spamgourmet.com",
+	"temp-mail.org", "tempail.com", "tempmail.com", "tempr.email",
+	"throwawaymail.com", "trashmail.com", "yopmail.com",
+}
+
+// builtinRoleAccounts are local parts that usually reach a team or system
+// rather than a person
+var builtinRoleAccounts = []string{
+	"abuse", "accounting", "admin", "administrator", "billing", "careers",
+	"contact", "customerservice", "devnull", "enquiries", "feedback", "finance",
+	"hello", "help", "helpdesk", "hostmaster", "hr", "info", "inquiries", "jobs",
+	"legal", "mail", "mailer-daemon", "marketing", "media", "newsletter",
+	"no-reply", "noc", "noreply", "office", "orders", "postmaster", "press",
+	"privacy", "root", "sales", "security", "support", "team", "webmaster",
+}
+
+// AddressClassifier flags disposable and role addresses locally, so bulk
+// workflows can filter recipients without a network call per address. It
+// is safe for concurrent use, and its datasets can be extended at runtime.
+type AddressClassifier struct {
+	mu         sync.RWMutex
+	disposable map[string]bool
+	roles      map[string]bool
+}
+
+// NewAddressClassifier creates a classifier seeded with the built-in
+// datasets
+func NewAddressClassifier() *AddressClassifier {
+	c := &AddressClassifier{
+		disposable: make(map[string]bool),
+		roles:      make(map[string]bool),
+	}
+	c.AddDisposableDomains(builtinDisposableDomains...)
+	c.AddRoleAccounts(builtinRoleAccounts...)
+	return c
+}
+
+// DefaultAddressClassifier backs the package-level IsDisposable and
+// IsRoleAccount functions
+var DefaultAddressClassifier = NewAddressClassifier()
+
+// IsDisposable reports whether address belongs to a known disposable mail
+// provider, using DefaultAddressClassifier
+func IsDisposable(address string) bool {
+	return DefaultAddressClassifier.IsDisposable(address)
+}
+
+// IsRoleAccount reports whether address is a role account such as
+// support@ or noreply@, using DefaultAddressClassifier
+func IsRoleAccount(address string) bool {
+	return DefaultAddressClassifier.IsRoleAccount(address)
+}
+
+// IsDisposable reports whether address, or any parent of its domain, is a
+// known disposable mail provider
+func (c *AddressClassifier) IsDisposable(address string) bool {
+	_, domain, ok := splitAddress(address)
+	if !ok {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for {
+		if c.disposable[domain] {
+			return true
+		}
+		_, parent, found := strings.Cut(domain, ".")
+		if !found || !strings.Contains(parent, ".") {
+			return false
+		}
+		domain = parent
+	}
+}
+
+// IsRoleAccount reports whether the local part of address, ignoring any
+// +tag, is a role account
+func (c *AddressClassifier) IsRoleAccount(address string) bool {
+	local, _, ok := splitAddress(address)
+	if !ok {
+		return false
+	}
+	local, _, _ = strings.Cut(local, "+")
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.roles[local]
+}
+
+// AddDisposableDomains adds domains to the disposable dataset
+func (c *AddressClassifier) AddDisposableDomains(domains ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, d := range domains {
+		if d = strings.ToLower(strings.TrimSpace(d)); d != "" {
+			c.disposable[strings.TrimSuffix(d, ".")] = true
+		}
+	}
+}
+
+// AddRoleAccounts adds local parts to the role account dataset
+func (c *AddressClassifier) AddRoleAccounts(names ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, n := range names {
+		if n = strings.ToLower(strings.TrimSpace(n)); n != "" {
+			c.roles[n] = true
+		}
+	}
+}
+
+// LoadDisposableDomains adds domains read one per line from r, the format
+// used by community-maintained blocklists. Blank lines and lines starting
+// with # are ignored.
+func (c *AddressClassifier) LoadDisposableDomains(r io.Reader) error {
+	var domains []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains = append(domains, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read disposable domains: %w", err)
+	}
+	c.AddDisposableDomains(domains...)
+	return nil
+}
+
+// UpdateFrom merges the datasets maintained by the API into the
+// classifier. Call it periodically to pick up newly seen providers.
+func (c *AddressClassifier) UpdateFrom(ctx context.Context, verify *VerifyService) error {
+	resp, err := verify.Datasets(ctx)
+	if err != nil {
+		return err
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("failed to fetch address datasets: %s", resp.Error)
+	}
+	if resp.Data != nil {
+		c.AddDisposableDomains(resp.Data.DisposableDomains...)
+		c.AddRoleAccounts(resp.Data.RoleAccounts...)
+	}
+	return nil
+}
+
+// splitAddress returns the lowercased local part and domain of an address,
+// which may include a display name
+func splitAddress(address string) (local, domain string, ok bool) {
+	address = strings.TrimSpace(address)
+	if parsed, err := mail.ParseAddress(address); err == nil {
+		address = parsed.Address
+	}
+	at := strings.LastIndexByte(address, '@')
+	if at <= 0 || at == len(address)-1 {
+		return "", "", false
+	}
+	return strings.ToLower(address[:at]), strings.TrimSuffix(strings.ToLower(address[at+1:]), "."), true
+}
@@ -0,0 +1,82 @@
+package inboundgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestIsDisposable(t *testing.T) {
+	tests := map[string]bool{
+		"someone@mailinator.com":          true,
+		"Someone <x@MAILINATOR.com>":      true,
+		"x@eu.mailinator.com":             true,
+		"x@gmail.com":                     false,
+		"x@notmailinator.com":             false,
+		"not-an-address":                  false,
+		"trailing@yopmail.com.":           true,
+		"role+tag@guerrillamailblock.com": true,
+	}
+	for address, want := range tests {
+		if got := inboundgo.IsDisposable(address); got != want {
+			t.Errorf("IsDisposable(%q) = %v, want %v", address, got, want)
+		}
+	}
+}
+
+func TestIsRoleAccount(t *testing.T) {
+	tests := map[string]bool{
+		"support@example.com":          true,
+		"NoReply@example.com":          true,
+		"support+eu@example.com":       true,
+		"Help Desk <help@example.com>": true,
+		"jane@example.com":             false,
+		"supporter@example.com":        false,
+		"@example.com":                 false,
+	}
+	for address, want := range tests {
+		if got := inboundgo.IsRoleAccount(address); got != want {
+			t.Errorf("IsRoleAccount(%q) = %v, want %v", address, got, want)
+		}
+	}
+}
+
+func TestAddressClassifierDatasets(t *testing.T) {
+	c := inboundgo.NewAddressClassifier()
+	if err := c.LoadDisposableDomains(strings.NewReader("# list\n\nburner.example\n")); err != nil {
+		t.Fatalf("LoadDisposableDomains failed: %v", err)
+	}
+	if !c.IsDisposable("a@burner.example") {
+		t.Error("Expected loaded domain to be disposable")
+	}
+	if inboundgo.IsDisposable("a@burner.example") {
+		t.Error("Expected default classifier to be unaffected")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/verify/datasets" {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"disposableDomains": ["newburner.example"], "roleAccounts": ["ops"], "updatedAt": "2025-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if err := c.UpdateFrom(context.Background(), client.Verify()); err != nil {
+		t.Fatalf("UpdateFrom failed: %v", err)
+	}
+	if !c.IsDisposable("a@newburner.example") || !c.IsRoleAccount("ops@example.com") {
+		t.Error("Expected API datasets to be merged")
+	}
+	if !c.IsDisposable("a@burner.example") {
+		t.Error("Expected existing datasets to be kept")
+	}
+}
@@ -0,0 +1,157 @@
+package inboundgo
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// emailPattern finds addresses, and Message-IDs, embedded in free text
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9.!#$%&'*+/=?^_{|}~-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)+`)
+
+// Anonymizer scrubs personal data from webhook payloads, API responses,
+// and fixtures saved by WebhookRecorder, so they can be attached to bug
+// reports or committed as test data. Replacements are deterministic: the
+// same address always maps to the same placeholder, so threads, reply-all
+// recipients, and sender domains stay consistent across files.
+//
+// Addresses and display names are replaced wherever they appear, bodies
+// are replaced with a placeholder noting their length, and attachment
+// filenames (keeping the extension) and URLs are replaced. Subjects,
+// timestamps, IDs, and verdicts are kept.
+type Anonymizer struct {
+	// Salt keys the replacements. Without one, anyone can confirm a
+	// guessed address by anonymizing it; set a secret salt, and reuse it
+	// to keep replacements stable between runs.
+	Salt string
+	// KeepDomains are domains, such as your own, whose addresses are not
+	// replaced.
+	KeepDomains []string
+}
+
+// AnonymizeJSON scrubs data with an unsalted Anonymizer
+func AnonymizeJSON(data []byte) ([]byte, error) {
+	return (&Anonymizer{}).JSON(data)
+}
+
+// JSON returns data with personal data replaced, indented with two spaces
+func (a *Anonymizer) JSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	out, err := json.MarshalIndent(a.value("", v, nil), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// value scrubs v, the value of key in parent
+func (a *Anonymizer) value(key string, v any, parent map[string]any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, child := range v {
+			out[k] = a.value(k, child, v)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, child := range v {
+			out[i] = a.value(key, child, parent)
+		}
+		return out
+	case string:
+		return a.field(key, v, parent)
+	}
+	return v
+}
+
+// field scrubs a string field
+func (a *Anonymizer) field(key, s string, parent map[string]any) string {
+	if s == "" {
+		return s
+	}
+	_, isAddress := parent["address"]
+	_, isGroup := parent["addresses"]
+	switch lower := strings.ToLower(key); {
+	case lower == "name" && isAddress, lower == "firstname", lower == "lastname", lower == "fullname", lower == "displayname":
+		return a.Name(s)
+	case lower == "text" && isGroup:
+		return a.Text(s)
+	case lower == "text" || lower == "textbody" || lower == "body":
+		return fmt.Sprintf("[text body removed, %d characters]", len(s))
+	case lower == "html" || lower == "htmlbody":
+		return fmt.Sprintf("<p>[HTML body removed, %d characters]</p>", len(s))
+	case lower == "raw" || lower == "content":
+		return fmt.Sprintf("[content removed, %d bytes]", len(s))
+	case lower == "filename":
+		return "attachment-" + a.hash("file", s, 6) + path.Ext(s)
+	case strings.HasSuffix(lower, "url") && (strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://")):
+		return "https://files.example.com/" + a.hash("url", s, 12)
+	}
+	return a.Text(s)
+}
+
+// Address returns the replacement for an email address
+func (a *Anonymizer) Address(address string) string {
+	local, domain, ok := splitAddress(address)
+	if !ok {
+		return address
+	}
+	for _, keep := range a.KeepDomains {
+		if strings.EqualFold(strings.TrimSuffix(keep, "."), domain) {
+			return address
+		}
+	}
+	return "user-" + a.hash("address", local+"@"+domain, 8) + "@" + a.domain(domain)
+}
+
+// domain returns the replacement for a domain, under the reserved
+// .example TLD
+func (a *Anonymizer) domain(domain string) string {
+	return "domain-" + a.hash("domain", domain, 6) + ".example"
+}
+
+// Name returns the replacement for a display name
+func (a *Anonymizer) Name(name string) string {
+	return "Person " + a.hash("name", strings.ToLower(strings.TrimSpace(name)), 6)
+}
+
+// Text replaces the addresses and display names in s: an address list such
+// as a From header is rebuilt, and addresses in other text, including
+// Message-IDs, are replaced where they appear
+func (a *Anonymizer) Text(s string) string {
+	// Message-IDs parse as addresses but must keep their brackets
+	if strings.Contains(s, "@") && !strings.HasPrefix(strings.TrimSpace(s), "<") {
+		if list, err := mail.ParseAddressList(s); err == nil {
+			parts := make([]string, len(list))
+			for i, addr := range list {
+				name := addr.Name
+				if name != "" {
+					name = a.Name(name)
+				}
+				parts[i] = formatAddress(name, a.Address(addr.Address))
+			}
+			return strings.Join(parts, ", ")
+		}
+	}
+	return emailPattern.ReplaceAllStringFunc(s, a.Address)
+}
+
+// hash returns the first n hex digits of the keyed hash of kind and value
+func (a *Anonymizer) hash(kind, value string, n int) string {
+	mac := hmac.New(sha256.New, []byte(a.Salt))
+	mac.Write([]byte(kind + ":" + value))
+	return hex.EncodeToString(mac.Sum(nil))[:n]
+}
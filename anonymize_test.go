@@ -0,0 +1,86 @@
+package inboundgo_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+const personalPayload = `{"event": "email.received", "email": {
+	"id": "email_1",
+	"messageId": "<CAF123@mail.gmail.com>",
+	"from": {"text": "Jane Doe <jane.doe@gmail.com>", "addresses": [{"name": "Jane Doe", "address": "jane.doe@gmail.com"}]},
+	"recipient": "support@acme.com",
+	"subject": "Order for jane.doe@gmail.com",
+	"parsedData": {
+		"textBody": "Call me on 555-0100",
+		"htmlBody": "<p>Call me on 555-0100</p>",
+		"inReplyTo": "<CAF123@mail.gmail.com>",
+		"attachments": [{"filename": "Jane Doe passport.pdf", "size": 1024, "downloadUrl": "https://inbound.new/api/attachments/email_1/passport.pdf?token=abc"}],
+		"headers": {"from": "\"Doe, Jane\" <jane.doe@gmail.com>", "x-spam-score": 0.5}
+	}
+}}`
+
+func TestAnonymizer(t *testing.T) {
+	anon := &inboundgo.Anonymizer{Salt: "s3cret", KeepDomains: []string{"acme.com"}}
+	out, err := anon.JSON([]byte(personalPayload))
+	if err != nil {
+		t.Fatalf("JSON failed: %v", err)
+	}
+	for _, leak := range []string{"jane", "Jane", "555-0100", "passport", "inbound.new/api/attachments", "gmail.com"} {
+		if strings.Contains(string(out), leak) {
+			t.Errorf("Output contains %q:\n%s", leak, out)
+		}
+	}
+
+	payload, err := inboundgo.ParseWebhookPayload(strings.NewReader(string(out)))
+	if err != nil {
+		t.Fatalf("Anonymized payload does not parse: %v", err)
+	}
+	address := anon.Address("jane.doe@gmail.com")
+	if !strings.HasPrefix(address, "user-") || !strings.HasSuffix(address, ".example") {
+		t.Errorf("Unexpected replacement %q", address)
+	}
+	if got := payload.GetFromAddress(); got != anon.Name("Jane Doe")+" <"+address+">" {
+		t.Errorf("Unexpected from %q", got)
+	}
+	if *payload.Email.From.Addresses[0].Address != address || *payload.Email.Subject != "Order for "+address {
+		t.Errorf("Address was not replaced consistently: %+v", payload.Email)
+	}
+	if payload.Email.Recipient != "support@acme.com" {
+		t.Errorf("Kept domain was replaced: %q", payload.Email.Recipient)
+	}
+	if *payload.Email.MessageID != *payload.Email.ParsedData.InReplyTo || !strings.HasPrefix(*payload.Email.MessageID, "<user-") {
+		t.Errorf("Message-IDs were not replaced consistently: %q %q", *payload.Email.MessageID, *payload.Email.ParsedData.InReplyTo)
+	}
+	attachment := payload.Email.ParsedData.Attachments[0]
+	if !strings.HasSuffix(*attachment.Filename, ".pdf") || *attachment.Size != 1024 || !strings.HasPrefix(attachment.DownloadUrl, "https://files.example.com/") {
+		t.Errorf("Unexpected attachment %+v", attachment)
+	}
+	if *payload.Email.ParsedData.TextBody != "[text body removed, 19 characters]" {
+		t.Errorf("Unexpected body %q", *payload.Email.ParsedData.TextBody)
+	}
+	var raw map[string]any
+	json.Unmarshal(out, &raw)
+	headers := raw["email"].(map[string]any)["parsedData"].(map[string]any)["headers"].(map[string]any)
+	if headers["x-spam-score"] != 0.5 || headers["from"] != anon.Name("Doe, Jane")+" <"+address+">" {
+		t.Errorf("Unexpected headers %v", headers)
+	}
+
+	// Deterministic, and keyed by the salt
+	again, _ := anon.JSON([]byte(personalPayload))
+	if string(again) != string(out) {
+		t.Errorf("Output is not deterministic")
+	}
+	if other := (&inboundgo.Anonymizer{Salt: "other"}).Address("jane.doe@gmail.com"); other == address {
+		t.Errorf("Salt did not change the replacement")
+	}
+}
+
+func TestAnonymizeJSONInvalid(t *testing.T) {
+	if _, err := inboundgo.AnonymizeJSON([]byte("not json")); err == nil {
+		t.Error("Expected an error for invalid JSON")
+	}
+}
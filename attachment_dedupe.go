@@ -0,0 +1,196 @@
+package inboundgo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"sync"
+)
+
+// AttachmentStore uploads attachment content somewhere the API can fetch it
+// from, such as a bucket behind signed URLs, and returns its URL
+type AttachmentStore interface {
+	StoreAttachment(ctx context.Context, filename, contentType string, data []byte) (url string, err error)
+}
+
+// AttachmentStoreFunc adapts a function to AttachmentStore
+type AttachmentStoreFunc func(ctx context.Context, filename, contentType string, data []byte) (string, error)
+
+func (f AttachmentStoreFunc) StoreAttachment(ctx context.Context, filename, contentType string, data []byte) (string, error) {
+	return f(ctx, filename, contentType, data)
+}
+
+// AttachmentDedupeStats counts what an AttachmentDeduper saved
+type AttachmentDedupeStats struct {
+	// Distinct is the number of different attachment contents seen.
+	Distinct int
+	// Reused is the number of attachments that shared an earlier copy.
+	Reused int
+	// Stored is the number of contents uploaded to the Store.
+	Stored int
+	// BytesNotSent is the base64 content left out of requests because it
+	// was referenced by URL instead.
+	BytesNotSent int64
+}
+
+// AttachmentDeduper shares identical attachments across the emails of a
+// batch, such as the same PDF sent to hundreds of recipients. Identical
+// content is held once in memory, and with a Store it is uploaded once and
+// each email references it by URL, so requests carry a link instead of
+// megabytes of base64. Set it as SenderPoolOptions.Attachments, or call
+// Dedupe before sending. It is safe for concurrent use.
+type AttachmentDeduper struct {
+	// Store, when set, receives each distinct attachment of at least
+	// MinStoreSize bytes once. Inline images with a ContentID stay inline.
+	Store AttachmentStore
+	// MinStoreSize is the length of the smallest base64 content uploaded
+	// (default 64KB): below it, a URL saves little over the content.
+	MinStoreSize int
+
+	mu sync.Mutex
+	// byHash holds contents by the hash of their base64, byData by the
+	// hash of the raw data given to Attachment
+	byHash    map[[sha256.Size]byte]*sharedAttachment
+	byData    map[[sha256.Size]byte]*sharedAttachment
+	byPointer map[*string]*sharedAttachment
+	stats     AttachmentDedupeStats
+}
+
+// sharedAttachment is one distinct attachment content
+type sharedAttachment struct {
+	content *string
+
+	// mu serializes the upload, so concurrent emails wait for one
+	mu  sync.Mutex
+	url string
+}
+
+// Attachment returns an attachment of data, base64-encoding each distinct
+// content only once however many emails it is built for
+func (d *AttachmentDeduper) Attachment(filename, contentType string, data []byte) AttachmentData {
+	hash := sha256.Sum256(data)
+	d.mu.Lock()
+	d.init()
+	shared, ok := d.byData[hash]
+	if ok {
+		d.stats.Reused++
+	}
+	d.mu.Unlock()
+	if !ok {
+		encoded := base64.StdEncoding.EncodeToString(data)
+		shared = d.share(&encoded)
+		d.mu.Lock()
+		d.byData[hash] = shared
+		d.mu.Unlock()
+	}
+	attachment := AttachmentData{Content: shared.content, Filename: filename}
+	if contentType != "" {
+		attachment.ContentType = String(contentType)
+	}
+	return attachment
+}
+
+// Dedupe returns attachments with identical contents shared, and uploaded
+// and referenced by URL if there is a Store. attachments is not modified,
+// but their contents must not change once seen.
+func (d *AttachmentDeduper) Dedupe(ctx context.Context, attachments []AttachmentData) ([]AttachmentData, error) {
+	if len(attachments) == 0 {
+		return attachments, nil
+	}
+	out := make([]AttachmentData, len(attachments))
+	copy(out, attachments)
+	for i := range out {
+		a := &out[i]
+		if a.Content == nil || *a.Content == "" {
+			continue
+		}
+		shared := d.share(a.Content)
+		a.Content = shared.content
+		if d.Store == nil || a.ContentID != nil || len(*a.Content) < d.minStoreSize() {
+			continue
+		}
+		url, err := d.upload(ctx, shared, a)
+		if err != nil {
+			return nil, fmt.Errorf("failed to store attachment %s: %w", a.Filename, err)
+		}
+		a.Path, a.Content = String(url), nil
+	}
+	return out, nil
+}
+
+// Stats returns what the deduper has saved so far
+func (d *AttachmentDeduper) Stats() AttachmentDedupeStats {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.stats
+}
+
+// share returns the shared copy of content. A pointer seen before is
+// matched without hashing, as when every email of a merge carries the
+// same attachment.
+func (d *AttachmentDeduper) share(content *string) *sharedAttachment {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.init()
+	if shared, ok := d.byPointer[content]; ok {
+		d.stats.Reused++
+		return shared
+	}
+	hash := sha256.Sum256([]byte(*content))
+	shared, ok := d.byHash[hash]
+	if ok {
+		d.stats.Reused++
+	} else {
+		shared = &sharedAttachment{content: content}
+		d.byHash[hash] = shared
+		d.stats.Distinct++
+	}
+	d.byPointer[content] = shared
+	return shared
+}
+
+// upload stores shared once and returns its URL. A failed upload is
+// retried by the next email that carries the attachment.
+func (d *AttachmentDeduper) upload(ctx context.Context, shared *sharedAttachment, a *AttachmentData) (string, error) {
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+	if shared.url == "" {
+		data, err := base64.StdEncoding.DecodeString(*shared.content)
+		if err != nil {
+			return "", fmt.Errorf("invalid base64 content: %w", err)
+		}
+		contentType := ""
+		if a.ContentType != nil {
+			contentType = *a.ContentType
+		}
+		url, err := d.Store.StoreAttachment(ctx, a.Filename, contentType, data)
+		if err != nil {
+			return "", err
+		}
+		shared.url = url
+		d.mu.Lock()
+		d.stats.Stored++
+		d.mu.Unlock()
+	}
+	d.mu.Lock()
+	d.stats.BytesNotSent += int64(len(*shared.content))
+	d.mu.Unlock()
+	return shared.url, nil
+}
+
+// init allocates the maps. d.mu must be held.
+func (d *AttachmentDeduper) init() {
+	if d.byHash == nil {
+		d.byHash = make(map[[sha256.Size]byte]*sharedAttachment)
+		d.byData = make(map[[sha256.Size]byte]*sharedAttachment)
+		d.byPointer = make(map[*string]*sharedAttachment)
+	}
+}
+
+func (d *AttachmentDeduper) minStoreSize() int {
+	if d.MinStoreSize > 0 {
+		return d.MinStoreSize
+	}
+	return 64 << 10
+}
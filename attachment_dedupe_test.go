@@ -0,0 +1,103 @@
+package inboundgo_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestAttachmentDeduperShares(t *testing.T) {
+	d := &inboundgo.AttachmentDeduper{}
+	pdf := bytes.Repeat([]byte("%PDF"), 1000)
+
+	a := d.Attachment("invoice.pdf", "application/pdf", pdf)
+	b := d.Attachment("invoice.pdf", "application/pdf", pdf)
+	if a.Content != b.Content {
+		t.Error("Expected identical data to share one encoded copy")
+	}
+
+	// Encoded separately, as when each email reads the file again
+	separate := base64.StdEncoding.EncodeToString(pdf)
+	out, err := d.Dedupe(context.Background(), []inboundgo.AttachmentData{
+		{Filename: "copy.pdf", Content: &separate},
+		{Filename: "logo.png", Path: inboundgo.String("https://example.com/logo.png")},
+	})
+	if err != nil {
+		t.Fatalf("Dedupe failed: %v", err)
+	}
+	if out[0].Content != a.Content || out[0].Filename != "copy.pdf" || *out[1].Path != "https://example.com/logo.png" {
+		t.Errorf("Unexpected attachments %+v", out)
+	}
+	if stats := d.Stats(); stats.Distinct != 1 || stats.Reused != 2 || stats.Stored != 0 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
+
+func TestAttachmentDeduperStoresOnce(t *testing.T) {
+	var mu sync.Mutex
+	var received []inboundgo.AttachmentData
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req inboundgo.PostEmailsRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		received = append(received, req.Attachments...)
+		mu.Unlock()
+		w.Write([]byte(`{"id":"email-1"}`))
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+
+	var uploads int32
+	deduper := &inboundgo.AttachmentDeduper{
+		MinStoreSize: 1024,
+		Store: inboundgo.AttachmentStoreFunc(func(ctx context.Context, filename, contentType string, data []byte) (string, error) {
+			atomic.AddInt32(&uploads, 1)
+			if len(data) != 100<<10 || contentType != "application/pdf" {
+				t.Errorf("Unexpected upload %s %s %d", filename, contentType, len(data))
+			}
+			return "https://files.example.com/" + filename, nil
+		}),
+	}
+	pdf := bytes.Repeat([]byte{0x25}, 100<<10)
+	pool := inboundgo.NewSenderPool(client, inboundgo.SenderPoolOptions{
+		Concurrency: 4,
+		Attachments: deduper,
+		OnFailure:   func(r inboundgo.SendResult) { t.Errorf("Unexpected failure: %s", r.Error) },
+	})
+	jobs := make(chan inboundgo.SendJob, 10)
+	for i := 0; i < 10; i++ {
+		jobs <- inboundgo.SendJob{Request: &inboundgo.PostEmailsRequest{
+			From: "billing@example.com", To: "user@example.com", Subject: "Invoice",
+			Attachments: []inboundgo.AttachmentData{{
+				Filename:    "invoice.pdf",
+				ContentType: inboundgo.String("application/pdf"),
+				Content:     inboundgo.String(base64.StdEncoding.EncodeToString(pdf)),
+			}},
+		}}
+	}
+	close(jobs)
+	pool.Run(context.Background(), jobs)
+
+	if uploads != 1 {
+		t.Errorf("Expected one upload, got %d", uploads)
+	}
+	if len(received) != 10 {
+		t.Fatalf("Expected 10 sends, got %d", len(received))
+	}
+	for _, a := range received {
+		if a.Content != nil || a.Path == nil || *a.Path != "https://files.example.com/invoice.pdf" {
+			t.Errorf("Expected the attachment to be sent by URL, got %+v", a)
+		}
+	}
+	if stats := deduper.Stats(); stats.Stored != 1 || stats.Reused != 9 || stats.BytesNotSent == 0 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
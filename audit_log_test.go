@@ -0,0 +1,63 @@
+package inboundgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestAuditLogList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/audit-log" {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("resourceType") != "domain" || q.Get("since") != "2025-01-01T00:00:00Z" || q.Get("limit") != "20" {
+			t.Errorf("Unexpected filters: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [{
+			"id": "log-1",
+			"actor": {"id": "key-1", "type": "api_key", "name": "CI deploy key"},
+			"action": "domain.deleted",
+			"resource": {"type": "domain", "id": "dom-1", "name": "old.example.com"},
+			"timestamp": "2025-01-03T12:00:00Z",
+			"ipAddress": "198.51.100.7",
+			"metadata": {"reason": "cleanup"}
+		}], "pagination": {"limit": 20, "offset": 0, "total": 41, "hasMore": true}}`))
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	resp, err := client.AuditLog().List(context.Background(), &inboundgo.GetAuditLogRequest{
+		Limit:        inboundgo.Int(20),
+		ResourceType: "domain",
+		Since:        &since,
+	})
+	if err != nil || resp.Error != "" {
+		t.Fatalf("List failed: %v %s", err, resp.Error)
+	}
+	if !resp.Data.Pagination.HasMore || len(resp.Data.Data) != 1 {
+		t.Fatalf("Unexpected response: %+v", resp.Data)
+	}
+
+	entry := resp.Data.Data[0]
+	if entry.Actor.Type != "api_key" || *entry.Actor.Name != "CI deploy key" {
+		t.Errorf("Unexpected actor: %+v", entry.Actor)
+	}
+	if entry.Action != "domain.deleted" || entry.Resource.ID != "dom-1" || *entry.IPAddress != "198.51.100.7" {
+		t.Errorf("Unexpected entry: %+v", entry)
+	}
+	if entry.Metadata["reason"] != "cleanup" {
+		t.Errorf("Unexpected metadata: %v", entry.Metadata)
+	}
+}
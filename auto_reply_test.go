@@ -0,0 +1,92 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestCreateAutoReply(t *testing.T) {
+	var got inboundgo.PutAutoReplyRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /email-addresses":
+			if r.URL.Query().Get("offset") == "0" {
+				addresses := make([]map[string]any, 100)
+				for i := range addresses {
+					addresses[i] = map[string]any{"id": "other", "address": "other@example.com"}
+				}
+				json.NewEncoder(w).Encode(map[string]any{"data": addresses, "pagination": map[string]any{"total": 101}})
+				return
+			}
+			w.Write([]byte(`{"data": [{"id": "addr-101", "address": "Jane@example.com"}], "pagination": {"total": 101}}`))
+		case "PUT /email-addresses/addr-101/auto-reply":
+			json.NewDecoder(r.Body).Decode(&got)
+			w.Write([]byte(`{"emailAddressId": "addr-101", "enabled": true, "subject": "Out of office", "senderCooldownHours": 24}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	until := time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC)
+	resp, err := client.CreateAutoReply(context.Background(), "jane@example.com", "Out of office", "Back on the 15th.", time.Time{}, until)
+	if err != nil || resp.Error != "" {
+		t.Fatalf("CreateAutoReply failed: %v %+v", err, resp)
+	}
+	if resp.Data.EmailAddressID != "addr-101" || resp.Data.SenderCooldownHours != 24 {
+		t.Errorf("Unexpected auto-reply: %+v", resp.Data)
+	}
+	if got.StartsAt != nil || got.EndsAt == nil || !got.EndsAt.Equal(until) || *got.Text != "Back on the 15th." {
+		t.Errorf("Unexpected request: %+v", got)
+	}
+
+	if _, err := client.CreateAutoReply(context.Background(), "missing@example.com", "x", "y", time.Time{}, time.Time{}); err == nil {
+		t.Error("Expected error for unknown address")
+	}
+}
+
+func TestAutoReplyService(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/email-addresses/addr-1/auto-reply" {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`{"emailAddressId": "addr-1", "enabled": true, "subject": "Away", "templateId": "tpl-ooo", "endsAt": "2025-08-15T00:00:00Z"}`))
+		case "DELETE":
+			w.Write([]byte(`{"message": "deleted"}`))
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	got, err := client.Email().Address.GetAutoReply(ctx, "addr-1")
+	if err != nil || got.Error != "" || *got.Data.TemplateID != "tpl-ooo" || got.Data.EndsAt.Day() != 15 {
+		t.Fatalf("GetAutoReply failed: %v %+v", err, got)
+	}
+	deleted, err := client.Email().Address.DeleteAutoReply(ctx, "addr-1")
+	if err != nil || deleted.Error != "" {
+		t.Fatalf("DeleteAutoReply failed: %v %+v", err, deleted)
+	}
+}
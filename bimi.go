@@ -0,0 +1,61 @@
+package inboundgo
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// NewBIMIRecord builds the BIMI TXT record for domain, for publishing at the
+// DNS provider alongside the SPF, DKIM, and DMARC records returned by
+// GetDNSRecords. The logo must be an HTTPS URL to an SVG; the VMC URL is
+// optional but required by Gmail and Apple Mail to display the logo.
+func NewBIMIRecord(domain string, params *PutDomainBIMIRequest) (DNSRecord, error) {
+	if domain == "" {
+		return DNSRecord{}, errors.New("bimi: domain is required")
+	}
+	if params == nil || params.LogoURL == "" {
+		return DNSRecord{}, errors.New("bimi: logo URL is required")
+	}
+	if err := checkBIMIURL("logo", params.LogoURL, ".svg"); err != nil {
+		return DNSRecord{}, err
+	}
+
+	selector := params.Selector
+	if selector == "" {
+		selector = "default"
+	}
+	value := "v=BIMI1; l=" + params.LogoURL
+	if params.VMCURL != nil && *params.VMCURL != "" {
+		if err := checkBIMIURL("VMC", *params.VMCURL, ".pem"); err != nil {
+			return DNSRecord{}, err
+		}
+		value += "; a=" + *params.VMCURL
+	}
+
+	return DNSRecord{
+		Type:  "TXT",
+		Name:  fmt.Sprintf("%s._bimi.%s", selector, strings.TrimSuffix(domain, ".")),
+		Value: value,
+	}, nil
+}
+
+func checkBIMIURL(kind, raw, ext string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("bimi: invalid %s URL %q", kind, raw)
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("bimi: %s URL must use https", kind)
+	}
+	if !strings.EqualFold(path.Ext(u.Path), ext) {
+		return fmt.Errorf("bimi: %s URL must point to a %s file", kind, ext)
+	}
+	// Semicolons separate BIMI tags and would corrupt the record
+	if strings.ContainsAny(raw, "; ") {
+		return fmt.Errorf("bimi: %s URL must not contain spaces or semicolons", kind)
+	}
+	return nil
+}
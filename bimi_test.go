@@ -0,0 +1,87 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestNewBIMIRecord(t *testing.T) {
+	record, err := inboundgo.NewBIMIRecord("example.com", &inboundgo.PutDomainBIMIRequest{
+		LogoURL: "https://cdn.example.com/logo.svg",
+		VMCURL:  inboundgo.String("https://cdn.example.com/vmc.pem"),
+	})
+	if err != nil {
+		t.Fatalf("NewBIMIRecord failed: %v", err)
+	}
+	if record.Type != "TXT" || record.Name != "default._bimi.example.com" {
+		t.Errorf("Unexpected record: %+v", record)
+	}
+	if want := "v=BIMI1; l=https://cdn.example.com/logo.svg; a=https://cdn.example.com/vmc.pem"; record.Value != want {
+		t.Errorf("Expected value %q, got %q", want, record.Value)
+	}
+
+	record, err = inboundgo.NewBIMIRecord("example.com", &inboundgo.PutDomainBIMIRequest{
+		LogoURL:  "https://cdn.example.com/logo.svg",
+		Selector: "brand",
+	})
+	if err != nil || record.Name != "brand._bimi.example.com" || record.Value != "v=BIMI1; l=https://cdn.example.com/logo.svg" {
+		t.Errorf("Unexpected record without VMC: %+v %v", record, err)
+	}
+
+	invalid := []*inboundgo.PutDomainBIMIRequest{
+		nil,
+		{LogoURL: "http://cdn.example.com/logo.svg"},
+		{LogoURL: "https://cdn.example.com/logo.png"},
+		{LogoURL: "https://cdn.example.com/logo.svg", VMCURL: inboundgo.String("https://cdn.example.com/vmc.txt")},
+		{LogoURL: "https://cdn.example.com/a;b.svg"},
+	}
+	for _, params := range invalid {
+		if _, err := inboundgo.NewBIMIRecord("example.com", params); err == nil {
+			t.Errorf("Expected error for %+v", params)
+		}
+	}
+}
+
+func TestDomainBIMI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/domains/dom-1/bimi" {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+		if r.Method == "PUT" {
+			var req inboundgo.PutDomainBIMIRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.LogoURL != "https://cdn.example.com/logo.svg" {
+				t.Errorf("Unexpected request: %+v", req)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"domainId": "dom-1", "selector": "default", "logoUrl": "https://cdn.example.com/logo.svg",
+			"status": "failed", "dmarcCompliant": false, "errors": ["DMARC policy must be quarantine or reject"],
+			"dnsRecord": {"type": "TXT", "name": "default._bimi.example.com", "value": "v=BIMI1; l=https://cdn.example.com/logo.svg"}}`))
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	set, err := client.Domain().SetBIMI(ctx, "dom-1", &inboundgo.PutDomainBIMIRequest{LogoURL: "https://cdn.example.com/logo.svg"})
+	if err != nil || set.Error != "" || set.Data.DNSRecord.Name != "default._bimi.example.com" {
+		t.Fatalf("SetBIMI failed: %v %+v", err, set)
+	}
+
+	got, err := client.Domain().GetBIMI(ctx, "dom-1")
+	if err != nil || got.Error != "" {
+		t.Fatalf("GetBIMI failed: %v %+v", err, got)
+	}
+	if got.Data.DMARCCompliant || len(got.Data.Errors) != 1 {
+		t.Errorf("Unexpected BIMI status: %+v", got.Data)
+	}
+}
@@ -0,0 +1,234 @@
+package inboundgo
+
+import (
+	"regexp"
+	"strings"
+)
+
+// BounceType classifies why a message came back, so retry and suppression
+// policies can be written against types instead of SMTP diagnostics
+type BounceType string
+
+const (
+	// BounceTypeHard is a permanent failure such as an unknown mailbox
+	BounceTypeHard BounceType = "hard"
+	// BounceTypeSoft is a temporary failure such as a full mailbox
+	BounceTypeSoft BounceType = "soft"
+	// BounceTypeBlock is a rejection based on content, policy, or sender
+	// reputation rather than the recipient
+	BounceTypeBlock BounceType = "block"
+	// BounceTypeAutoReply is an out-of-office or other automatic reply
+	BounceTypeAutoReply BounceType = "auto_reply"
+	// BounceTypeChallengeResponse asks the sender to prove they are human
+	BounceTypeChallengeResponse BounceType = "challenge_response"
+	// BounceTypeUnknown could not be classified
+	BounceTypeUnknown BounceType = "unknown"
+)
+
+// ShouldSuppress reports whether the recipient should stop receiving mail
+func (t BounceType) ShouldSuppress() bool {
+	return t == BounceTypeHard
+}
+
+// ShouldRetry reports whether sending again later may succeed
+func (t BounceType) ShouldRetry() bool {
+	return t == BounceTypeSoft
+}
+
+var (
+	enhancedStatusPattern = regexp.MustCompile(`\b([245])\.(\d{1,3})\.(\d{1,3})\b`)
+	basicStatusPattern    = regexp.MustCompile(`\b([45])(\d\d)\b`)
+
+	challengeKeywords = []string{
+		"verify you are human", "verify that you are human", "prove you are human",
+		"challenge-response", "challenge response", "boxbe", "spamarrest", "spam arrest",
+		"sanebox", "approve your message", "confirm you are a real person",
+		"added to my approved senders", "please click the link below to deliver",
+	}
+	autoReplyKeywords = []string{
+		"out of office", "out of the office", "auto-reply", "autoreply", "automatic reply",
+		"auto reply", "vacation", "away from the office", "on leave", "annual leave",
+	}
+	blockKeywords = []string{
+		"blocked", "blacklist", "blocklist", "denylist", "spamhaus", "barracuda",
+		"reputation", "spam", "rejected for policy", "policy reasons", "content rejected",
+		"not authorized to send", "dmarc", "rbl",
+	}
+	hardKeywords = []string{
+		"user unknown", "unknown user", "no such user", "does not exist", "mailbox unavailable",
+		"invalid recipient", "recipient rejected", "address rejected", "no mailbox",
+		"account disabled", "account has been disabled", "mailbox not found", "unrouteable address",
+		"host not found", "domain not found",
+	}
+	softKeywords = []string{
+		"mailbox full", "over quota", "quota exceeded", "insufficient storage",
+		"try again later", "temporarily", "temporary failure", "greylist", "rate limit",
+		"too many connections", "delayed",
+	}
+)
+
+// ClassifyBounce classifies a bounce from its SMTP diagnostic text, such as
+// "550 5.1.1 <user@example.com>: Recipient address rejected". Enhanced
+// status codes take precedence over basic reply codes, which take
+// precedence over keywords.
+func ClassifyBounce(diagnostic string) BounceType {
+	text := strings.ToLower(diagnostic)
+	switch {
+	case text == "":
+		return BounceTypeUnknown
+	case containsAny(text, challengeKeywords):
+		return BounceTypeChallengeResponse
+	case containsAny(text, autoReplyKeywords):
+		return BounceTypeAutoReply
+	}
+
+	if m := enhancedStatusPattern.FindStringSubmatch(text); m != nil {
+		class, subject, detail := m[1], m[2], m[3]
+		switch {
+		case class == "2":
+			// Success codes only appear in reports for other recipients
+		case class == "4":
+			return BounceTypeSoft
+		case subject == "7" || containsAny(text, blockKeywords):
+			return BounceTypeBlock
+		case subject == "2" && detail == "2":
+			return BounceTypeSoft // Mailbox full
+		default:
+			return BounceTypeHard
+		}
+	}
+
+	if m := basicStatusPattern.FindStringSubmatch(text); m != nil {
+		switch {
+		case m[1] == "4":
+			return BounceTypeSoft
+		case containsAny(text, blockKeywords):
+			return BounceTypeBlock
+		case m[2] == "52":
+			return BounceTypeSoft // 552: exceeded storage allocation
+		case m[2] == "50" || m[2] == "51" || m[2] == "53":
+			return BounceTypeHard
+		}
+	}
+
+	switch {
+	case containsAny(text, blockKeywords):
+		return BounceTypeBlock
+	case containsAny(text, softKeywords):
+		return BounceTypeSoft
+	case containsAny(text, hardKeywords):
+		return BounceTypeHard
+	}
+	return BounceTypeUnknown
+}
+
+// Classify returns the bounce's type, preferring the SMTP diagnostic and
+// falling back to the API's hard/soft type
+func (b *Bounce) Classify() BounceType {
+	var parts []string
+	if b.DiagnosticCode != nil {
+		parts = append(parts, *b.DiagnosticCode)
+	}
+	parts = append(parts, b.Reason)
+	if t := ClassifyBounce(strings.Join(parts, " ")); t != BounceTypeUnknown {
+		return t
+	}
+	if b.SubType != nil && *b.SubType == "mailbox_full" {
+		return BounceTypeSoft
+	}
+	return bounceTypeFromAPI(b.Type)
+}
+
+// ClassifyBounce returns the type of a bounced event, or BounceTypeUnknown
+// for other events
+func (e *EmailEvent) ClassifyBounce() BounceType {
+	if e.Type != EmailEventBounced {
+		return BounceTypeUnknown
+	}
+	if e.Reason != nil {
+		if t := ClassifyBounce(*e.Reason); t != BounceTypeUnknown {
+			return t
+		}
+	}
+	if e.BounceType != nil {
+		return bounceTypeFromAPI(*e.BounceType)
+	}
+	return BounceTypeUnknown
+}
+
+// ClassifyBounce reports whether a received email is a delivery status
+// notification, automatic reply, or challenge, and classifies it. It
+// returns false for ordinary mail.
+func (w *WebhookPayload) ClassifyBounce() (BounceType, bool) {
+	headers := w.GetHeaders()
+	subject := ""
+	if w.Email.Subject != nil {
+		subject = *w.Email.Subject
+	}
+	body := ""
+	if w.Email.ParsedData.TextBody != nil {
+		body = *w.Email.ParsedData.TextBody
+	} else if w.Email.CleanedContent.Text != nil {
+		body = *w.Email.CleanedContent.Text
+	}
+	lowerSubject := strings.ToLower(subject)
+
+	contentType := strings.ToLower(headerValue(headers, "Content-Type"))
+	local, _, _ := splitAddress(w.GetFromAddress())
+	if strings.Contains(contentType, "report-type=delivery-status") ||
+		strings.Contains(contentType, "report-type=\"delivery-status\"") ||
+		local == "mailer-daemon" || local == "postmaster" {
+		if strings.Contains(strings.ToLower(body), "action: delayed") {
+			return BounceTypeSoft, true
+		}
+		if t := ClassifyBounce(body); t != BounceTypeUnknown && t != BounceTypeAutoReply {
+			return t, true
+		}
+		// A failure report whose diagnostic could not be classified
+		return BounceTypeHard, true
+	}
+
+	if containsAny(lowerSubject+"\n"+strings.ToLower(body), challengeKeywords) {
+		return BounceTypeChallengeResponse, true
+	}
+	autoSubmitted := strings.ToLower(headerValue(headers, "Auto-Submitted"))
+	precedence := strings.ToLower(headerValue(headers, "Precedence"))
+	if (autoSubmitted != "" && autoSubmitted != "no") ||
+		headerValue(headers, "X-Autoreply") != "" ||
+		headerValue(headers, "X-Autorespond") != "" ||
+		precedence == "auto_reply" ||
+		containsAny(lowerSubject, autoReplyKeywords) {
+		return BounceTypeAutoReply, true
+	}
+	return "", false
+}
+
+func bounceTypeFromAPI(t string) BounceType {
+	switch strings.ToLower(t) {
+	case "hard", "permanent":
+		return BounceTypeHard
+	case "soft", "transient":
+		return BounceTypeSoft
+	}
+	return BounceTypeUnknown
+}
+
+// headerValue returns the first value of a header, matching the name
+// case-insensitively
+func headerValue(headers map[string][]string, name string) string {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) && len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
+}
+
+func containsAny(s string, substrs []string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
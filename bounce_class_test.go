@@ -0,0 +1,116 @@
+package inboundgo_test
+
+import (
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestClassifyBounce(t *testing.T) {
+	tests := []struct {
+		diagnostic string
+		want       inboundgo.BounceType
+	}{
+		{"550 5.1.1 <user@example.com>: Recipient address rejected: User unknown", inboundgo.BounceTypeHard},
+		{"smtp; 552 5.2.2 Mailbox full", inboundgo.BounceTypeSoft},
+		{"421 4.7.0 Try again later, closing connection", inboundgo.BounceTypeSoft},
+		{"550 5.7.1 Service unavailable; client host blocked using Spamhaus", inboundgo.BounceTypeBlock},
+		{"554 Message rejected due to sender reputation", inboundgo.BounceTypeBlock},
+		{"550 Requested action not taken: mailbox unavailable", inboundgo.BounceTypeHard},
+		{"452 Too many recipients", inboundgo.BounceTypeSoft},
+		{"Mailbox does not exist", inboundgo.BounceTypeHard},
+		{"I am out of the office until Monday", inboundgo.BounceTypeAutoReply},
+		{"Please verify you are human by clicking the link below", inboundgo.BounceTypeChallengeResponse},
+		{"something went wrong", inboundgo.BounceTypeUnknown},
+		{"", inboundgo.BounceTypeUnknown},
+	}
+	for _, tt := range tests {
+		if got := inboundgo.ClassifyBounce(tt.diagnostic); got != tt.want {
+			t.Errorf("ClassifyBounce(%q) = %s, want %s", tt.diagnostic, got, tt.want)
+		}
+	}
+	if !inboundgo.BounceTypeHard.ShouldSuppress() || inboundgo.BounceTypeBlock.ShouldSuppress() {
+		t.Error("Only hard bounces should suppress")
+	}
+	if !inboundgo.BounceTypeSoft.ShouldRetry() || inboundgo.BounceTypeHard.ShouldRetry() {
+		t.Error("Only soft bounces should retry")
+	}
+}
+
+func TestBounceClassifyFallback(t *testing.T) {
+	b := &inboundgo.Bounce{Type: "hard", Reason: "Bounced"}
+	if got := b.Classify(); got != inboundgo.BounceTypeHard {
+		t.Errorf("Expected API type fallback, got %s", got)
+	}
+	b = &inboundgo.Bounce{Type: "hard", DiagnosticCode: inboundgo.String("550 5.7.1 Message blocked")}
+	if got := b.Classify(); got != inboundgo.BounceTypeBlock {
+		t.Errorf("Expected diagnostic to take precedence, got %s", got)
+	}
+
+	event := &inboundgo.EmailEvent{Type: inboundgo.EmailEventBounced, BounceType: inboundgo.String("soft")}
+	if got := event.ClassifyBounce(); got != inboundgo.BounceTypeSoft {
+		t.Errorf("Expected soft event bounce, got %s", got)
+	}
+	event = &inboundgo.EmailEvent{Type: inboundgo.EmailEventDelivered}
+	if got := event.ClassifyBounce(); got != inboundgo.BounceTypeUnknown {
+		t.Errorf("Expected unknown for non-bounce event, got %s", got)
+	}
+}
+
+func TestWebhookPayloadClassifyBounce(t *testing.T) {
+	payload := func(from, subject, body string, headers map[string]any) *inboundgo.WebhookPayload {
+		p := &inboundgo.WebhookPayload{}
+		p.Email.From = &inboundgo.WebhookAddressGroup{Addresses: []inboundgo.WebhookAddress{{Address: inboundgo.String(from)}}}
+		p.Email.Subject = inboundgo.String(subject)
+		p.Email.ParsedData.TextBody = inboundgo.String(body)
+		p.Email.ParsedData.Headers = headers
+		return p
+	}
+
+	tests := []struct {
+		name    string
+		payload *inboundgo.WebhookPayload
+		want    inboundgo.BounceType
+		ok      bool
+	}{
+		{
+			name: "dsn",
+			payload: payload("MAILER-DAEMON@mx.example.com", "Undelivered Mail Returned to Sender",
+				"Action: failed\nStatus: 5.1.1\nDiagnostic-Code: smtp; 550 5.1.1 user unknown", map[string]any{
+					"content-type": "multipart/report; report-type=delivery-status; boundary=x",
+				}),
+			want: inboundgo.BounceTypeHard, ok: true,
+		},
+		{
+			name:    "delayed dsn",
+			payload: payload("postmaster@mx.example.com", "Delivery delayed", "Action: delayed\nStatus: 4.4.7", nil),
+			want:    inboundgo.BounceTypeSoft, ok: true,
+		},
+		{
+			name:    "auto reply header",
+			payload: payload("jane@example.com", "Re: Invoice", "Thanks for your email.", map[string]any{"auto-submitted": "auto-replied"}),
+			want:    inboundgo.BounceTypeAutoReply, ok: true,
+		},
+		{
+			name:    "out of office subject",
+			payload: payload("jane@example.com", "Automatic reply: Invoice", "I'm away.", nil),
+			want:    inboundgo.BounceTypeAutoReply, ok: true,
+		},
+		{
+			name:    "challenge",
+			payload: payload("jane@example.com", "Please confirm", "To deliver your message, verify you are human.", map[string]any{"auto-submitted": "auto-replied"}),
+			want:    inboundgo.BounceTypeChallengeResponse, ok: true,
+		},
+		{
+			name:    "regular mail",
+			payload: payload("jane@example.com", "Lunch?", "Free at noon?", map[string]any{"auto-submitted": "no"}),
+			ok:      false,
+		},
+	}
+	for _, tt := range tests {
+		got, ok := tt.payload.ClassifyBounce()
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("%s: got (%s, %v), want (%s, %v)", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
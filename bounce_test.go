@@ -0,0 +1,67 @@
+package inboundgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestListBouncesAndComplaints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/emails/bounces":
+			if q.Get("type") != "hard" || q.Get("domain") != "example.com" || q.Get("since") != "2025-01-01T00:00:00Z" {
+				t.Errorf("Unexpected bounce filters: %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"data": [{
+				"id": "b-1", "emailId": "email-1", "recipient": "gone@example.org", "type": "hard",
+				"subType": "no_email", "reason": "Mailbox does not exist",
+				"diagnosticCode": "smtp; 550 5.1.1 user unknown", "domain": "example.com",
+				"bouncedAt": "2025-01-05T10:00:00Z"
+			}], "pagination": {"total": 1}}`))
+		case "/emails/complaints":
+			if q.Get("until") != "2025-02-01T00:00:00Z" {
+				t.Errorf("Unexpected complaint filters: %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"data": [{
+				"id": "c-1", "emailId": "email-2", "recipient": "annoyed@example.org",
+				"feedbackType": "abuse", "domain": "example.com", "complainedAt": "2025-01-06T10:00:00Z"
+			}], "pagination": {"total": 1}}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	bounces, err := client.Email().ListBounces(ctx, &inboundgo.GetBouncesRequest{Type: "hard", Domain: "example.com", Since: &since})
+	if err != nil || bounces.Error != "" || len(bounces.Data.Data) != 1 {
+		t.Fatalf("ListBounces failed: %v %+v", err, bounces)
+	}
+	b := bounces.Data.Data[0]
+	if b.EmailID != "email-1" || b.Type != "hard" || *b.SubType != "no_email" || !b.BouncedAt.Equal(time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected bounce: %+v", b)
+	}
+
+	complaints, err := client.Email().ListComplaints(ctx, &inboundgo.GetComplaintsRequest{Until: &until})
+	if err != nil || complaints.Error != "" || len(complaints.Data.Data) != 1 {
+		t.Fatalf("ListComplaints failed: %v %+v", err, complaints)
+	}
+	if c := complaints.Data.Data[0]; c.EmailID != "email-2" || *c.FeedbackType != "abuse" {
+		t.Errorf("Unexpected complaint: %+v", c)
+	}
+}
@@ -0,0 +1,98 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestBroadcastService(t *testing.T) {
+	broadcast := func(status string) string {
+		return `{"id": "bc-1", "name": "January newsletter", "audienceId": "aud-1", "templateId": "tpl-1",
+			"from": "news@yourdomain.com", "status": "` + status + `", "createdAt": "2025-01-01T00:00:00Z", "updatedAt": "2025-01-01T00:00:00Z"}`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /broadcasts":
+			var req inboundgo.PostBroadcastsRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.TemplateID != "tpl-1" || req.AudienceID == nil || *req.AudienceID != "aud-1" || req.ScheduledAt != nil {
+				t.Errorf("Unexpected create request: %+v", req)
+			}
+			w.Write([]byte(broadcast("draft")))
+		case "GET /broadcasts":
+			if r.URL.Query().Get("status") != "draft" {
+				t.Errorf("Expected status filter, got %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"data": [` + broadcast("draft") + `], "pagination": {"total": 1}}`))
+		case "POST /broadcasts/bc-1/send":
+			if r.Header.Get("Idempotency-Key") != "send-bc-1" {
+				t.Errorf("Expected idempotency key, got %q", r.Header.Get("Idempotency-Key"))
+			}
+			w.Write([]byte(broadcast("sending")))
+		case "POST /broadcasts/bc-1/pause":
+			w.Write([]byte(broadcast("paused")))
+		case "POST /broadcasts/bc-1/resume":
+			w.Write([]byte(broadcast("sending")))
+		case "GET /broadcasts/bc-1/stats":
+			w.Write([]byte(`{"recipients": 100, "sent": 100, "delivered": 97, "opened": 40, "bounced": 3, "openRate": 0.41}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+	svc := client.Broadcast()
+
+	created, err := svc.Create(ctx, &inboundgo.PostBroadcastsRequest{
+		Name:       "January newsletter",
+		AudienceID: inboundgo.String("aud-1"),
+		TemplateID: "tpl-1",
+		From:       "news@yourdomain.com",
+	})
+	if err != nil || created.Error != "" || created.Data.Status != "draft" {
+		t.Fatalf("Create failed: %v %+v", err, created)
+	}
+
+	list, err := svc.List(ctx, &inboundgo.GetBroadcastsRequest{Status: "draft"})
+	if err != nil || list.Error != "" || len(list.Data.Data) != 1 {
+		t.Fatalf("List failed: %v %+v", err, list)
+	}
+
+	for _, step := range []struct {
+		name string
+		call func() (*inboundgo.ApiResponse[inboundgo.Broadcast], error)
+		want string
+	}{
+		{"Send", func() (*inboundgo.ApiResponse[inboundgo.Broadcast], error) {
+			return svc.Send(ctx, "bc-1", &inboundgo.IdempotencyOptions{IdempotencyKey: "send-bc-1"})
+		}, "sending"},
+		{"Pause", func() (*inboundgo.ApiResponse[inboundgo.Broadcast], error) { return svc.Pause(ctx, "bc-1") }, "paused"},
+		{"Resume", func() (*inboundgo.ApiResponse[inboundgo.Broadcast], error) { return svc.Resume(ctx, "bc-1") }, "sending"},
+	} {
+		resp, err := step.call()
+		if err != nil || resp.Error != "" || resp.Data.Status != step.want {
+			t.Errorf("%s: expected status %s, got %v %+v", step.name, step.want, err, resp)
+		}
+	}
+
+	stats, err := svc.Stats(ctx, "bc-1")
+	if err != nil || stats.Error != "" {
+		t.Fatalf("Stats failed: %v %+v", err, stats)
+	}
+	if stats.Data.Delivered != 97 || stats.Data.Bounced != 3 || stats.Data.OpenRate != 0.41 {
+		t.Errorf("Unexpected stats: %+v", stats.Data)
+	}
+}
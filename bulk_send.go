@@ -0,0 +1,314 @@
+package inboundgo
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SendSkipped is the status of a bulk send item an earlier run already sent
+const SendSkipped SendStatus = "skipped"
+
+// ErrBulkSendStopped is returned by BulkSend when the error rate passed
+// BulkOptions.StopOnErrorRate
+var ErrBulkSendStopped = errors.New("bulk send stopped")
+
+// errorRateMinFinished is how many items must finish before the error
+// rate is checked, so a failure among the first few does not stop a send
+const errorRateMinFinished = 20
+
+// BulkOptions configures Inbound.BulkSend
+type BulkOptions struct {
+	// Concurrency, RatePerSecond, MaxRetries, RetryBackoff, and Attachments
+	// are as in SenderPoolOptions.
+	Concurrency   int
+	RatePerSecond float64
+	MaxRetries    int
+	RetryBackoff  time.Duration
+	Attachments   *AttachmentDeduper
+	// OnProgress is called after each item finishes, one call at a time.
+	OnProgress func(BulkProgress)
+	// StopOnErrorRate stops the send once more than this fraction (0 to 1)
+	// of the finished items failed, checked from the 20th item on. Zero
+	// never stops.
+	StopOnErrorRate float64
+	// RunID names the send. Each item is sent with an idempotency key made
+	// of the RunID and its index, so running again with the same RunID and
+	// requests cannot send an item twice. One is generated when empty.
+	RunID string
+	// Store records the items that were sent, so running again with the
+	// same RunID skips them instead of sending each one to the API.
+	Store BulkStore
+}
+
+// BulkProgress is a snapshot of a bulk send
+type BulkProgress struct {
+	Total int
+	// Sent counts items sent in this run, Duplicate those the API had
+	// already received, Skipped those the Store recorded as sent by an
+	// earlier run, and Failed the rest that finished.
+	Sent      int
+	Duplicate int
+	Skipped   int
+	Failed    int
+	Elapsed   time.Duration
+	// ETA estimates the time left from the pace so far, 0 until an item
+	// finishes.
+	ETA time.Duration
+}
+
+// Done returns the number of finished items
+func (p BulkProgress) Done() int {
+	return p.Sent + p.Duplicate + p.Skipped + p.Failed
+}
+
+// BulkResult is the outcome of a bulk send
+type BulkResult struct {
+	RunID string
+	// Results holds the outcome of each request, in order. Job.Metadata is
+	// the request's index. Items not sent because the send was cancelled
+	// or stopped are failed with the reason as their error.
+	Results  []SendResult
+	Progress BulkProgress
+}
+
+// Failed returns the indexes of the requests that were not sent
+func (r *BulkResult) Failed() []int {
+	var failed []int
+	for i, result := range r.Results {
+		if result.Status == SendFailed {
+			failed = append(failed, i)
+		}
+	}
+	return failed
+}
+
+// BulkStore persists which items of a bulk send were sent
+type BulkStore interface {
+	// Sent returns the email IDs of the items of run recorded so far, by
+	// index.
+	Sent(ctx context.Context, runID string) (map[int]string, error)
+	// Record marks an item of run as sent.
+	Record(ctx context.Context, runID string, index int, emailID string) error
+}
+
+// BulkSend sends reqs through a SenderPool, reporting progress as it goes.
+// Cancelling ctx stops it cleanly: sends in flight finish or are abandoned,
+// no new ones start, and it returns the results so far with ctx.Err(). With
+// a Store, the same call resumes where it stopped.
+func (c *Inbound) BulkSend(ctx context.Context, reqs []*PostEmailsRequest, opts BulkOptions) (*BulkResult, error) {
+	runID := opts.RunID
+	if runID == "" {
+		runID = newIdempotencyKey()
+	}
+	result := &BulkResult{RunID: runID, Results: make([]SendResult, len(reqs))}
+	sent := map[int]string{}
+	if opts.Store != nil {
+		var err error
+		if sent, err = opts.Store.Sent(ctx, runID); err != nil {
+			return nil, fmt.Errorf("failed to load bulk send state: %w", err)
+		}
+	}
+
+	notSent := errors.New("not sent")
+	progress := BulkProgress{Total: len(reqs)}
+	for i, req := range reqs {
+		job := SendJob{Request: req, Options: &IdempotencyOptions{IdempotencyKey: fmt.Sprintf("%s-%d", runID, i)}, Metadata: i}
+		r := &result.Results[i]
+		*r = SendResult{Job: job, Status: SendFailed, IdempotencyKey: job.Options.IdempotencyKey}
+		if id, ok := sent[i]; ok {
+			r.Status, r.Response = SendSkipped, &PostEmailsResponse{ID: id}
+			progress.Skipped++
+		} else {
+			r.fail(notSent)
+		}
+	}
+
+	runCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	var mu sync.Mutex
+	var stopErr error
+	recording := opts.Store != nil
+	start := time.Now()
+	finish := func(res SendResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		i := res.Job.Metadata.(int)
+		if res.Status == SendFailed && runCtx.Err() != nil && stopErr != nil {
+			// abandoned by a stop; report why rather than the cancellation
+			res.fail(stopErr)
+		}
+		result.Results[i] = res
+		switch res.Status {
+		case SendSucceeded:
+			progress.Sent++
+		case SendDuplicate:
+			progress.Duplicate++
+		default:
+			progress.Failed++
+		}
+		if (res.Status == SendSucceeded || res.Status == SendDuplicate) && recording {
+			id := ""
+			if res.Response != nil {
+				id = res.Response.ID
+			}
+			if err := opts.Store.Record(ctx, runID, i, id); err != nil {
+				recording = false
+				stopErr = fmt.Errorf("failed to record bulk send state: %w", err)
+				stop()
+			}
+		}
+		finished := progress.Sent + progress.Duplicate + progress.Failed
+		if opts.StopOnErrorRate > 0 && stopErr == nil && finished >= errorRateMinFinished &&
+			float64(progress.Failed)/float64(finished) > opts.StopOnErrorRate {
+			stopErr = fmt.Errorf("%w: %d of %d sends failed", ErrBulkSendStopped, progress.Failed, finished)
+			stop()
+		}
+		progress.Elapsed = time.Since(start)
+		remaining := progress.Total - progress.Done()
+		progress.ETA = time.Duration(float64(progress.Elapsed) / float64(finished) * float64(remaining))
+		if opts.OnProgress != nil {
+			opts.OnProgress(progress)
+		}
+	}
+
+	pool := NewSenderPool(c, SenderPoolOptions{
+		Concurrency:   opts.Concurrency,
+		RatePerSecond: opts.RatePerSecond,
+		MaxRetries:    opts.MaxRetries,
+		RetryBackoff:  opts.RetryBackoff,
+		Attachments:   opts.Attachments,
+		OnSuccess:     finish,
+		OnDuplicate:   finish,
+		OnFailure:     finish,
+	})
+	jobs := make(chan SendJob)
+	go func() {
+		defer close(jobs)
+		for i := range reqs {
+			if _, ok := sent[i]; ok {
+				continue
+			}
+			select {
+			case jobs <- result.Results[i].Job:
+			case <-runCtx.Done():
+				return
+			}
+		}
+	}()
+	pool.Run(runCtx, jobs)
+	for range jobs {
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	progress.Elapsed = time.Since(start)
+	result.Progress = progress
+	err := stopErr
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		for i := range result.Results {
+			if result.Results[i].Err == notSent {
+				result.Results[i].fail(fmt.Errorf("not sent: %w", err))
+			}
+		}
+	}
+	return result, err
+}
+
+// MemoryBulkStore keeps bulk send state in memory, for resuming within a
+// process and for tests
+type MemoryBulkStore struct {
+	mu   sync.Mutex
+	runs map[string]map[int]string
+}
+
+func (s *MemoryBulkStore) Sent(ctx context.Context, runID string) (map[int]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sent := make(map[int]string, len(s.runs[runID]))
+	for i, id := range s.runs[runID] {
+		sent[i] = id
+	}
+	return sent, nil
+}
+
+func (s *MemoryBulkStore) Record(ctx context.Context, runID string, index int, emailID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.runs == nil {
+		s.runs = make(map[string]map[int]string)
+	}
+	if s.runs[runID] == nil {
+		s.runs[runID] = make(map[int]string)
+	}
+	s.runs[runID][index] = emailID
+	return nil
+}
+
+// FileBulkStore appends sent items to a file in Dir per run, one
+// "<index>\t<email-id>" line each, so a crashed send loses at most the
+// items in flight
+type FileBulkStore struct {
+	Dir string
+
+	mu sync.Mutex
+}
+
+func (s *FileBulkStore) Sent(ctx context.Context, runID string) (map[int]string, error) {
+	sent := map[int]string{}
+	f, err := os.Open(s.path(runID))
+	if errors.Is(err, os.ErrNotExist) {
+		return sent, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadString('\n')
+		if err == io.EOF {
+			// a torn last line from a crash is ignored and its item resent
+			return sent, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		index, id, _ := strings.Cut(strings.TrimSuffix(line, "\n"), "\t")
+		if i, err := strconv.Atoi(index); err == nil {
+			sent[i] = id
+		}
+	}
+}
+
+func (s *FileBulkStore) Record(ctx context.Context, runID string, index int, emailID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.path(runID), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(f, "%d\t%s\n", index, emailID); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (s *FileBulkStore) path(runID string) string {
+	return filepath.Join(s.Dir, strings.ReplaceAll(runID, string(filepath.Separator), "_")+".bulk")
+}
@@ -0,0 +1,120 @@
+package inboundgo_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func bulkRequests(n int) []*inboundgo.PostEmailsRequest {
+	reqs := make([]*inboundgo.PostEmailsRequest, n)
+	for i := range reqs {
+		reqs[i] = &inboundgo.PostEmailsRequest{From: "news@example.com", To: fmt.Sprintf("user%d@example.com", i), Subject: "News"}
+	}
+	return reqs
+}
+
+func TestBulkSendResumes(t *testing.T) {
+	var mu sync.Mutex
+	keys := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys[r.Header.Get("Idempotency-Key")]++
+		mu.Unlock()
+		w.Write([]byte(`{"id":"email-1"}`))
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+
+	reqs := bulkRequests(10)
+	store := &inboundgo.FileBulkStore{Dir: t.TempDir()}
+	ctx, cancel := context.WithCancel(context.Background())
+	var last inboundgo.BulkProgress
+	result, err := client.BulkSend(ctx, reqs, inboundgo.BulkOptions{
+		Concurrency: 1,
+		RunID:       "newsletter-42",
+		Store:       store,
+		OnProgress: func(p inboundgo.BulkProgress) {
+			last = p
+			if p.Sent == 4 {
+				cancel()
+			}
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the send to stop on cancellation, got %v", err)
+	}
+	if last.Total != 10 || last.Sent != 4 || last.ETA <= 0 {
+		t.Errorf("Unexpected progress %+v", last)
+	}
+	if len(result.Failed()) != 6 || !errors.Is(result.Results[9].Err, context.Canceled) {
+		t.Errorf("Expected the unsent items to be reported, got %v", result.Failed())
+	}
+
+	result, err = client.BulkSend(context.Background(), reqs, inboundgo.BulkOptions{RunID: "newsletter-42", Store: store})
+	if err != nil {
+		t.Fatalf("Resumed BulkSend failed: %v", err)
+	}
+	if p := result.Progress; p.Skipped != 4 || p.Sent != 6 || p.Done() != 10 {
+		t.Errorf("Unexpected progress after resuming %+v", p)
+	}
+	if len(keys) != 10 {
+		t.Errorf("Expected 10 distinct idempotency keys, got %d", len(keys))
+	}
+	for key, n := range keys {
+		if n != 1 {
+			t.Errorf("Key %s was sent %d times", key, n)
+		}
+	}
+	if result.Results[0].Status != inboundgo.SendSkipped || result.Results[0].IdempotencyKey != "newsletter-42-0" {
+		t.Errorf("Unexpected first result %+v", result.Results[0])
+	}
+}
+
+func TestBulkSendStopsOnErrorRate(t *testing.T) {
+	var sends int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&sends, 1)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"Domain not verified"}`))
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+
+	result, err := client.BulkSend(context.Background(), bulkRequests(100), inboundgo.BulkOptions{
+		Concurrency:     2,
+		StopOnErrorRate: 0.5,
+	})
+	if !errors.Is(err, inboundgo.ErrBulkSendStopped) {
+		t.Fatalf("Expected ErrBulkSendStopped, got %v", err)
+	}
+	// Handlers of abandoned requests may still be running until Close
+	server.Close()
+	if n := atomic.LoadInt32(&sends); n >= 100 || len(result.Failed()) != 100 {
+		t.Errorf("Expected the send to stop early, made %d sends, %d failed", n, len(result.Failed()))
+	}
+	if !errors.Is(result.Results[99].Err, inboundgo.ErrBulkSendStopped) {
+		t.Errorf("Expected unsent items to report the stop, got %v", result.Results[99].Err)
+	}
+}
+
+func TestFileBulkStoreIgnoresTornLine(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "run.bulk"), []byte("0\temail-0\n1\temail-1\n12\tem"), 0o600)
+	sent, err := (&inboundgo.FileBulkStore{Dir: dir}).Sent(context.Background(), "run")
+	if err != nil {
+		t.Fatalf("Sent failed: %v", err)
+	}
+	if len(sent) != 2 || sent[1] != "email-1" {
+		t.Errorf("Unexpected state %v", sent)
+	}
+}
@@ -0,0 +1,202 @@
+package inboundgo
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheStore is a pluggable key/value store for cached GET responses.
+// Implementations must be safe for concurrent use; a Redis or memcached
+// adapter only needs these four methods.
+type CacheStore interface {
+	// Get returns the value for key if present and not expired.
+	Get(key string) ([]byte, bool)
+	// Set stores value for key for the given ttl.
+	Set(key string, value []byte, ttl time.Duration)
+	// Delete removes key.
+	Delete(key string)
+	// DeletePrefix removes every key starting with prefix.
+	DeletePrefix(prefix string)
+}
+
+// DefaultCachePaths are the API paths cached when ResponseCacheOptions.Paths
+// is empty. They cover metadata that is read on hot routing paths and
+// changes rarely.
+var DefaultCachePaths = []string{"/domains", "/email-addresses", "/endpoints"}
+
+// cacheDependencies lists the cached paths whose responses embed data owned
+// by another resource, so a mutation on the key also invalidates the values.
+var cacheDependencies = map[string][]string{
+	"/domains":         {"/email-addresses"},
+	"/email-addresses": {"/domains"},
+	"/endpoints":       {"/domains", "/email-addresses"},
+}
+
+// ResponseCacheOptions configures the in-process response cache
+type ResponseCacheOptions struct {
+	// Store holds cached bodies (default: a new MemoryCacheStore).
+	Store CacheStore
+	// TTL is how long a response stays cached (default: 1 minute).
+	TTL time.Duration
+	// Paths restricts caching to GETs under these path prefixes
+	// (default: DefaultCachePaths).
+	Paths []string
+}
+
+// responseCache is the configured cache attached to a client
+type responseCache struct {
+	store CacheStore
+	ttl   time.Duration
+	paths []string
+}
+
+// WithResponseCache enables an opt-in TTL cache for idempotent GET requests.
+// Successful mutating requests (POST, PUT, PATCH, DELETE) automatically
+// invalidate cached entries for the affected resource; InvalidateCache can
+// be used for explicit invalidation.
+func (c *Inbound) WithResponseCache(opts ResponseCacheOptions) *Inbound {
+	if opts.Store == nil {
+		opts.Store = NewMemoryCacheStore()
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = time.Minute
+	}
+	if len(opts.Paths) == 0 {
+		opts.Paths = DefaultCachePaths
+	}
+	c.cache = &responseCache{store: opts.Store, ttl: opts.TTL, paths: opts.Paths}
+	return c
+}
+
+// InvalidateCache removes cached responses under the given path prefixes,
+// e.g. "/domains". With no arguments every cached path is invalidated.
+func (c *Inbound) InvalidateCache(paths ...string) {
+	if c.cache == nil {
+		return
+	}
+	if len(paths) == 0 {
+		paths = c.cache.paths
+	}
+	for _, p := range paths {
+		c.cache.store.DeletePrefix(p)
+	}
+}
+
+// cachedResponse returns a cached body for a GET request
+func (c *Inbound) cachedResponse(method, endpoint string) ([]byte, bool) {
+	if c.cache == nil || method != http.MethodGet || !c.cache.cacheable(endpoint) {
+		return nil, false
+	}
+	return c.cache.store.Get(endpoint)
+}
+
+// updateCache stores successful GET responses and invalidates entries
+// affected by successful mutations.
+func (c *Inbound) updateCache(method, endpoint string, status int, body []byte) {
+	if c.cache == nil || status >= 400 {
+		return
+	}
+	if method == http.MethodGet {
+		if status == http.StatusOK && c.cache.cacheable(endpoint) {
+			c.cache.store.Set(endpoint, body, c.cache.ttl)
+		}
+		return
+	}
+
+	root := resourceRoot(endpoint)
+	c.cache.store.DeletePrefix(root)
+	for _, dep := range cacheDependencies[root] {
+		c.cache.store.DeletePrefix(dep)
+	}
+}
+
+func (rc *responseCache) cacheable(endpoint string) bool {
+	for _, p := range rc.paths {
+		if pathHasPrefix(endpoint, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// pathHasPrefix reports whether endpoint is prefix itself or nested under it,
+// ignoring any query string. "/domains" matches "/domains/abc" and
+// "/domains?limit=1" but not "/domainsx".
+func pathHasPrefix(endpoint, prefix string) bool {
+	if !strings.HasPrefix(endpoint, prefix) {
+		return false
+	}
+	rest := endpoint[len(prefix):]
+	return rest == "" || rest[0] == '/' || rest[0] == '?'
+}
+
+// resourceRoot returns the first path segment of an endpoint, e.g.
+// "/domains/abc/auth" becomes "/domains".
+func resourceRoot(endpoint string) string {
+	path := endpoint
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	if i := strings.IndexByte(strings.TrimPrefix(path, "/"), '/'); i >= 0 {
+		return path[:i+1]
+	}
+	return path
+}
+
+// MemoryCacheStore is an in-process CacheStore with per-entry expiry
+type MemoryCacheStore struct {
+	mu      sync.RWMutex
+	entries map[string]memoryCacheEntry
+}
+
+type memoryCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemoryCacheStore creates an empty in-memory cache store
+func NewMemoryCacheStore() *MemoryCacheStore {
+	return &MemoryCacheStore{entries: make(map[string]memoryCacheEntry)}
+}
+
+// Get returns the value for key if present and not expired
+func (m *MemoryCacheStore) Get(key string) ([]byte, bool) {
+	m.mu.RLock()
+	e, ok := m.entries[key]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(e.expiresAt) {
+		m.Delete(key)
+		return nil, false
+	}
+	return e.value, true
+}
+
+// Set stores value for key for the given ttl
+func (m *MemoryCacheStore) Set(key string, value []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = memoryCacheEntry{value: value, expiresAt: time.Now().Add(ttl)}
+}
+
+// Delete removes key
+func (m *MemoryCacheStore) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+}
+
+// DeletePrefix removes every key starting with prefix
+func (m *MemoryCacheStore) DeletePrefix(prefix string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for k := range m.entries {
+		if strings.HasPrefix(k, prefix) {
+			delete(m.entries, k)
+		}
+	}
+}
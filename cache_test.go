@@ -0,0 +1,100 @@
+package inboundgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestResponseCache(t *testing.T) {
+	hits := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits[r.Method+" "+r.URL.Path]++
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/domains":
+			w.Write([]byte(`{"data": [{"id": "dom-1", "domain": "example.com"}], "pagination": {"total": 1}}`))
+		case "/email-addresses/addr-1":
+			w.Write([]byte(`{"id": "addr-1", "address": "support@example.com"}`))
+		case "/mail":
+			w.Write([]byte(`{"emails": [], "pagination": {"total": 0}}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.WithResponseCache(inboundgo.ResponseCacheOptions{TTL: time.Minute})
+	ctx := context.Background()
+
+	t.Run("should serve repeated GETs from cache", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			resp, _ := client.Domain().List(ctx, nil)
+			if resp.Data == nil || len(resp.Data.Data) != 1 {
+				t.Fatalf("Expected cached domain list, got %+v", resp)
+			}
+			addr, _ := client.Email().Address.Get(ctx, "addr-1")
+			if addr.Data == nil || addr.Data.Address != "support@example.com" {
+				t.Fatalf("Expected cached address, got %+v", addr)
+			}
+		}
+		if hits["GET /domains"] != 1 || hits["GET /email-addresses/addr-1"] != 1 {
+			t.Errorf("Expected one request per path, got %v", hits)
+		}
+	})
+
+	t.Run("should not cache paths outside the configured set", func(t *testing.T) {
+		client.Mail().List(ctx, nil)
+		client.Mail().List(ctx, nil)
+		if hits["GET /mail"] != 2 {
+			t.Errorf("Expected mail listing to bypass the cache, got %d requests", hits["GET /mail"])
+		}
+	})
+
+	t.Run("should invalidate on mutation", func(t *testing.T) {
+		client.Email().Address.Update(ctx, "addr-1", &inboundgo.PutEmailAddressByIDRequest{IsActive: inboundgo.Bool(false)})
+		client.Email().Address.Get(ctx, "addr-1")
+		client.Domain().List(ctx, nil)
+		if hits["GET /email-addresses/addr-1"] != 2 {
+			t.Errorf("Expected address to be refetched after update, got %d requests", hits["GET /email-addresses/addr-1"])
+		}
+		if hits["GET /domains"] != 2 {
+			t.Errorf("Expected dependent domain list to be refetched, got %d requests", hits["GET /domains"])
+		}
+	})
+
+	t.Run("should invalidate explicitly", func(t *testing.T) {
+		client.InvalidateCache("/domains")
+		client.Domain().List(ctx, nil)
+		if hits["GET /domains"] != 3 {
+			t.Errorf("Expected refetch after InvalidateCache, got %d requests", hits["GET /domains"])
+		}
+	})
+}
+
+func TestMemoryCacheStoreExpiry(t *testing.T) {
+	store := inboundgo.NewMemoryCacheStore()
+	store.Set("/domains", []byte("x"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := store.Get("/domains"); ok {
+		t.Error("Expected entry to expire")
+	}
+
+	store.Set("/domains/a", []byte("a"), time.Minute)
+	store.Set("/domainsx", []byte("b"), time.Minute)
+	store.DeletePrefix("/domains/")
+	if _, ok := store.Get("/domains/a"); ok {
+		t.Error("Expected prefix delete to remove /domains/a")
+	}
+	if _, ok := store.Get("/domainsx"); !ok {
+		t.Error("Expected unrelated key to survive")
+	}
+}
@@ -0,0 +1,207 @@
+package inboundgo
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CalendarMethod is the iTIP method of a calendar invite
+type CalendarMethod string
+
+const (
+	// CalendarRequest invites attendees or updates an event
+	CalendarRequest CalendarMethod = "REQUEST"
+	// CalendarCancel cancels a previously sent event
+	CalendarCancel CalendarMethod = "CANCEL"
+)
+
+// CalendarAttendee is an invited participant
+type CalendarAttendee struct {
+	Email string
+	Name  string
+	// Optional marks the attendee as an optional participant.
+	Optional bool
+}
+
+// CalendarEvent is a meeting sent as an iCalendar invite
+type CalendarEvent struct {
+	// UID identifies the event across updates and cancellation. A UID is
+	// generated on first use when empty; keep it to update or cancel the
+	// event later.
+	UID string
+	// Sequence must be increased each time the event is updated or
+	// cancelled.
+	Sequence    int
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Start       time.Time
+	End         time.Time
+	// AllDay uses the dates of Start and End; End is exclusive.
+	AllDay         bool
+	OrganizerEmail string
+	OrganizerName  string
+	Attendees      []CalendarAttendee
+}
+
+// ICS renders the event as an iCalendar (RFC 5545) object for method
+func (e *CalendarEvent) ICS(method CalendarMethod) ([]byte, error) {
+	if method != CalendarRequest && method != CalendarCancel {
+		return nil, fmt.Errorf("unsupported calendar method %q", method)
+	}
+	if e.Start.IsZero() {
+		return nil, errors.New("calendar event has no start time")
+	}
+	if !e.End.IsZero() && e.End.Before(e.Start) {
+		return nil, errors.New("calendar event ends before it starts")
+	}
+	if e.OrganizerEmail == "" {
+		return nil, errors.New("calendar event has no organizer")
+	}
+	// Addresses and the URL are written unescaped, so a line break in one
+	// would inject its own properties
+	if hasControlChars(e.OrganizerEmail) {
+		return nil, fmt.Errorf("invalid organizer email %q", e.OrganizerEmail)
+	}
+	for _, a := range e.Attendees {
+		if hasControlChars(a.Email) {
+			return nil, fmt.Errorf("invalid attendee email %q", a.Email)
+		}
+	}
+	if hasControlChars(e.URL) {
+		return nil, fmt.Errorf("invalid calendar event URL %q", e.URL)
+	}
+	if e.UID == "" {
+		e.UID = newIdempotencyKey() + "@inbound.new"
+	}
+
+	var b strings.Builder
+	line := func(s string) { writeContentLine(&b, s) }
+	line("BEGIN:VCALENDAR")
+	line("PRODID:-//Inbound//inbound-golang-sdk//EN")
+	line("VERSION:2.0")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:" + string(method))
+	line("BEGIN:VEVENT")
+	line("UID:" + escapeContentText(e.UID))
+	line("SEQUENCE:" + fmt.Sprint(e.Sequence))
+	line("DTSTAMP:" + formatICSTime(time.Now()))
+	if e.AllDay {
+		end := e.End
+		if end.IsZero() || !end.After(e.Start) {
+			end = e.Start.AddDate(0, 0, 1)
+		}
+		line("DTSTART;VALUE=DATE:" + e.Start.Format("20060102"))
+		line("DTEND;VALUE=DATE:" + end.Format("20060102"))
+	} else {
+		line("DTSTART:" + formatICSTime(e.Start))
+		if !e.End.IsZero() {
+			line("DTEND:" + formatICSTime(e.End))
+		}
+	}
+	line("SUMMARY:" + escapeContentText(e.Summary))
+	if e.Description != "" {
+		line("DESCRIPTION:" + escapeContentText(e.Description))
+	}
+	if e.Location != "" {
+		line("LOCATION:" + escapeContentText(e.Location))
+	}
+	if e.URL != "" {
+		line("URL:" + e.URL)
+	}
+	line("ORGANIZER" + icsCommonName(e.OrganizerName) + ":mailto:" + e.OrganizerEmail)
+	for _, a := range e.Attendees {
+		role := "REQ-PARTICIPANT"
+		if a.Optional {
+			role = "OPT-PARTICIPANT"
+		}
+		line("ATTENDEE" + icsCommonName(a.Name) + ";ROLE=" + role + ";PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:" + a.Email)
+	}
+	if method == CalendarCancel {
+		line("STATUS:CANCELLED")
+	} else {
+		line("STATUS:CONFIRMED")
+	}
+	line("END:VEVENT")
+	line("END:VCALENDAR")
+	return []byte(b.String()), nil
+}
+
+// CalendarInvite returns the event as a text/calendar attachment for
+// method
+func CalendarInvite(event *CalendarEvent, method CalendarMethod) (AttachmentData, error) {
+	ics, err := event.ICS(method)
+	if err != nil {
+		return AttachmentData{}, err
+	}
+	filename := "invite.ics"
+	if method == CalendarCancel {
+		filename = "cancel.ics"
+	}
+	return AttachmentData{
+		Content:     String(base64.StdEncoding.EncodeToString(ics)),
+		Filename:    filename,
+		ContentType: String("text/calendar; charset=utf-8; method=" + string(method)),
+	}, nil
+}
+
+// AttachCalendarInvite attaches the event as an invite or cancellation and
+// sets the header Outlook uses to show it as a meeting request. Gmail and
+// Outlook then offer to add it to the recipient's calendar.
+func (r *PostEmailsRequest) AttachCalendarInvite(event *CalendarEvent, method CalendarMethod) error {
+	attachment, err := CalendarInvite(event, method)
+	if err != nil {
+		return err
+	}
+	r.Attachments = append(r.Attachments, attachment)
+	if r.Headers == nil {
+		r.Headers = make(map[string]string)
+	}
+	r.Headers["Content-Class"] = "urn:content-classes:calendarmessage"
+	return nil
+}
+
+func formatICSTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// escapeContentText escapes a TEXT value in iCalendar and vCard
+func escapeContentText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", "").Replace(s)
+}
+
+// icsCommonName renders a CN parameter, quoted since names may contain
+// separators
+func icsCommonName(name string) string {
+	if name == "" {
+		return ""
+	}
+	return `;CN="` + strings.NewReplacer(`"`, "", "\r", "", "\n", " ").Replace(name) + `"`
+}
+
+// hasControlChars reports whether s contains ASCII control characters
+func hasControlChars(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool { return r < 0x20 || r == 0x7f }) >= 0
+}
+
+// writeContentLine writes an iCalendar or vCard content line folded at 75
+// octets, without splitting UTF-8 sequences
+func writeContentLine(b *strings.Builder, s string) {
+	limit := 75
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut] + "\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space, which counts
+		limit = 74
+	}
+	b.WriteString(s + "\r\n")
+}
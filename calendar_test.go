@@ -0,0 +1,123 @@
+package inboundgo_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestCalendarEventICS(t *testing.T) {
+	event := &inboundgo.CalendarEvent{
+		UID:            "evt-1@acme.test",
+		Summary:        "Planning; Q3, budget",
+		Description:    "Agenda:\n1. Review",
+		Location:       "Room 4",
+		Start:          time.Date(2025, 3, 10, 15, 0, 0, 0, time.FixedZone("CET", 3600)),
+		End:            time.Date(2025, 3, 10, 16, 0, 0, 0, time.FixedZone("CET", 3600)),
+		OrganizerEmail: "ada@acme.test",
+		OrganizerName:  "Ada Lovelace",
+		Attendees: []inboundgo.CalendarAttendee{
+			{Email: "bob@example.com", Name: "Bob"},
+			{Email: "eve@example.com", Optional: true},
+		},
+	}
+	ics, err := event.ICS(inboundgo.CalendarRequest)
+	if err != nil {
+		t.Fatalf("ICS failed: %v", err)
+	}
+	text := string(ics)
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"METHOD:REQUEST\r\n",
+		"UID:evt-1@acme.test\r\n",
+		"DTSTART:20250310T140000Z\r\n",
+		"DTEND:20250310T150000Z\r\n",
+		`SUMMARY:Planning\; Q3\, budget` + "\r\n",
+		`DESCRIPTION:Agenda:\n1. Review` + "\r\n",
+		`ORGANIZER;CN="Ada Lovelace":mailto:ada@acme.test` + "\r\n",
+		"ROLE=OPT-PARTICIPANT",
+		"STATUS:CONFIRMED\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected ICS to contain %q:\n%s", want, text)
+		}
+	}
+	for _, line := range strings.Split(text, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("Line longer than 75 octets: %q", line)
+		}
+	}
+
+	cancel, err := event.ICS(inboundgo.CalendarCancel)
+	if err != nil {
+		t.Fatalf("ICS failed: %v", err)
+	}
+	if !strings.Contains(string(cancel), "METHOD:CANCEL\r\n") || !strings.Contains(string(cancel), "STATUS:CANCELLED\r\n") {
+		t.Errorf("Unexpected cancellation:\n%s", cancel)
+	}
+}
+
+func TestCalendarEventFoldsLongLines(t *testing.T) {
+	event := &inboundgo.CalendarEvent{
+		Summary:        strings.Repeat("Überprüfung ", 20),
+		Start:          time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+		AllDay:         true,
+		OrganizerEmail: "ada@acme.test",
+	}
+	ics, err := event.ICS(inboundgo.CalendarRequest)
+	if err != nil {
+		t.Fatalf("ICS failed: %v", err)
+	}
+	if event.UID == "" {
+		t.Error("Expected a UID to be generated")
+	}
+	text := string(ics)
+	if !strings.Contains(text, "DTSTART;VALUE=DATE:20250310\r\nDTEND;VALUE=DATE:20250311\r\n") {
+		t.Errorf("Expected all-day dates:\n%s", text)
+	}
+	unfolded := strings.ReplaceAll(text, "\r\n ", "")
+	if !strings.Contains(unfolded, "SUMMARY:"+strings.Repeat("Überprüfung ", 20)) {
+		t.Errorf("Summary did not survive folding:\n%s", text)
+	}
+}
+
+func TestCalendarEventRejectsLineBreaksInAddresses(t *testing.T) {
+	start := time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC)
+	for name, event := range map[string]*inboundgo.CalendarEvent{
+		"organizer": {Start: start, OrganizerEmail: "ada@acme.test\r\nATTENDEE:mailto:eve@evil.test"},
+		"attendee": {Start: start, OrganizerEmail: "ada@acme.test", Attendees: []inboundgo.CalendarAttendee{
+			{Email: "bob@example.com\nSTATUS:CANCELLED"},
+		}},
+	} {
+		if _, err := event.ICS(inboundgo.CalendarRequest); err == nil {
+			t.Errorf("Expected an error for a line break in the %s email", name)
+		}
+	}
+}
+
+func TestAttachCalendarInvite(t *testing.T) {
+	req := &inboundgo.PostEmailsRequest{Subject: "Invitation: Planning"}
+	event := &inboundgo.CalendarEvent{Summary: "Planning", Start: time.Now(), OrganizerEmail: "ada@acme.test"}
+	if err := req.AttachCalendarInvite(event, inboundgo.CalendarRequest); err != nil {
+		t.Fatalf("AttachCalendarInvite failed: %v", err)
+	}
+	a := req.Attachments[0]
+	if a.Filename != "invite.ics" || *a.ContentType != "text/calendar; charset=utf-8; method=REQUEST" {
+		t.Errorf("Unexpected attachment %+v", a)
+	}
+	data, _ := base64.StdEncoding.DecodeString(*a.Content)
+	if !strings.Contains(string(data), "SUMMARY:Planning") {
+		t.Errorf("Unexpected attachment content %s", data)
+	}
+	if req.Headers["Content-Class"] != "urn:content-classes:calendarmessage" {
+		t.Errorf("Expected Content-Class header, got %v", req.Headers)
+	}
+
+	if err := req.AttachCalendarInvite(&inboundgo.CalendarEvent{Start: time.Now()}, inboundgo.CalendarRequest); err == nil {
+		t.Error("Expected error for event without organizer")
+	}
+}
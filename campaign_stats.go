@@ -0,0 +1,91 @@
+package inboundgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// campaignPageSize is how many emails CampaignStats lists per request
+const campaignPageSize = 100
+
+// CampaignReport summarizes how the emails sent with a tag fared, for
+// dashboards. The rates are as the EmailStats methods compute them.
+type CampaignReport struct {
+	Tag           EmailTag   `json:"tag"`
+	Since         *time.Time `json:"since,omitempty"`
+	Until         *time.Time `json:"until,omitempty"`
+	Stats         EmailStats `json:"stats"`
+	DeliveryRate  float64    `json:"deliveryRate"`
+	OpenRate      float64    `json:"openRate"`
+	ClickRate     float64    `json:"clickRate"`
+	BounceRate    float64    `json:"bounceRate"`
+	ComplaintRate float64    `json:"complaintRate"`
+	GeneratedAt   time.Time  `json:"generatedAt"`
+}
+
+// CampaignStats lists the emails sent with params.Tag, such as
+// campaign=spring-launch, within the optional date range, and counts their
+// events. params.Limit caps how many emails are counted (default all);
+// they are listed campaignPageSize at a time. It fetches each email's event
+// timeline, so a large campaign takes a request per email; run it in the
+// background, not per page view.
+func (s *EmailService) CampaignStats(ctx context.Context, params *GetEmailsRequest) (*CampaignReport, error) {
+	if params == nil || params.Tag == nil || params.Tag.Name == "" {
+		return nil, errors.New("campaign tag is required")
+	}
+	page := *params
+	offset := 0
+	if page.Offset != nil {
+		offset = *page.Offset
+	}
+
+	var ids []string
+	for params.Limit == nil || len(ids) < *params.Limit {
+		size := campaignPageSize
+		if params.Limit != nil {
+			size = min(size, *params.Limit-len(ids))
+		}
+		page.Limit = Int(size)
+		page.Offset = Int(offset)
+		resp, err := s.List(ctx, &page)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Error != "" {
+			return nil, fmt.Errorf("failed to list campaign emails: %w", &ApiError{StatusCode: resp.StatusCode, Message: resp.Error})
+		}
+		if resp.Data == nil {
+			return nil, fmt.Errorf("failed to list campaign emails: %w", ErrNoData)
+		}
+		for _, email := range resp.Data.Data {
+			if params.Limit != nil && len(ids) == *params.Limit {
+				break
+			}
+			ids = append(ids, email.ID)
+		}
+		offset += len(resp.Data.Data)
+		p := resp.Data.Pagination
+		if len(resp.Data.Data) == 0 || !p.HasMore && (p.Total == 0 || offset >= p.Total) {
+			break
+		}
+	}
+
+	stats, err := s.Stats(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return &CampaignReport{
+		Tag:           *params.Tag,
+		Since:         params.Since,
+		Until:         params.Until,
+		Stats:         *stats,
+		DeliveryRate:  stats.DeliveryRate(),
+		OpenRate:      stats.OpenRate(),
+		ClickRate:     stats.ClickRate(),
+		BounceRate:    stats.BounceRate(),
+		ComplaintRate: stats.ComplaintRate(),
+		GeneratedAt:   time.Now(),
+	}, nil
+}
@@ -0,0 +1,86 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestCampaignStats(t *testing.T) {
+	events := map[string]string{
+		"email_0": `{"type":"delivered","timestamp":"2025-01-16T10:00:00Z"},{"type":"opened","timestamp":"2025-01-16T10:05:00Z"},{"type":"clicked","timestamp":"2025-01-16T10:06:00Z"}`,
+		"email_1": `{"type":"delivered","timestamp":"2025-01-16T10:00:00Z"},{"type":"opened","timestamp":"2025-01-16T10:05:00Z"}`,
+		"email_2": `{"type":"delivered","timestamp":"2025-01-16T10:00:00Z"},{"type":"complained","timestamp":"2025-01-16T11:00:00Z"}`,
+		"email_3": `{"type":"bounced","timestamp":"2025-01-16T10:00:00Z","bounce_type":"hard"}`,
+		"email_4": `{"type":"delivered","timestamp":"2025-01-16T10:00:00Z"}`,
+	}
+	var pages int
+	var limits []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/emails/"), "/events"); ok {
+			fmt.Fprintf(w, `{"email_id":%q,"events":[%s]}`, id, events[id])
+			return
+		}
+		q := r.URL.Query()
+		if q.Get("tag[name]") != "campaign" || q.Get("tag[value]") != "spring-launch" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+		pages++
+		limits = append(limits, q.Get("limit"))
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		// The server caps pages at two emails
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		limit = min(limit, 2)
+		var emails []map[string]string
+		for i := offset; i < min(offset+limit, 5); i++ {
+			emails = append(emails, map[string]string{"id": fmt.Sprintf("email_%d", i)})
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"data":       emails,
+			"pagination": map[string]any{"limit": limit, "offset": offset, "total": 5, "hasMore": offset+limit < 5},
+		})
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+
+	tag := &inboundgo.EmailTag{Name: "campaign", Value: "spring-launch"}
+	report, err := client.Email().CampaignStats(context.Background(), &inboundgo.GetEmailsRequest{Tag: tag})
+	if err != nil {
+		t.Fatalf("CampaignStats failed: %v", err)
+	}
+	if pages != 3 {
+		t.Errorf("Expected 3 pages, got %d", pages)
+	}
+	want := inboundgo.EmailStats{Emails: 5, Delivered: 4, Opened: 2, Clicked: 1, Bounced: 1, Complained: 1}
+	if report.Stats != want {
+		t.Errorf("Expected %+v, got %+v", want, report.Stats)
+	}
+	if report.DeliveryRate != 0.8 || report.OpenRate != 0.5 || report.ClickRate != 0.25 || report.BounceRate != 0.2 || report.ComplaintRate != 0.25 {
+		t.Errorf("Unexpected rates %+v", report)
+	}
+
+	t.Run("limit caps the emails counted", func(t *testing.T) {
+		pages, limits = 0, nil
+		report, err := client.Email().CampaignStats(context.Background(), &inboundgo.GetEmailsRequest{Tag: tag, Limit: inboundgo.Int(3)})
+		if err != nil {
+			t.Fatalf("CampaignStats failed: %v", err)
+		}
+		if report.Stats.Emails != 3 {
+			t.Errorf("Expected 3 emails counted, got %d", report.Stats.Emails)
+		}
+		if strings.Join(limits, ",") != "3,1" {
+			t.Errorf("Expected page limits 3,1, got %v", limits)
+		}
+	})
+
+	if _, err := client.Email().CampaignStats(context.Background(), &inboundgo.GetEmailsRequest{}); err == nil {
+		t.Error("Expected a missing tag to be an error")
+	}
+}
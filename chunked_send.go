@@ -0,0 +1,107 @@
+package inboundgo
+
+import (
+	"context"
+	"fmt"
+)
+
+// MaxRecipientsPerEmail is the most recipients, across To, CC, and BCC,
+// that one email can have
+const MaxRecipientsPerEmail = 50
+
+// ChunkedSendResult is the outcome of SendChunked
+type ChunkedSendResult struct {
+	// IDs are the IDs of the emails sent, one per chunk, in order.
+	IDs []string
+	// Chunks are the emails the send was split into, in order. Emails
+	// after a failed one are not sent.
+	Chunks []*PostEmailsRequest
+}
+
+// SendChunked sends an email whose recipients exceed
+// MaxRecipientsPerEmail as several emails, each with a share of the
+// recipients and the rest of the email, tags and attachments included. An
+// email within the limit is sent as is.
+//
+// Recipients are taken in the order To, CC, BCC, keeping their role. A
+// chunk with no To recipients is addressed to its CC recipients, or, when
+// it has only BCC recipients, to From. With an idempotency key, each chunk
+// is sent with the key suffixed with its index, so retrying the call sends
+// no chunk twice. It stops at the first chunk that fails, returning the
+// IDs sent so far and an error wrapping an *ApiError.
+func (s *EmailService) SendChunked(ctx context.Context, params *PostEmailsRequest, options *IdempotencyOptions) (*ChunkedSendResult, error) {
+	result := &ChunkedSendResult{Chunks: chunkRecipients(params, MaxRecipientsPerEmail)}
+	for i, chunk := range result.Chunks {
+		chunkOptions := options
+		if options != nil && options.IdempotencyKey != "" && len(result.Chunks) > 1 {
+			chunkOptions = &IdempotencyOptions{IdempotencyKey: fmt.Sprintf("%s-%d", options.IdempotencyKey, i)}
+		}
+		resp, err := s.Send(ctx, chunk, chunkOptions)
+		if err == nil && resp.Error != "" {
+			err = &ApiError{StatusCode: resp.StatusCode, Message: resp.Error}
+		}
+		if err == nil && resp.Data == nil {
+			err = ErrNoData
+		}
+		if err != nil {
+			return result, fmt.Errorf("failed to send chunk %d of %d: %w", i+1, len(result.Chunks), err)
+		}
+		result.IDs = append(result.IDs, resp.Data.ID)
+	}
+	return result, nil
+}
+
+// chunkRecipients splits params into emails of at most size recipients
+func chunkRecipients(params *PostEmailsRequest, size int) []*PostEmailsRequest {
+	to, cc, bcc := addressList(params.To), addressList(params.CC), addressList(params.BCC)
+	if len(to)+len(cc)+len(bcc) <= size {
+		return []*PostEmailsRequest{params}
+	}
+
+	var chunks []*PostEmailsRequest
+	for len(to)+len(cc)+len(bcc) > 0 {
+		chunk := *params
+		chunk.To, chunk.CC, chunk.BCC = nil, nil, nil
+		room := size
+		if len(to) == 0 && len(cc) == 0 {
+			// Addressed to From, which takes a place
+			room--
+		}
+		take := func(list *[]string) []string {
+			n := min(room, len(*list))
+			taken := (*list)[:n:n]
+			*list = (*list)[n:]
+			room -= n
+			return taken
+		}
+		chunkTo, chunkCC, chunkBCC := take(&to), take(&cc), take(&bcc)
+		switch {
+		case len(chunkTo) > 0:
+			chunk.To = chunkTo
+			if len(chunkCC) > 0 {
+				chunk.CC = chunkCC
+			}
+		case len(chunkCC) > 0:
+			chunk.To = chunkCC
+		default:
+			chunk.To = params.From
+		}
+		if len(chunkBCC) > 0 {
+			chunk.BCC = chunkBCC
+		}
+		chunks = append(chunks, &chunk)
+	}
+	return chunks
+}
+
+// addressList returns the addresses of a To, CC, or BCC field, which may
+// be a string or a []string, with comma separated lists split up
+func addressList(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return expandAddresses([]string{v})
+	case []string:
+		return expandAddresses(v)
+	}
+	return nil
+}
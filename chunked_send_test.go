@@ -0,0 +1,91 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func addresses(prefix string, n int) []string {
+	list := make([]string, n)
+	for i := range list {
+		list[i] = fmt.Sprintf("%s%d@example.com", prefix, i)
+	}
+	return list
+}
+
+func TestSendChunked(t *testing.T) {
+	var sent []inboundgo.PostEmailsRequest
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req inboundgo.PostEmailsRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		sent = append(sent, req)
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if req.Subject == "fail" && len(sent) == 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"Invalid recipient"}`))
+			return
+		}
+		fmt.Fprintf(w, `{"id":"email-%d"}`, len(sent))
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	ctx := context.Background()
+
+	params := &inboundgo.PostEmailsRequest{
+		From:        "news@example.com",
+		To:          addresses("to", 120),
+		CC:          addresses("cc", 10),
+		BCC:         addresses("bcc", 30),
+		Subject:     "Update",
+		Tags:        []inboundgo.EmailTag{{Name: "campaign", Value: "update"}},
+		Attachments: []inboundgo.AttachmentData{{Filename: "notes.txt", Content: inboundgo.String("aGk=")}},
+	}
+	result, err := client.Email().SendChunked(ctx, params, &inboundgo.IdempotencyOptions{IdempotencyKey: "update-1"})
+	if err != nil {
+		t.Fatalf("SendChunked failed: %v", err)
+	}
+	if len(result.IDs) != 4 || result.IDs[3] != "email-4" {
+		t.Fatalf("Expected 4 emails, got %v", result.IDs)
+	}
+	count := func(v any) int { list, _ := v.([]any); return len(list) }
+	want := [][3]int{{50, 0, 0}, {50, 0, 0}, {20, 10, 20}, {0, 0, 10}}
+	for i, req := range sent {
+		if got := [3]int{count(req.To), count(req.CC), count(req.BCC)}; got != want[i] {
+			t.Errorf("Chunk %d has %v recipients, expected %v", i, got, want[i])
+		}
+		if len(req.Tags) != 1 || len(req.Attachments) != 1 {
+			t.Errorf("Chunk %d lost its tags or attachments", i)
+		}
+		if keys[i] != fmt.Sprintf("update-1-%d", i) {
+			t.Errorf("Chunk %d has key %q", i, keys[i])
+		}
+	}
+	if sent[3].To != "news@example.com" {
+		t.Errorf("Expected a BCC-only chunk to be addressed to From, got %v", sent[3].To)
+	}
+
+	sent, keys = nil, nil
+	small := &inboundgo.PostEmailsRequest{From: "news@example.com", To: addresses("to", 3), Subject: "Small"}
+	if _, err := client.Email().SendChunked(ctx, small, &inboundgo.IdempotencyOptions{IdempotencyKey: "small-1"}); err != nil || len(sent) != 1 || keys[0] != "small-1" {
+		t.Errorf("Expected an email within the limit to be sent as is, got %v %v", keys, err)
+	}
+
+	sent = nil
+	params.Subject = "fail"
+	result, err = client.Email().SendChunked(ctx, params, nil)
+	var apiErr *inboundgo.ApiError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Expected an API error, got %v", err)
+	}
+	if len(result.IDs) != 1 || len(sent) != 2 {
+		t.Errorf("Expected the send to stop at the failed chunk, got %v", result.IDs)
+	}
+}
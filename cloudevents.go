@@ -0,0 +1,201 @@
+package inboundgo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// CloudEventsSpecVersion is the CloudEvents version produced and accepted
+	CloudEventsSpecVersion = "1.0"
+	// CloudEventTypePrefix prefixes webhook event names to form CloudEvent
+	// types, e.g. "new.inbound.email.received"
+	CloudEventTypePrefix = "new.inbound."
+	// CloudEventSource is the source of events converted from webhooks; the
+	// endpoint ID is appended when known
+	CloudEventSource = "https://inbound.new"
+)
+
+// cloudEventsContentType marks a structured-mode CloudEvent
+const cloudEventsContentType = "application/cloudevents+json"
+
+// CloudEvent is a CloudEvents v1.0 event in the JSON event format. Data
+// holds a JSON payload; binary payloads are carried in DataBase64.
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            *time.Time      `json:"time,omitempty"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+	DataBase64      string          `json:"data_base64,omitempty"`
+}
+
+// CloudEvent converts the webhook to a CloudEvent whose data is the
+// payload itself. The ID is derived from the event and email ID, so a
+// redelivered webhook converts to the same event and can be deduplicated.
+func (w *WebhookPayload) CloudEvent() (*CloudEvent, error) {
+	return newCloudEvent(w, w.Event, w.Email.ID, w.Email.ID, w.Timestamp, w.Endpoint.ID)
+}
+
+// CloudEvent converts the delivery webhook to a CloudEvent whose data is
+// the payload itself, keyed by the bounce or complaint ID
+func (w *DeliveryWebhookPayload) CloudEvent() (*CloudEvent, error) {
+	var id, subject string
+	switch {
+	case w.Bounce != nil:
+		id, subject = w.Bounce.ID, w.Bounce.EmailID
+	case w.Complaint != nil:
+		id, subject = w.Complaint.ID, w.Complaint.EmailID
+	}
+	return newCloudEvent(w, w.Event, id, subject, w.Timestamp, w.Endpoint.ID)
+}
+
+func newCloudEvent(payload any, event, id, subject, timestamp, endpointID string) (*CloudEvent, error) {
+	if event == "" {
+		return nil, errors.New("webhook payload has no event")
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+	ce := &CloudEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		ID:              event + ":" + id,
+		Source:          CloudEventSource,
+		Type:            CloudEventTypePrefix + event,
+		Subject:         subject,
+		DataContentType: "application/json",
+		Data:            data,
+	}
+	if endpointID != "" {
+		ce.Source += "/endpoints/" + endpointID
+	}
+	if t, err := time.Parse(time.RFC3339, timestamp); err == nil {
+		ce.Time = &t
+	}
+	return ce, nil
+}
+
+// ParseCloudEvent parses a CloudEvent in the JSON event format (structured
+// content mode)
+func ParseCloudEvent(reader io.Reader) (*CloudEvent, error) {
+	var ce CloudEvent
+	if err := json.NewDecoder(reader).Decode(&ce); err != nil {
+		return nil, fmt.Errorf("failed to parse CloudEvent: %w", err)
+	}
+	if err := ce.validate(); err != nil {
+		return nil, err
+	}
+	return &ce, nil
+}
+
+// ParseCloudEventRequest reads a CloudEvent delivered over HTTP in either
+// structured content mode (application/cloudevents+json) or binary content
+// mode (attributes in ce- headers, data in the body)
+func ParseCloudEventRequest(r *http.Request) (*CloudEvent, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == cloudEventsContentType {
+		return ParseCloudEvent(r.Body)
+	}
+
+	ce := &CloudEvent{
+		SpecVersion:     r.Header.Get("Ce-Specversion"),
+		ID:              r.Header.Get("Ce-Id"),
+		Source:          r.Header.Get("Ce-Source"),
+		Type:            r.Header.Get("Ce-Type"),
+		Subject:         r.Header.Get("Ce-Subject"),
+		DataContentType: r.Header.Get("Content-Type"),
+	}
+	if v := r.Header.Get("Ce-Time"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CloudEvent time: %w", err)
+		}
+		ce.Time = &t
+	}
+	if err := ce.validate(); err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CloudEvent data: %w", err)
+	}
+	if isJSONMediaType(mediaType) {
+		ce.Data = data
+	} else if len(data) > 0 {
+		ce.DataBase64 = base64.StdEncoding.EncodeToString(data)
+	}
+	return ce, nil
+}
+
+func (e *CloudEvent) validate() error {
+	if e.SpecVersion != CloudEventsSpecVersion {
+		return fmt.Errorf("unsupported CloudEvents specversion %q", e.SpecVersion)
+	}
+	if e.ID == "" || e.Source == "" || e.Type == "" {
+		return errors.New("CloudEvent is missing id, source, or type")
+	}
+	return nil
+}
+
+// DataBytes returns the event data, decoding DataBase64 if needed
+func (e *CloudEvent) DataBytes() ([]byte, error) {
+	if e.DataBase64 != "" {
+		return base64.StdEncoding.DecodeString(e.DataBase64)
+	}
+	return e.Data, nil
+}
+
+// WebhookPayload decodes the event data as a webhook payload. If the
+// payload has no event name, it is taken from the CloudEvent type.
+func (e *CloudEvent) WebhookPayload() (*WebhookPayload, error) {
+	var payload WebhookPayload
+	if err := e.decodeData(&payload); err != nil {
+		return nil, err
+	}
+	if payload.Event == "" {
+		payload.Event = strings.TrimPrefix(e.Type, CloudEventTypePrefix)
+	}
+	return &payload, nil
+}
+
+// DeliveryWebhookPayload decodes the event data as a delivery webhook
+// payload (email.bounced or email.complained)
+func (e *CloudEvent) DeliveryWebhookPayload() (*DeliveryWebhookPayload, error) {
+	var payload DeliveryWebhookPayload
+	if err := e.decodeData(&payload); err != nil {
+		return nil, err
+	}
+	if payload.Event == "" {
+		payload.Event = strings.TrimPrefix(e.Type, CloudEventTypePrefix)
+	}
+	return &payload, nil
+}
+
+func (e *CloudEvent) decodeData(v any) error {
+	data, err := e.DataBytes()
+	if err != nil {
+		return fmt.Errorf("invalid CloudEvent data: %w", err)
+	}
+	if len(data) == 0 {
+		return errors.New("CloudEvent has no data")
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse CloudEvent data: %w", err)
+	}
+	return nil
+}
+
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "" || mediaType == "application/json" || mediaType == "text/json" || strings.HasSuffix(mediaType, "+json")
+}
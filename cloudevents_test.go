@@ -0,0 +1,130 @@
+package inboundgo_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestWebhookPayloadCloudEventRoundTrip(t *testing.T) {
+	payload, err := inboundgo.ParseWebhookPayload(strings.NewReader(`{
+		"event": "email.received",
+		"timestamp": "2025-01-15T10:30:00Z",
+		"email": {"id": "email-1", "recipient": "support@acme.test", "subject": "Hi"},
+		"endpoint": {"id": "ep-1", "name": "Support", "type": "webhook"}
+	}`))
+	if err != nil {
+		t.Fatalf("ParseWebhookPayload failed: %v", err)
+	}
+
+	ce, err := payload.CloudEvent()
+	if err != nil {
+		t.Fatalf("CloudEvent failed: %v", err)
+	}
+	if ce.SpecVersion != "1.0" || ce.Type != "new.inbound.email.received" || ce.ID != "email.received:email-1" {
+		t.Errorf("Unexpected attributes %+v", ce)
+	}
+	if ce.Source != "https://inbound.new/endpoints/ep-1" || ce.Subject != "email-1" {
+		t.Errorf("Unexpected source or subject: %q %q", ce.Source, ce.Subject)
+	}
+	if ce.Time == nil || ce.Time.Format("2006-01-02T15:04:05Z") != "2025-01-15T10:30:00Z" {
+		t.Errorf("Unexpected time %v", ce.Time)
+	}
+
+	encoded, err := json.Marshal(ce)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	parsed, err := inboundgo.ParseCloudEvent(bytes.NewReader(encoded))
+	if err != nil {
+		t.Fatalf("ParseCloudEvent failed: %v", err)
+	}
+	back, err := parsed.WebhookPayload()
+	if err != nil {
+		t.Fatalf("WebhookPayload failed: %v", err)
+	}
+	if back.Email.ID != "email-1" || back.Email.Recipient != "support@acme.test" || back.Endpoint.ID != "ep-1" {
+		t.Errorf("Unexpected payload %+v", back)
+	}
+}
+
+func TestDeliveryWebhookPayloadCloudEvent(t *testing.T) {
+	payload := &inboundgo.DeliveryWebhookPayload{
+		Event:  "email.bounced",
+		Bounce: &inboundgo.Bounce{ID: "bounce-1", EmailID: "sent-1", Recipient: "x@example.com", Type: "hard"},
+	}
+	ce, err := payload.CloudEvent()
+	if err != nil {
+		t.Fatalf("CloudEvent failed: %v", err)
+	}
+	if ce.ID != "email.bounced:bounce-1" || ce.Subject != "sent-1" || ce.Source != "https://inbound.new" || ce.Time != nil {
+		t.Errorf("Unexpected attributes %+v", ce)
+	}
+	back, err := ce.DeliveryWebhookPayload()
+	if err != nil {
+		t.Fatalf("DeliveryWebhookPayload failed: %v", err)
+	}
+	if back.Bounce == nil || back.Bounce.Recipient != "x@example.com" {
+		t.Errorf("Unexpected payload %+v", back)
+	}
+
+	if _, err := (&inboundgo.WebhookPayload{}).CloudEvent(); err == nil {
+		t.Error("Expected error for payload without event")
+	}
+}
+
+func TestParseCloudEventRequest(t *testing.T) {
+	t.Run("structured mode", func(t *testing.T) {
+		body := `{"specversion": "1.0", "id": "1", "source": "/s", "type": "new.inbound.email.received",
+			"data_base64": "eyJlbWFpbCI6IHsiaWQiOiAiZW1haWwtMSJ9fQ=="}`
+		req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/cloudevents+json; charset=utf-8")
+		ce, err := inboundgo.ParseCloudEventRequest(req)
+		if err != nil {
+			t.Fatalf("ParseCloudEventRequest failed: %v", err)
+		}
+		payload, err := ce.WebhookPayload()
+		if err != nil {
+			t.Fatalf("WebhookPayload failed: %v", err)
+		}
+		if payload.Email.ID != "email-1" || payload.Event != "email.received" {
+			t.Errorf("Unexpected payload %+v", payload)
+		}
+	})
+
+	t.Run("binary mode", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(`{"event": "email.received", "email": {"id": "email-2"}}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Ce-Specversion", "1.0")
+		req.Header.Set("Ce-Id", "abc")
+		req.Header.Set("Ce-Source", "https://inbound.new")
+		req.Header.Set("Ce-Type", "new.inbound.email.received")
+		req.Header.Set("Ce-Time", "2025-01-15T10:30:00Z")
+		ce, err := inboundgo.ParseCloudEventRequest(req)
+		if err != nil {
+			t.Fatalf("ParseCloudEventRequest failed: %v", err)
+		}
+		if ce.ID != "abc" || ce.Time == nil {
+			t.Errorf("Unexpected attributes %+v", ce)
+		}
+		payload, err := ce.WebhookPayload()
+		if err != nil {
+			t.Fatalf("WebhookPayload failed: %v", err)
+		}
+		if payload.Email.ID != "email-2" {
+			t.Errorf("Unexpected payload %+v", payload)
+		}
+	})
+
+	t.Run("missing attributes", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		if _, err := inboundgo.ParseCloudEventRequest(req); err == nil {
+			t.Error("Expected error for request without CloudEvent headers")
+		}
+	})
+}
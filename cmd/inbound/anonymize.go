@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	inbound "github.com/inboundemail/inbound-golang-sdk"
+)
+
+var anonymizeCommand = &command{
+	name:    "anonymize",
+	args:    "[file]... [flags]",
+	summary: "scrub personal data from webhook or mail JSON for sharing",
+	run:     runAnonymize,
+}
+
+func runAnonymize(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("anonymize")
+	salt := fs.String("salt", a.getenv("INBOUND_ANONYMIZE_SALT"), "secret `salt` keying the replacements; reuse it to keep them stable")
+	var keep listFlag
+	fs.Var(&keep, "keep-domain", "do not replace addresses at `domain`, such as your own; repeatable")
+	write := fs.Bool("w", false, "rewrite the files in place instead of printing them")
+	paths, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	anon := &inbound.Anonymizer{Salt: *salt, KeepDomains: keep}
+
+	if len(paths) == 0 {
+		if *write {
+			return usagef("-w needs files")
+		}
+		data, err := io.ReadAll(a.stdin)
+		if err != nil {
+			return err
+		}
+		out, err := anon.JSON(data)
+		if err != nil {
+			return fmt.Errorf("stdin: %w", err)
+		}
+		_, err = a.stdout.Write(out)
+		return err
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		out, err := anon.JSON(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if *write {
+			if err := os.WriteFile(path, out, 0o644); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := a.stdout.Write(out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
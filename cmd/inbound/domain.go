@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	inbound "github.com/inboundemail/inbound-golang-sdk"
+	"github.com/inboundemail/inbound-golang-sdk/dns"
+)
+
+var domainCommand = &command{
+	name: "domain",
+	sub: []*command{
+		{name: "list", args: "[flags]", summary: "list domains", run: runDomainList},
+		{name: "add", args: "<domain> [flags]", summary: "add a domain and print its DNS records", run: runDomainAdd},
+		{name: "verify", args: "<domain-id> [flags]", summary: "start verification of a domain", run: runDomainVerify},
+		{name: "setup", args: "<domain> [flags]", summary: "add a domain, apply its DNS records, and wait for verification", run: runDomainSetup},
+	},
+}
+
+func runDomainList(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("domain list")
+	status := fs.String("status", "", "filter by `status`: pending, verified, or failed")
+	asJSON := fs.Bool("json", false, "print the API response as JSON")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+	list, err := inbound.Unwrap(client.Domain().List(ctx, &inbound.GetDomainsRequest{
+		Limit:  inbound.Int(100),
+		Status: inbound.DomainStatus(*status),
+	}))
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return a.printJSON(list)
+	}
+
+	tw := a.table()
+	fmt.Fprintln(tw, "ID\tDOMAIN\tSTATUS\tCAN RECEIVE\tADDRESSES")
+	for _, d := range list.Data {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%t\t%d\n", d.ID, d.Domain, d.Status, d.CanReceiveEmails, d.Stats.TotalEmailAddresses)
+	}
+	return tw.Flush()
+}
+
+func runDomainAdd(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("domain add")
+	asJSON := fs.Bool("json", false, "print the API response as JSON")
+	names, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(names) != 1 {
+		return usagef("expected one domain")
+	}
+
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+	domain, err := inbound.Unwrap(client.Domain().Create(ctx, &inbound.PostDomainsRequest{Domain: names[0]}))
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return a.printJSON(domain)
+	}
+
+	fmt.Fprintf(a.stdout, "Added %s (%s, %s)\n\nAdd these DNS records, then run: inbound domain verify %s\n\n", domain.Domain, domain.ID, domain.Status, domain.ID)
+	a.printDNSRecords(domain.DNSRecords)
+	return nil
+}
+
+// printDNSRecords prints DNS records as a table
+func (a *app) printDNSRecords(records []inbound.DNSRecord) {
+	tw := a.table()
+	fmt.Fprintln(tw, "TYPE\tNAME\tVALUE")
+	for _, r := range records {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.Type, r.Name, r.Value)
+	}
+	tw.Flush()
+}
+
+func runDomainVerify(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("domain verify")
+	ids, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(ids) != 1 {
+		return usagef("expected one domain ID")
+	}
+
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+	result, err := inbound.Unwrap(client.Domain().Verify(ctx, ids[0]))
+	if err != nil {
+		return err
+	}
+	return a.printJSON(result)
+}
+
+func runDomainSetup(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("domain setup")
+	ttl := fs.Int("ttl", 3600, "TTL in `seconds` for the zone file records")
+	provider := fs.String("dns-provider", a.getenv("INBOUND_DNS_PROVIDER"), "apply the records with `provider`: cloudflare (using CLOUDFLARE_API_TOKEN and CLOUDFLARE_ZONE_ID) or command")
+	command := fs.String("dns-command", a.getenv("INBOUND_DNS_COMMAND"), "with --dns-provider command, the `command` run for each record with its type, name, and value appended")
+	noWait := fs.Bool("no-wait", false, "do not wait for verification")
+	interval := fs.Duration("interval", 30*time.Second, "time between verification checks")
+	timeout := fs.Duration("timeout", time.Hour, "give up waiting for verification after this `duration`")
+	names, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(names) != 1 {
+		return usagef("expected one domain")
+	}
+	dnsHost, err := a.dnsProvider(*provider, *command)
+	if err != nil {
+		return err
+	}
+
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+	domain, err := inbound.Unwrap(client.Domain().Create(ctx, &inbound.PostDomainsRequest{Domain: names[0]}))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "Added %s (%s, %s)\n\nDNS records:\n\n", domain.Domain, domain.ID, domain.Status)
+	a.printDNSRecords(domain.DNSRecords)
+	fmt.Fprintf(a.stdout, "\nZone file:\n\n%s\n", inbound.ZoneFile(domain.DNSRecords, *ttl))
+
+	if dnsHost != nil {
+		if err := inbound.ApplyDNSRecords(ctx, dnsHost, domain.DNSRecords); err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stdout, "Applied %d records with %s.\n", len(domain.DNSRecords), *provider)
+	}
+	if *noWait {
+		fmt.Fprintf(a.stdout, "Once the records are in place, run: inbound domain verify %s\n", domain.ID)
+		return nil
+	}
+
+	fmt.Fprintf(a.stdout, "Waiting for verification, checking every %s...\n", *interval)
+	waitCtx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+	final, err := client.Domain().WaitForDomain(waitCtx, domain.ID, *interval)
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		status := domain.Status
+		if final != nil {
+			status = final.Status
+		}
+		return fmt.Errorf("%s is still %s after %s; run inbound domain verify %s once DNS has propagated", domain.Domain, status, *timeout, domain.ID)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "%s is verified.\n", domain.Domain)
+	return nil
+}
+
+// dnsProvider returns the DNS provider named by --dns-provider, or nil if
+// none is set
+func (a *app) dnsProvider(name, command string) (inbound.DNSProvider, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case "cloudflare":
+		token, zone := a.getenv("CLOUDFLARE_API_TOKEN"), a.getenv("CLOUDFLARE_ZONE_ID")
+		if token == "" || zone == "" {
+			return nil, errors.New("CLOUDFLARE_API_TOKEN and CLOUDFLARE_ZONE_ID must be set")
+		}
+		return &dns.Cloudflare{APIToken: token, ZoneID: zone}, nil
+	case "command":
+		fields := strings.Fields(command)
+		if len(fields) == 0 {
+			return nil, usagef("--dns-command is required with --dns-provider command")
+		}
+		return dns.Command(fields[0], fields[1:]...), nil
+	}
+	return nil, usagef("unknown DNS provider %q", name)
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	inbound "github.com/inboundemail/inbound-golang-sdk"
+)
+
+var endpointCommand = &command{
+	name: "endpoint",
+	sub: []*command{
+		{name: "list", args: "[flags]", summary: "list endpoints", run: runEndpointList},
+		{name: "create", args: "[flags]", summary: "create a webhook or forwarding endpoint", run: runEndpointCreate},
+		{name: "test", args: "<endpoint-id> [flags]", summary: "send a test delivery to an endpoint and show how it answered", run: runEndpointTest},
+		{name: "deliveries", args: "<endpoint-id> [flags]", summary: "list recent delivery attempts to an endpoint", run: runEndpointDeliveries},
+	},
+}
+
+func runEndpointList(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("endpoint list")
+	endpointType := fs.String("type", "", "filter by `type`: webhook, email, or email_group")
+	asJSON := fs.Bool("json", false, "print the API response as JSON")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+	list, err := inbound.Unwrap(client.Endpoint().List(ctx, &inbound.GetEndpointsRequest{
+		Limit: inbound.Int(100),
+		Type:  inbound.EndpointType(*endpointType),
+	}))
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return a.printJSON(list)
+	}
+
+	tw := a.table()
+	fmt.Fprintln(tw, "ID\tNAME\tTYPE\tACTIVE\tDELIVERED\tFAILED")
+	for _, e := range list.Data {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%t\t%d\t%d\n", e.ID, e.Name, e.Type, e.IsActive, e.DeliveryStats.Successful, e.DeliveryStats.Failed)
+	}
+	return tw.Flush()
+}
+
+func runEndpointCreate(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("endpoint create")
+	var forwardTo listFlag
+	name := fs.String("name", "", "endpoint `name`")
+	description := fs.String("description", "", "endpoint `description`")
+	webhookURL := fs.String("url", "", "webhook `URL` to POST received mail to")
+	fs.Var(&forwardTo, "forward-to", "`address`es to forward received mail to; several make an email group")
+	asJSON := fs.Bool("json", false, "print the API response as JSON")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if *name == "" {
+		return usagef("--name is required")
+	}
+
+	req := &inbound.PostEndpointsRequest{Name: *name, Description: optional(*description)}
+	switch {
+	case *webhookURL != "" && len(forwardTo) > 0:
+		return usagef("--url and --forward-to are mutually exclusive")
+	case *webhookURL != "":
+		req.Type = inbound.EndpointTypeWebhook
+		req.Config = &inbound.WebhookConfig{URL: *webhookURL, Timeout: 30000, RetryAttempts: 3}
+	case len(forwardTo) == 1:
+		req.Type = inbound.EndpointTypeEmail
+		req.Config = &inbound.EmailConfig{Email: forwardTo[0]}
+	case len(forwardTo) > 1:
+		req.Type = inbound.EndpointTypeEmailGroup
+		req.Config = &inbound.EmailGroupConfig{Emails: forwardTo}
+	default:
+		return usagef("--url or --forward-to is required")
+	}
+
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+	endpoint, err := inbound.Unwrap(client.Endpoint().Create(ctx, req))
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return a.printJSON(endpoint)
+	}
+	fmt.Fprintln(a.stdout, endpoint.ID)
+	return nil
+}
+
+func runEndpointTest(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("endpoint test")
+	asJSON := fs.Bool("json", false, "print the API response as JSON")
+	ids, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(ids) != 1 {
+		return usagef("expected one endpoint ID")
+	}
+
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+	result, err := inbound.Unwrap(client.Endpoint().Test(ctx, ids[0]))
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		if err := a.printJSON(result); err != nil {
+			return err
+		}
+	} else {
+		outcome := "Delivered"
+		if !result.Success {
+			outcome = "Failed"
+		}
+		code := "no response"
+		if result.StatusCode != nil {
+			code = fmt.Sprintf("HTTP %d", *result.StatusCode)
+		}
+		fmt.Fprintf(a.stdout, "%s: %s in %s\n", outcome, code, result.Latency())
+		for _, detail := range []*string{result.Error, result.ResponseBody} {
+			if detail != nil && strings.TrimSpace(*detail) != "" {
+				fmt.Fprintf(a.stdout, "  %s\n", oneLine(*detail, 200))
+			}
+		}
+	}
+	if !result.Success {
+		return fmt.Errorf("test delivery to %s failed", ids[0])
+	}
+	return nil
+}
+
+func runEndpointDeliveries(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("endpoint deliveries")
+	failed := fs.Bool("failed", false, "only show failed deliveries")
+	asJSON := fs.Bool("json", false, "print the deliveries as JSON")
+	ids, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(ids) != 1 {
+		return usagef("expected one endpoint ID")
+	}
+
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+	endpoint, err := inbound.Unwrap(client.Endpoint().Get(ctx, ids[0]))
+	if err != nil {
+		return err
+	}
+	deliveries := []inbound.EndpointDelivery{}
+	for _, d := range endpoint.RecentDeliveries {
+		if !*failed || d.Status == "failed" {
+			deliveries = append(deliveries, d)
+		}
+	}
+	if *asJSON {
+		return a.printJSON(deliveries)
+	}
+
+	tw := a.table()
+	fmt.Fprintln(tw, "ID\tEMAIL\tSTATUS\tATTEMPTS\tLAST ATTEMPT\tCODE\tLATENCY\tERROR")
+	for _, d := range deliveries {
+		resp := d.Response()
+		last, code, latency := "-", "-", "-"
+		if d.LastAttemptAt != nil {
+			last = d.LastAttemptAt.Local().Format("2006-01-02 15:04:05")
+		}
+		if resp.StatusCode != 0 {
+			code = strconv.Itoa(resp.StatusCode)
+		}
+		if resp.Latency != 0 {
+			latency = resp.Latency.Round(time.Millisecond).String()
+		}
+		detail := resp.Error
+		if detail == "" && d.Status == "failed" {
+			detail = resp.Body
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\n", d.ID, d.EmailID, d.Status, d.Attempts, last, code, latency, oneLine(detail, 60))
+	}
+	return tw.Flush()
+}
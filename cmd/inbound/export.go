@@ -0,0 +1,271 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	inbound "github.com/inboundemail/inbound-golang-sdk"
+)
+
+var exportCommand = &command{
+	name:    "export",
+	args:    "--out <dir> [flags]",
+	summary: "export received emails as mbox, eml, jsonl, or maildir",
+	run:     runExport,
+}
+
+// exportStateFile records the emails already exported to a directory, one
+// "<id>\t<size>" line each after a "format <format>" line, so an
+// interrupted export resumes where it stopped. size is the length of the
+// mbox or jsonl file once the email was written; anything after the last
+// recorded size is a partial write and is truncated on resume.
+const exportStateFile = ".inbound-export"
+
+func runExport(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("export")
+	out := fs.String("out", "", "write the export to `dir`")
+	format := fs.String("format", "mbox", "`format`: mbox (one file), eml (a file per email), jsonl (one file), or maildir")
+	domain := fs.String("domain", "", "only export mail received by `domain`")
+	address := fs.String("address", "", "only export mail received by `address`")
+	since := fs.String("since", "", "only export mail received on or after `date` (YYYY-MM-DD or RFC 3339)")
+	archived := fs.Bool("include-archived", true, "include archived mail")
+	restart := fs.Bool("restart", false, "start over instead of resuming a previous export to the same directory")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if *out == "" {
+		return usagef("--out is required")
+	}
+	switch *format {
+	case "mbox", "eml", "jsonl", "maildir":
+	default:
+		return usagef("unknown format %q", *format)
+	}
+	var sinceTime time.Time
+	if *since != "" {
+		var err error
+		if sinceTime, err = parseSince(*since); err != nil {
+			return usagef("invalid --since %q, expected YYYY-MM-DD or RFC 3339", *since)
+		}
+	}
+
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		return err
+	}
+	state, err := openExportState(*out, *format, *restart)
+	if err != nil {
+		return err
+	}
+	defer state.close()
+
+	// mbox and jsonl append to one file, truncated to the last complete
+	// email in case the previous run stopped mid-write
+	var data *os.File
+	if *format == "mbox" || *format == "jsonl" {
+		data, err = os.OpenFile(filepath.Join(*out, "inbound."+*format), os.O_RDWR|os.O_CREATE, 0o644)
+		if err != nil {
+			return err
+		}
+		defer data.Close()
+		if err := data.Truncate(state.size); err != nil {
+			return err
+		}
+		if _, err := data.Seek(state.size, io.SeekStart); err != nil {
+			return err
+		}
+	}
+	maildir := inbound.NewMaildirExporter(client, *out)
+
+	filter := &inbound.GetMailRequest{
+		Limit:           inbound.Int(100),
+		Domain:          *domain,
+		EmailAddress:    *address,
+		IncludeArchived: archived,
+		TimeRange:       timeRangeSince(sinceTime),
+	}
+	exported, skipped := 0, 0
+	err = client.Mail().Each(ctx, filter, func(item inbound.EmailItem) error {
+		if !sinceTime.IsZero() && item.ReceivedAt.Before(sinceTime) {
+			return nil
+		}
+		if state.done[item.ID] {
+			skipped++
+			return nil
+		}
+		var size int64
+		switch *format {
+		case "mbox":
+			if err := client.Mail().ExportMbox(ctx, item.ID, data); err != nil {
+				return err
+			}
+		case "jsonl":
+			email, err := inbound.Unwrap(client.Mail().Get(ctx, item.ID))
+			if err != nil {
+				return fmt.Errorf("failed to get email %s: %w", item.ID, err)
+			}
+			line, err := json.Marshal(email)
+			if err != nil {
+				return err
+			}
+			if _, err := data.Write(append(line, '\n')); err != nil {
+				return err
+			}
+		case "eml":
+			if err := exportEMLFile(ctx, client, *out, item.ID); err != nil {
+				return err
+			}
+		case "maildir":
+			if _, err := maildir.ExportEmail(ctx, item); err != nil {
+				return err
+			}
+		}
+		if data != nil {
+			if size, err = data.Seek(0, io.SeekCurrent); err != nil {
+				return err
+			}
+		}
+		exported++
+		return state.record(item.ID, size)
+	})
+	if err != nil {
+		return fmt.Errorf("%w (exported %d emails; run the same command again to resume)", err, exported)
+	}
+	fmt.Fprintf(a.stdout, "Exported %d emails to %s", exported, *out)
+	if skipped > 0 {
+		fmt.Fprintf(a.stdout, " (%d already exported)", skipped)
+	}
+	fmt.Fprintln(a.stdout)
+	return nil
+}
+
+// exportEMLFile writes an email to dir/<id>.eml, through a temporary file
+// so an interrupted export leaves no partial message
+func exportEMLFile(ctx context.Context, client *inbound.Inbound, dir, id string) error {
+	tmp, err := os.CreateTemp(dir, ".export-*.eml")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := client.Mail().ExportEML(ctx, id, tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, strings.ReplaceAll(id, string(filepath.Separator), "_")+".eml"))
+}
+
+// exportState tracks the progress of an export directory
+type exportState struct {
+	file *os.File
+	done map[string]bool
+	// size is the length of the data file after the last recorded email
+	size int64
+}
+
+// openExportState reads the state of a previous export to dir, or starts a
+// new one. A previous export in another format is an error unless restart
+// is set.
+func openExportState(dir, format string, restart bool) (*exportState, error) {
+	path := filepath.Join(dir, exportStateFile)
+	state := &exportState{done: make(map[string]bool)}
+	if restart {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	f, err := os.Open(path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, err
+	default:
+		scanner := bufio.NewScanner(f)
+		if scanner.Scan() && scanner.Text() != "format "+format {
+			f.Close()
+			return nil, fmt.Errorf("%s holds a %s export; use another directory or --restart", dir, strings.TrimPrefix(scanner.Text(), "format "))
+		}
+		for scanner.Scan() {
+			id, size, _ := strings.Cut(scanner.Text(), "\t")
+			if id == "" {
+				continue
+			}
+			state.done[id] = true
+			if n, err := strconv.ParseInt(size, 10, 64); err == nil {
+				state.size = n
+			}
+		}
+		f.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	state.file, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if len(state.done) == 0 {
+		if err := state.file.Truncate(0); err != nil {
+			state.file.Close()
+			return nil, err
+		}
+		if _, err := fmt.Fprintf(state.file, "format %s\n", format); err != nil {
+			state.file.Close()
+			return nil, err
+		}
+	}
+	return state, nil
+}
+
+// record marks an email as exported
+func (s *exportState) record(id string, size int64) error {
+	s.done[id] = true
+	s.size = size
+	_, err := fmt.Fprintf(s.file, "%s\t%d\n", id, size)
+	return err
+}
+
+func (s *exportState) close() error {
+	return s.file.Close()
+}
+
+// parseSince parses a date or an RFC 3339 time
+func parseSince(s string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// timeRangeSince returns the narrowest API time range that covers since,
+// so fewer pages are fetched, or "" for all time
+func timeRangeSince(since time.Time) string {
+	if since.IsZero() {
+		return ""
+	}
+	age := time.Since(since)
+	for _, r := range []struct {
+		name string
+		d    time.Duration
+	}{{"24h", 24 * time.Hour}, {"7d", 7 * 24 * time.Hour}, {"30d", 30 * 24 * time.Hour}, {"90d", 90 * 24 * time.Hour}} {
+		if age <= r.d {
+			return r.name
+		}
+	}
+	return ""
+}
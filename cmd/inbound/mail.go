@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	inbound "github.com/inboundemail/inbound-golang-sdk"
+)
+
+var mailCommand = &command{
+	name: "mail",
+	sub: []*command{
+		{name: "list", args: "[flags]", summary: "list received emails", run: runMailList},
+		{name: "get", args: "<id> [flags]", summary: "show a received email", run: runMailGet},
+		{name: "tail", args: "[flags]", summary: "print received emails as they arrive", run: runMailTail},
+	},
+}
+
+func runMailList(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("mail list")
+	limit := fs.Int("limit", 20, "maximum number of emails")
+	offset := fs.Int("offset", 0, "number of emails to skip")
+	status := fs.String("status", "", "filter by `status`: all, processed, or failed")
+	domain := fs.String("domain", "", "filter by receiving `domain`")
+	address := fs.String("address", "", "filter by receiving `address`")
+	search := fs.String("search", "", "search `text` in subjects and senders")
+	timeRange := fs.String("range", "", "time `range`: 24h, 7d, 30d, or 90d")
+	asJSON := fs.Bool("json", false, "print the API response as JSON")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+	list, err := inbound.Unwrap(client.Mail().List(ctx, &inbound.GetMailRequest{
+		Limit:        limit,
+		Offset:       offset,
+		Status:       inbound.MailStatus(*status),
+		Domain:       *domain,
+		EmailAddress: *address,
+		Search:       *search,
+		TimeRange:    *timeRange,
+	}))
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return a.printJSON(list)
+	}
+
+	tw := a.table()
+	fmt.Fprintln(tw, "ID\tRECEIVED\tFROM\tTO\tSUBJECT")
+	for _, e := range list.Emails {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", e.ID, e.ReceivedAt.Local().Format(time.DateTime), e.From, e.Recipient, e.Subject)
+	}
+	return tw.Flush()
+}
+
+func runMailGet(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("mail get")
+	html := fs.Bool("html", false, "print the HTML body instead of the text body")
+	asJSON := fs.Bool("json", false, "print the API response as JSON")
+	ids, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(ids) != 1 {
+		return usagef("expected one email ID")
+	}
+
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+	email, err := inbound.Unwrap(client.Mail().Get(ctx, ids[0]))
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return a.printJSON(email)
+	}
+
+	fmt.Fprintf(a.stdout, "ID:       %s\n", email.ID)
+	fmt.Fprintf(a.stdout, "From:     %s\n", email.From)
+	fmt.Fprintf(a.stdout, "To:       %s\n", email.To)
+	fmt.Fprintf(a.stdout, "Subject:  %s\n", email.Subject)
+	fmt.Fprintf(a.stdout, "Received: %s\n", email.ReceivedAt.Local().Format(time.DateTime))
+	if n := len(email.Attachments); n > 0 {
+		fmt.Fprintf(a.stdout, "Attachments: %d\n", n)
+	}
+	body := email.TextBody
+	if *html || body == "" {
+		body = email.HTMLBody
+	}
+	fmt.Fprintf(a.stdout, "\n%s\n", body)
+	return nil
+}
+
+// maxPreviewLength caps the preview line printed by mail tail
+const maxPreviewLength = 120
+
+func runMailTail(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("mail tail")
+	poll := fs.Bool("poll", false, "poll the mail list instead of streaming")
+	interval := fs.Duration("interval", 10*time.Second, "time between polls")
+	since := fs.Duration("since", 0, "with --poll, first print mail received in this `duration` before starting")
+	domain := fs.String("domain", "", "only print mail to this `domain`")
+	address := fs.String("address", "", "only print mail to this `address`")
+	asJSON := fs.Bool("json", false, "print each email as a line of JSON")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if *since > 0 && !*poll {
+		return usagef("--since requires --poll")
+	}
+
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+	var next func() (*inbound.EmailItem, error)
+	if *poll {
+		watcher := inbound.NewMailWatcher(client, nil)
+		watcher.Interval = *interval
+		if *since > 0 {
+			watcher.Since = time.Now().Add(-*since)
+		}
+		emails := watcher.Watch(ctx)
+		next = func() (*inbound.EmailItem, error) {
+			email, ok := <-emails
+			if !ok {
+				if err := watcher.Err(); err != nil {
+					return nil, err
+				}
+				return nil, ctx.Err()
+			}
+			return &email, nil
+		}
+	} else {
+		stream := client.Mail().Stream(ctx)
+		stream.PollInterval = *interval
+		defer stream.Close()
+		next = stream.Next
+	}
+
+	enc := json.NewEncoder(a.stdout)
+	for {
+		email, err := next()
+		if err != nil {
+			if ctx.Err() != nil {
+				// Interrupted by the user
+				return nil
+			}
+			return err
+		}
+		if !recipientMatches(email.Recipient, *address, *domain) {
+			continue
+		}
+		if *asJSON {
+			if err := enc.Encode(email); err != nil {
+				return err
+			}
+			continue
+		}
+		fmt.Fprintf(a.stdout, "%s  %s -> %s  %s\n", email.ReceivedAt.Local().Format(time.TimeOnly), email.From, email.Recipient, email.Subject)
+		if preview := oneLine(email.Preview, maxPreviewLength); preview != "" {
+			fmt.Fprintf(a.stdout, "    %s\n", preview)
+		}
+	}
+}
+
+// recipientMatches reports whether recipient is address, if set, and in
+// domain, if set
+func recipientMatches(recipient, address, domain string) bool {
+	if address != "" && !strings.EqualFold(recipient, address) {
+		return false
+	}
+	if domain != "" && !strings.HasSuffix(strings.ToLower(recipient), "@"+strings.ToLower(domain)) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,267 @@
+// Command inbound is a command-line client for the Inbound Email API, for
+// scripting and for debugging API behavior.
+//
+// It reads the API key from INBOUND_API_KEY and, optionally, the base URL
+// from INBOUND_BASE_URL. Run "inbound help" for the list of commands.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+
+	inbound "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	a := &app{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr, getenv: os.Getenv}
+	os.Exit(a.run(ctx, os.Args[1:]))
+}
+
+// app holds the state shared by all commands
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+	client *inbound.Inbound
+}
+
+// command is a CLI command, or a group of subcommands when run is nil
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(ctx context.Context, a *app, args []string) error
+	sub     []*command
+}
+
+// commands is the command tree. It is built in init because the help
+// command refers to it.
+var commands []*command
+
+func init() {
+	commands = []*command{
+		sendCommand,
+		mailCommand,
+		domainCommand,
+		endpointCommand,
+		scheduleCommand,
+		exportCommand,
+		webhookCommand,
+		anonymizeCommand,
+		{name: "help", summary: "show this help", run: func(ctx context.Context, a *app, args []string) error {
+			a.usage(a.stdout)
+			return nil
+		}},
+	}
+}
+
+// usageError is returned for invalid command lines
+type usageError struct{ msg string }
+
+func (e *usageError) Error() string { return e.msg }
+
+func usagef(format string, args ...any) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// run executes the command line and returns the exit status
+func (a *app) run(ctx context.Context, args []string) int {
+	cmd, path, rest := findCommand(commands, args)
+	if cmd == nil || cmd.run == nil {
+		if len(args) > len(path) {
+			fmt.Fprintf(a.stderr, "inbound: unknown command %q\n\n", strings.Join(args[:len(path)+1], " "))
+		}
+		a.usage(a.stderr)
+		return 2
+	}
+
+	err := cmd.run(ctx, a, rest)
+	var usage *usageError
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.As(err, &usage):
+		fmt.Fprintf(a.stderr, "inbound %s: %v\nusage: inbound %s %s\n", strings.Join(path, " "), err, strings.Join(path, " "), cmd.args)
+		return 2
+	default:
+		fmt.Fprintf(a.stderr, "inbound %s: %v\n", strings.Join(path, " "), err)
+		return 1
+	}
+}
+
+// findCommand follows args down the command tree. It returns the command,
+// the names leading to it, and the remaining arguments.
+func findCommand(cmds []*command, args []string) (*command, []string, []string) {
+	var found *command
+	var path []string
+	for len(args) > 0 {
+		var next *command
+		for _, c := range cmds {
+			if c.name == args[0] {
+				next = c
+				break
+			}
+		}
+		if next == nil {
+			break
+		}
+		found, path, args = next, append(path, next.name), args[1:]
+		if next.run != nil {
+			break
+		}
+		cmds = next.sub
+	}
+	return found, path, args
+}
+
+// usage prints the command list
+func (a *app) usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: inbound <command> [arguments]")
+	fmt.Fprintln(w, "\nThe API key is read from INBOUND_API_KEY, and the base URL from INBOUND_BASE_URL if set.")
+	fmt.Fprintln(w, "\nCommands:")
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	var walk func(prefix string, cmds []*command)
+	walk = func(prefix string, cmds []*command) {
+		for _, c := range cmds {
+			if c.run == nil {
+				walk(prefix+c.name+" ", c.sub)
+				continue
+			}
+			fmt.Fprintf(tw, "  %s%s %s\t%s\n", prefix, c.name, c.args, c.summary)
+		}
+	}
+	walk("", commands)
+	tw.Flush()
+	fmt.Fprintln(w, "\nRun a command with -h for its flags.")
+}
+
+// api returns the API client, creating it from the environment on first use
+func (a *app) api() (*inbound.Inbound, error) {
+	if a.client != nil {
+		return a.client, nil
+	}
+	key := a.getenv("INBOUND_API_KEY")
+	if key == "" {
+		return nil, errors.New("INBOUND_API_KEY is not set")
+	}
+	client, err := inbound.NewClient(key, a.getenv("INBOUND_BASE_URL"))
+	if err != nil {
+		return nil, err
+	}
+	a.client = client
+	return client, nil
+}
+
+// newFlags returns a flag set for a command that reports errors to stderr
+func (a *app) newFlags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	return fs
+}
+
+// parseArgs parses flags wherever they appear among the positional
+// arguments, so "mail get <id> --json" works, and returns the positional
+// arguments
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// printJSON writes v as indented JSON
+func (a *app) printJSON(v any) error {
+	enc := json.NewEncoder(a.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// table returns a writer that aligns tab-separated columns; call Flush when
+// done
+func (a *app) table() *tabwriter.Writer {
+	return tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+}
+
+// listFlag collects a flag that may be repeated or hold comma-separated
+// values
+type listFlag []string
+
+func (l *listFlag) String() string { return strings.Join(*l, ",") }
+
+func (l *listFlag) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*l = append(*l, v)
+		}
+	}
+	return nil
+}
+
+// value returns the list as a single string or a slice, as the API's
+// address fields accept, or nil if it is empty
+func (l listFlag) value() any {
+	switch len(l) {
+	case 0:
+		return nil
+	case 1:
+		return l[0]
+	}
+	return []string(l)
+}
+
+// repeatedFlag collects a flag that may be repeated, for values that can
+// contain commas
+type repeatedFlag []string
+
+func (r *repeatedFlag) String() string { return strings.Join(*r, ", ") }
+
+func (r *repeatedFlag) Set(value string) error {
+	*r = append(*r, value)
+	return nil
+}
+
+// optional returns a pointer to s, or nil if it is empty
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// deref returns *s, or "" if s is nil
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// oneLine collapses the whitespace in s and shortens it to n runes
+func oneLine(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if runes := []rune(s); len(runes) > n {
+		s = string(runes[:n]) + "..."
+	}
+	return s
+}
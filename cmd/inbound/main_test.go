@@ -0,0 +1,443 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	inbound "github.com/inboundemail/inbound-golang-sdk"
+)
+
+// runCLI runs a command line against server and returns its exit status,
+// stdout, and stderr
+func runCLI(t *testing.T, server *httptest.Server, args ...string) (int, string, string) {
+	t.Helper()
+	return runCLIContext(t, context.Background(), server, args...)
+}
+
+func runCLIContext(t *testing.T, ctx context.Context, server *httptest.Server, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	env := map[string]string{"INBOUND_API_KEY": "test-api-key"}
+	if server != nil {
+		env["INBOUND_BASE_URL"] = server.URL
+	}
+	a := &app{stdin: strings.NewReader(""), stdout: &stdout, stderr: &stderr, getenv: func(k string) string { return env[k] }}
+	code := a.run(ctx, args)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestSend(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/emails" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Idempotency-Key") != "job-42" {
+			t.Errorf("Missing idempotency key")
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["from"] != "app@example.com" || body["subject"] != "Hi" || body["text"] != "Hello" {
+			t.Errorf("Unexpected body %v", body)
+		}
+		if to, ok := body["to"].([]any); !ok || len(to) != 2 {
+			t.Errorf("Expected two recipients, got %v", body["to"])
+		}
+		w.Write([]byte(`{"id":"email_123"}`))
+	}))
+	defer server.Close()
+
+	code, stdout, stderr := runCLI(t, server, "send", "--from", "app@example.com", "--to", "a@example.com,b@example.com",
+		"--subject", "Hi", "--text", "Hello", "--idempotency-key", "job-42")
+	if code != 0 || stdout != "email_123\n" {
+		t.Errorf("Unexpected result %d %q %q", code, stdout, stderr)
+	}
+}
+
+func TestSendFilesAndSchedule(t *testing.T) {
+	dir := t.TempDir()
+	htmlFile := filepath.Join(dir, "invoice.html")
+	dataFile := filepath.Join(dir, "data.json")
+	pdf := filepath.Join(dir, "invoice.pdf")
+	os.WriteFile(htmlFile, []byte(`<p>Hi {{.name}}, you owe {{.amount}}</p>`), 0o644)
+	os.WriteFile(dataFile, []byte(`{"name": "Jane", "amount": "$10"}`), 0o644)
+	os.WriteFile(pdf, []byte("%PDF-1.4"), 0o644)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/emails/schedule" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body inbound.PostEmailsRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.HTML == nil || *body.HTML != "<p>Hi Jane, you owe $10</p>" {
+			t.Errorf("Unexpected HTML %v", body.HTML)
+		}
+		if body.ScheduledAt == nil || *body.ScheduledAt != "tomorrow 9am" || body.Timezone == nil || *body.Timezone != "Europe/Berlin" {
+			t.Errorf("Unexpected schedule %v %v", body.ScheduledAt, body.Timezone)
+		}
+		if len(body.Attachments) != 2 || body.Attachments[0].Filename != "invoice.pdf" || *body.Attachments[0].ContentType != "application/pdf" ||
+			*body.Attachments[0].Content != "JVBERi0xLjQ=" || *body.Attachments[1].Path != "https://cdn.example.com/terms.pdf?v=2" ||
+			body.Attachments[1].Filename != "terms.pdf" {
+			t.Errorf("Unexpected attachments %+v", body.Attachments)
+		}
+		if body.Headers["X-Campaign"] != "billing, monthly" || len(body.Tags) != 1 || body.Tags[0] != (inbound.EmailTag{Name: "kind", Value: "invoice"}) {
+			t.Errorf("Unexpected headers or tags %v %v", body.Headers, body.Tags)
+		}
+		w.Write([]byte(`{"id":"email_123","scheduled_at":"2025-01-17T09:00:00+01:00","status":"scheduled"}`))
+	}))
+	defer server.Close()
+
+	code, stdout, stderr := runCLI(t, server, "send", "--from", "billing@example.com", "--to", "jane@example.com", "--subject", "Invoice",
+		"--html-file", htmlFile, "--data", dataFile, "--attach", pdf, "--attach", "https://cdn.example.com/terms.pdf?v=2",
+		"--header", "X-Campaign: billing, monthly", "--tag", "kind=invoice", "--schedule", "tomorrow 9am", "--timezone", "Europe/Berlin")
+	if code != 0 || stdout != "email_123\n" || !strings.Contains(stderr, "scheduled for 2025-01-17T09:00:00+01:00") {
+		t.Errorf("Unexpected result %d %q %q", code, stdout, stderr)
+	}
+}
+
+func TestSendTemplate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body inbound.PostTemplateEmailRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		if r.URL.Path != "/emails" || body.TemplateID != "tmpl_1" || body.Variables["name"] != "Jane" || body.Variables["count"] != float64(3) {
+			t.Errorf("Unexpected request %s %+v", r.URL.Path, body)
+		}
+		w.Write([]byte(`{"id":"email_456"}`))
+	}))
+	defer server.Close()
+
+	code, stdout, stderr := runCLI(t, server, "send", "--to", "jane@example.com", "--template", "tmpl_1", "--var", "name=Jane", "--var", "count=3")
+	if code != 0 || stdout != "email_456\n" {
+		t.Errorf("Unexpected result %d %q %q", code, stdout, stderr)
+	}
+
+	code, _, stderr = runCLI(t, server, "send", "--to", "jane@example.com", "--template", "tmpl_1", "--subject", "Hi")
+	if code != 2 || !strings.Contains(stderr, "cannot be combined") {
+		t.Errorf("Expected a usage error, got %d %q", code, stderr)
+	}
+}
+
+func TestMailListAndGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/mail":
+			if r.URL.Query().Get("status") != "failed" || r.URL.Query().Get("limit") != "5" {
+				t.Errorf("Unexpected query %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"emails":[{"id":"email_1","from":"jane@example.com","recipient":"support@example.com","subject":"Help","receivedAt":"2025-01-16T10:00:00Z"}],"pagination":{"limit":5,"offset":0,"total":1}}`))
+		case "/mail/email_1":
+			w.Write([]byte(`{"id":"email_1","from":"jane@example.com","to":"support@example.com","subject":"Help","textBody":"My order is late","receivedAt":"2025-01-16T10:00:00Z"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Email not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	code, stdout, _ := runCLI(t, server, "mail", "list", "--status", "failed", "--limit", "5")
+	if code != 0 || !strings.Contains(stdout, "email_1") || !strings.Contains(stdout, "Help") {
+		t.Errorf("Unexpected list output %d %q", code, stdout)
+	}
+
+	code, stdout, _ = runCLI(t, server, "mail", "get", "email_1", "--json")
+	var email map[string]any
+	if code != 0 || json.Unmarshal([]byte(stdout), &email) != nil || email["textBody"] != "My order is late" {
+		t.Errorf("Unexpected get output %d %q", code, stdout)
+	}
+
+	code, _, stderr := runCLI(t, server, "mail", "get", "email_missing")
+	if code != 1 || !strings.Contains(stderr, "Email not found") {
+		t.Errorf("Expected an API error, got %d %q", code, stderr)
+	}
+}
+
+func TestEndpointCreateChoosesType(t *testing.T) {
+	var types []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Type string `json:"type"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		types = append(types, body.Type)
+		w.Write([]byte(`{"id":"ep_1"}`))
+	}))
+	defer server.Close()
+
+	runCLI(t, server, "endpoint", "create", "--name", "Hook", "--url", "https://example.com/hook")
+	runCLI(t, server, "endpoint", "create", "--name", "Fwd", "--forward-to", "ops@example.com")
+	runCLI(t, server, "endpoint", "create", "--name", "Team", "--forward-to", "a@example.com", "--forward-to", "b@example.com")
+	if strings.Join(types, ",") != "webhook,email,email_group" {
+		t.Errorf("Unexpected endpoint types %v", types)
+	}
+}
+
+func TestUsageErrors(t *testing.T) {
+	tests := [][]string{
+		{"bogus"},
+		{"mail"},
+		{"send", "--from", "app@example.com"},
+		{"mail", "get"},
+		{"endpoint", "create", "--name", "x"},
+	}
+	for _, args := range tests {
+		if code, _, stderr := runCLI(t, nil, args...); code != 2 || stderr == "" {
+			t.Errorf("%v: expected exit status 2 with usage, got %d %q", args, code, stderr)
+		}
+	}
+}
+
+func TestMailTail(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls > 1 {
+			// The first poll has been printed; stop tailing
+			cancel()
+		}
+		now := time.Now().UTC().Format(time.RFC3339)
+		w.Write([]byte(`{"emails":[
+			{"id":"email_2","from":"bob@example.com","recipient":"sales@other.com","subject":"Quote","receivedAt":"` + now + `"},
+			{"id":"email_1","from":"jane@example.com","recipient":"support@example.com","subject":"Help","preview":"My   order\nis late","receivedAt":"` + now + `"}
+		],"pagination":{"limit":50,"offset":0,"total":2}}`))
+	}))
+	defer server.Close()
+
+	code, stdout, stderr := runCLIContext(t, ctx, server, "mail", "tail", "--poll", "--since", "1h", "--interval", "10ms", "--domain", "example.com")
+	if code != 0 {
+		t.Fatalf("Unexpected exit status %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "jane@example.com -> support@example.com  Help\n    My order is late\n") {
+		t.Errorf("Unexpected output %q", stdout)
+	}
+	if strings.Contains(stdout, "Quote") {
+		t.Errorf("Mail to another domain was printed: %q", stdout)
+	}
+}
+
+func TestDomainSetup(t *testing.T) {
+	verified := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/domains":
+			w.Write([]byte(`{"id":"dom_1","domain":"example.com","status":"pending","dnsRecords":[{"type":"MX","name":"example.com","value":"10 inbound-smtp.us-east-2.amazonaws.com"}]}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/domains/dom_1/auth":
+			w.Write([]byte(`{}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/domains/dom_1":
+			status := "pending"
+			if verified {
+				status = "verified"
+			}
+			verified = true
+			w.Write([]byte(`{"id":"dom_1","domain":"example.com","status":"` + status + `"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	code, stdout, stderr := runCLI(t, server, "domain", "setup", "example.com", "--interval", "1ms", "--dns-provider", "command", "--dns-command", "true")
+	if code != 0 {
+		t.Fatalf("Unexpected exit %d: %s", code, stderr)
+	}
+	for _, want := range []string{
+		"MX    example.com  10 inbound-smtp.us-east-2.amazonaws.com",
+		"example.com.\t3600\tIN\tMX\t10 inbound-smtp.us-east-2.amazonaws.com.",
+		"Applied 1 records with command.",
+		"example.com is verified.",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Output is missing %q:\n%s", want, stdout)
+		}
+	}
+
+	code, _, stderr = runCLI(t, server, "domain", "setup", "example.com", "--dns-provider", "route53")
+	if code != 2 || !strings.Contains(stderr, "unknown DNS provider") {
+		t.Errorf("Expected a usage error, got %d %q", code, stderr)
+	}
+}
+
+func TestWebhookReplay(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.json")
+	bad := filepath.Join(dir, "bad.json")
+	os.WriteFile(good, []byte(`{"event":"email.received","headers":{"X-Test":["1"]},"payload":{"event":"email.received","email":{"id":"email_1"}}}`), 0o644)
+	os.WriteFile(bad, []byte(`{"event":"email.received","email":{"id":"email_2"}}`), 0o644)
+
+	handler := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Secret") != "s3cret" {
+			t.Errorf("Missing added header")
+		}
+		var body struct {
+			Email struct{ ID string } `json:"email"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Email.ID == "email_2" {
+			http.Error(w, "handler exploded", http.StatusInternalServerError)
+			return
+		}
+		if r.Header.Get("X-Test") != "1" {
+			t.Errorf("Saved header was not replayed")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer handler.Close()
+
+	code, stdout, stderr := runCLI(t, nil, "webhook", "replay", good, bad, "--to", handler.URL, "--header", "X-Secret: s3cret")
+	if code != 1 || !strings.Contains(stdout, "good.json  204 No Content") || !strings.Contains(stdout, "handler exploded") ||
+		!strings.Contains(stderr, "1 of 2 deliveries failed") {
+		t.Errorf("Unexpected result %d %q %q", code, stdout, stderr)
+	}
+}
+
+func TestAnonymize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	os.WriteFile(path, []byte(`{"event":"email.received","email":{"recipient":"jane@gmail.com","parsedData":{"textBody":"secret"}}}`), 0o644)
+
+	code, stdout, stderr := runCLI(t, nil, "anonymize", path, "--salt", "s3cret")
+	if code != 0 || strings.Contains(stdout, "jane") || strings.Contains(stdout, "secret\"") || !strings.Contains(stdout, "@domain-") {
+		t.Errorf("Unexpected result %d %q %q", code, stdout, stderr)
+	}
+
+	code, _, stderr = runCLI(t, nil, "anonymize", "-w", path, "--salt", "s3cret")
+	data, _ := os.ReadFile(path)
+	if code != 0 || string(data) != stdout {
+		t.Errorf("Expected the file to be rewritten, got %d %q %q", code, data, stderr)
+	}
+}
+
+func TestExportResumes(t *testing.T) {
+	failMail2 := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/mail":
+			if r.URL.Query().Get("domain") != "example.com" || r.URL.Query().Get("includeArchived") != "true" {
+				t.Errorf("Unexpected query %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"emails":[
+				{"id":"mail-1","receivedAt":"2025-01-03T10:00:00Z"},
+				{"id":"mail-2","receivedAt":"2025-01-02T10:00:00Z"},
+				{"id":"mail-old","receivedAt":"2024-12-01T10:00:00Z"}],
+				"pagination":{"limit":100,"offset":0,"total":3}}`))
+		case strings.HasPrefix(r.URL.Path, "/mail/"):
+			id := strings.TrimPrefix(r.URL.Path, "/mail/")
+			if id == "mail-old" {
+				t.Errorf("Mail before --since was exported")
+			}
+			if id == "mail-2" && failMail2 {
+				w.WriteHeader(http.StatusBadGateway)
+				w.Write([]byte(`{"error":"upstream unavailable"}`))
+				return
+			}
+			fmt.Fprintf(w, `{"id":%q,"from":"jane@example.com","to":"support@example.com","subject":"Hi","textBody":"Hello","receivedAt":"2025-01-02T10:00:00Z"}`, id)
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	args := []string{"export", "--out", dir, "--domain", "example.com", "--since", "2025-01-01", "--format", "mbox"}
+	code, _, stderr := runCLI(t, server, args...)
+	if code != 1 || !strings.Contains(stderr, "exported 1 emails; run the same command again to resume") {
+		t.Fatalf("Expected the first run to fail after one email, got %d %q", code, stderr)
+	}
+
+	failMail2 = false
+	code, stdout, stderr := runCLI(t, server, args...)
+	if code != 0 || stdout != "Exported 1 emails to "+dir+" (1 already exported)\n" {
+		t.Fatalf("Unexpected resume result %d %q %q", code, stdout, stderr)
+	}
+	mbox, _ := os.ReadFile(filepath.Join(dir, "inbound.mbox"))
+	if n := strings.Count(string(mbox), "\nFrom jane@example.com ") + 1; n != 2 || !strings.Contains(string(mbox), "X-Inbound-Email-Id: mail-2") {
+		t.Errorf("Expected both emails in the mbox, got %d:\n%s", n, mbox)
+	}
+
+	code, _, stderr = runCLI(t, server, "export", "--out", dir, "--format", "jsonl", "--domain", "example.com")
+	if code != 1 || !strings.Contains(stderr, "holds a mbox export") {
+		t.Errorf("Expected a format mismatch error, got %d %q", code, stderr)
+	}
+}
+
+func TestScheduleRescheduleAndCancelAll(t *testing.T) {
+	var cancelled []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/emails/schedule":
+			if r.URL.Query().Get("status") != "scheduled" {
+				t.Errorf("Unexpected query %s", r.URL.RawQuery)
+			}
+			if r.URL.Query().Get("offset") == "0" {
+				w.Write([]byte(`{"data":[{"id":"sched_1"},{"id":"sched_2"}],"pagination":{"limit":2,"offset":0,"total":3,"hasMore":true}}`))
+			} else {
+				w.Write([]byte(`{"data":[{"id":"sched_3"}],"pagination":{"limit":2,"offset":2,"total":3}}`))
+			}
+		case r.Method == http.MethodGet && r.URL.Path == "/emails/schedule/sched_1":
+			w.Write([]byte(`{"id":"sched_1","from":"app@example.com","to":["user@example.com"],"subject":"Hi","text":"Hello","timezone":"UTC","status":"scheduled"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/emails/schedule":
+			w.Write([]byte(`{"id":"sched_9","scheduled_at":"2025-01-20T10:00:00Z","status":"scheduled"}`))
+		case r.Method == http.MethodDelete:
+			id := strings.TrimPrefix(r.URL.Path, "/emails/schedule/")
+			cancelled = append(cancelled, id)
+			fmt.Fprintf(w, `{"id":%q,"status":"cancelled"}`, id)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	code, stdout, stderr := runCLI(t, server, "schedule", "reschedule", "sched_1", "monday 10am")
+	if code != 0 || stdout != "sched_1 rescheduled for 2025-01-20T10:00:00Z as sched_9\n" {
+		t.Errorf("Unexpected reschedule result %d %q %q", code, stdout, stderr)
+	}
+
+	cancelled = nil
+	code, _, stderr = runCLI(t, server, "schedule", "cancel", "--all-pending")
+	if code != 2 || !strings.Contains(stderr, "--yes") || len(cancelled) != 0 {
+		t.Errorf("Expected --all-pending to need confirmation, got %d %q", code, stderr)
+	}
+	code, stdout, _ = runCLI(t, server, "schedule", "cancel", "--all-pending", "--yes")
+	if code != 0 || strings.Join(cancelled, ",") != "sched_1,sched_2,sched_3" || strings.Count(stdout, "cancelled") != 3 {
+		t.Errorf("Unexpected cancel result %d %q %v", code, stdout, cancelled)
+	}
+}
+
+func TestEndpointTestAndDeliveries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/endpoints/ep_1/test":
+			w.Write([]byte(`{"success":false,"message":"Webhook test failed","responseTime":840,"statusCode":503,"responseBody":"upstream\nunavailable"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/endpoints/ep_1":
+			w.Write([]byte(`{"id":"ep_1","recentDeliveries":[
+				{"id":"del_1","emailId":"email_1","status":"success","attempts":1,"lastAttemptAt":"2025-01-16T10:00:00Z","responseData":{"statusCode":200,"responseTime":35}},
+				{"id":"del_2","emailId":"email_2","status":"failed","attempts":3,"lastAttemptAt":"2025-01-16T10:05:00Z","responseData":{"error":"connect: connection refused"}}
+			]}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	code, stdout, stderr := runCLI(t, server, "endpoint", "test", "ep_1")
+	if code != 1 || !strings.Contains(stdout, "Failed: HTTP 503 in 840ms") || !strings.Contains(stdout, "upstream unavailable") {
+		t.Errorf("Unexpected test output %d %q %q", code, stdout, stderr)
+	}
+
+	code, stdout, stderr = runCLI(t, server, "endpoint", "deliveries", "ep_1")
+	if code != 0 || !strings.Contains(stdout, "35ms") || !strings.Contains(stdout, "connection refused") {
+		t.Errorf("Unexpected deliveries output %d %q %q", code, stdout, stderr)
+	}
+	code, stdout, _ = runCLI(t, server, "endpoint", "deliveries", "ep_1", "--failed")
+	if code != 0 || strings.Contains(stdout, "del_1") || !strings.Contains(stdout, "del_2") {
+		t.Errorf("Unexpected failed deliveries output %q", stdout)
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	inbound "github.com/inboundemail/inbound-golang-sdk"
+)
+
+var scheduleCommand = &command{
+	name: "schedule",
+	sub: []*command{
+		{name: "list", args: "[flags]", summary: "list scheduled emails", run: runScheduleList},
+		{name: "get", args: "<id> [flags]", summary: "show a scheduled email", run: runScheduleGet},
+		{name: "cancel", args: "<id>... [flags]", summary: "cancel scheduled emails", run: runScheduleCancel},
+		{name: "reschedule", args: "<id> <time> [flags]", summary: "move a scheduled email to a new time", run: runScheduleReschedule},
+	},
+}
+
+func runScheduleList(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("schedule list")
+	limit := fs.Int("limit", 50, "maximum number of emails")
+	status := fs.String("status", "", "filter by `status`: scheduled, sending, sent, failed, or cancelled")
+	asJSON := fs.Bool("json", false, "print the API response as JSON")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+	list, err := inbound.Unwrap(client.Email().ListScheduled(ctx, &inbound.GetScheduledEmailsRequest{
+		Limit:  limit,
+		Status: inbound.ScheduledStatus(*status),
+	}))
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return a.printJSON(list)
+	}
+
+	tw := a.table()
+	fmt.Fprintln(tw, "ID\tSCHEDULED AT\tSTATUS\tTO\tSUBJECT")
+	for _, e := range list.Data {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", e.ID, e.ScheduledAt, e.Status, strings.Join(e.To, ", "), e.Subject)
+	}
+	return tw.Flush()
+}
+
+func runScheduleGet(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("schedule get")
+	asJSON := fs.Bool("json", false, "print the API response as JSON")
+	ids, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(ids) != 1 {
+		return usagef("expected one scheduled email ID")
+	}
+
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+	email, err := inbound.Unwrap(client.Email().GetScheduled(ctx, ids[0]))
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return a.printJSON(email)
+	}
+
+	fmt.Fprintf(a.stdout, "ID:           %s\n", email.ID)
+	fmt.Fprintf(a.stdout, "Status:       %s\n", email.Status)
+	fmt.Fprintf(a.stdout, "Scheduled at: %s (%s)\n", email.ScheduledAt, email.Timezone)
+	fmt.Fprintf(a.stdout, "From:         %s\n", email.From)
+	fmt.Fprintf(a.stdout, "To:           %s\n", strings.Join(email.To, ", "))
+	fmt.Fprintf(a.stdout, "Subject:      %s\n", email.Subject)
+	fmt.Fprintf(a.stdout, "Attempts:     %d of %d\n", email.Attempts, email.MaxAttempts)
+	if email.LastError != nil {
+		fmt.Fprintf(a.stdout, "Last error:   %s\n", *email.LastError)
+	}
+	if email.SentEmailID != nil {
+		fmt.Fprintf(a.stdout, "Sent as:      %s\n", *email.SentEmailID)
+	}
+	return nil
+}
+
+func runScheduleCancel(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("schedule cancel")
+	allPending := fs.Bool("all-pending", false, "cancel every email that has not started sending")
+	yes := fs.Bool("yes", false, "confirm --all-pending")
+	ids, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	switch {
+	case *allPending && len(ids) > 0:
+		return usagef("give IDs or --all-pending, not both")
+	case *allPending && !*yes:
+		return usagef("--all-pending cancels every pending email; add --yes to confirm")
+	case !*allPending && len(ids) == 0:
+		return usagef("expected at least one scheduled email ID")
+	}
+
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+	if *allPending {
+		if ids, err = pendingScheduledIDs(ctx, client); err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			fmt.Fprintln(a.stdout, "No pending scheduled emails")
+			return nil
+		}
+	}
+	var failed int
+	for _, id := range ids {
+		cancelled, err := inbound.Unwrap(client.Email().Cancel(ctx, id))
+		if err != nil {
+			fmt.Fprintf(a.stderr, "%s: %v\n", id, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(a.stdout, "%s %s\n", cancelled.ID, cancelled.Status)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d cancellations failed", failed, len(ids))
+	}
+	return nil
+}
+
+// pendingScheduledIDs returns the IDs of all emails still scheduled. They
+// are collected before any is cancelled, so cancelling does not shift the
+// pages being read.
+func pendingScheduledIDs(ctx context.Context, client *inbound.Inbound) ([]string, error) {
+	var ids []string
+	for offset := 0; ; {
+		page, err := inbound.Unwrap(client.Email().ListScheduled(ctx, &inbound.GetScheduledEmailsRequest{
+			Limit:  inbound.Int(100),
+			Offset: inbound.Int(offset),
+			Status: inbound.ScheduledStatusScheduled,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range page.Data {
+			ids = append(ids, e.ID)
+		}
+		offset += len(page.Data)
+		if len(page.Data) == 0 || !page.Pagination.HasMore {
+			return ids, nil
+		}
+	}
+}
+
+func runScheduleReschedule(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("schedule reschedule")
+	timezone := fs.String("timezone", "", "IANA `zone` for interpreting the time; defaults to the email's")
+	asJSON := fs.Bool("json", false, "print the API response as JSON")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return usagef("expected a scheduled email ID and a time")
+	}
+
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+	rescheduled, err := inbound.Unwrap(client.Email().Reschedule(ctx, positional[0], positional[1], optional(*timezone)))
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return a.printJSON(rescheduled)
+	}
+	fmt.Fprintf(a.stdout, "%s rescheduled for %s as %s\n", positional[0], rescheduled.ScheduledAt, rescheduled.ID)
+	return nil
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	inbound "github.com/inboundemail/inbound-golang-sdk"
+)
+
+var sendCommand = &command{
+	name:    "send",
+	args:    "[flags]",
+	summary: "send or schedule an email",
+	run:     runSend,
+}
+
+func runSend(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("send")
+	var to, cc, bcc, replyTo listFlag
+	var attach, headers, tags, vars repeatedFlag
+	from := fs.String("from", "", "sender `address`")
+	fs.Var(&to, "to", "recipient `address`es, repeated or comma-separated")
+	fs.Var(&cc, "cc", "CC `address`es")
+	fs.Var(&bcc, "bcc", "BCC `address`es")
+	fs.Var(&replyTo, "reply-to", "Reply-To `address`es")
+	subject := fs.String("subject", "", "`subject` line")
+	text := fs.String("text", "", "plain text `body`")
+	html := fs.String("html", "", "HTML `body`")
+	textFile := fs.String("text-file", "", "read the plain text body from `file`, or - for stdin")
+	htmlFile := fs.String("html-file", "", "read the HTML body from `file`, or - for stdin; .mjml files are compiled with the mjml CLI")
+	data := fs.String("data", "", "render the HTML body as a Go template with the JSON object in `file`")
+	inlineCSS := fs.Bool("inline-css", false, "move <style> rules into style attributes")
+	fs.Var(&attach, "attach", "attach a `file`, or a remote file by https URL; repeatable")
+	fs.Var(&headers, "header", "add a `header` as \"Name: value\"; repeatable")
+	fs.Var(&tags, "tag", "add a `name=value` tag; repeatable")
+	inReplyTo := fs.String("in-reply-to", "", "`message-id` of the email being replied to, for threading")
+	schedule := fs.String("schedule", "", "send later, at an ISO 8601 `time` or in natural language such as \"tomorrow 9am\"")
+	timezone := fs.String("timezone", a.getenv("TZ"), "IANA `zone` for interpreting --schedule, e.g. Europe/Berlin")
+	template := fs.String("template", "", "send the stored template with this `id` instead of a body")
+	fs.Var(&vars, "var", "template variable as `name=value`; repeatable")
+	idempotencyKey := fs.String("idempotency-key", "", "`key` that makes retries of this command send once")
+	asJSON := fs.Bool("json", false, "print the API response as JSON")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if len(to) == 0 {
+		return usagef("--to is required")
+	}
+	if *template == "" {
+		if *from == "" || *subject == "" {
+			return usagef("--from, --to, and --subject are required")
+		}
+		if *text == "" && *html == "" && *textFile == "" && *htmlFile == "" {
+			return usagef("--text, --html, --text-file, or --html-file is required")
+		}
+	} else if *text != "" || *html != "" || *textFile != "" || *htmlFile != "" || *subject != "" {
+		return usagef("--template cannot be combined with a subject or body")
+	} else if *data != "" || *inReplyTo != "" || *inlineCSS {
+		return usagef("--data, --in-reply-to, and --inline-css cannot be combined with --template")
+	}
+	if *text != "" && *textFile != "" || *html != "" && *htmlFile != "" {
+		return usagef("give a body inline or from a file, not both")
+	}
+	stdin := 0
+	for _, f := range []string{*textFile, *htmlFile, *data} {
+		if f == "-" {
+			stdin++
+		}
+	}
+	if stdin > 1 {
+		return usagef("only one of --text-file, --html-file, and --data can read stdin")
+	}
+
+	attachments, err := readAttachments(attach)
+	if err != nil {
+		return err
+	}
+	headerMap, err := parseHeaders(headers)
+	if err != nil {
+		return err
+	}
+	tagList, err := parseTags(tags)
+	if err != nil {
+		return err
+	}
+	var options *inbound.IdempotencyOptions
+	if *idempotencyKey != "" {
+		options = &inbound.IdempotencyOptions{IdempotencyKey: *idempotencyKey}
+	}
+
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+	var sent any
+	var id string
+	var scheduledAt *string
+	if *template != "" {
+		variables, err := parseVars(vars)
+		if err != nil {
+			return err
+		}
+		opts := &inbound.TemplateSendOptions{
+			From:           *from,
+			CC:             cc.value(),
+			BCC:            bcc.value(),
+			ReplyTo:        replyTo.value(),
+			Headers:        headerMap,
+			Attachments:    attachments,
+			Tags:           tagList,
+			Timezone:       optional(*timezone),
+			IdempotencyKey: *idempotencyKey,
+		}
+		if *schedule != "" {
+			resp, err := inbound.Unwrap(client.Email().ScheduleTemplate(ctx, *template, to.value(), variables, *schedule, opts))
+			if err != nil {
+				return err
+			}
+			sent, id, scheduledAt = resp, resp.ID, &resp.ScheduledAt
+		} else {
+			resp, err := inbound.Unwrap(client.Email().SendTemplate(ctx, *template, to.value(), variables, opts))
+			if err != nil {
+				return err
+			}
+			sent, id = resp, resp.ID
+		}
+	} else {
+		req := &inbound.PostEmailsRequest{
+			From:        *from,
+			To:          to.value(),
+			CC:          cc.value(),
+			BCC:         bcc.value(),
+			ReplyTo:     replyTo.value(),
+			Subject:     *subject,
+			Headers:     headerMap,
+			Attachments: attachments,
+			Tags:        tagList,
+			ScheduledAt: optional(*schedule),
+		}
+		if *schedule != "" {
+			req.Timezone = optional(*timezone)
+		}
+		if *inlineCSS {
+			req.InlineCSS = inbound.Bool(true)
+		}
+		if *inReplyTo != "" {
+			req.Threading = inbound.ReplyingTo(*inReplyTo)
+		}
+		if err := a.readBodies(req, *text, *html, *textFile, *htmlFile, *data); err != nil {
+			return err
+		}
+		resp, err := inbound.Unwrap(client.Email().Send(ctx, req, options))
+		if err != nil {
+			return err
+		}
+		sent, id, scheduledAt = resp, resp.ID, resp.ScheduledAt
+	}
+
+	if *asJSON {
+		return a.printJSON(sent)
+	}
+	fmt.Fprintln(a.stdout, id)
+	if scheduledAt != nil && *scheduledAt != "" {
+		fmt.Fprintf(a.stderr, "scheduled for %s\n", *scheduledAt)
+	}
+	return nil
+}
+
+// readBodies sets the email's bodies from the inline flags or files, and
+// its body template when the HTML is MJML or --data is given
+func (a *app) readBodies(req *inbound.PostEmailsRequest, text, html, textFile, htmlFile, dataFile string) error {
+	if textFile != "" {
+		body, err := a.readInput(textFile)
+		if err != nil {
+			return err
+		}
+		text = body
+	}
+	if htmlFile != "" {
+		body, err := a.readInput(htmlFile)
+		if err != nil {
+			return err
+		}
+		html = body
+	}
+	req.Text = optional(text)
+
+	isMJML := strings.EqualFold(filepath.Ext(htmlFile), ".mjml")
+	if dataFile == "" && !isMJML {
+		req.HTML = optional(html)
+		return nil
+	}
+	if html == "" {
+		return usagef("--data needs an HTML body to render")
+	}
+	var data any
+	if dataFile != "" {
+		raw, err := a.readInput(dataFile)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal([]byte(raw), &data); err != nil {
+			return fmt.Errorf("%s: %w", dataFile, err)
+		}
+	}
+	var renderer inbound.BodyRenderer = inbound.HTMLTemplateRenderer{}
+	if isMJML {
+		renderer = inbound.MJMLRenderer{Compile: inbound.MJMLCommand("")}
+	}
+	req.Body = &inbound.BodyTemplate{Template: html, Data: data, Renderer: renderer}
+	return nil
+}
+
+// readInput returns the contents of a file, or of stdin for "-"
+func (a *app) readInput(name string) (string, error) {
+	if name == "-" {
+		data, err := io.ReadAll(a.stdin)
+		return string(data), err
+	}
+	data, err := os.ReadFile(name)
+	return string(data), err
+}
+
+// readAttachments turns --attach values into attachments: local files are
+// embedded, and https URLs are fetched by the API
+func readAttachments(values []string) ([]inbound.AttachmentData, error) {
+	var attachments []inbound.AttachmentData
+	for _, v := range values {
+		if strings.HasPrefix(v, "https://") || strings.HasPrefix(v, "http://") {
+			u, err := url.Parse(v)
+			if err != nil {
+				return nil, usagef("invalid attachment URL %q", v)
+			}
+			attachments = append(attachments, inbound.AttachmentData{Path: inbound.String(v), Filename: path.Base(u.Path)})
+			continue
+		}
+		data, err := os.ReadFile(v)
+		if err != nil {
+			return nil, err
+		}
+		attachment := inbound.AttachmentData{
+			Content:  inbound.String(base64.StdEncoding.EncodeToString(data)),
+			Filename: filepath.Base(v),
+		}
+		if contentType := mime.TypeByExtension(filepath.Ext(v)); contentType != "" {
+			attachment.ContentType = inbound.String(contentType)
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
+
+// parseHeaders parses "Name: value" flags
+func parseHeaders(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	headers := make(map[string]string)
+	for _, h := range values {
+		name, value, ok := strings.Cut(h, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, usagef("invalid header %q, expected \"Name: value\"", h)
+		}
+		headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+// parseTags parses "name=value" tag flags
+func parseTags(values []string) ([]inbound.EmailTag, error) {
+	var tags []inbound.EmailTag
+	for _, t := range values {
+		name, value, ok := strings.Cut(t, "=")
+		if !ok || name == "" {
+			return nil, usagef("invalid tag %q, expected name=value", t)
+		}
+		tags = append(tags, inbound.EmailTag{Name: name, Value: value})
+	}
+	return tags, nil
+}
+
+// parseVars parses "name=value" template variables. Values that are valid
+// JSON, such as numbers or objects, are passed as such; others as strings.
+func parseVars(values []string) (map[string]any, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	vars := make(map[string]any)
+	for _, v := range values {
+		name, value, ok := strings.Cut(v, "=")
+		if !ok || name == "" {
+			return nil, usagef("invalid variable %q, expected name=value", v)
+		}
+		var decoded any
+		if err := json.Unmarshal([]byte(value), &decoded); err == nil {
+			vars[name] = decoded
+		} else {
+			vars[name] = value
+		}
+	}
+	return vars, nil
+}
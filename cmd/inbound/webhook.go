@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	inbound "github.com/inboundemail/inbound-golang-sdk"
+)
+
+var webhookCommand = &command{
+	name: "webhook",
+	sub: []*command{
+		{name: "dev", args: "[flags]", summary: "receive webhooks locally, print them, and save them as fixtures", run: runWebhookDev},
+		{name: "replay", args: "<fixture>... [flags]", summary: "re-send saved webhook fixtures to a handler", run: runWebhookReplay},
+	},
+}
+
+func runWebhookDev(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("webhook dev")
+	addr := fs.String("addr", "localhost:8787", "`address` to listen on")
+	dir := fs.String("dir", "webhook-fixtures", "save fixtures in `dir`; empty to not save")
+	secretHeader := fs.String("secret-header", "", "verify deliveries carry the secret in this `header`, which is not saved")
+	secret := fs.String("secret", a.getenv("INBOUND_WEBHOOK_SECRET"), "the webhook `secret`, for --secret-header")
+	quiet := fs.Bool("quiet", false, "print one line per delivery instead of the payload")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if *secretHeader != "" && *secret == "" {
+		return usagef("--secret or INBOUND_WEBHOOK_SECRET is required with --secret-header")
+	}
+
+	// Deliveries are handled concurrently; keep their output together
+	var mu sync.Mutex
+	rec := &inbound.WebhookRecorder{
+		Dir: *dir,
+		OnDelivery: func(fixture *inbound.WebhookFixture, path string) {
+			mu.Lock()
+			defer mu.Unlock()
+			fmt.Fprintf(a.stdout, "%s  %s\n", fixture.ReceivedAt.Local().Format("15:04:05"), fixture.Summary())
+			if path != "" {
+				fmt.Fprintf(a.stdout, "  saved %s\n", path)
+			}
+			if !*quiet {
+				var pretty bytes.Buffer
+				json.Indent(&pretty, fixture.Payload, "  ", "  ")
+				fmt.Fprintf(a.stdout, "  %s\n", pretty.String())
+			}
+		},
+		OnError: func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			fmt.Fprintf(a.stderr, "%s  %v\n", time.Now().Format("15:04:05"), err)
+		},
+	}
+	if *secretHeader != "" {
+		rec.Verify = func(r *http.Request) error { return inbound.VerifyWebhookHeader(r, *secretHeader, *secret) }
+		rec.OmitHeaders = []string{*secretHeader}
+	}
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{Handler: rec, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+	fmt.Fprintf(a.stdout, "Listening on http://%s, press Ctrl-C to stop\n", ln.Addr())
+	if err := server.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func runWebhookReplay(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("webhook replay")
+	to := fs.String("to", "", "`url` of the webhook handler")
+	var headers repeatedFlag
+	fs.Var(&headers, "header", "add a request `header` as \"Name: value\", e.g. the secret; repeatable")
+	paths, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if *to == "" || len(paths) == 0 {
+		return usagef("expected fixtures and --to")
+	}
+	extra := make(http.Header)
+	for _, h := range headers {
+		name, value, ok := strings.Cut(h, ":")
+		if !ok {
+			return usagef("invalid header %q, expected \"Name: value\"", h)
+		}
+		extra.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
+	failed := 0
+	for _, path := range paths {
+		fixture, err := inbound.LoadWebhookFixture(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		resp, err := inbound.ReplayWebhook(ctx, nil, *to, fixture, extra)
+		if err != nil {
+			return err
+		}
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		resp.Body.Close()
+		fmt.Fprintf(a.stdout, "%s  %s\n", path, resp.Status)
+		if resp.StatusCode >= 300 {
+			failed++
+			if msg := strings.TrimSpace(string(body)); msg != "" {
+				fmt.Fprintf(a.stdout, "  %s\n", msg)
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d deliveries failed", failed, len(paths))
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package inboundgo
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// ErrNoCommand is returned by CommandRouter.Dispatch when an email contains
+// no registered command
+var ErrNoCommand = errors.New("no command found in email")
+
+// DefaultReplyMarker is a line placed in outgoing mail so replies can be cut
+// at the point where the quoted original begins
+const DefaultReplyMarker = "##- Please type your reply above this line -##"
+
+// Command is a command extracted from a received email
+type Command struct {
+	// Name is the registered command name, upper-cased.
+	Name string
+	Args []string
+	// Source is "subject" or "body".
+	Source  string
+	Payload *WebhookPayload
+}
+
+// CommandHandler handles one command
+type CommandHandler func(ctx context.Context, cmd *Command) error
+
+// CommandRouter extracts commands such as "APPROVE 123" from received mail
+// and dispatches them to registered handlers, for approve-by-email and
+// email-to-ticket flows. The subject is checked first, after stripping
+// reply prefixes; then each line of the reply text, with quoted history
+// removed.
+type CommandRouter struct {
+	// ReplyMarker cuts the body at this line (default DefaultReplyMarker).
+	ReplyMarker string
+	// SkipBody only looks for commands in the subject.
+	SkipBody bool
+
+	handlers map[string]CommandHandler
+}
+
+// NewCommandRouter creates a router with no commands registered
+func NewCommandRouter() *CommandRouter {
+	return &CommandRouter{ReplyMarker: DefaultReplyMarker, handlers: make(map[string]CommandHandler)}
+}
+
+// Handle registers handler for a command name, matched case-insensitively
+func (r *CommandRouter) Handle(name string, handler CommandHandler) {
+	r.handlers[strings.ToUpper(name)] = handler
+}
+
+// Parse returns the first registered command in payload, or nil if there is
+// none
+func (r *CommandRouter) Parse(payload *WebhookPayload) *Command {
+	if payload.Email.Subject != nil {
+		if cmd := r.parseLine(stripSubjectPrefixes(*payload.Email.Subject)); cmd != nil {
+			cmd.Source, cmd.Payload = "subject", payload
+			return cmd
+		}
+	}
+	if r.SkipBody {
+		return nil
+	}
+	body := ""
+	if payload.Email.ParsedData.TextBody != nil {
+		body = *payload.Email.ParsedData.TextBody
+	} else if payload.Email.CleanedContent.Text != nil {
+		body = *payload.Email.CleanedContent.Text
+	}
+	for _, line := range strings.Split(StripQuotedReply(body, r.ReplyMarker), "\n") {
+		if cmd := r.parseLine(line); cmd != nil {
+			cmd.Source, cmd.Payload = "body", payload
+			return cmd
+		}
+	}
+	return nil
+}
+
+// Dispatch parses payload and runs the matching handler. It returns
+// ErrNoCommand if the email contains no registered command.
+func (r *CommandRouter) Dispatch(ctx context.Context, payload *WebhookPayload) (*Command, error) {
+	cmd := r.Parse(payload)
+	if cmd == nil {
+		return nil, ErrNoCommand
+	}
+	return cmd, r.handlers[cmd.Name](ctx, cmd)
+}
+
+func (r *CommandRouter) parseLine(line string) *Command {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+	name := strings.ToUpper(strings.TrimRight(fields[0], ":"))
+	if _, ok := r.handlers[name]; !ok {
+		return nil
+	}
+	return &Command{Name: name, Args: fields[1:]}
+}
+
+var (
+	subjectPrefixPattern = regexp.MustCompile(`(?i)^\s*((re|fw|fwd|aw|wg|sv|vs|antw)(\[\d+\])?\s*:|\[[^\]]*\])\s*`)
+	// quoteHeaderPattern matches the attribution line mail clients put above
+	// quoted text, e.g. "On Tue, Jan 2, 2025 at 10:00 AM Jane <j@x.com> wrote:"
+	quoteHeaderPattern = regexp.MustCompile(`(?i)^\s*(on\s.+wrote:|am\s.+schrieb.+:|le\s.+a écrit\s?:|-{2,}\s*original message\s*-{2,}|_{5,}|from:\s.+)\s*$`)
+)
+
+// stripSubjectPrefixes removes any number of Re:/Fwd: prefixes and
+// [list] tags
+func stripSubjectPrefixes(subject string) string {
+	for {
+		stripped := subjectPrefixPattern.ReplaceAllString(subject, "")
+		if stripped == subject {
+			return strings.TrimSpace(subject)
+		}
+		subject = stripped
+	}
+}
+
+// StripQuotedReply returns only the newly written part of a reply: the text
+// above marker (if present), with quoted lines, the quote attribution line,
+// and everything below it removed. An empty marker skips marker detection.
+func StripQuotedReply(text, marker string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if marker != "" {
+		if i := strings.Index(text, marker); i >= 0 {
+			text = text[:i]
+		}
+	}
+	var kept []string
+	for _, line := range strings.Split(text, "\n") {
+		if quoteHeaderPattern.MatchString(line) {
+			break
+		}
+		if strings.HasPrefix(strings.TrimSpace(line), ">") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
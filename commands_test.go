@@ -0,0 +1,84 @@
+package inboundgo_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func commandPayload(subject, body string) *inboundgo.WebhookPayload {
+	p := &inboundgo.WebhookPayload{}
+	p.Email.Subject = inboundgo.String(subject)
+	p.Email.ParsedData.TextBody = inboundgo.String(body)
+	return p
+}
+
+func TestCommandRouterDispatch(t *testing.T) {
+	router := inboundgo.NewCommandRouter()
+	var approved, rejected []string
+	router.Handle("approve", func(ctx context.Context, cmd *inboundgo.Command) error {
+		approved = append(approved, strings.Join(cmd.Args, " ")+"@"+cmd.Source)
+		return nil
+	})
+	router.Handle("REJECT", func(ctx context.Context, cmd *inboundgo.Command) error {
+		rejected = append(rejected, strings.Join(cmd.Args, " ")+"@"+cmd.Source)
+		return errors.New("handler failed")
+	})
+	ctx := context.Background()
+
+	if _, err := router.Dispatch(ctx, commandPayload("Re: Fwd: [ops] APPROVE 123", "")); err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+
+	body := "reject: 456 budget too high\n\n" + inboundgo.DefaultReplyMarker + "\nAPPROVE 456\n"
+	cmd, err := router.Dispatch(ctx, commandPayload("Re: Expense report", body))
+	if err == nil || err.Error() != "handler failed" || cmd.Name != "REJECT" {
+		t.Errorf("Expected handler error to propagate, got %v %+v", err, cmd)
+	}
+
+	if _, err := router.Dispatch(ctx, commandPayload("Hello", "Thanks!\n> APPROVE 789")); !errors.Is(err, inboundgo.ErrNoCommand) {
+		t.Errorf("Expected ErrNoCommand for quoted command, got %v", err)
+	}
+
+	if len(approved) != 1 || approved[0] != "123@subject" {
+		t.Errorf("Unexpected approvals: %v", approved)
+	}
+	if len(rejected) != 1 || rejected[0] != "456 budget too high@body" {
+		t.Errorf("Unexpected rejections: %v", rejected)
+	}
+}
+
+func TestStripQuotedReply(t *testing.T) {
+	tests := []struct {
+		name, text, want string
+	}{
+		{
+			name: "gmail attribution",
+			text: "Looks good.\r\n\r\nOn Tue, Jan 7, 2025 at 10:00 AM Jane <jane@example.com> wrote:\r\n> Can you approve?\r\n",
+			want: "Looks good.",
+		},
+		{
+			name: "outlook separator",
+			text: "Approved\n\n-----Original Message-----\nFrom: Jane\nSubject: Request",
+			want: "Approved",
+		},
+		{
+			name: "inline quotes",
+			text: "> question one\nanswer one\n> question two\nanswer two",
+			want: "answer one\nanswer two",
+		},
+		{
+			name: "marker",
+			text: "Done\n" + inboundgo.DefaultReplyMarker + "\nTicket #42 was updated",
+			want: "Done",
+		},
+	}
+	for _, tt := range tests {
+		if got := inboundgo.StripQuotedReply(tt.text, inboundgo.DefaultReplyMarker); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
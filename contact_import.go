@@ -0,0 +1,179 @@
+package inboundgo
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"strconv"
+	"strings"
+)
+
+// ContactImportOptions configures ContactService.ImportCSV
+type ContactImportOptions struct {
+	// AudienceID adds every imported contact to this audience.
+	AudienceID string
+	// BatchSize is the number of contacts sent per bulk request (default 500).
+	BatchSize int
+}
+
+// ImportCSV creates or updates contacts from CSV with a header row. The
+// email column is required; first name, last name, and unsubscribed columns
+// are recognized in common spellings (first_name, "First Name", firstName),
+// and every other non-empty column becomes a string custom attribute named
+// after its header. Rows without a valid email are reported in Failed with
+// their row number instead of aborting the import.
+func (s *ContactService) ImportCSV(ctx context.Context, r io.Reader, opts *ContactImportOptions) (*PostContactsBulkResponse, error) {
+	batchSize := 500
+	var audienceID *string
+	if opts != nil {
+		if opts.BatchSize > 0 {
+			batchSize = opts.BatchSize
+		}
+		if opts.AudienceID != "" {
+			audienceID = String(opts.AudienceID)
+		}
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := make([]string, len(header))
+	emailColumn := -1
+	for i, h := range header {
+		columns[i] = contactColumn(h)
+		if columns[i] == "email" {
+			emailColumn = i
+		}
+	}
+	if emailColumn < 0 {
+		return nil, errors.New("CSV has no email column")
+	}
+
+	result := &PostContactsBulkResponse{}
+	batch := make([]PostContactsRequest, 0, batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		resp, err := s.BulkUpsert(ctx, &PostContactsBulkRequest{Contacts: batch, AudienceID: audienceID})
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return fmt.Errorf("failed to import contacts: %s", resp.Error)
+		}
+		if resp.Data != nil {
+			result.Created += resp.Data.Created
+			result.Updated += resp.Data.Updated
+			result.Failed = append(result.Failed, resp.Data.Failed...)
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return result, fmt.Errorf("failed to read CSV row %d: %w", row, err)
+		}
+
+		contact, err := contactFromRecord(columns, header, record)
+		if err != nil {
+			email := ""
+			if emailColumn < len(record) {
+				email = record[emailColumn]
+			}
+			result.Failed = append(result.Failed, ContactImportError{Row: row, Email: email, Error: err.Error()})
+			continue
+		}
+		batch = append(batch, contact)
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// contactColumn maps a CSV header to a contact field name, or "" for a
+// custom attribute
+func contactColumn(header string) string {
+	key := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '_' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(header)))
+
+	switch key {
+	case "email", "emailaddress":
+		return "email"
+	case "firstname", "givenname":
+		return "firstName"
+	case "lastname", "surname", "familyname":
+		return "lastName"
+	case "unsubscribed":
+		return "unsubscribed"
+	}
+	return ""
+}
+
+func contactFromRecord(columns, header, record []string) (PostContactsRequest, error) {
+	var contact PostContactsRequest
+	for i, value := range record {
+		if i >= len(columns) {
+			break
+		}
+		value = strings.TrimSpace(value)
+		switch columns[i] {
+		case "email":
+			addr, err := mail.ParseAddress(value)
+			if err != nil {
+				return contact, fmt.Errorf("invalid email %q", value)
+			}
+			contact.Email = addr.Address
+		case "firstName":
+			if value != "" {
+				contact.FirstName = String(value)
+			}
+		case "lastName":
+			if value != "" {
+				contact.LastName = String(value)
+			}
+		case "unsubscribed":
+			if value != "" {
+				b, err := strconv.ParseBool(value)
+				if err != nil {
+					return contact, fmt.Errorf("invalid unsubscribed value %q", value)
+				}
+				contact.Unsubscribed = Bool(b)
+			}
+		default:
+			if value == "" {
+				continue
+			}
+			if contact.Attributes == nil {
+				contact.Attributes = make(map[string]any)
+			}
+			contact.Attributes[strings.TrimSpace(header[i])] = value
+		}
+	}
+	if contact.Email == "" {
+		return contact, errors.New("missing email")
+	}
+	return contact, nil
+}
@@ -0,0 +1,154 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestContactService(t *testing.T) {
+	const contactJSON = `{"id": "ct-1", "email": "ada@example.com", "firstName": "Ada", "attributes": {"plan": "pro"},
+		"audienceIds": ["aud-1"], "createdAt": "2025-01-01T00:00:00Z", "updatedAt": "2025-01-01T00:00:00Z"}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /contacts", "GET /contacts/ct-1", "PUT /contacts/ct-1":
+			w.Write([]byte(contactJSON))
+		case "GET /contacts":
+			if r.URL.Query().Get("audienceId") != "aud-1" {
+				t.Errorf("Expected audience filter, got %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"data": [` + contactJSON + `], "pagination": {"total": 1}}`))
+		case "DELETE /contacts/ct-1":
+			w.Write([]byte(`{"message": "Contact deleted"}`))
+		case "POST /audiences":
+			w.Write([]byte(`{"id": "aud-1", "name": "Newsletter", "contactCount": 0}`))
+		case "POST /audiences/aud-1/contacts":
+			var req inboundgo.PostAudienceContactsRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if len(req.ContactIDs) != 2 {
+				t.Errorf("Expected two contact IDs, got %v", req.ContactIDs)
+			}
+			w.Write([]byte(`{"added": 2}`))
+		case "DELETE /audiences/aud-1/contacts/ct-1":
+			w.Write([]byte(`{"removed": 1}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+	svc := client.Contact()
+
+	created, err := svc.Create(ctx, &inboundgo.PostContactsRequest{
+		Email:      "ada@example.com",
+		FirstName:  inboundgo.String("Ada"),
+		Attributes: map[string]any{"plan": "pro"},
+	})
+	if err != nil || created.Error != "" || created.Data.Attributes["plan"] != "pro" {
+		t.Fatalf("Create failed: %v %+v", err, created)
+	}
+	if list, err := svc.List(ctx, &inboundgo.GetContactsRequest{AudienceID: "aud-1"}); err != nil || list.Error != "" || len(list.Data.Data) != 1 {
+		t.Errorf("List failed: %v %+v", err, list)
+	}
+	if got, err := svc.Get(ctx, "ct-1"); err != nil || got.Error != "" || got.Data.AudienceIDs[0] != "aud-1" {
+		t.Errorf("Get failed: %v %+v", err, got)
+	}
+	if updated, err := svc.Update(ctx, "ct-1", &inboundgo.PutContactByIDRequest{Attributes: map[string]any{"plan": nil}}); err != nil || updated.Error != "" {
+		t.Errorf("Update failed: %v %+v", err, updated)
+	}
+	if deleted, err := svc.Delete(ctx, "ct-1"); err != nil || deleted.Error != "" {
+		t.Errorf("Delete failed: %v %+v", err, deleted)
+	}
+
+	if audience, err := svc.CreateAudience(ctx, &inboundgo.PostAudiencesRequest{Name: "Newsletter"}); err != nil || audience.Error != "" || audience.Data.ID != "aud-1" {
+		t.Errorf("CreateAudience failed: %v %+v", err, audience)
+	}
+	if resp, err := svc.AddToAudience(ctx, "aud-1", "ct-1", "ct-2"); err != nil || resp.Error != "" {
+		t.Errorf("AddToAudience failed: %v %+v", err, resp)
+	}
+	if resp, err := svc.RemoveFromAudience(ctx, "aud-1", "ct-1"); err != nil || resp.Error != "" {
+		t.Errorf("RemoveFromAudience failed: %v %+v", err, resp)
+	}
+}
+
+func TestContactImportCSV(t *testing.T) {
+	var batches []inboundgo.PostContactsBulkRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/contacts/bulk" {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+		var req inboundgo.PostContactsBulkRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		batches = append(batches, req)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"created": 1, "updated": 1, "failed": []}`))
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	csv := "Email,First Name,last_name,Plan,Unsubscribed\n" +
+		"ada@example.com,Ada,Lovelace,pro,false\n" +
+		"not-an-email,Bad,Row,,\n" +
+		"Grace <grace@example.com>,Grace,,free,\n" +
+		"alan@example.com,Alan,Turing,,true\n"
+
+	result, err := client.Contact().ImportCSV(context.Background(), strings.NewReader(csv), &inboundgo.ContactImportOptions{
+		AudienceID: "aud-1",
+		BatchSize:  2,
+	})
+	if err != nil {
+		t.Fatalf("ImportCSV failed: %v", err)
+	}
+
+	if len(batches) != 2 || len(batches[0].Contacts) != 2 || len(batches[1].Contacts) != 1 {
+		t.Fatalf("Expected batches of 2 and 1, got %+v", batches)
+	}
+	if batches[0].AudienceID == nil || *batches[0].AudienceID != "aud-1" {
+		t.Error("Expected audience ID on every batch")
+	}
+	ada := batches[0].Contacts[0]
+	if ada.Email != "ada@example.com" || *ada.FirstName != "Ada" || *ada.LastName != "Lovelace" || ada.Attributes["Plan"] != "pro" || *ada.Unsubscribed {
+		t.Errorf("Unexpected first contact: %+v", ada)
+	}
+	if grace := batches[0].Contacts[1]; grace.Email != "grace@example.com" || grace.LastName != nil {
+		t.Errorf("Unexpected second contact: %+v", grace)
+	}
+	if alan := batches[1].Contacts[0]; alan.Attributes != nil || !*alan.Unsubscribed {
+		t.Errorf("Unexpected third contact: %+v", alan)
+	}
+
+	if result.Created != 2 || result.Updated != 2 {
+		t.Errorf("Expected aggregated counts, got %+v", result)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].Row != 3 || result.Failed[0].Email != "not-an-email" {
+		t.Errorf("Expected row 3 to fail locally, got %+v", result.Failed)
+	}
+}
+
+func TestContactImportCSVRequiresEmail(t *testing.T) {
+	client, err := inboundgo.NewClient("test-api-key", "http://127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if _, err := client.Contact().ImportCSV(context.Background(), strings.NewReader("name\nAda\n"), nil); err == nil {
+		t.Error("Expected error for CSV without an email column")
+	}
+}
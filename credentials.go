@@ -0,0 +1,224 @@
+package inboundgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIKeyProvider returns the API key to use for a request. It is called for
+// every request, so it can return short-lived credentials from Vault, AWS
+// Secrets Manager, or the OS keychain; wrap slow providers with
+// CachedAPIKeyProvider.
+type APIKeyProvider func(ctx context.Context) (string, error)
+
+// NewClientWithKeyProvider creates a client that gets its API key from
+// provider at request time
+func NewClientWithKeyProvider(provider APIKeyProvider, baseURL ...string) (*Inbound, error) {
+	if provider == nil {
+		return nil, fmt.Errorf("API key provider is required")
+	}
+	// The static key is never used while a provider is set
+	c, err := NewClient("provider", baseURL...)
+	if err != nil {
+		return nil, err
+	}
+	return c.WithAPIKeyProvider(provider), nil
+}
+
+// WithAPIKeyProvider makes the client get its API key from provider at
+// request time instead of using the key it was created with. A nil provider
+// restores the static key.
+func (c *Inbound) WithAPIKeyProvider(provider APIKeyProvider) *Inbound {
+	c.keyProvider = provider
+	return c
+}
+
+// SetAPIKey replaces the API key used by subsequent requests, for rotating
+// keys without recreating the client. It is safe to call while requests are
+// in flight.
+func (c *Inbound) SetAPIKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("API key is required")
+	}
+	c.keyMu.Lock()
+	defer c.keyMu.Unlock()
+	c.apiKey = key
+	return nil
+}
+
+// WithAPIKeyRefresher sets a hook that is called when the API rejects the
+// current key with 401 Unauthorized. The key it returns replaces the
+// current one and the request is retried once. Concurrent rejections share
+// a single refresh. It does not apply while an APIKeyProvider is set.
+func (c *Inbound) WithAPIKeyRefresher(refresh APIKeyProvider) *Inbound {
+	c.keyRefresher = refresh
+	return c
+}
+
+// refreshAPIKey replaces rejected with a fresh key and reports whether the
+// request should be retried
+func (c *Inbound) refreshAPIKey(ctx context.Context, rejected string) bool {
+	if c.keyRefresher == nil || c.keyProvider != nil {
+		return false
+	}
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	c.keyMu.RLock()
+	current := c.apiKey
+	c.keyMu.RUnlock()
+	if current != rejected {
+		// Another request already refreshed it
+		return true
+	}
+	key, err := c.keyRefresher(ctx)
+	if err != nil || key == "" || key == rejected {
+		return false
+	}
+	return c.SetAPIKey(key) == nil
+}
+
+// WithFallbackAPIKeys configures keys that are tried in order when the API
+// rejects the current key (401 or 403) or rate limits it (429), for
+// migrating between keys or workspaces. The client keeps using the key that
+// last worked until it fails too. ApiResponse.KeyIndex reports which key
+// served each request. Fallback keys do not apply while an APIKeyProvider is
+// set.
+func (c *Inbound) WithFallbackAPIKeys(keys ...string) *Inbound {
+	c.fallbackKeys = append([]string(nil), keys...)
+	c.activeKey.Store(0)
+	return c
+}
+
+// apiKeyOverrideKey is the context key of an API key set with
+// ContextWithAPIKey
+type apiKeyOverrideKey struct{}
+
+// ContextWithAPIKey returns a context whose requests are sent with key
+// instead of the client's own key, so a platform managing many customer
+// workspaces can share one client and its connections between them:
+//
+//	ctx := inboundgo.ContextWithAPIKey(ctx, tenant.InboundKey)
+//	resp, err := client.Mail().List(ctx, nil)
+//
+// Such requests bypass the client's response and ETag caches, whose entries
+// belong to its own key, and are not refreshed or failed over to fallback
+// keys.
+func ContextWithAPIKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, apiKeyOverrideKey{}, key)
+}
+
+// apiKeyOverride returns the API key set in ctx with ContextWithAPIKey, or
+// "" if there is none
+func apiKeyOverride(ctx context.Context) string {
+	key, _ := ctx.Value(apiKeyOverrideKey{}).(string)
+	return key
+}
+
+// apiKeyIndexKey is the context key of the index of the API key a request
+// is sent with: 0 for the primary key, then the fallback keys
+type apiKeyIndexKey struct{}
+
+// keyIndex returns the index of the API key selected in ctx
+func keyIndex(ctx context.Context) int {
+	index, _ := ctx.Value(apiKeyIndexKey{}).(int)
+	return index
+}
+
+// isKeyFailoverStatus reports whether a status is specific to the key used
+func isKeyFailoverStatus(status int) bool {
+	return status == http.StatusUnauthorized || status == http.StatusForbidden || status == http.StatusTooManyRequests
+}
+
+// sendWithKeys sends a request, refreshing a rejected primary key or
+// failing over to fallback keys as configured
+func (c *Inbound) sendWithKeys(ctx context.Context, method string, build requestBuilder) (*http.Response, error) {
+	if apiKeyOverride(ctx) != "" {
+		return c.send(ctx, method, build)
+	}
+	active := 0
+	if len(c.fallbackKeys) > 0 && c.keyProvider == nil {
+		active = int(c.activeKey.Load())
+		ctx = context.WithValue(ctx, apiKeyIndexKey{}, active)
+	}
+	resp, err := c.send(ctx, method, build)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized && active == 0 {
+		rejected := strings.TrimPrefix(resp.Request.Header.Get("Authorization"), "Bearer ")
+		if c.refreshAPIKey(ctx, rejected) {
+			discardResponse(resp)
+			resp, err = c.send(ctx, method, build)
+		}
+	}
+	if c.keyProvider != nil {
+		return resp, err
+	}
+
+	index := active
+	for tried := 0; err == nil && isKeyFailoverStatus(resp.StatusCode) && tried < len(c.fallbackKeys); tried++ {
+		index = (index + 1) % (len(c.fallbackKeys) + 1)
+		discardResponse(resp)
+		resp, err = c.send(context.WithValue(ctx, apiKeyIndexKey{}, index), method, build)
+	}
+	if err == nil && !isKeyFailoverStatus(resp.StatusCode) && index != active {
+		c.activeKey.Store(int32(index))
+	}
+	return resp, err
+}
+
+// discardResponse drains and closes a response that will not be used, so
+// its connection can be reused
+func discardResponse(resp *http.Response) {
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
+
+// resolveAPIKey returns the API key for a request
+func (c *Inbound) resolveAPIKey(ctx context.Context) (string, error) {
+	if key := apiKeyOverride(ctx); key != "" {
+		return key, nil
+	}
+	if c.keyProvider == nil {
+		if index := keyIndex(ctx); index > 0 && index <= len(c.fallbackKeys) {
+			return c.fallbackKeys[index-1], nil
+		}
+		c.keyMu.RLock()
+		defer c.keyMu.RUnlock()
+		return c.apiKey, nil
+	}
+	key, err := c.keyProvider(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get API key: %w", err)
+	}
+	if key == "" {
+		return "", errors.New("failed to get API key: provider returned an empty key")
+	}
+	return key, nil
+}
+
+// CachedAPIKeyProvider wraps provider so a key is fetched at most once per
+// ttl. Errors are not cached. Concurrent callers share a single fetch.
+func CachedAPIKeyProvider(provider APIKeyProvider, ttl time.Duration) APIKeyProvider {
+	var (
+		mu      sync.Mutex
+		key     string
+		expires time.Time
+	)
+	return func(ctx context.Context) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if key != "" && time.Now().Before(expires) {
+			return key, nil
+		}
+		fresh, err := provider(ctx)
+		if err != nil {
+			return "", err
+		}
+		key, expires = fresh, time.Now().Add(ttl)
+		return key, nil
+	}
+}
@@ -0,0 +1,245 @@
+package inboundgo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestAPIKeyProvider(t *testing.T) {
+	var auth atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth.Store(r.Header.Get("Authorization"))
+		w.Write([]byte(`{"emails":[],"pagination":{}}`))
+	}))
+	defer server.Close()
+
+	var calls atomic.Int32
+	client, err := inboundgo.NewClientWithKeyProvider(func(ctx context.Context) (string, error) {
+		return "key-" + string(rune('a'+calls.Add(1)-1)), nil
+	}, server.URL)
+	if err != nil {
+		t.Fatalf("NewClientWithKeyProvider failed: %v", err)
+	}
+	ctx := context.Background()
+	client.Mail().List(ctx, nil)
+	client.Mail().List(ctx, nil)
+	if auth.Load() != "Bearer key-b" {
+		t.Errorf("Expected the provider to be called per request, got %v", auth.Load())
+	}
+
+	client.WithAPIKeyProvider(func(ctx context.Context) (string, error) {
+		return "", errors.New("vault sealed")
+	})
+	resp, err := client.Mail().List(ctx, nil)
+	if err != nil || !strings.Contains(resp.Error, "vault sealed") {
+		t.Errorf("Expected provider error in response, got %v %q", err, resp.Error)
+	}
+
+	if _, err := inboundgo.NewClientWithKeyProvider(nil); err == nil {
+		t.Error("Expected error for nil provider")
+	}
+}
+
+func TestCachedAPIKeyProvider(t *testing.T) {
+	var calls int
+	fail := true
+	provider := inboundgo.CachedAPIKeyProvider(func(ctx context.Context) (string, error) {
+		calls++
+		if fail {
+			return "", errors.New("unavailable")
+		}
+		return "key", nil
+	}, 50*time.Millisecond)
+
+	ctx := context.Background()
+	if _, err := provider(ctx); err == nil {
+		t.Fatal("Expected error")
+	}
+	fail = false
+	for i := 0; i < 3; i++ {
+		if key, err := provider(ctx); err != nil || key != "key" {
+			t.Fatalf("Unexpected result %q %v", key, err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Expected errors not to be cached and keys to be, got %d calls", calls)
+	}
+	time.Sleep(60 * time.Millisecond)
+	provider(ctx)
+	if calls != 3 {
+		t.Errorf("Expected refetch after ttl, got %d calls", calls)
+	}
+}
+
+func TestSetAPIKey(t *testing.T) {
+	var auth atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth.Store(r.Header.Get("Authorization"))
+		w.Write([]byte(`{"emails":[],"pagination":{}}`))
+	}))
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("old-key", server.URL)
+	if err := client.SetAPIKey(""); err == nil {
+		t.Error("Expected error for empty key")
+	}
+	if err := client.SetAPIKey("new-key"); err != nil {
+		t.Fatalf("SetAPIKey failed: %v", err)
+	}
+	client.Mail().List(context.Background(), nil)
+	if auth.Load() != "Bearer new-key" {
+		t.Errorf("Expected new key, got %v", auth.Load())
+	}
+}
+
+func TestAPIKeyRefresherOnUnauthorized(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("Authorization") != "Bearer rotated-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"Invalid API key"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"email_1"}`))
+	}))
+	defer server.Close()
+
+	var refreshes atomic.Int32
+	client, _ := inboundgo.NewClient("revoked-key", server.URL)
+	client.WithAPIKeyRefresher(func(ctx context.Context) (string, error) {
+		refreshes.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return "rotated-key", nil
+	})
+
+	done := make(chan *inboundgo.ApiResponse[inboundgo.PostEmailsResponse], 5)
+	for i := 0; i < 5; i++ {
+		go func() {
+			resp, _ := client.Email().Send(context.Background(), &inboundgo.PostEmailsRequest{From: "a@acme.test", Text: inboundgo.String("Hi")}, nil)
+			done <- resp
+		}()
+	}
+	for i := 0; i < 5; i++ {
+		if resp := <-done; resp.Error != "" {
+			t.Errorf("Expected request to succeed after refresh, got %q", resp.Error)
+		}
+	}
+	if refreshes.Load() != 1 {
+		t.Errorf("Expected a single refresh, got %d", refreshes.Load())
+	}
+
+	// A refresher that cannot produce a new key leaves the 401 in place
+	client.SetAPIKey("revoked-key")
+	client.WithAPIKeyRefresher(func(ctx context.Context) (string, error) {
+		return "", errors.New("rotation in progress")
+	})
+	resp, _ := client.Email().Send(context.Background(), &inboundgo.PostEmailsRequest{}, nil)
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected 401, got %d", resp.StatusCode)
+	}
+}
+
+func TestFallbackAPIKeys(t *testing.T) {
+	var mu sync.Mutex
+	status := map[string]int{"Bearer primary": http.StatusUnauthorized, "Bearer secondary": http.StatusTooManyRequests}
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		auth := r.Header.Get("Authorization")
+		seen = append(seen, auth)
+		if code := status[auth]; code != 0 {
+			w.WriteHeader(code)
+			w.Write([]byte(`{"error":"rejected"}`))
+			return
+		}
+		w.Write([]byte(`{"emails":[],"pagination":{}}`))
+	}))
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("primary", server.URL)
+	client.WithFallbackAPIKeys("secondary", "tertiary")
+	ctx := context.Background()
+
+	resp, _ := client.Mail().List(ctx, nil)
+	if resp.Error != "" || resp.KeyIndex != 2 {
+		t.Fatalf("Expected tertiary key to serve the request, got index %d error %q", resp.KeyIndex, resp.Error)
+	}
+	if len(seen) != 3 {
+		t.Errorf("Expected three attempts, got %v", seen)
+	}
+
+	// The working key sticks
+	seen = nil
+	resp, _ = client.Mail().List(ctx, nil)
+	if resp.KeyIndex != 2 || len(seen) != 1 {
+		t.Errorf("Expected the tertiary key to be reused, got index %d after %v", resp.KeyIndex, seen)
+	}
+
+	// When it fails, the others are tried again from the next one
+	mu.Lock()
+	status["Bearer tertiary"] = http.StatusForbidden
+	delete(status, "Bearer primary")
+	mu.Unlock()
+	seen = nil
+	resp, _ = client.Mail().List(ctx, nil)
+	if resp.Error != "" || resp.KeyIndex != 0 || len(seen) != 2 {
+		t.Errorf("Expected fail back to the primary key, got index %d after %v", resp.KeyIndex, seen)
+	}
+}
+
+func TestFallbackAPIKeysExhausted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"Invalid API key"}`))
+	}))
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("primary", server.URL)
+	client.WithFallbackAPIKeys("secondary")
+	resp, _ := client.Mail().List(context.Background(), nil)
+	if resp.StatusCode != http.StatusUnauthorized || resp.KeyIndex != 1 {
+		t.Errorf("Expected the last 401, got %d from key %d", resp.StatusCode, resp.KeyIndex)
+	}
+}
+
+func TestContextWithAPIKey(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Authorization"))
+		if r.Header.Get("Authorization") == "Bearer tenant-b-key-123" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"Key tenant-b-key-123 is revoked"}`))
+			return
+		}
+		w.Write([]byte(`{"data":[],"pagination":{"limit":50,"offset":0,"total":0,"hasMore":false}}`))
+	}))
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("own-key", server.URL)
+	client.WithResponseCache(inboundgo.ResponseCacheOptions{TTL: time.Minute, Paths: []string{"/mail"}}).WithFallbackAPIKeys("fallback-key")
+
+	ctx := context.Background()
+	client.Mail().List(ctx, nil)
+	client.Mail().List(inboundgo.ContextWithAPIKey(ctx, "tenant-a-key"), nil)
+	resp, _ := client.Mail().List(inboundgo.ContextWithAPIKey(ctx, "tenant-b-key-123"), nil)
+
+	want := []string{"Bearer own-key", "Bearer tenant-a-key", "Bearer tenant-b-key-123"}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("Expected keys %v, got %v", want, keys)
+	}
+	if resp.StatusCode != http.StatusUnauthorized || resp.Error != "Key [REDACTED] is revoked" {
+		t.Errorf("Unexpected response %d %q", resp.StatusCode, resp.Error)
+	}
+}
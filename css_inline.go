@@ -0,0 +1,339 @@
+package inboundgo
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// WithCSSInlining enables or disables CSS inlining for every email sent.
+// PostEmailsRequest.InlineCSS overrides it per email.
+func (c *Inbound) WithCSSInlining(enabled bool) *Inbound {
+	c.inlineCSS = enabled
+	return c
+}
+
+var (
+	// protectedPattern matches markup whose content must not be touched:
+	// comments (including Outlook conditional comments) and scripts
+	protectedPattern  = regexp.MustCompile(`(?is)<!--.*?-->|<script\b.*?</script\s*>`)
+	styleBlockPattern = regexp.MustCompile(`(?is)<style\b([^>]*)>(.*?)</style\s*>`)
+	startTagPattern   = regexp.MustCompile(`<([a-zA-Z][a-zA-Z0-9-]*)((?:\s+[^\s=/>]+(?:\s*=\s*(?:"[^"]*"|'[^']*'|[^\s>]+))?)*)\s*(/?)>`)
+	attributePattern  = regexp.MustCompile(`([^\s=/>]+)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+)))?`)
+	cssCommentPattern = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	// simpleSelectorPattern matches the selectors that are inlined: a tag,
+	// class, and ID compound without combinators or pseudo-classes
+	simpleSelectorPattern = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9-]*|\*)?((?:[.#][a-zA-Z0-9_-]+)*)$`)
+	selectorPartPattern   = regexp.MustCompile(`[.#][^.#]+`)
+)
+
+// noInlineTags are elements that never get a style attribute
+var noInlineTags = map[string]bool{
+	"html": true, "head": true, "title": true, "meta": true, "link": true,
+	"base": true, "style": true, "script": true,
+}
+
+// cssRule is an inlinable rule with a single selector
+type cssRule struct {
+	tag         string
+	classes     []string
+	id          string
+	specificity int
+	order       int
+	decls       []cssDeclaration
+}
+
+type cssDeclaration struct {
+	property  string
+	value     string
+	important bool
+}
+
+// InlineCSS copies the rules of <style> blocks into the style attributes of
+// the elements they match, since many mail clients drop <style> in the
+// head. Rules that cannot be inlined, such as @media queries, pseudo-classes
+// and selectors with combinators, stay in their <style> block so responsive
+// layouts keep working; blocks left empty are removed, and blocks marked
+// data-embed are left alone. Existing style attributes take precedence over
+// stylesheet rules, as in a browser.
+func InlineCSS(html string) string {
+	var protected []string
+	placeholder := func(s string) string {
+		protected = append(protected, s)
+		return fmt.Sprintf("\x00%d\x00", len(protected)-1)
+	}
+	html = protectedPattern.ReplaceAllStringFunc(html, placeholder)
+
+	var rules []cssRule
+	html = styleBlockPattern.ReplaceAllStringFunc(html, func(block string) string {
+		m := styleBlockPattern.FindStringSubmatch(block)
+		if strings.Contains(strings.ToLower(m[1]), "data-embed") {
+			return placeholder(block)
+		}
+		inlinable, rest := parseStylesheet(m[2], len(rules))
+		rules = append(rules, inlinable...)
+		if strings.TrimSpace(rest) == "" {
+			return ""
+		}
+		return placeholder("<style" + m[1] + ">" + rest + "</style>")
+	})
+
+	if len(rules) > 0 {
+		html = startTagPattern.ReplaceAllStringFunc(html, func(tag string) string {
+			return inlineTag(tag, rules)
+		})
+	}
+
+	for i := len(protected) - 1; i >= 0; i-- {
+		html = strings.Replace(html, fmt.Sprintf("\x00%d\x00", i), protected[i], 1)
+	}
+	return html
+}
+
+// parseStylesheet splits css into inlinable rules and the remaining CSS
+func parseStylesheet(css string, order int) ([]cssRule, string) {
+	css = cssCommentPattern.ReplaceAllString(css, "")
+	var rules []cssRule
+	var rest strings.Builder
+	for {
+		css = strings.TrimSpace(css)
+		open := strings.IndexByte(css, '{')
+		if open < 0 {
+			break
+		}
+		prelude := strings.TrimSpace(css[:open])
+		end := matchingBrace(css, open)
+		if end < 0 {
+			// Unterminated block; leave it for the client to deal with
+			rest.WriteString(css)
+			break
+		}
+		body := css[open+1 : end]
+		css = css[end+1:]
+
+		if strings.HasPrefix(prelude, "@") {
+			rest.WriteString(prelude + " {" + body + "}\n")
+			continue
+		}
+		decls := parseDeclarations(body)
+		var keep []string
+		for _, selector := range strings.Split(prelude, ",") {
+			selector = strings.TrimSpace(selector)
+			rule, ok := parseSimpleSelector(selector)
+			if !ok {
+				keep = append(keep, selector)
+				continue
+			}
+			rule.order = order
+			order++
+			rule.decls = decls
+			rules = append(rules, rule)
+		}
+		if len(keep) > 0 {
+			rest.WriteString(strings.Join(keep, ", ") + " {" + body + "}\n")
+		}
+	}
+	return rules, rest.String()
+}
+
+// matchingBrace returns the index of the brace closing the one at open
+func matchingBrace(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func parseSimpleSelector(selector string) (cssRule, bool) {
+	m := simpleSelectorPattern.FindStringSubmatch(selector)
+	if m == nil || selector == "" {
+		return cssRule{}, false
+	}
+	rule := cssRule{tag: strings.ToLower(m[1])}
+	if rule.tag == "*" {
+		rule.tag = ""
+	} else if rule.tag != "" {
+		rule.specificity = 1
+	}
+	for _, part := range selectorPartPattern.FindAllString(m[2], -1) {
+		if part[0] == '#' {
+			if rule.id != "" && rule.id != part[1:] {
+				return cssRule{}, false
+			}
+			rule.id = part[1:]
+			rule.specificity += 100
+		} else {
+			rule.classes = append(rule.classes, part[1:])
+			rule.specificity += 10
+		}
+	}
+	return rule, true
+}
+
+// parseDeclarations splits a declaration block, keeping semicolons inside
+// quotes and parentheses (as in data: URLs)
+func parseDeclarations(body string) []cssDeclaration {
+	var decls []cssDeclaration
+	var quote byte
+	depth, start := 0, 0
+	add := func(s string) {
+		property, value, ok := strings.Cut(s, ":")
+		property, value = strings.ToLower(strings.TrimSpace(property)), strings.TrimSpace(value)
+		if !ok || property == "" || value == "" {
+			return
+		}
+		d := cssDeclaration{property: property, value: value}
+		if lower := strings.ToLower(value); strings.HasSuffix(lower, "!important") {
+			d.important = true
+			d.value = strings.TrimSpace(value[:len(value)-len("!important")])
+		}
+		// The value ends up in a double-quoted attribute
+		d.value = strings.ReplaceAll(d.value, `"`, "'")
+		decls = append(decls, d)
+	}
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ';' && depth <= 0:
+			add(body[start:i])
+			start = i + 1
+		}
+	}
+	add(body[start:])
+	return decls
+}
+
+// matches reports whether the rule applies to an element
+func (r *cssRule) matches(tag, id string, classes []string) bool {
+	if r.tag != "" && r.tag != tag {
+		return false
+	}
+	if r.id != "" && r.id != id {
+		return false
+	}
+	for _, want := range r.classes {
+		found := false
+		for _, class := range classes {
+			if class == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// inlineTag adds the declarations of matching rules to a start tag
+func inlineTag(tag string, rules []cssRule) string {
+	m := startTagPattern.FindStringSubmatch(tag)
+	name := strings.ToLower(m[1])
+	if noInlineTags[name] {
+		return tag
+	}
+	var id, style string
+	var classes []string
+	hasStyle := false
+	for _, attr := range attributePattern.FindAllStringSubmatch(m[2], -1) {
+		value := attr[2] + attr[3] + attr[4]
+		switch strings.ToLower(attr[1]) {
+		case "id":
+			id = value
+		case "class":
+			classes = strings.Fields(value)
+		case "style":
+			style, hasStyle = value, true
+		}
+	}
+
+	var matched []*cssRule
+	for i := range rules {
+		if rules[i].matches(name, id, classes) {
+			matched = append(matched, &rules[i])
+		}
+	}
+	if len(matched) == 0 {
+		return tag
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		if matched[i].specificity != matched[j].specificity {
+			return matched[i].specificity < matched[j].specificity
+		}
+		return matched[i].order < matched[j].order
+	})
+
+	// Cascade: rules by specificity, then the style attribute, then
+	// !important rules, then !important in the style attribute
+	inline := parseDeclarations(style)
+	var cascade []cssDeclaration
+	for pass := 0; pass < 2; pass++ {
+		important := pass == 1
+		for _, rule := range matched {
+			for _, d := range rule.decls {
+				if d.important == important {
+					cascade = append(cascade, d)
+				}
+			}
+		}
+		for _, d := range inline {
+			if d.important == important {
+				cascade = append(cascade, d)
+			}
+		}
+	}
+	winners := make(map[string]int)
+	for i, d := range cascade {
+		winners[d.property] = i
+	}
+	var parts []string
+	for i, d := range cascade {
+		if winners[d.property] != i {
+			continue
+		}
+		value := d.value
+		if d.important {
+			value += " !important"
+		}
+		parts = append(parts, d.property+": "+value)
+	}
+	merged := strings.Join(parts, "; ")
+
+	attrs := m[2]
+	if hasStyle {
+		attrs = attributePattern.ReplaceAllStringFunc(attrs, func(attr string) string {
+			if strings.EqualFold(attributePattern.FindStringSubmatch(attr)[1], "style") {
+				return `style="` + merged + `"`
+			}
+			return attr
+		})
+	} else {
+		attrs += ` style="` + merged + `"`
+	}
+	closing := ">"
+	if m[3] != "" {
+		closing = " />"
+	}
+	return "<" + m[1] + attrs + closing
+}
@@ -0,0 +1,78 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestInlineCSS(t *testing.T) {
+	html := `<html><head><style>
+/* brand */
+p { color: #333; margin: 0 }
+.button, a.button:hover { background: url("data:image/png;base64,AAA"); color: white !important }
+#cta { padding: 12px }
+@media (max-width: 600px) { p { font-size: 18px } }
+</style></head>
+<body><p class="lead">Hi</p><a class="button x" id="cta" href="#" style="color: red; margin: 4px">Go</a><br/>
+<!--[if mso]><style>p { color: blue }</style><![endif]--></body></html>`
+
+	out := inboundgo.InlineCSS(html)
+	for _, want := range []string{
+		`<p class="lead" style="color: #333; margin: 0">`,
+		`<a class="button x" id="cta" href="#" style="background: url('data:image/png;base64,AAA'); padding: 12px; margin: 4px; color: white !important">`,
+		`@media (max-width: 600px) { p { font-size: 18px } }`,
+		`a.button:hover {`,
+		`<!--[if mso]><style>p { color: blue }</style><![endif]-->`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "#cta {") || strings.Contains(out, "<head style") {
+		t.Errorf("Unexpected output:\n%s", out)
+	}
+}
+
+func TestInlineCSSRemovesEmptyStyleBlocks(t *testing.T) {
+	out := inboundgo.InlineCSS(`<style>td { border: 0 }</style><style data-embed>td { padding: 0 }</style><table><tr><td>x</td></tr></table>`)
+	if out != `<style data-embed>td { padding: 0 }</style><table><tr><td style="border: 0">x</td></tr></table>` {
+		t.Errorf("Unexpected output %s", out)
+	}
+	if plain := `<p>No styles</p>`; inboundgo.InlineCSS(plain) != plain {
+		t.Error("HTML without styles should be unchanged")
+	}
+}
+
+func TestSendInlinesCSS(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"id":"email_1"}`))
+	}))
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	html := `<style>p { color: red }</style><p>Hi</p>`
+
+	client.Email().Send(context.Background(), &inboundgo.PostEmailsRequest{HTML: inboundgo.String(html)}, nil)
+	if got["html"] != html {
+		t.Errorf("Inlining should be opt-in, got %v", got["html"])
+	}
+
+	client.Email().Send(context.Background(), &inboundgo.PostEmailsRequest{HTML: inboundgo.String(html), InlineCSS: inboundgo.Bool(true)}, nil)
+	if got["html"] != `<p style="color: red">Hi</p>` {
+		t.Errorf("Expected inlined HTML, got %v", got["html"])
+	}
+
+	client.WithCSSInlining(true)
+	client.Email().Send(context.Background(), &inboundgo.PostEmailsRequest{HTML: inboundgo.String(html), InlineCSS: inboundgo.Bool(false)}, nil)
+	if got["html"] != html {
+		t.Errorf("Expected per-email switch to disable inlining, got %v", got["html"])
+	}
+}
@@ -0,0 +1,156 @@
+package inboundgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrDeliveryTimeout is returned by WaitForDelivery when the email has not
+// reached a final state before the timeout
+var ErrDeliveryTimeout = errors.New("timed out waiting for delivery")
+
+// DeliveryOutcome is the final state of a sent email
+type DeliveryOutcome string
+
+const (
+	// DeliveryPending means no final state has been reported yet
+	DeliveryPending   DeliveryOutcome = "pending"
+	DeliveryDelivered DeliveryOutcome = "delivered"
+	DeliveryBounced   DeliveryOutcome = "bounced"
+	DeliveryFailed    DeliveryOutcome = "failed"
+)
+
+// DeliveryResult reports how a sent email ended up
+type DeliveryResult struct {
+	EmailID string
+	Outcome DeliveryOutcome
+	// Event is the event that decided the outcome, when the event timeline
+	// is available.
+	Event *EmailEvent
+	// Events is the full timeline at the last poll.
+	Events []EmailEvent
+}
+
+// Delivered reports whether the email was accepted by the recipient's server
+func (r *DeliveryResult) Delivered() bool {
+	return r != nil && r.Outcome == DeliveryDelivered
+}
+
+// Delivery checks start close together, since most emails are delivered
+// within seconds, and back off to the maximum
+const (
+	minDeliveryPollInterval = 250 * time.Millisecond
+	maxDeliveryPollInterval = 5 * time.Second
+)
+
+// WaitForDelivery polls a sent email's event timeline until it is
+// delivered, bounced, or failed, for tests and workflows that must confirm
+// delivery before proceeding. A non-positive timeout waits until ctx ends.
+//
+// On timeout the last known state is returned along with
+// ErrDeliveryTimeout. A bounced or failed email is not an error; check the
+// result's Outcome.
+func (s *EmailService) WaitForDelivery(ctx context.Context, id string, timeout time.Duration) (*DeliveryResult, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	result := &DeliveryResult{EmailID: id, Outcome: DeliveryPending}
+	useTimeline := true
+	interval := minDeliveryPollInterval
+	for {
+		var err error
+		if useTimeline {
+			useTimeline, err = s.checkDeliveryEvents(ctx, result)
+		}
+		if !useTimeline {
+			// The events endpoint is unavailable; fall back to LastEvent
+			err = s.checkDeliveryStatus(ctx, result)
+		}
+		if err != nil && ctx.Err() == nil {
+			return result, err
+		}
+		if result.Outcome != DeliveryPending {
+			return result, nil
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return result, ErrDeliveryTimeout
+			}
+			return result, err
+		}
+		interval = min(interval*2, maxDeliveryPollInterval)
+	}
+}
+
+// checkDeliveryEvents updates result from the event timeline. It reports
+// false if the timeline is not available for this email.
+func (s *EmailService) checkDeliveryEvents(ctx context.Context, result *DeliveryResult) (bool, error) {
+	resp, err := s.ListEvents(ctx, result.EmailID)
+	if err != nil {
+		return true, err
+	}
+	if resp.Error != "" {
+		if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented {
+			return false, nil
+		}
+		if isTransientStatus(resp.StatusCode) {
+			return true, nil
+		}
+		return true, fmt.Errorf("failed to get email events: %s", resp.Error)
+	}
+	result.Events = resp.Data.Events
+	for i := len(result.Events) - 1; i >= 0; i-- {
+		event := &result.Events[i]
+		switch event.Type {
+		case EmailEventDelivered:
+			result.Outcome = DeliveryDelivered
+		case EmailEventBounced:
+			result.Outcome = DeliveryBounced
+		case EmailEventFailed:
+			result.Outcome = DeliveryFailed
+		default:
+			continue
+		}
+		result.Event = event
+		break
+	}
+	return true, nil
+}
+
+// checkDeliveryStatus updates result from the email's last_event
+func (s *EmailService) checkDeliveryStatus(ctx context.Context, result *DeliveryResult) error {
+	resp, err := s.Get(ctx, result.EmailID)
+	if err != nil {
+		return err
+	}
+	if resp.Error != "" {
+		if isTransientStatus(resp.StatusCode) {
+			return nil
+		}
+		return fmt.Errorf("failed to get email: %s", resp.Error)
+	}
+	if outcome, ok := lastEventOutcome(resp.Data.LastEvent); ok {
+		result.Outcome = outcome
+	}
+	return nil
+}
+
+// lastEventOutcome maps an email's last_event to a final outcome
+func lastEventOutcome(lastEvent string) (DeliveryOutcome, bool) {
+	switch lastEvent {
+	case "delivered":
+		return DeliveryDelivered, true
+	case "bounced":
+		return DeliveryBounced, true
+	case "failed":
+		return DeliveryFailed, true
+	}
+	return "", false
+}
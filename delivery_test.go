@@ -0,0 +1,97 @@
+package inboundgo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestWaitForDelivery(t *testing.T) {
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/emails/sent-1/events" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if atomic.AddInt32(&polls, 1) == 1 {
+			w.Write([]byte(`{"email_id": "sent-1", "events": [{"type": "queued", "timestamp": "2025-01-15T10:00:00Z"}]}`))
+			return
+		}
+		w.Write([]byte(`{"email_id": "sent-1", "events": [
+			{"type": "delivered", "timestamp": "2025-01-15T10:00:02Z", "recipient": "b@example.com"},
+			{"type": "queued", "timestamp": "2025-01-15T10:00:00Z"},
+			{"type": "sent", "timestamp": "2025-01-15T10:00:01Z"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	result, err := client.Email().WaitForDelivery(context.Background(), "sent-1", 5*time.Second)
+	if err != nil {
+		t.Fatalf("WaitForDelivery failed: %v", err)
+	}
+	if !result.Delivered() || result.Event == nil || *result.Event.Recipient != "b@example.com" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if len(result.Events) != 3 || atomic.LoadInt32(&polls) != 2 {
+		t.Errorf("Expected 3 events after 2 polls, got %d after %d", len(result.Events), polls)
+	}
+}
+
+func TestWaitForDeliveryBounced(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"events": [{"type": "sent", "timestamp": "2025-01-15T10:00:01Z"}, {"type": "bounced", "timestamp": "2025-01-15T10:00:02Z", "bounce_type": "hard"}]}`))
+	}))
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	result, err := client.Email().WaitForDelivery(context.Background(), "sent-1", time.Second)
+	if err != nil {
+		t.Fatalf("WaitForDelivery failed: %v", err)
+	}
+	if result.Outcome != inboundgo.DeliveryBounced || result.Delivered() {
+		t.Errorf("Expected bounced outcome, got %s", result.Outcome)
+	}
+}
+
+func TestWaitForDeliveryFallsBackToLastEvent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/emails/sent-1/events" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "not found"}`))
+			return
+		}
+		w.Write([]byte(`{"id": "sent-1", "last_event": "failed"}`))
+	}))
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	result, err := client.Email().WaitForDelivery(context.Background(), "sent-1", time.Second)
+	if err != nil {
+		t.Fatalf("WaitForDelivery failed: %v", err)
+	}
+	if result.Outcome != inboundgo.DeliveryFailed || result.Event != nil {
+		t.Errorf("Expected failed outcome from last_event, got %+v", result)
+	}
+}
+
+func TestWaitForDeliveryTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"events": [{"type": "queued", "timestamp": "2025-01-15T10:00:00Z"}]}`))
+	}))
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	result, err := client.Email().WaitForDelivery(context.Background(), "sent-1", 300*time.Millisecond)
+	if !errors.Is(err, inboundgo.ErrDeliveryTimeout) {
+		t.Fatalf("Expected ErrDeliveryTimeout, got %v", err)
+	}
+	if result.Outcome != inboundgo.DeliveryPending || len(result.Events) != 1 {
+		t.Errorf("Expected pending result with the last timeline, got %+v", result)
+	}
+}
@@ -0,0 +1,101 @@
+package inboundgo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultZoneTTL is the TTL, in seconds, of records written by ZoneFile when
+// none is given
+const defaultZoneTTL = 3600
+
+// ZoneFile formats records as BIND zone file lines, for importing into DNS
+// hosts that accept zone files. Names and host values are made fully
+// qualified and TXT values are quoted, split into 255-byte strings where
+// needed. ttl is in seconds; 0 means one hour.
+func ZoneFile(records []DNSRecord, ttl int) string {
+	if ttl <= 0 {
+		ttl = defaultZoneTTL
+	}
+	var b strings.Builder
+	for _, r := range records {
+		fmt.Fprintf(&b, "%s\t%d\tIN\t%s\t%s\n", fqdn(r.Name), ttl, strings.ToUpper(r.Type), zoneValue(r))
+	}
+	return b.String()
+}
+
+// zoneValue returns the record data as written in a zone file
+func zoneValue(r DNSRecord) string {
+	switch strings.ToUpper(r.Type) {
+	case "TXT":
+		value := strings.Trim(r.Value, `"`)
+		var parts []string
+		for len(value) > 255 {
+			parts = append(parts, quoteZoneString(value[:255]))
+			value = value[255:]
+		}
+		return strings.Join(append(parts, quoteZoneString(value)), " ")
+	case "CNAME", "NS", "PTR":
+		return fqdn(r.Value)
+	case "MX":
+		if priority, host, ok := splitMX(r.Value); ok {
+			return strconv.Itoa(priority) + " " + fqdn(host)
+		}
+	}
+	return r.Value
+}
+
+func quoteZoneString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// fqdn returns name with a trailing dot
+func fqdn(name string) string {
+	if name == "" || strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// splitMX splits an MX value such as "10 inbound-smtp.us-east-2.amazonaws.com"
+// into its priority and host
+func splitMX(value string) (int, string, bool) {
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return 0, "", false
+	}
+	priority, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, "", false
+	}
+	return priority, strings.TrimSuffix(fields[1], "."), true
+}
+
+// DNSProvider creates DNS records at a DNS host, so a domain's verification
+// records can be applied without copying them by hand. The dns package has
+// providers for Cloudflare and for DNS hosts with a CLI.
+type DNSProvider interface {
+	// SetDNSRecord creates record unless an identical record exists.
+	// Other records with the same name are left alone.
+	SetDNSRecord(ctx context.Context, record DNSRecord) error
+}
+
+// DNSProviderFunc adapts a function to DNSProvider
+type DNSProviderFunc func(ctx context.Context, record DNSRecord) error
+
+func (f DNSProviderFunc) SetDNSRecord(ctx context.Context, record DNSRecord) error {
+	return f(ctx, record)
+}
+
+// ApplyDNSRecords sets each record with provider, stopping at the first
+// failure
+func ApplyDNSRecords(ctx context.Context, provider DNSProvider, records []DNSRecord) error {
+	for _, r := range records {
+		if err := provider.SetDNSRecord(ctx, r); err != nil {
+			return fmt.Errorf("failed to set %s record %s: %w", r.Type, r.Name, err)
+		}
+	}
+	return nil
+}
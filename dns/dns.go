@@ -0,0 +1,163 @@
+// Package dns applies an Inbound domain's DNS records at a DNS host. It
+// provides inboundgo.DNSProvider implementations for Cloudflare and for
+// hosts with a command-line tool.
+//
+//	provider := &dns.Cloudflare{APIToken: token, ZoneID: zoneID}
+//	err := inboundgo.ApplyDNSRecords(ctx, provider, domain.DNSRecords)
+package dns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+// Command returns a provider that runs a command for each record, with the
+// record's type, name, and value appended to args, for DNS hosts with a CLI
+// (such as gcloud or doctl) or in-house tooling
+func Command(name string, args ...string) inboundgo.DNSProvider {
+	return inboundgo.DNSProviderFunc(func(ctx context.Context, record inboundgo.DNSRecord) error {
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, name, append(append([]string(nil), args...), record.Type, record.Name, record.Value)...)
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return fmt.Errorf("%w: %s", err, msg)
+			}
+			return err
+		}
+		return nil
+	})
+}
+
+// defaultCloudflareURL is the base URL of the Cloudflare API
+const defaultCloudflareURL = "https://api.cloudflare.com/client/v4"
+
+// Cloudflare sets records in a Cloudflare zone. The API token needs the
+// Zone.DNS edit permission.
+type Cloudflare struct {
+	APIToken string
+	ZoneID   string
+	// BaseURL defaults to the Cloudflare API.
+	BaseURL string
+	// HTTPClient defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// cloudflareRecord is a DNS record in the Cloudflare API
+type cloudflareRecord struct {
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Content  string `json:"content"`
+	TTL      int    `json:"ttl"`
+	Priority *int   `json:"priority,omitempty"`
+}
+
+func (p *Cloudflare) SetDNSRecord(ctx context.Context, record inboundgo.DNSRecord) error {
+	if p.APIToken == "" || p.ZoneID == "" {
+		return errors.New("cloudflare: API token and zone ID are required")
+	}
+	// TTL 1 lets Cloudflare choose
+	want := cloudflareRecord{
+		Type:    strings.ToUpper(record.Type),
+		Name:    strings.TrimSuffix(record.Name, "."),
+		Content: record.Value,
+		TTL:     1,
+	}
+	switch want.Type {
+	case "MX":
+		if priority, host, ok := splitMX(record.Value); ok {
+			want.Content, want.Priority = host, &priority
+		}
+	case "TXT":
+		want.Content = strings.Trim(record.Value, `"`)
+	}
+
+	var existing []cloudflareRecord
+	query := url.Values{"type": {want.Type}, "name": {want.Name}}
+	if err := p.call(ctx, "GET", "/dns_records?"+query.Encode(), nil, &existing); err != nil {
+		return err
+	}
+	for _, r := range existing {
+		if strings.Trim(r.Content, `"`) == want.Content && (want.Priority == nil || r.Priority != nil && *r.Priority == *want.Priority) {
+			return nil
+		}
+	}
+	return p.call(ctx, "POST", "/dns_records", want, nil)
+}
+
+// call sends a request to the zone's API and decodes its result into out
+func (p *Cloudflare) call(ctx context.Context, method, path string, body, out any) error {
+	base := p.BaseURL
+	if base == "" {
+		base = defaultCloudflareURL
+	}
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(base, "/")+"/zones/"+url.PathEscape(p.ZoneID)+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.APIToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := p.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("cloudflare: %s", strings.ReplaceAll(err.Error(), p.APIToken, "[REDACTED]"))
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Success bool                       `json:"success"`
+		Errors  []struct{ Message string } `json:"errors"`
+		Result  json.RawMessage            `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("cloudflare: HTTP %d: %w", resp.StatusCode, err)
+	}
+	if !result.Success {
+		var messages []string
+		for _, e := range result.Errors {
+			messages = append(messages, e.Message)
+		}
+		return fmt.Errorf("cloudflare: HTTP %d: %s", resp.StatusCode, strings.Join(messages, "; "))
+	}
+	if out != nil {
+		return json.Unmarshal(result.Result, out)
+	}
+	return nil
+}
+
+// splitMX splits an MX value such as "10 inbound-smtp.us-east-2.amazonaws.com"
+// into its priority and host
+func splitMX(value string) (int, string, bool) {
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return 0, "", false
+	}
+	priority, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, "", false
+	}
+	return priority, strings.TrimSuffix(fields[1], "."), true
+}
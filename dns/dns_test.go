@@ -0,0 +1,65 @@
+package dns_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+	"github.com/inboundemail/inbound-golang-sdk/dns"
+)
+
+func TestCloudflare(t *testing.T) {
+	var created []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer cf-token" || r.URL.Path != "/zones/zone-1/dns_records" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		switch r.Method {
+		case http.MethodGet:
+			if r.URL.Query().Get("name") == "_dmarc.example.com" {
+				w.Write([]byte(`{"success":true,"result":[{"type":"TXT","name":"_dmarc.example.com","content":"\"v=DMARC1; p=none\""}]}`))
+				return
+			}
+			w.Write([]byte(`{"success":true,"result":[]}`))
+		case http.MethodPost:
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			created = append(created, body)
+			w.Write([]byte(`{"success":true,"result":{}}`))
+		}
+	}))
+	defer server.Close()
+
+	provider := &dns.Cloudflare{APIToken: "cf-token", ZoneID: "zone-1", BaseURL: server.URL}
+	err := inboundgo.ApplyDNSRecords(context.Background(), provider, []inboundgo.DNSRecord{
+		{Type: "MX", Name: "example.com", Value: "10 inbound-smtp.us-east-2.amazonaws.com"},
+		{Type: "TXT", Name: "_dmarc.example.com", Value: "v=DMARC1; p=none"},
+	})
+	if err != nil {
+		t.Fatalf("ApplyDNSRecords failed: %v", err)
+	}
+	if len(created) != 1 {
+		t.Fatalf("Expected only the MX record to be created, got %v", created)
+	}
+	if created[0]["content"] != "inbound-smtp.us-east-2.amazonaws.com" || created[0]["priority"] != float64(10) {
+		t.Errorf("Unexpected MX record %v", created[0])
+	}
+}
+
+func TestCloudflareError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"success":false,"errors":[{"code":10000,"message":"Authentication error"}]}`))
+	}))
+	defer server.Close()
+
+	provider := &dns.Cloudflare{APIToken: "cf-token", ZoneID: "zone-1", BaseURL: server.URL}
+	err := provider.SetDNSRecord(context.Background(), inboundgo.DNSRecord{Type: "TXT", Name: "example.com", Value: "v=spf1 -all"})
+	if err == nil || !strings.Contains(err.Error(), "Authentication error") {
+		t.Errorf("Expected the Cloudflare error, got %v", err)
+	}
+}
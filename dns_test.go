@@ -0,0 +1,42 @@
+package inboundgo_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestZoneFile(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	zone := inboundgo.ZoneFile([]inboundgo.DNSRecord{
+		{Type: "MX", Name: "example.com", Value: "10 inbound-smtp.us-east-2.amazonaws.com"},
+		{Type: "TXT", Name: "_dmarc.example.com", Value: `v=DMARC1; p=none`},
+		{Type: "CNAME", Name: "abc._domainkey.example.com.", Value: "abc.dkim.amazonses.com"},
+		{Type: "TXT", Name: "example.com", Value: long},
+	}, 0)
+
+	want := "example.com.\t3600\tIN\tMX\t10 inbound-smtp.us-east-2.amazonaws.com.\n" +
+		"_dmarc.example.com.\t3600\tIN\tTXT\t\"v=DMARC1; p=none\"\n" +
+		"abc._domainkey.example.com.\t3600\tIN\tCNAME\tabc.dkim.amazonses.com.\n" +
+		"example.com.\t3600\tIN\tTXT\t\"" + long[:255] + "\" \"" + long[255:] + "\"\n"
+	if zone != want {
+		t.Errorf("Unexpected zone file:\n%s", zone)
+	}
+}
+
+func TestApplyDNSRecordsStopsOnError(t *testing.T) {
+	calls := 0
+	failing := inboundgo.DNSProviderFunc(func(ctx context.Context, record inboundgo.DNSRecord) error {
+		calls++
+		return errors.New("denied")
+	})
+	err := inboundgo.ApplyDNSRecords(context.Background(), failing, []inboundgo.DNSRecord{
+		{Type: "MX", Name: "example.com"}, {Type: "TXT", Name: "example.com"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "MX record example.com") || calls != 1 {
+		t.Errorf("Unexpected result after %d calls: %v", calls, err)
+	}
+}
@@ -0,0 +1,26 @@
+package inboundgo
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// Do calls an API endpoint the SDK does not wrap yet, with the client's
+// authentication, failover, caching, and error handling. path is relative
+// to the base URL, such as "/domains/dom_123/stats?period=7d". body, if not
+// nil, is sent as JSON, and a successful response is decoded into out if it
+// is not nil. The returned response holds the raw JSON data.
+func (c *Inbound) Do(ctx context.Context, method, path string, body, out any) (*ApiResponse[json.RawMessage], error) {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	resp, err := makeRequest[json.RawMessage](c, ctx, strings.ToUpper(method), path, body, nil)
+	if err != nil || resp.Data == nil || out == nil {
+		return resp, err
+	}
+	if err := json.Unmarshal(*resp.Data, out); err != nil {
+		return &ApiResponse[json.RawMessage]{Error: "Failed to parse response", StatusCode: resp.StatusCode, KeyIndex: resp.KeyIndex}, nil
+	}
+	return resp, nil
+}
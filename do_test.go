@@ -0,0 +1,48 @@
+package inboundgo_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestDo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-api-key" {
+			t.Errorf("Missing authorization header")
+		}
+		switch r.Method + " " + r.URL.RequestURI() {
+		case "POST /domains/dom_1/stats?period=7d":
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != `{"metric":"opens"}`+"\n" && string(body) != `{"metric":"opens"}` {
+				t.Errorf("Unexpected body %q", body)
+			}
+			w.Write([]byte(`{"opens":42}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Not found"}`))
+		}
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+
+	var stats struct {
+		Opens int `json:"opens"`
+	}
+	resp, err := client.Do(context.Background(), "post", "domains/dom_1/stats?period=7d", map[string]string{"metric": "opens"}, &stats)
+	if err != nil || resp.Error != "" {
+		t.Fatalf("Do failed: %v %s", err, resp.Error)
+	}
+	if stats.Opens != 42 || string(*resp.Data) != `{"opens":42}` {
+		t.Errorf("Unexpected result %+v %s", stats, *resp.Data)
+	}
+
+	resp, _ = client.Do(context.Background(), http.MethodGet, "/unknown", nil, &stats)
+	if resp.StatusCode != http.StatusNotFound || resp.Error != "Not found" {
+		t.Errorf("Unexpected response %d %q", resp.StatusCode, resp.Error)
+	}
+}
@@ -0,0 +1,76 @@
+package inboundgo
+
+import "fmt"
+
+// ReputationThresholds are the limits a domain's reputation is checked
+// against. Zero values disable the corresponding check.
+type ReputationThresholds struct {
+	MaxBounceRate    float64
+	MaxComplaintRate float64
+	MinDeliveryRate  float64
+	// MinSent skips rate checks until the window has enough volume to be
+	// meaningful.
+	MinSent int
+}
+
+// DefaultReputationThresholds mirror the levels at which mailbox providers
+// and sending platforms typically start throttling or reviewing a sender
+var DefaultReputationThresholds = ReputationThresholds{
+	MaxBounceRate:    0.05,
+	MaxComplaintRate: 0.001,
+	MinDeliveryRate:  0.95,
+	MinSent:          100,
+}
+
+// ReputationIssue describes one metric that crossed its threshold
+type ReputationIssue struct {
+	Metric    string // "bounceRate", "complaintRate", "deliveryRate", or "signal:<provider>"
+	Value     float64
+	Threshold float64
+	Message   string
+}
+
+// Check returns the metrics that crossed t, suitable for driving alerts.
+// Provider signals reported as "poor" are always included.
+func (r *GetDomainReputationResponse) Check(t ReputationThresholds) []ReputationIssue {
+	var issues []ReputationIssue
+	if r.Sent >= t.MinSent {
+		if t.MaxBounceRate > 0 && r.BounceRate > t.MaxBounceRate {
+			issues = append(issues, ReputationIssue{
+				Metric: "bounceRate", Value: r.BounceRate, Threshold: t.MaxBounceRate,
+				Message: fmt.Sprintf("%s bounce rate %.2f%% exceeds %.2f%%", r.Domain, r.BounceRate*100, t.MaxBounceRate*100),
+			})
+		}
+		if t.MaxComplaintRate > 0 && r.ComplaintRate > t.MaxComplaintRate {
+			issues = append(issues, ReputationIssue{
+				Metric: "complaintRate", Value: r.ComplaintRate, Threshold: t.MaxComplaintRate,
+				Message: fmt.Sprintf("%s complaint rate %.3f%% exceeds %.3f%%", r.Domain, r.ComplaintRate*100, t.MaxComplaintRate*100),
+			})
+		}
+		if t.MinDeliveryRate > 0 && r.DeliveryRate < t.MinDeliveryRate {
+			issues = append(issues, ReputationIssue{
+				Metric: "deliveryRate", Value: r.DeliveryRate, Threshold: t.MinDeliveryRate,
+				Message: fmt.Sprintf("%s delivery rate %.2f%% is below %.2f%%", r.Domain, r.DeliveryRate*100, t.MinDeliveryRate*100),
+			})
+		}
+	}
+	for _, sig := range r.Signals {
+		if sig.Status != "poor" {
+			continue
+		}
+		issue := ReputationIssue{
+			Metric:  "signal:" + sig.Provider,
+			Message: fmt.Sprintf("%s reputation at %s is poor", r.Domain, sig.Provider),
+		}
+		if sig.Score != nil {
+			issue.Value = *sig.Score
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// Healthy reports whether no metric crossed t
+func (r *GetDomainReputationResponse) Healthy(t ReputationThresholds) bool {
+	return len(r.Check(t)) == 0
+}
@@ -0,0 +1,84 @@
+package inboundgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestDomainReputation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/domains/dom-1/reputation" {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("window"); got != "30d" {
+			t.Errorf("Expected window 30d, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"domainId": "dom-1",
+			"domain": "example.com",
+			"window": "30d",
+			"sent": 1000,
+			"delivered": 920,
+			"bounced": 70,
+			"complained": 3,
+			"deliveryRate": 0.92,
+			"bounceRate": 0.07,
+			"complaintRate": 0.003,
+			"signals": [
+				{"provider": "gmail", "status": "poor", "score": 0.2},
+				{"provider": "microsoft", "status": "good"}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	resp, err := client.Domain().Reputation(context.Background(), "dom-1", &inboundgo.GetDomainReputationRequest{
+		Window: inboundgo.ReputationWindow30d,
+	})
+	if err != nil || resp.Error != "" {
+		t.Fatalf("Reputation failed: %v %s", err, resp.Error)
+	}
+	rep := resp.Data
+	if rep.Window != inboundgo.ReputationWindow30d || rep.Sent != 1000 || len(rep.Signals) != 2 {
+		t.Fatalf("Unexpected reputation: %+v", rep)
+	}
+
+	issues := rep.Check(inboundgo.DefaultReputationThresholds)
+	var metrics []string
+	for _, issue := range issues {
+		metrics = append(metrics, issue.Metric)
+	}
+	want := []string{"bounceRate", "complaintRate", "deliveryRate", "signal:gmail"}
+	if len(metrics) != len(want) {
+		t.Fatalf("Expected issues %v, got %v", want, metrics)
+	}
+	for i := range want {
+		if metrics[i] != want[i] {
+			t.Errorf("Expected issues %v, got %v", want, metrics)
+		}
+	}
+	if rep.Healthy(inboundgo.DefaultReputationThresholds) {
+		t.Error("Expected domain to be unhealthy")
+	}
+}
+
+func TestDomainReputationCheckMinSent(t *testing.T) {
+	rep := &inboundgo.GetDomainReputationResponse{
+		Domain:     "example.com",
+		Sent:       10,
+		BounceRate: 0.5,
+	}
+	if !rep.Healthy(inboundgo.DefaultReputationThresholds) {
+		t.Errorf("Expected low-volume window to skip rate checks, got %v", rep.Check(inboundgo.DefaultReputationThresholds))
+	}
+}
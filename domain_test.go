@@ -0,0 +1,110 @@
+package inboundgo_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestSetupDomain(t *testing.T) {
+	endpointFails := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/domains":
+			w.Write([]byte(`{"id": "dom_1", "domain": "example.com", "status": "pending",
+				"dnsRecords": [{"type": "MX", "name": "example.com", "value": "10 inbound-smtp.us-east-2.amazonaws.com"}],
+				"createdAt": "2025-01-16T00:00:00Z"}`))
+		case "/endpoints":
+			if endpointFails {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"Invalid webhook URL"}`))
+				return
+			}
+			w.Write([]byte(`{"id": "ep_1", "name": "example.com Webhook", "type": "webhook", "isActive": true, "createdAt": "2025-01-16T00:00:00Z"}`))
+		}
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	ctx := context.Background()
+
+	resp, err := client.SetupDomain(ctx, "example.com", inboundgo.String("https://example.com/hook"))
+	if err != nil || resp.Error != "" {
+		t.Fatalf("SetupDomain failed: %v %s", err, resp.Error)
+	}
+	result := resp.Data
+	if result.Domain.ID != "dom_1" || result.Endpoint == nil || result.Endpoint.ID != "ep_1" || len(result.DNSRecords) != 1 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	endpointFails = true
+	resp, _ = client.SetupDomain(ctx, "example.com", inboundgo.String("not a url"))
+	if resp.Error != "Invalid webhook URL" || resp.Data == nil || resp.Data.Domain.ID != "dom_1" || resp.Data.Endpoint != nil {
+		t.Errorf("Expected the domain with the endpoint error, got %+v %q", resp.Data, resp.Error)
+	}
+}
+
+func TestWaitForDomain(t *testing.T) {
+	checks := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPatch && r.URL.Path == "/domains/dom_1/auth":
+			checks++
+			w.Write([]byte(`{"success": true}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/domains/dom_1":
+			status := "pending"
+			if checks >= 2 {
+				status = "verified"
+			}
+			w.Write([]byte(`{"id": "dom_1", "domain": "example.com", "status": "` + status + `"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	domain, err := client.Domain().WaitForDomain(context.Background(), "dom_1", time.Millisecond)
+	if err != nil {
+		t.Fatalf("WaitForDomain failed: %v", err)
+	}
+	if domain.Status != inboundgo.DomainStatusVerified || checks != 2 {
+		t.Errorf("Unexpected result after %d checks: %+v", checks, domain)
+	}
+}
+
+func TestUpdateDomainSendsOnlySetFields(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, strings.TrimSpace(string(body)))
+		w.Write([]byte(`{"id": "dom_1", "domain": "example.com", "isCatchAllEnabled": true, "updatedAt": "2025-01-16T00:00:00Z"}`))
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	ctx := context.Background()
+
+	client.Domain().Update(ctx, "dom_1", &inboundgo.PutDomainByIDRequest{IsCatchAllEnabled: inboundgo.Bool(true)})
+	client.Domain().Update(ctx, "dom_1", &inboundgo.PutDomainByIDRequest{CatchAllEndpointID: inboundgo.String("ep_1")})
+	client.Domain().Update(ctx, "dom_1", &inboundgo.PutDomainByIDRequest{ClearCatchAllEndpoint: true})
+	want := []string{`{"isCatchAllEnabled":true}`, `{"catchAllEndpointId":"ep_1"}`, `{"catchAllEndpointId":null}`}
+	for i := range want {
+		if i >= len(bodies) || bodies[i] != want[i] {
+			t.Errorf("Expected body %s, got %q", want[i], bodies)
+		}
+	}
+
+	if _, err := client.Domain().Update(ctx, "dom_1", &inboundgo.PutDomainByIDRequest{CatchAllEndpointID: inboundgo.String("ep_1"), ClearCatchAllEndpoint: true}); err == nil {
+		t.Error("Expected setting and clearing the endpoint to be an error")
+	}
+}
@@ -0,0 +1,16 @@
+package inboundgo
+
+import "encoding/json"
+
+// MarshalJSON encodes the settings that are set, with a null endpoint when
+// ClearCatchAllEndpoint is set
+func (r PutDomainByIDRequest) MarshalJSON() ([]byte, error) {
+	type settings PutDomainByIDRequest
+	if !r.ClearCatchAllEndpoint {
+		return json.Marshal(settings(r))
+	}
+	return json.Marshal(struct {
+		settings
+		CatchAllEndpointID *string `json:"catchAllEndpointId"`
+	}{settings: settings(r)})
+}
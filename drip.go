@@ -0,0 +1,85 @@
+package inboundgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// dripMinLead is how far ahead a drip starts when its start is now or has
+// passed, so the first email is not scheduled in the past
+const dripMinLead = time.Minute
+
+// DripOptions configures Inbound.Drip. The embedded BulkOptions control the
+// submission of the scheduled emails, and resuming with RunID and Store.
+type DripOptions struct {
+	BulkOptions
+	// Start is when the first email goes out (default now). Set it
+	// explicitly for a drip you may resume, so the emails keep their times.
+	Start time.Time
+	// Window is the time the emails are spread over, e.g. 10,000 emails
+	// over 4 hours go out one every 1.44 seconds.
+	Window time.Duration
+}
+
+// DripSchedule returns the send times of n emails spread evenly over
+// window from start, the first at start
+func DripSchedule(n int, start time.Time, window time.Duration) []time.Time {
+	times := make([]time.Time, n)
+	for i := range times {
+		times[i] = start.Add(time.Duration(int64(window) * int64(i) / int64(n)))
+	}
+	return times
+}
+
+// Drip schedules reqs spread evenly over a time window, to throttle a large
+// campaign without keeping a process running while it goes out: each email
+// is submitted right away with a staggered ScheduledAt, through BulkSend.
+// Requests that already have a ScheduledAt keep it.
+//
+// Resumed with the same Start, RunID, and Store, a drip skips the emails
+// already submitted. If the next one's time has passed, the rest keep
+// their spacing but start a minute from now, so the window ends late
+// rather than a backlog going out at once.
+func (c *Inbound) Drip(ctx context.Context, reqs []*PostEmailsRequest, opts DripOptions) (*BulkResult, error) {
+	if opts.Window < 0 {
+		return nil, errors.New("drip window must not be negative")
+	}
+	earliest := time.Now().Add(dripMinLead)
+	start := opts.Start
+	if start.IsZero() {
+		start = earliest
+	}
+	times := DripSchedule(len(reqs), start, opts.Window)
+
+	next := 0
+	if opts.Store != nil && opts.RunID != "" {
+		sent, err := opts.Store.Sent(ctx, opts.RunID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load bulk send state: %w", err)
+		}
+		for next < len(reqs) {
+			if _, ok := sent[next]; !ok {
+				break
+			}
+			next++
+		}
+	}
+	var delay time.Duration
+	if next < len(times) && times[next].Before(earliest) {
+		delay = earliest.Sub(times[next])
+	}
+
+	scheduled := make([]*PostEmailsRequest, len(reqs))
+	for i, req := range reqs {
+		if req.ScheduledAt != nil {
+			scheduled[i] = req
+			continue
+		}
+		copied := *req
+		copied.ScheduledAt = String(times[i].Add(delay).UTC().Format(time.RFC3339))
+		scheduled[i] = &copied
+	}
+	return c.BulkSend(ctx, scheduled, opts.BulkOptions)
+}
@@ -0,0 +1,99 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestDripSchedule(t *testing.T) {
+	start := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	times := inboundgo.DripSchedule(10000, start, 4*time.Hour)
+	if !times[0].Equal(start) || times[1].Sub(times[0]) != 1440*time.Millisecond {
+		t.Errorf("Unexpected spacing %v %v", times[0], times[1])
+	}
+	if last := times[9999]; !last.Before(start.Add(4*time.Hour)) || last.Before(start.Add(4*time.Hour-2*time.Second)) {
+		t.Errorf("Expected the last email at the end of the window, got %v", last)
+	}
+}
+
+func TestDrip(t *testing.T) {
+	var mu sync.Mutex
+	scheduled := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/emails/schedule" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var req inboundgo.PostEmailsRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		scheduled[req.To.(string)] = *req.ScheduledAt
+		mu.Unlock()
+		w.Write([]byte(`{"id":"email-1","scheduled_at":"` + *req.ScheduledAt + `","status":"scheduled"}`))
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour).UTC()
+	reqs := bulkRequests(4)
+	result, err := client.Drip(context.Background(), reqs, inboundgo.DripOptions{Start: start, Window: 4 * time.Hour})
+	if err != nil {
+		t.Fatalf("Drip failed: %v", err)
+	}
+	if result.Progress.Sent != 4 {
+		t.Errorf("Unexpected progress %+v", result.Progress)
+	}
+	for i, to := range []string{"user0@example.com", "user1@example.com", "user2@example.com", "user3@example.com"} {
+		if want := start.Add(time.Duration(i) * time.Hour).Format(time.RFC3339); scheduled[to] != want {
+			t.Errorf("Expected %s at %s, got %s", to, want, scheduled[to])
+		}
+	}
+	if reqs[0].ScheduledAt != nil {
+		t.Error("Expected the requests to be left unchanged")
+	}
+}
+
+func TestDripResumeShiftsLateEmails(t *testing.T) {
+	var mu sync.Mutex
+	scheduled := map[string]time.Time{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req inboundgo.PostEmailsRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		at, _ := time.Parse(time.RFC3339, *req.ScheduledAt)
+		mu.Lock()
+		scheduled[req.To.(string)] = at
+		mu.Unlock()
+		w.Write([]byte(`{"id":"email-1","status":"scheduled"}`))
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+
+	// The first two emails went out before a restart two hours into the window
+	store := &inboundgo.MemoryBulkStore{}
+	store.Record(context.Background(), "drip", 0, "email-0")
+	store.Record(context.Background(), "drip", 1, "email-1")
+	start := time.Now().Add(-2 * time.Hour)
+	opts := inboundgo.DripOptions{Start: start, Window: 4 * time.Hour}
+	opts.RunID = "drip"
+	opts.Store = store
+	result, err := client.Drip(context.Background(), bulkRequests(4), opts)
+	if err != nil {
+		t.Fatalf("Drip failed: %v", err)
+	}
+	if result.Progress.Skipped != 2 || result.Progress.Sent != 2 || len(scheduled) != 2 {
+		t.Fatalf("Expected the sent emails to be skipped, got %+v", result.Progress)
+	}
+	first, second := scheduled["user2@example.com"], scheduled["user3@example.com"]
+	if first.Before(time.Now()) || first.After(time.Now().Add(2*time.Minute)) {
+		t.Errorf("Expected the next email shortly from now, got %v", first)
+	}
+	if gap := second.Sub(first); gap != time.Hour {
+		t.Errorf("Expected the spacing to be kept, got %v", gap)
+	}
+}
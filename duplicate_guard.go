@@ -0,0 +1,160 @@
+package inboundgo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrDuplicateMessage is returned by Email().Send when the duplicate guard
+// blocks an email identical to one sent within its window
+var ErrDuplicateMessage = errors.New("identical email was sent recently")
+
+// DuplicateAction is what a DuplicateGuard does with a duplicate
+type DuplicateAction string
+
+const (
+	// DuplicateBlock refuses to send the duplicate
+	DuplicateBlock DuplicateAction = "block"
+	// DuplicateWarn reports the duplicate to OnDuplicate and sends it
+	DuplicateWarn DuplicateAction = "warn"
+)
+
+// DuplicateMessage describes an email the guard caught
+type DuplicateMessage struct {
+	From    string
+	To      []string
+	Subject string
+	// FirstSentAt is when the identical email was first sent.
+	FirstSentAt time.Time
+	// Blocked reports whether the email was refused.
+	Blocked bool
+}
+
+// DuplicateGuard catches the same email going to the same recipients more
+// than once within a window, the signature of a bug such as a retry loop
+// or a webhook handled five times. Emails match when their sender,
+// recipients, subject, body, and scheduled time are identical. A retry
+// with the idempotency key of the first send is not a duplicate: the API
+// deduplicates it. The guard is per client and in memory.
+type DuplicateGuard struct {
+	// Window is how long a sent email is remembered (default 10 minutes).
+	Window time.Duration
+	// Action is DuplicateBlock (the default) or DuplicateWarn.
+	Action DuplicateAction
+	// OnDuplicate, when set, is called for every duplicate caught.
+	OnDuplicate func(DuplicateMessage)
+
+	mu        sync.Mutex
+	sent      map[string]guardEntry
+	nextPrune time.Time
+}
+
+// guardEntry is a remembered email
+type guardEntry struct {
+	at             time.Time
+	idempotencyKey string
+}
+
+// WithDuplicateGuard enables guard on every email sent with Email().Send,
+// including through a SenderPool, which reports blocked emails as
+// SendDuplicate. nil disables it.
+func (c *Inbound) WithDuplicateGuard(guard *DuplicateGuard) *Inbound {
+	c.duplicateGuard = guard
+	return c
+}
+
+// check reserves a prepared email, or returns ErrDuplicateMessage if it
+// is a blocked duplicate. release forgets the reservation, for an email
+// that failed to send.
+func (g *DuplicateGuard) check(params *PostEmailsRequest, options *IdempotencyOptions) (release func(), err error) {
+	key := ""
+	if options != nil {
+		key = options.IdempotencyKey
+	}
+	to := guardRecipients(params.To)
+	fingerprint := messageFingerprint(params, to)
+	now := time.Now()
+
+	g.mu.Lock()
+	window := g.Window
+	if window <= 0 {
+		window = 10 * time.Minute
+	}
+	if g.sent == nil {
+		g.sent = make(map[string]guardEntry)
+	}
+	if now.After(g.nextPrune) {
+		for fp, entry := range g.sent {
+			if now.Sub(entry.at) > window {
+				delete(g.sent, fp)
+			}
+		}
+		g.nextPrune = now.Add(window)
+	}
+	entry, seen := g.sent[fingerprint]
+	duplicate := seen && now.Sub(entry.at) <= window && (key == "" || key != entry.idempotencyKey)
+	blocked := duplicate && g.Action != DuplicateWarn
+	if !duplicate {
+		g.sent[fingerprint] = guardEntry{at: now, idempotencyKey: key}
+	}
+	g.mu.Unlock()
+
+	if duplicate && g.OnDuplicate != nil {
+		g.OnDuplicate(DuplicateMessage{From: params.From, To: to, Subject: params.Subject, FirstSentAt: entry.at, Blocked: blocked})
+	}
+	if blocked {
+		return nil, fmt.Errorf("%w: %q to %s at %s", ErrDuplicateMessage, params.Subject, strings.Join(to, ", "), entry.at.Format(time.RFC3339))
+	}
+	release = func() {}
+	if !duplicate {
+		release = func() {
+			g.mu.Lock()
+			defer g.mu.Unlock()
+			if current, ok := g.sent[fingerprint]; ok && current.at.Equal(now) {
+				delete(g.sent, fingerprint)
+			}
+		}
+	}
+	return release, nil
+}
+
+// guardRecipients returns the recipients of To, lowercased and sorted
+func guardRecipients(to any) []string {
+	list := addressList(to)
+	for i, addr := range list {
+		if local, domain, ok := splitAddress(addr); ok {
+			list[i] = local + "@" + domain
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+// messageFingerprint hashes what makes two emails the same message
+func messageFingerprint(params *PostEmailsRequest, to []string) string {
+	h := sha256.New()
+	field := func(s string) {
+		fmt.Fprintf(h, "%d:%s", len(s), s)
+	}
+	from := params.From
+	if local, domain, ok := splitAddress(from); ok {
+		from = local + "@" + domain
+	}
+	field(from)
+	field(strings.Join(to, ","))
+	field(params.Subject)
+	for _, part := range []*string{params.Text, params.HTML, params.ScheduledAt} {
+		if part == nil {
+			field("")
+		} else {
+			field(*part)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,90 @@
+package inboundgo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestDuplicateGuard(t *testing.T) {
+	var sends int32
+	fail := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&sends, 1)
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error":"unavailable"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"email-1"}`))
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	var caught []inboundgo.DuplicateMessage
+	client.WithDuplicateGuard(&inboundgo.DuplicateGuard{
+		OnDuplicate: func(d inboundgo.DuplicateMessage) { caught = append(caught, d) },
+	})
+	ctx := context.Background()
+	email := func(to string) *inboundgo.PostEmailsRequest {
+		return &inboundgo.PostEmailsRequest{From: "app@example.com", To: to, Subject: "Receipt", Text: inboundgo.String("Thanks")}
+	}
+
+	if _, err := inboundgo.Unwrap(client.Email().Send(ctx, email("jane@example.com"), nil)); err != nil {
+		t.Fatalf("First send failed: %v", err)
+	}
+	_, err := client.Email().Send(ctx, email("Jane <JANE@example.com>"), nil)
+	if !errors.Is(err, inboundgo.ErrDuplicateMessage) {
+		t.Fatalf("Expected the duplicate to be blocked, got %v", err)
+	}
+	if len(caught) != 1 || !caught[0].Blocked || caught[0].To[0] != "jane@example.com" {
+		t.Errorf("Unexpected duplicates reported %+v", caught)
+	}
+	if _, err := inboundgo.Unwrap(client.Email().Send(ctx, email("tom@example.com"), nil)); err != nil {
+		t.Errorf("Expected another recipient to be sent, got %v", err)
+	}
+
+	// A failed send is forgotten, and a retry with the same key is allowed
+	fail = true
+	key := &inboundgo.IdempotencyOptions{IdempotencyKey: "receipt-ann"}
+	client.Email().Send(ctx, email("ann@example.com"), key)
+	fail = false
+	if _, err := inboundgo.Unwrap(client.Email().Send(ctx, email("ann@example.com"), nil)); err != nil {
+		t.Errorf("Expected a send after a failure to go through, got %v", err)
+	}
+	if _, err := inboundgo.Unwrap(client.Email().Send(ctx, email("bob@example.com"), key)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := inboundgo.Unwrap(client.Email().Send(ctx, email("bob@example.com"), key)); err != nil {
+		t.Errorf("Expected a retry with the same idempotency key to be left to the API, got %v", err)
+	}
+	if sends != 6 {
+		t.Errorf("Expected 6 requests, got %d", sends)
+	}
+}
+
+func TestDuplicateGuardWarns(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"email-1"}`))
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	warned := 0
+	client.WithDuplicateGuard(&inboundgo.DuplicateGuard{
+		Action:      inboundgo.DuplicateWarn,
+		OnDuplicate: func(d inboundgo.DuplicateMessage) { warned++ },
+	})
+	req := &inboundgo.PostEmailsRequest{From: "app@example.com", To: []string{"a@example.com", "b@example.com"}, Subject: "Hi"}
+	for i := 0; i < 3; i++ {
+		if _, err := inboundgo.Unwrap(client.Email().Send(context.Background(), req, nil)); err != nil {
+			t.Fatalf("Send %d failed: %v", i, err)
+		}
+	}
+	if warned != 2 {
+		t.Errorf("Expected 2 warnings, got %d", warned)
+	}
+}
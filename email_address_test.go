@@ -0,0 +1,30 @@
+package inboundgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestConfigureReceiptRule(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/email-addresses/addr_1/receipt-rule" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"id": "addr_1", "address": "support@example.com", "isReceiptRuleConfigured": true,
+			"receiptRuleName": "inbound-example-com", "updatedAt": "2025-01-16T00:00:00Z"}`))
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+
+	result, err := inboundgo.Unwrap(client.Email().Address.ConfigureReceiptRule(context.Background(), "addr_1"))
+	if err != nil {
+		t.Fatalf("ConfigureReceiptRule failed: %v", err)
+	}
+	if !result.IsReceiptRuleConfigured || result.ReceiptRuleName == nil || *result.ReceiptRuleName != "inbound-example-com" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+}
@@ -0,0 +1,60 @@
+package inboundgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestListEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/emails/email-1/events" {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		// Deliberately out of order
+		w.Write([]byte(`{"email_id": "email-1", "events": [
+			{"type": "clicked", "timestamp": "2025-01-01T10:05:00Z", "url": "https://example.com/offer", "ip_address": "203.0.113.9"},
+			{"type": "queued", "timestamp": "2025-01-01T10:00:00Z"},
+			{"type": "delivered", "timestamp": "2025-01-01T10:00:02Z", "recipient": "ada@example.com"},
+			{"type": "sent", "timestamp": "2025-01-01T10:00:01Z"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	resp, err := client.Email().ListEvents(context.Background(), "email-1")
+	if err != nil || resp.Error != "" {
+		t.Fatalf("ListEvents failed: %v %s", err, resp.Error)
+	}
+
+	want := []inboundgo.EmailEventType{
+		inboundgo.EmailEventQueued,
+		inboundgo.EmailEventSent,
+		inboundgo.EmailEventDelivered,
+		inboundgo.EmailEventClicked,
+	}
+	if len(resp.Data.Events) != len(want) {
+		t.Fatalf("Expected %d events, got %d", len(want), len(resp.Data.Events))
+	}
+	for i, e := range resp.Data.Events {
+		if e.Type != want[i] {
+			t.Errorf("Event %d: expected %s, got %s", i, want[i], e.Type)
+		}
+	}
+
+	latest := resp.Data.Latest()
+	if latest == nil || latest.Type != inboundgo.EmailEventClicked || *latest.URL != "https://example.com/offer" {
+		t.Errorf("Unexpected latest event: %+v", latest)
+	}
+	if (&inboundgo.GetEmailEventsResponse{}).Latest() != nil {
+		t.Error("Expected nil latest event for an empty timeline")
+	}
+}
@@ -0,0 +1,125 @@
+package inboundgo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// EmailStats counts how a set of sent emails fared. Each email is counted
+// once per kind of event, however many times it was opened or clicked.
+type EmailStats struct {
+	Emails     int `json:"emails"`
+	Delivered  int `json:"delivered"`
+	Opened     int `json:"opened"`
+	Clicked    int `json:"clicked"`
+	Bounced    int `json:"bounced"`
+	Complained int `json:"complained"`
+	Failed     int `json:"failed"`
+}
+
+// DeliveryRate is the fraction of emails delivered
+func (s EmailStats) DeliveryRate() float64 { return rate(s.Delivered, s.Emails) }
+
+// OpenRate is the fraction of delivered emails opened
+func (s EmailStats) OpenRate() float64 { return rate(s.Opened, s.Delivered) }
+
+// ClickRate is the fraction of delivered emails with a link clicked
+func (s EmailStats) ClickRate() float64 { return rate(s.Clicked, s.Delivered) }
+
+// BounceRate is the fraction of emails that bounced
+func (s EmailStats) BounceRate() float64 { return rate(s.Bounced, s.Emails) }
+
+// ComplaintRate is the fraction of delivered emails reported as spam
+func (s EmailStats) ComplaintRate() float64 { return rate(s.Complained, s.Delivered) }
+
+// rate returns n/of, or 0 if of is 0
+func rate(n, of int) float64 {
+	if of == 0 {
+		return 0
+	}
+	return float64(n) / float64(of)
+}
+
+// add counts one email with its event timeline
+func (s *EmailStats) add(events []EmailEvent) {
+	s.Emails++
+	seen := map[EmailEventType]bool{}
+	for _, event := range events {
+		if seen[event.Type] {
+			continue
+		}
+		seen[event.Type] = true
+		switch event.Type {
+		case EmailEventDelivered:
+			s.Delivered++
+		case EmailEventOpened:
+			s.Opened++
+		case EmailEventClicked:
+			s.Clicked++
+		case EmailEventBounced:
+			s.Bounced++
+		case EmailEventComplained:
+			s.Complained++
+		case EmailEventFailed:
+			s.Failed++
+		}
+	}
+}
+
+// statsConcurrency is how many event timelines Stats fetches at once
+const statsConcurrency = 4
+
+// Stats fetches the event timeline of each email in ids and counts the
+// results. Empty IDs, such as of sends reported as duplicates, are skipped.
+func (s *EmailService) Stats(ctx context.Context, ids []string) (*EmailStats, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stats := &EmailStats{}
+	var mu sync.Mutex
+	var firstErr error
+	work := make(chan string)
+	var wg sync.WaitGroup
+	for w := 0; w < statsConcurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range work {
+				resp, err := s.ListEvents(ctx, id)
+				if err == nil && resp.Error != "" {
+					err = fmt.Errorf("failed to get events of email %s: %s", id, resp.Error)
+				}
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+				} else if resp.Data != nil {
+					stats.add(resp.Data.Events)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+feed:
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+		select {
+		case work <- id:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(work)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
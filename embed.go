@@ -0,0 +1,100 @@
+package inboundgo
+
+import (
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// maxContentIDLength is the longest Content ID the API accepts
+const maxContentIDLength = 128
+
+// imgSrcPattern matches the quoted src attribute of <img> tags, capturing
+// the prefix and the double- or single-quoted value
+var imgSrcPattern = regexp.MustCompile(`(?i)(<img\b[^>]*?\bsrc\s*=\s*)(?:"([^"]*)"|'([^']*)')`)
+
+// EmbeddedImage reads the image at path and returns an inline attachment
+// that HTML can reference as "cid:" followed by cid. The content type is
+// sniffed from the data, falling back to the file extension.
+func EmbeddedImage(cid, path string) (AttachmentData, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return AttachmentData{}, fmt.Errorf("failed to read image: %w", err)
+	}
+	return EmbeddedImageData(cid, filepath.Base(path), data)
+}
+
+// EmbeddedImageData returns an inline attachment for image data, like
+// EmbeddedImage
+func EmbeddedImageData(cid, filename string, data []byte) (AttachmentData, error) {
+	cid = strings.Trim(cid, "<>")
+	if cid == "" || len(cid) > maxContentIDLength || strings.ContainsAny(cid, " \t\r\n\"") {
+		return AttachmentData{}, fmt.Errorf("invalid content ID %q", cid)
+	}
+	contentType := sniffImageType(filename, data)
+	if contentType == "" {
+		return AttachmentData{}, fmt.Errorf("%s is not a supported image", filename)
+	}
+	return AttachmentData{
+		Content:     String(base64.StdEncoding.EncodeToString(data)),
+		Filename:    filename,
+		ContentType: String(contentType),
+		ContentID:   String(cid),
+	}, nil
+}
+
+// sniffImageType returns the image MIME type of data, or "" if it is not
+// an image
+func sniffImageType(filename string, data []byte) string {
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+	if strings.HasPrefix(contentType, "image/") {
+		return contentType
+	}
+	// SVG sniffs as text/xml or text/plain
+	byExt, _, _ := mime.ParseMediaType(mime.TypeByExtension(strings.ToLower(filepath.Ext(filename))))
+	if strings.HasPrefix(byExt, "image/") {
+		return byExt
+	}
+	return ""
+}
+
+// EmbedImage attaches the image at path inline under cid. <img> tags in
+// the HTML body whose src is path are rewritten to "cid:"+cid, so a
+// template previewed from disk can be sent unchanged; HTML that already
+// references cid:cid is left as is.
+//
+//	req.EmbedImage("logo", "assets/logo.png")
+//	// <img src="assets/logo.png"> becomes <img src="cid:logo">
+func (r *PostEmailsRequest) EmbedImage(cid, path string) error {
+	attachment, err := EmbeddedImage(cid, path)
+	if err != nil {
+		return err
+	}
+	r.Attachments = append(r.Attachments, attachment)
+	if r.HTML != nil {
+		r.HTML = String(rewriteImageSources(*r.HTML, path, "cid:"+*attachment.ContentID))
+	}
+	return nil
+}
+
+// rewriteImageSources replaces <img> src values referring to path
+func rewriteImageSources(html, path, replacement string) string {
+	clean := filepath.ToSlash(filepath.Clean(path))
+	return imgSrcPattern.ReplaceAllStringFunc(html, func(tag string) string {
+		m := imgSrcPattern.FindStringSubmatch(tag)
+		src, quote := m[2], `"`
+		if strings.HasPrefix(tag[len(m[1]):], "'") {
+			src, quote = m[3], "'"
+		}
+		src = strings.TrimSpace(src)
+		if src == "" || strings.Contains(src, ":") || filepath.ToSlash(filepath.Clean(filepath.FromSlash(src))) != clean {
+			return tag
+		}
+		return m[1] + quote + replacement + quote
+	})
+}
@@ -0,0 +1,71 @@
+package inboundgo_test
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+// onePixelPNG is a valid 1x1 PNG
+var onePixelPNG, _ = base64.StdEncoding.DecodeString("iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNkYPhfDwAChwGA60e6kgAAAABJRU5ErkJggg==")
+
+func TestEmbedImage(t *testing.T) {
+	dir := t.TempDir()
+	assets := filepath.Join(dir, "assets")
+	os.Mkdir(assets, 0o755)
+	logo := filepath.Join(assets, "logo.png")
+	if err := os.WriteFile(logo, onePixelPNG, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// An SVG is only recognized by its extension
+	icon := filepath.Join(assets, "icon.svg")
+	os.WriteFile(icon, []byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`), 0o644)
+
+	req := &inboundgo.PostEmailsRequest{
+		HTML: inboundgo.String(`<img src="` + logo + `" alt="Logo"><IMG class=x SRC='` + logo + `'><img src="https://cdn.test/logo.png"><img src="cid:icon">`),
+	}
+	if err := req.EmbedImage("logo", logo); err != nil {
+		t.Fatalf("EmbedImage failed: %v", err)
+	}
+	if err := req.EmbedImage("icon", icon); err != nil {
+		t.Fatalf("EmbedImage failed: %v", err)
+	}
+
+	want := `<img src="cid:logo" alt="Logo"><IMG class=x SRC='cid:logo'><img src="https://cdn.test/logo.png"><img src="cid:icon">`
+	if *req.HTML != want {
+		t.Errorf("Expected HTML %s, got %s", want, *req.HTML)
+	}
+	if len(req.Attachments) != 2 {
+		t.Fatalf("Expected 2 attachments, got %d", len(req.Attachments))
+	}
+	a := req.Attachments[0]
+	if a.Filename != "logo.png" || *a.ContentType != "image/png" || *a.ContentID != "logo" || *a.Content != base64.StdEncoding.EncodeToString(onePixelPNG) {
+		t.Errorf("Unexpected attachment %+v", a)
+	}
+	if *req.Attachments[1].ContentType != "image/svg+xml" {
+		t.Errorf("Expected SVG content type, got %s", *req.Attachments[1].ContentType)
+	}
+}
+
+func TestEmbedImageRejectsInvalidInput(t *testing.T) {
+	dir := t.TempDir()
+	text := filepath.Join(dir, "notes.txt")
+	os.WriteFile(text, []byte("hello"), 0o644)
+
+	req := &inboundgo.PostEmailsRequest{}
+	if err := req.EmbedImage("notes", text); err == nil {
+		t.Error("Expected error for a non-image file")
+	}
+	if err := req.EmbedImage("logo", filepath.Join(dir, "missing.png")); err == nil {
+		t.Error("Expected error for a missing file")
+	}
+	if _, err := inboundgo.EmbeddedImageData("has space", "logo.png", onePixelPNG); err == nil {
+		t.Error("Expected error for an invalid content ID")
+	}
+	if len(req.Attachments) != 0 {
+		t.Errorf("Expected no attachments, got %d", len(req.Attachments))
+	}
+}
@@ -0,0 +1,625 @@
+package inboundgo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// emlMessage is the intermediate representation used to write RFC 5322
+// messages. Headers are written in order.
+type emlMessage struct {
+	Headers     []emlHeader
+	Text        string
+	HTML        string
+	Attachments []emlAttachment
+}
+
+type emlHeader struct {
+	Key   string
+	Value string
+}
+
+type emlAttachment struct {
+	Filename    string
+	ContentType string
+	ContentID   string
+	Data        []byte
+}
+
+// addHeader adds a header unless value is empty or key is not a valid
+// header name. Line breaks in value are replaced with spaces, so a value
+// cannot start another header or the body.
+func (m *emlMessage) addHeader(key, value string) {
+	value = headerLineBreaks.Replace(value)
+	if strings.TrimSpace(value) != "" && validHeaderName(key) {
+		m.Headers = append(m.Headers, emlHeader{Key: key, Value: value})
+	}
+}
+
+// validHeaderName reports whether key is a valid RFC 5322 field name:
+// printable ASCII other than the colon
+func validHeaderName(key string) bool {
+	if key == "" {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] <= ' ' || key[i] >= 0x7f || key[i] == ':' {
+			return false
+		}
+	}
+	return true
+}
+
+// headerLineBreaks replaces line breaks in header values
+var headerLineBreaks = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ")
+
+// writeEML writes m as a MIME message with CRLF line endings. Text and HTML
+// bodies become multipart/alternative, and attachments wrap everything in
+// multipart/mixed.
+func writeEML(w io.Writer, m *emlMessage) error {
+	bw := bufio.NewWriter(w)
+	for _, h := range m.Headers {
+		fmt.Fprintf(bw, "%s: %s\r\n", h.Key, h.Value)
+	}
+	fmt.Fprintf(bw, "MIME-Version: 1.0\r\n")
+
+	var err error
+	if len(m.Attachments) == 0 {
+		err = writeEMLBody(bw, nil, m)
+	} else {
+		mw := multipart.NewWriter(bw)
+		fmt.Fprintf(bw, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", mw.Boundary())
+		if err = writeEMLBody(bw, mw, m); err == nil {
+			for _, a := range m.Attachments {
+				if err = writeEMLAttachment(mw, a); err != nil {
+					break
+				}
+			}
+		}
+		if err == nil {
+			err = mw.Close()
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// writeEMLBody writes the text/HTML content either directly after the
+// top-level headers (parent == nil) or as a part of parent.
+func writeEMLBody(w io.Writer, parent *multipart.Writer, m *emlMessage) error {
+	header := textproto.MIMEHeader{}
+	var body func(io.Writer) error
+
+	switch {
+	case m.Text != "" && m.HTML != "":
+		boundary := multipart.NewWriter(io.Discard).Boundary()
+		header.Set("Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", boundary))
+		body = func(w io.Writer) error {
+			alt := multipart.NewWriter(w)
+			if err := alt.SetBoundary(boundary); err != nil {
+				return err
+			}
+			if err := writeEMLTextPart(alt, "text/plain", m.Text); err != nil {
+				return err
+			}
+			if err := writeEMLTextPart(alt, "text/html", m.HTML); err != nil {
+				return err
+			}
+			return alt.Close()
+		}
+	case m.HTML != "":
+		header.Set("Content-Type", `text/html; charset="utf-8"`)
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+		body = func(w io.Writer) error { return writeQuotedPrintable(w, m.HTML) }
+	default:
+		header.Set("Content-Type", `text/plain; charset="utf-8"`)
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+		body = func(w io.Writer) error { return writeQuotedPrintable(w, m.Text) }
+	}
+
+	if parent != nil {
+		part, err := parent.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		return body(part)
+	}
+
+	for _, k := range []string{"Content-Type", "Content-Transfer-Encoding"} {
+		if v := header.Get(k); v != "" {
+			fmt.Fprintf(w, "%s: %s\r\n", k, v)
+		}
+	}
+	fmt.Fprintf(w, "\r\n")
+	return body(w)
+}
+
+func writeEMLTextPart(mw *multipart.Writer, contentType, content string) error {
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", contentType+`; charset="utf-8"`)
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+	part, err := mw.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	return writeQuotedPrintable(part, content)
+}
+
+func writeQuotedPrintable(w io.Writer, content string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := io.WriteString(qp, content); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+func writeEMLAttachment(mw *multipart.Writer, a emlAttachment) error {
+	contentType := a.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	disposition := "attachment"
+	header := textproto.MIMEHeader{}
+	if a.ContentID != "" {
+		disposition = "inline"
+		header.Set("Content-ID", "<"+strings.Trim(a.ContentID, "<>")+">")
+	}
+	header.Set("Content-Type", mime.FormatMediaType(contentType, map[string]string{"name": a.Filename}))
+	header.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": a.Filename}))
+	header.Set("Content-Transfer-Encoding", "base64")
+
+	part, err := mw.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	// Wrap base64 at 76 characters per RFC 2045
+	encoded := base64.StdEncoding.EncodeToString(a.Data)
+	for len(encoded) > 76 {
+		if _, err := io.WriteString(part, encoded[:76]+"\r\n"); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err = io.WriteString(part, encoded+"\r\n")
+	return err
+}
+
+// encodeAddressHeader formats an address list header value, RFC 2047
+// encoding display names where needed. Unparseable input is passed through.
+func encodeAddressHeader(value string) string {
+	list, err := mail.ParseAddressList(value)
+	if err != nil {
+		return value
+	}
+	parts := make([]string, len(list))
+	for i, a := range list {
+		parts[i] = a.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// mailAttachment is the metadata of an attachment on GetMailByIDResponse
+type mailAttachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	ContentID   string `json:"contentId"`
+}
+
+// decodeMailAttachments converts the loosely typed attachment list returned
+// by the mail API into metadata structs.
+func decodeMailAttachments(raw []any) []mailAttachment {
+	if len(raw) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
+	var out []mailAttachment
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil
+	}
+	return out
+}
+
+// ExportEML writes the inbound email with the given ID to w as an RFC 5322
+// message (.eml), downloading its attachments so the file is self-contained
+// and can be archived or opened in desktop mail clients.
+func (s *MailService) ExportEML(ctx context.Context, id string, w io.Writer) error {
+	resp, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("failed to get email %s: %s", id, resp.Error)
+	}
+	if resp.Data == nil {
+		return fmt.Errorf("failed to get email %s: empty response", id)
+	}
+
+	msg, err := s.emlFromMail(ctx, resp.Data)
+	if err != nil {
+		return err
+	}
+	return writeEML(w, msg)
+}
+
+// emlFromMailBody builds the headers and bodies of an inbound email,
+// without attachments
+func emlFromMailBody(email *GetMailByIDResponse) *emlMessage {
+	msg := &emlMessage{Text: email.TextBody, HTML: email.HTMLBody}
+	msg.addHeader("From", encodeAddressHeader(email.From))
+	msg.addHeader("To", encodeAddressHeader(email.To))
+	msg.addHeader("Subject", mime.QEncoding.Encode("utf-8", email.Subject))
+	if !email.ReceivedAt.IsZero() {
+		msg.addHeader("Date", email.ReceivedAt.Format(time.RFC1123Z))
+	}
+	msg.addHeader("X-Inbound-Email-Id", email.ID)
+	return msg
+}
+
+// emlFromMail builds the MIME representation of an inbound email, including
+// downloaded attachments
+func (s *MailService) emlFromMail(ctx context.Context, email *GetMailByIDResponse) (*emlMessage, error) {
+	msg := emlFromMailBody(email)
+	for _, a := range decodeMailAttachments(email.Attachments) {
+		if a.Filename == "" {
+			continue
+		}
+		download, err := s.client.Attachment().Download(ctx, email.ID, a.Filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download attachment %q: %w", a.Filename, err)
+		}
+		contentType := a.ContentType
+		if contentType == "" {
+			contentType = download.Headers.Get("Content-Type")
+		}
+		msg.Attachments = append(msg.Attachments, emlAttachment{
+			Filename:    a.Filename,
+			ContentType: contentType,
+			ContentID:   a.ContentID,
+			Data:        download.Data,
+		})
+	}
+	return msg, nil
+}
+
+// ExportEMLBatch exports every email matching filter, paging through the mail
+// list. newWriter is called once per email to obtain its destination (for
+// example a file named after the email ID); the writer is closed after the
+// message is written. It returns the number of emails exported.
+func (s *MailService) ExportEMLBatch(ctx context.Context, filter *GetMailRequest, newWriter func(EmailItem) (io.WriteCloser, error)) (int, error) {
+	exported := 0
+	err := s.each(ctx, filter, func(item EmailItem) error {
+		w, err := newWriter(item)
+		if err != nil {
+			return err
+		}
+		if err := s.ExportEML(ctx, item.ID, w); err != nil {
+			w.Close()
+			return err
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+		exported++
+		return nil
+	})
+	return exported, err
+}
+
+// ExportMbox appends the inbound email with the given ID to w as an mbox
+// entry: a "From " separator line, then the message with its attachments,
+// LF line endings, and lines starting with ">*From " escaped (mboxrd), so
+// mail clients and NewMboxSource read it back unchanged.
+func (s *MailService) ExportMbox(ctx context.Context, id string, w io.Writer) error {
+	resp, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("failed to get email %s: %s", id, resp.Error)
+	}
+	if resp.Data == nil {
+		return fmt.Errorf("failed to get email %s: empty response", id)
+	}
+	msg, err := s.emlFromMail(ctx, resp.Data)
+	if err != nil {
+		return err
+	}
+	var eml bytes.Buffer
+	if err := writeEML(&eml, msg); err != nil {
+		return err
+	}
+
+	sender := "MAILER-DAEMON"
+	if addr, err := mail.ParseAddress(resp.Data.From); err == nil {
+		sender = addr.Address
+	}
+	received := resp.Data.ReceivedAt
+	if received.IsZero() {
+		received = time.Now()
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "From %s %s\n", sender, received.UTC().Format(time.ANSIC))
+	for _, line := range strings.SplitAfter(strings.ReplaceAll(eml.String(), "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(strings.TrimLeft(line, ">"), "From ") {
+			bw.WriteByte('>')
+		}
+		bw.WriteString(line)
+	}
+	bw.WriteString("\n")
+	return bw.Flush()
+}
+
+// Each calls fn for every email matching filter, paging through the mail
+// list, and stops at the first error fn returns. The filter's Limit sets
+// the page size and its Offset the starting point.
+func (s *MailService) Each(ctx context.Context, filter *GetMailRequest, fn func(EmailItem) error) error {
+	return s.each(ctx, filter, fn)
+}
+
+// each calls fn for every email matching filter, fetching pages as needed.
+// The filter's Limit sets the page size and its Offset the starting point.
+func (s *MailService) each(ctx context.Context, filter *GetMailRequest, fn func(EmailItem) error) error {
+	params := GetMailRequest{}
+	if filter != nil {
+		params = *filter
+	}
+	if params.Limit == nil {
+		params.Limit = Int(50)
+	}
+	offset := 0
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+
+	for {
+		params.Offset = Int(offset)
+		resp, err := s.List(ctx, &params)
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return fmt.Errorf("failed to list emails: %s", resp.Error)
+		}
+		if resp.Data == nil {
+			return nil
+		}
+		for _, item := range resp.Data.Emails {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+
+		offset += len(resp.Data.Emails)
+		if len(resp.Data.Emails) == 0 || (!resp.Data.Pagination.HasMore && offset >= resp.Data.Pagination.Total) {
+			return nil
+		}
+	}
+}
+
+// emlSkippedHeaders are structural or transport headers that ParseEML does
+// not copy into PostEmailsRequest.Headers; they are either represented by
+// dedicated fields or regenerated when the message is sent.
+var emlSkippedHeaders = map[string]bool{
+	"From": true, "To": true, "Cc": true, "Bcc": true, "Reply-To": true,
+	"Subject": true, "Date": true, "Message-Id": true, "Mime-Version": true,
+	"Content-Type": true, "Content-Transfer-Encoding": true,
+	"Received": true, "Return-Path": true, "Delivered-To": true,
+	"Dkim-Signature": true, "Authentication-Results": true,
+	"Arc-Seal": true, "Arc-Message-Signature": true, "Arc-Authentication-Results": true,
+	"Received-Spf": true, "X-Inbound-Email-Id": true,
+}
+
+// ParseEML converts an RFC 5322 message into a PostEmailsRequest: addresses
+// and subject map to their fields, text/HTML bodies are decoded, attachments
+// (including inline images) become base64 AttachmentData, and remaining
+// custom headers are preserved. It eases migrating SMTP-based code paths
+// that already produce .eml files.
+func ParseEML(r io.Reader) (*PostEmailsRequest, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse message: %w", err)
+	}
+
+	decoder := new(mime.WordDecoder)
+	req := &PostEmailsRequest{}
+
+	from, err := parseEMLAddresses(msg.Header, "From")
+	if err != nil {
+		return nil, err
+	}
+	if len(from) > 0 {
+		req.From = from[0]
+	}
+	for _, field := range []struct {
+		key  string
+		dest *any
+	}{
+		{"To", &req.To}, {"Cc", &req.CC}, {"Bcc", &req.BCC}, {"Reply-To", &req.ReplyTo},
+	} {
+		list, err := parseEMLAddresses(msg.Header, field.key)
+		if err != nil {
+			return nil, err
+		}
+		switch len(list) {
+		case 0:
+		case 1:
+			*field.dest = list[0]
+		default:
+			*field.dest = list
+		}
+	}
+
+	subject := msg.Header.Get("Subject")
+	if decoded, err := decoder.DecodeHeader(subject); err == nil {
+		subject = decoded
+	}
+	req.Subject = subject
+
+	for key, values := range msg.Header {
+		key = textproto.CanonicalMIMEHeaderKey(key)
+		if emlSkippedHeaders[key] || len(values) == 0 {
+			continue
+		}
+		if req.Headers == nil {
+			req.Headers = make(map[string]string)
+		}
+		req.Headers[key] = strings.Join(values, ", ")
+	}
+
+	header := textproto.MIMEHeader(msg.Header)
+	if err := parseEMLPart(req, header, msg.Body); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// parseEMLAddresses formats an address list header as "Name <address>"
+// strings suitable for the send API.
+func parseEMLAddresses(header mail.Header, key string) ([]string, error) {
+	if header.Get(key) == "" {
+		return nil, nil
+	}
+	list, err := header.AddressList(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s header: %w", key, err)
+	}
+	out := make([]string, len(list))
+	for i, a := range list {
+		if a.Name != "" {
+			out[i] = fmt.Sprintf("%s <%s>", a.Name, a.Address)
+		} else {
+			out[i] = a.Address
+		}
+	}
+	return out, nil
+}
+
+// parseEMLPart walks a MIME entity, filling bodies and attachments on req
+func parseEMLPart(req *PostEmailsRequest, header textproto.MIMEHeader, body io.Reader) error {
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "text/plain"
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, params = "application/octet-stream", nil
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read MIME part: %w", err)
+			}
+			if err := parseEMLPart(req, part.Header, part); err != nil {
+				return err
+			}
+		}
+	}
+
+	data, err := io.ReadAll(decodeTransferEncoding(header.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return fmt.Errorf("failed to decode MIME part: %w", err)
+	}
+
+	disposition, dispParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	isAttachment := disposition == "attachment" || header.Get("Content-ID") != ""
+	if !isAttachment {
+		switch {
+		case mediaType == "text/plain" && req.Text == nil:
+			req.Text = String(decodeCharset(params["charset"], data))
+			return nil
+		case mediaType == "text/html" && req.HTML == nil:
+			req.HTML = String(decodeCharset(params["charset"], data))
+			return nil
+		}
+	}
+
+	filename := dispParams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	if decoded, err := new(mime.WordDecoder).DecodeHeader(filename); err == nil {
+		filename = decoded
+	}
+	if filename == "" {
+		filename = "attachment"
+		if exts, _ := mime.ExtensionsByType(mediaType); len(exts) > 0 {
+			filename += exts[0]
+		}
+	}
+
+	attachment := AttachmentData{
+		Filename:    filename,
+		Content:     String(base64.StdEncoding.EncodeToString(data)),
+		ContentType: String(mediaType),
+	}
+	if cid := strings.Trim(header.Get("Content-ID"), "<> "); cid != "" {
+		attachment.ContentID = String(cid)
+	}
+	req.Attachments = append(req.Attachments, attachment)
+	return nil
+}
+
+// decodeTransferEncoding wraps r to undo a Content-Transfer-Encoding
+func decodeTransferEncoding(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, &base64LineReader{r: r})
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	}
+	return r
+}
+
+// base64LineReader strips whitespace so line-wrapped base64 decodes cleanly
+type base64LineReader struct {
+	r io.Reader
+}
+
+func (b *base64LineReader) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	out := p[:0]
+	for _, c := range p[:n] {
+		if c != '\r' && c != '\n' && c != ' ' && c != '\t' {
+			out = append(out, c)
+		}
+	}
+	return len(out), err
+}
+
+// decodeCharset converts body text to UTF-8. Only UTF-8, US-ASCII, and
+// ISO-8859-1 are understood; other charsets are returned unchanged.
+func decodeCharset(charset string, data []byte) string {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1":
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		return string(runes)
+	}
+	return string(data)
+}
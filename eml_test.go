@@ -0,0 +1,334 @@
+package inboundgo_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strings"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func newMailExportServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/mail":
+			offset := r.URL.Query().Get("offset")
+			w.Header().Set("Content-Type", "application/json")
+			if offset == "0" {
+				w.Write([]byte(`{"emails": [{"id": "mail-1"}, {"id": "mail-2"}], "pagination": {"limit": 2, "offset": 0, "total": 3, "hasMore": true}}`))
+			} else {
+				w.Write([]byte(`{"emails": [{"id": "mail-3"}], "pagination": {"limit": 2, "offset": 2, "total": 3}}`))
+			}
+		case strings.HasPrefix(r.URL.Path, "/mail/"):
+			id := strings.TrimPrefix(r.URL.Path, "/mail/")
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{
+				"id": %q,
+				"subject": "Quarterly report – ready",
+				"from": "José Sender <sender@example.com>",
+				"to": "team@yourdomain.com",
+				"textBody": "See attached.",
+				"htmlBody": "<p>See attached.</p>",
+				"receivedAt": "2025-01-02T15:04:05Z",
+				"attachments": [{"filename": "report.pdf", "contentType": "application/pdf"}]
+			}`, id)
+		case strings.HasPrefix(r.URL.Path, "/attachments/"):
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write([]byte("%PDF-1.4 fake"))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestExportEML(t *testing.T) {
+	server := newMailExportServer(t)
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := client.Mail().ExportEML(context.Background(), "mail-1", &buf); err != nil {
+		t.Fatalf("ExportEML failed: %v", err)
+	}
+
+	msg, err := mail.ReadMessage(&buf)
+	if err != nil {
+		t.Fatalf("Exported message is not valid RFC 5322: %v", err)
+	}
+
+	subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if subject != "Quarterly report – ready" {
+		t.Errorf("Expected decoded subject, got '%s'", subject)
+	}
+	from, err := msg.Header.AddressList("From")
+	if err != nil || from[0].Name != "José Sender" || from[0].Address != "sender@example.com" {
+		t.Errorf("Unexpected From header: %v (%v)", from, err)
+	}
+	if msg.Header.Get("Date") != "Thu, 02 Jan 2025 15:04:05 +0000" {
+		t.Errorf("Unexpected Date header: %s", msg.Header.Get("Date"))
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if mediaType != "multipart/mixed" {
+		t.Fatalf("Expected multipart/mixed, got %s", mediaType)
+	}
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+
+	body, err := reader.NextPart()
+	if err != nil {
+		t.Fatalf("Missing body part: %v", err)
+	}
+	if ct, _, _ := mime.ParseMediaType(body.Header.Get("Content-Type")); ct != "multipart/alternative" {
+		t.Errorf("Expected multipart/alternative body, got %s", ct)
+	}
+
+	attachment, err := reader.NextPart()
+	if err != nil {
+		t.Fatalf("Missing attachment part: %v", err)
+	}
+	if attachment.FileName() != "report.pdf" {
+		t.Errorf("Expected attachment filename 'report.pdf', got '%s'", attachment.FileName())
+	}
+	data, _ := io.ReadAll(base64.NewDecoder(base64.StdEncoding, attachment))
+	if string(data) != "%PDF-1.4 fake" {
+		t.Errorf("Unexpected attachment content: %q", data)
+	}
+}
+
+func TestExportEMLStripsLineBreaksFromHeaders(t *testing.T) {
+	email := &inboundgo.GetMailByIDResponse{
+		From:     "attacker\r\nBcc: victim@example.com\r\n\r\nInjected body",
+		To:       "team@yourdomain.com\nX-Injected: yes",
+		Subject:  "Hello",
+		TextBody: "Real body",
+	}
+	msg, err := email.ToMailMessage()
+	if err != nil {
+		t.Fatalf("ToMailMessage failed: %v", err)
+	}
+	if msg.Header.Get("Bcc") != "" || msg.Header.Get("X-Injected") != "" {
+		t.Errorf("Expected no injected headers, got %v", msg.Header)
+	}
+	if !strings.Contains(msg.Header.Get("From"), "Bcc: victim@example.com") {
+		t.Errorf("Expected the From value on one line, got %q", msg.Header.Get("From"))
+	}
+	body, _ := io.ReadAll(msg.Body)
+	if strings.Contains(string(body), "Injected body") {
+		t.Errorf("Expected no injected body, got %q", body)
+	}
+}
+
+type closingBuffer struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *closingBuffer) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestExportEMLBatch(t *testing.T) {
+	server := newMailExportServer(t)
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	files := map[string]*closingBuffer{}
+	n, err := client.Mail().ExportEMLBatch(context.Background(), &inboundgo.GetMailRequest{Limit: inboundgo.Int(2)}, func(item inboundgo.EmailItem) (io.WriteCloser, error) {
+		b := &closingBuffer{}
+		files[item.ID] = b
+		return b, nil
+	})
+	if err != nil {
+		t.Fatalf("ExportEMLBatch failed: %v", err)
+	}
+	if n != 3 || len(files) != 3 {
+		t.Fatalf("Expected 3 exported emails across pages, got %d", n)
+	}
+	for id, f := range files {
+		if !f.closed {
+			t.Errorf("Expected writer for %s to be closed", id)
+		}
+		if !strings.Contains(f.String(), "X-Inbound-Email-Id: "+id) {
+			t.Errorf("Expected export of %s to carry its ID header", id)
+		}
+	}
+}
+
+func TestExportMbox(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		id := strings.TrimPrefix(r.URL.Path, "/mail/")
+		fmt.Fprintf(w, `{"id": %q, "subject": "Hello", "from": "Jane <jane@example.com>", "to": "team@yourdomain.com",
+			"textBody": "Hi,\n\nFrom the team\n", "receivedAt": "2025-01-02T15:04:05Z"}`, id)
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	var buf bytes.Buffer
+	for _, id := range []string{"mail-1", "mail-2"} {
+		if err := client.Mail().ExportMbox(context.Background(), id, &buf); err != nil {
+			t.Fatalf("ExportMbox failed: %v", err)
+		}
+	}
+	if !strings.HasPrefix(buf.String(), "From jane@example.com Thu Jan  2 15:04:05 2025\n") {
+		t.Errorf("Unexpected separator line: %q", strings.SplitN(buf.String(), "\n", 2)[0])
+	}
+	if !strings.Contains(buf.String(), "\n>From the team") || strings.Contains(buf.String(), "\r\n") {
+		t.Errorf("Expected escaped From lines and LF endings:\n%s", buf.String())
+	}
+
+	source := inboundgo.NewMboxSource(&buf)
+	for _, id := range []string{"mail-1", "mail-2"} {
+		msg, err := source.Next()
+		if err != nil {
+			t.Fatalf("Failed to read back %s: %v", id, err)
+		}
+		parsed, err := mail.ReadMessage(bytes.NewReader(msg.Raw))
+		if err != nil || parsed.Header.Get("X-Inbound-Email-Id") != id {
+			t.Fatalf("Unexpected message for %s: %v", id, err)
+		}
+		body, _ := io.ReadAll(parsed.Body)
+		if !strings.Contains(string(body), "\nFrom the team") || strings.Contains(string(body), ">From") {
+			t.Errorf("Body of %s was not unescaped: %q", id, body)
+		}
+	}
+	if _, err := source.Next(); err != io.EOF {
+		t.Errorf("Expected two messages, got %v", err)
+	}
+}
+
+func TestParseEML(t *testing.T) {
+	raw := strings.Join([]string{
+		"From: =?utf-8?q?Jos=C3=A9_Sender?= <sender@example.com>",
+		"To: one@example.com, Two <two@example.com>",
+		"Cc: cc@example.com",
+		"Subject: =?utf-8?q?Caf=C3=A9_menu?=",
+		"Date: Thu, 02 Jan 2025 15:04:05 +0000",
+		"Message-ID: <abc@example.com>",
+		"X-Campaign: spring",
+		"MIME-Version: 1.0",
+		`Content-Type: multipart/mixed; boundary="outer"`,
+		"",
+		"--outer",
+		`Content-Type: multipart/alternative; boundary="inner"`,
+		"",
+		"--inner",
+		`Content-Type: text/plain; charset="iso-8859-1"`,
+		"Content-Transfer-Encoding: quoted-printable",
+		"",
+		"Caf=E9 is open.",
+		"--inner",
+		`Content-Type: text/html; charset="utf-8"`,
+		"",
+		"<p>Café is open.</p>",
+		"--inner--",
+		"--outer",
+		`Content-Type: image/png; name="logo.png"`,
+		"Content-Transfer-Encoding: base64",
+		"Content-ID: <logo>",
+		"Content-Disposition: inline; filename=\"logo.png\"",
+		"",
+		"ZmFrZS1wbmct",
+		"ZGF0YQ==",
+		"--outer",
+		`Content-Type: application/pdf`,
+		"Content-Transfer-Encoding: base64",
+		`Content-Disposition: attachment; filename="menu.pdf"`,
+		"",
+		base64.StdEncoding.EncodeToString([]byte("%PDF")),
+		"--outer--",
+		"",
+	}, "\r\n")
+
+	req, err := inboundgo.ParseEML(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ParseEML failed: %v", err)
+	}
+
+	if req.From != "José Sender <sender@example.com>" {
+		t.Errorf("Unexpected From: %s", req.From)
+	}
+	to, ok := req.To.([]string)
+	if !ok || len(to) != 2 || to[1] != "Two <two@example.com>" {
+		t.Errorf("Unexpected To: %#v", req.To)
+	}
+	if req.CC != "cc@example.com" {
+		t.Errorf("Expected single CC as string, got %#v", req.CC)
+	}
+	if req.Subject != "Café menu" {
+		t.Errorf("Unexpected Subject: %s", req.Subject)
+	}
+	if req.Text == nil || *req.Text != "Café is open." {
+		t.Errorf("Unexpected Text: %v", req.Text)
+	}
+	if req.HTML == nil || *req.HTML != "<p>Café is open.</p>" {
+		t.Errorf("Unexpected HTML: %v", req.HTML)
+	}
+	if req.Headers["X-Campaign"] != "spring" {
+		t.Errorf("Expected custom header to be preserved, got %v", req.Headers)
+	}
+	if _, ok := req.Headers["Message-Id"]; ok {
+		t.Error("Expected Message-ID to be dropped")
+	}
+
+	if len(req.Attachments) != 2 {
+		t.Fatalf("Expected 2 attachments, got %d", len(req.Attachments))
+	}
+	logo := req.Attachments[0]
+	if logo.Filename != "logo.png" || logo.ContentID == nil || *logo.ContentID != "logo" {
+		t.Errorf("Unexpected inline attachment: %+v", logo)
+	}
+	if data, _ := base64.StdEncoding.DecodeString(*logo.Content); string(data) != "fake-png-data" {
+		t.Errorf("Unexpected inline attachment content: %q", data)
+	}
+	if req.Attachments[1].Filename != "menu.pdf" || *req.Attachments[1].ContentType != "application/pdf" {
+		t.Errorf("Unexpected attachment: %+v", req.Attachments[1])
+	}
+}
+
+func TestParseEMLRoundTrip(t *testing.T) {
+	server := newMailExportServer(t)
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	var buf bytes.Buffer
+	if err := client.Mail().ExportEML(context.Background(), "mail-1", &buf); err != nil {
+		t.Fatalf("ExportEML failed: %v", err)
+	}
+
+	req, err := inboundgo.ParseEML(&buf)
+	if err != nil {
+		t.Fatalf("ParseEML failed: %v", err)
+	}
+	if req.Subject != "Quarterly report – ready" || req.To != "team@yourdomain.com" {
+		t.Errorf("Unexpected round-tripped request: %+v", req)
+	}
+	if req.Text == nil || *req.Text != "See attached." {
+		t.Errorf("Unexpected text body: %v", req.Text)
+	}
+	if len(req.Attachments) != 1 || req.Attachments[0].Filename != "report.pdf" {
+		t.Errorf("Unexpected attachments: %+v", req.Attachments)
+	}
+}
@@ -0,0 +1,87 @@
+package inboundgo
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// DeliveryResponse is how a webhook receiver answered a delivery attempt,
+// read from EndpointDelivery.ResponseData
+type DeliveryResponse struct {
+	// StatusCode is the HTTP status the receiver returned, or 0 if the
+	// request failed before a response, e.g. on a timeout
+	StatusCode int
+	// Latency is how long the receiver took to answer, or 0 if unknown
+	Latency time.Duration
+	// Body is the start of the response body
+	Body string
+	// Error describes a failed attempt, such as a connection error
+	Error string
+}
+
+// Latency returns how long the test delivery took
+func (r *EndpointTestResult) Latency() time.Duration {
+	return time.Duration(r.ResponseTime) * time.Millisecond
+}
+
+// Response returns the receiver's answer to the last attempt. Fields the
+// API did not record are left zero.
+func (d *EndpointDelivery) Response() DeliveryResponse {
+	var resp DeliveryResponse
+	data, ok := d.ResponseData.(map[string]any)
+	if !ok {
+		if s, ok := d.ResponseData.(string); ok {
+			resp.Error = s
+		}
+		return resp
+	}
+	if code, ok := deliveryNumber(data, "statusCode", "status", "responseStatus"); ok {
+		resp.StatusCode = int(code)
+	}
+	if ms, ok := deliveryNumber(data, "responseTime", "duration", "latency", "durationMs"); ok {
+		resp.Latency = time.Duration(ms * float64(time.Millisecond))
+	}
+	resp.Body = deliveryString(data, "body", "responseBody")
+	resp.Error = deliveryString(data, "error", "errorMessage")
+	return resp
+}
+
+// deliveryNumber returns the first of keys holding a number, or a string
+// of one
+func deliveryNumber(data map[string]any, keys ...string) (float64, bool) {
+	for _, key := range keys {
+		switch v := data[key].(type) {
+		case float64:
+			return v, true
+		case json.Number:
+			if f, err := v.Float64(); err == nil {
+				return f, true
+			}
+		case string:
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return f, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// deliveryString returns the first of keys holding a string, or the JSON
+// of a non-string value such as an object body
+func deliveryString(data map[string]any, keys ...string) string {
+	for _, key := range keys {
+		switch v := data[key].(type) {
+		case nil:
+		case string:
+			if v != "" {
+				return v
+			}
+		default:
+			if b, err := json.Marshal(v); err == nil {
+				return string(b)
+			}
+		}
+	}
+	return ""
+}
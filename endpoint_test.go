@@ -0,0 +1,114 @@
+package inboundgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestEndpointGetDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"id": "ep_1", "name": "Main", "type": "webhook", "isActive": true,
+			"deliveryStats": {"total": 3, "successful": 2, "failed": 1, "lastDelivery": null},
+			"recentDeliveries": [{"id": "del_1", "emailId": "email_1", "deliveryType": "webhook", "status": "failed",
+				"attempts": 3, "lastAttemptAt": "2025-01-16T10:00:00Z", "responseData": {"statusCode": 500},
+				"createdAt": "2025-01-16T09:59:00Z"}],
+			"associatedEmails": [{"id": "addr_1", "address": "support@example.com", "isActive": true, "createdAt": "2025-01-01T00:00:00Z"}],
+			"catchAllDomains": [{"id": "dom_1", "domain": "example.com", "status": "verified"}],
+			"createdAt": "2025-01-01T00:00:00Z", "updatedAt": "2025-01-01T00:00:00Z"
+		}`))
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+
+	resp, err := client.Endpoint().Get(context.Background(), "ep_1")
+	if err != nil || resp.Error != "" {
+		t.Fatalf("Get failed: %v %s", err, resp.Error)
+	}
+	ep := resp.Data
+	if len(ep.RecentDeliveries) != 1 || ep.RecentDeliveries[0].Attempts != 3 || ep.RecentDeliveries[0].LastAttemptAt == nil {
+		t.Errorf("Unexpected deliveries %+v", ep.RecentDeliveries)
+	}
+	if len(ep.AssociatedEmails) != 1 || ep.AssociatedEmails[0].Address != "support@example.com" {
+		t.Errorf("Unexpected addresses %+v", ep.AssociatedEmails)
+	}
+	if len(ep.CatchAllDomains) != 1 || ep.CatchAllDomains[0].Status != inboundgo.DomainStatusVerified {
+		t.Errorf("Unexpected catch-all domains %+v", ep.CatchAllDomains)
+	}
+}
+
+func TestEndpointDeleteCleanup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("Expected DELETE, got %s", r.Method)
+		}
+		w.Write([]byte(`{"message": "Endpoint deleted", "cleanup": {
+			"emailAddressesUpdated": 1, "domainsUpdated": 1, "groupEmailsDeleted": 0, "deliveriesDeleted": 4,
+			"emailAddresses": [{"id": "addr_1", "address": "support@example.com"}],
+			"domains": [{"id": "dom_1", "domain": "example.com"}]
+		}}`))
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+
+	resp, err := client.Endpoint().Delete(context.Background(), "ep_1")
+	if err != nil || resp.Error != "" {
+		t.Fatalf("Delete failed: %v %s", err, resp.Error)
+	}
+	cleanup := resp.Data.Cleanup
+	if len(cleanup.EmailAddresses) != 1 || cleanup.EmailAddresses[0].Address != "support@example.com" {
+		t.Errorf("Unexpected addresses %+v", cleanup.EmailAddresses)
+	}
+	if len(cleanup.Domains) != 1 || cleanup.Domains[0].Domain != "example.com" {
+		t.Errorf("Unexpected domains %+v", cleanup.Domains)
+	}
+}
+
+func TestEndpointTest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/endpoints/ep_1/test" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"success": false, "message": "Webhook test failed", "responseTime": 1250,
+			"statusCode": 502, "responseBody": "Bad Gateway"}`))
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+
+	result, err := inboundgo.Unwrap(client.Endpoint().Test(context.Background(), "ep_1"))
+	if err != nil {
+		t.Fatalf("Test failed: %v", err)
+	}
+	if result.Success || result.StatusCode == nil || *result.StatusCode != 502 || result.Latency() != 1250*time.Millisecond {
+		t.Errorf("Unexpected result %+v", result)
+	}
+}
+
+func TestEndpointDeliveryResponse(t *testing.T) {
+	tests := []struct {
+		data any
+		want inboundgo.DeliveryResponse
+	}{
+		{map[string]any{"statusCode": float64(500), "responseTime": float64(87), "body": "oops"},
+			inboundgo.DeliveryResponse{StatusCode: 500, Latency: 87 * time.Millisecond, Body: "oops"}},
+		{map[string]any{"status": "204", "duration": float64(12.5)},
+			inboundgo.DeliveryResponse{StatusCode: 204, Latency: 12500 * time.Microsecond}},
+		{map[string]any{"error": "connect: connection refused"},
+			inboundgo.DeliveryResponse{Error: "connect: connection refused"}},
+		{map[string]any{"statusCode": float64(400), "responseBody": map[string]any{"ok": false}},
+			inboundgo.DeliveryResponse{StatusCode: 400, Body: `{"ok":false}`}},
+		{"timeout", inboundgo.DeliveryResponse{Error: "timeout"}},
+		{nil, inboundgo.DeliveryResponse{}},
+	}
+	for _, tt := range tests {
+		d := inboundgo.EndpointDelivery{ResponseData: tt.data}
+		if got := d.Response(); got != tt.want {
+			t.Errorf("Response() of %v = %+v, want %+v", tt.data, got, tt.want)
+		}
+	}
+}
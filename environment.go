@@ -0,0 +1,44 @@
+package inboundgo
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Environment is the base URL of an Inbound API deployment. Only the
+// documented production deployment has a preset; for any other, such as a
+// dedicated or self-hosted one, pass its base URL to NewClient.
+type Environment string
+
+const (
+	// EnvironmentProduction is the default deployment
+	EnvironmentProduction Environment = "https://inbound.new/api/v2"
+)
+
+// WithEnvironment sends requests to the given deployment instead of the
+// base URL the client was created with
+func (c *Inbound) WithEnvironment(env Environment) *Inbound {
+	c.baseURL = string(env)
+	return c
+}
+
+// validateBaseURL checks that a base URL is an absolute http or https URL
+// and returns it without a trailing slash, so endpoint paths can be
+// appended to it
+func validateBaseURL(baseURL string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL %q: %w", baseURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("invalid base URL %q: scheme must be http or https", baseURL)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("invalid base URL %q: missing host", baseURL)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("invalid base URL %q: must not have a query or fragment", baseURL)
+	}
+	return strings.TrimRight(baseURL, "/"), nil
+}
@@ -0,0 +1,51 @@
+package inboundgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestNewClientValidatesBaseURL(t *testing.T) {
+	for _, baseURL := range []string{
+		"inbound.new/api/v2",
+		"ftp://inbound.new/api/v2",
+		"https://",
+		"https://inbound.new/api/v2?key=1",
+		"https://inbound.new/api/v2#top",
+		"http://[::1",
+	} {
+		if _, err := inboundgo.NewClient("test-api-key", baseURL); err == nil {
+			t.Errorf("Expected an error for base URL %q", baseURL)
+		}
+	}
+}
+
+func TestNewClientTrimsTrailingSlash(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/mail" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"data":[],"pagination":{"limit":50,"offset":0,"total":0,"hasMore":false}}`))
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL+"/api/v2/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Mail().List(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWithEnvironment(t *testing.T) {
+	client, _ := inboundgo.NewClient("test-api-key", "https://custom-api-url.com")
+	client.WithEnvironment(inboundgo.EnvironmentProduction)
+	if got := client.String(); got != "inboundgo.Inbound{baseURL: https://inbound.new/api/v2, apiKey: [REDACTED]}" {
+		t.Errorf("Unexpected client %s", got)
+	}
+}
@@ -0,0 +1,188 @@
+package inboundgo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strings"
+)
+
+// ErasureReport lists what EraseAddressData removed
+type ErasureReport struct {
+	Address string
+	// Emails are the IDs of deleted received emails.
+	Emails []string
+	// ThreadMessages are the IDs of deleted thread messages that were not
+	// already removed as received emails, such as replies sent to the address.
+	ThreadMessages []string
+	// Attachments is the number of attachments deleted with those messages.
+	Attachments int
+	// Contacts are the IDs of deleted contacts.
+	Contacts []string
+}
+
+// EraseAddressData permanently deletes the received emails, thread messages,
+// attachments, and contacts that reference address as sender or recipient,
+// for answering data-deletion (right to erasure) requests. Suppressions are
+// kept so the address is not mailed again.
+//
+// Everything is collected before anything is deleted. On error the report
+// lists what was removed so far; erasure is safe to run again.
+func (c *Inbound) EraseAddressData(ctx context.Context, address string) (*ErasureReport, error) {
+	target := strings.ToLower(strings.TrimSpace(address))
+	if parsed, err := mail.ParseAddress(address); err == nil {
+		target = strings.ToLower(parsed.Address)
+	}
+	if !strings.Contains(target, "@") {
+		return nil, fmt.Errorf("invalid address %q", address)
+	}
+	report := &ErasureReport{Address: target}
+	matches := func(values ...string) bool {
+		for _, v := range values {
+			if referencesAddress(v, target) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Received emails
+	var emailIDs []string
+	err := c.Mail().each(ctx, &GetMailRequest{Search: target, IncludeArchived: Bool(true)}, func(item EmailItem) error {
+		if matches(item.From, item.Recipient) {
+			emailIDs = append(emailIDs, item.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+
+	// Thread messages, including outbound replies that never appear in mail
+	type threadMessage struct{ threadID, messageID string }
+	var messages []threadMessage
+	var threadIDs []string
+	err = c.Thread().each(ctx, &GetThreadsRequest{Address: target}, func(thread ThreadSummary) error {
+		threadIDs = append(threadIDs, thread.ID)
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+	deleted := make(map[string]bool, len(emailIDs))
+	for _, id := range emailIDs {
+		deleted[id] = true
+	}
+	for _, threadID := range threadIDs {
+		resp, err := c.Thread().Get(ctx, threadID)
+		if err != nil {
+			return report, err
+		}
+		if resp.Error != "" {
+			return report, fmt.Errorf("failed to get thread %s: %s", threadID, resp.Error)
+		}
+		for _, m := range resp.Data.Messages {
+			if deleted[m.ID] {
+				continue
+			}
+			from := m.From
+			if m.FromAddress != nil {
+				from += ", " + *m.FromAddress
+			}
+			if matches(append(append(append([]string{from}, m.To...), m.CC...), m.BCC...)...) {
+				messages = append(messages, threadMessage{threadID, m.ID})
+			}
+		}
+	}
+
+	// Contacts
+	var contactIDs []string
+	params := GetContactsRequest{Search: target, Limit: Int(100), Offset: Int(0)}
+	for {
+		resp, err := c.Contact().List(ctx, &params)
+		if err != nil {
+			return report, err
+		}
+		if resp.Error != "" {
+			return report, fmt.Errorf("failed to list contacts: %s", resp.Error)
+		}
+		if resp.Data == nil || len(resp.Data.Data) == 0 {
+			break
+		}
+		for _, contact := range resp.Data.Data {
+			if strings.EqualFold(contact.Email, target) {
+				contactIDs = append(contactIDs, contact.ID)
+			}
+		}
+		*params.Offset += len(resp.Data.Data)
+		if !resp.Data.Pagination.HasMore && *params.Offset >= resp.Data.Pagination.Total {
+			break
+		}
+	}
+
+	// Delete, treating anything already gone as erased
+	for _, id := range emailIDs {
+		resp, err := c.Mail().Delete(ctx, id)
+		if err := erasureError("email", id, resp, err); err != nil {
+			return report, err
+		}
+		report.Emails = append(report.Emails, id)
+		if resp.Data != nil {
+			report.Attachments += resp.Data.DeletedAttachments
+		}
+	}
+	for _, m := range messages {
+		resp, err := c.Thread().DeleteMessage(ctx, m.threadID, m.messageID)
+		if err := erasureError("thread message", m.messageID, resp, err); err != nil {
+			return report, err
+		}
+		report.ThreadMessages = append(report.ThreadMessages, m.messageID)
+		if resp.Data != nil {
+			report.Attachments += resp.Data.DeletedAttachments
+		}
+	}
+	for _, id := range contactIDs {
+		resp, err := c.Contact().Delete(ctx, id)
+		if err := erasureError("contact", id, resp, err); err != nil {
+			return report, err
+		}
+		report.Contacts = append(report.Contacts, id)
+	}
+	return report, nil
+}
+
+// erasureError turns a failed delete into an error, ignoring 404s
+func erasureError[T any](kind, id string, resp *ApiResponse[T], err error) error {
+	if err != nil {
+		return fmt.Errorf("failed to delete %s %s: %w", kind, id, err)
+	}
+	if resp.Error != "" && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete %s %s: %s", kind, id, resp.Error)
+	}
+	return nil
+}
+
+// referencesAddress reports whether an address header value, possibly a
+// list with display names, contains target
+func referencesAddress(value, target string) bool {
+	if value == "" {
+		return false
+	}
+	if list, err := mail.ParseAddressList(value); err == nil {
+		for _, a := range list {
+			if strings.EqualFold(a.Address, target) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, part := range strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return r == ',' || r == ';' || r == ' ' || r == '<' || r == '>' || r == '"'
+	}) {
+		if part == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package inboundgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestEraseAddressData(t *testing.T) {
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "DELETE" {
+			deleted = append(deleted, r.URL.Path)
+			switch r.URL.Path {
+			case "/mail/email-2":
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error": "Email not found"}`))
+			case "/mail/email-1":
+				w.Write([]byte(`{"message": "deleted", "deletedAttachments": 2}`))
+			case "/threads/thread-1/messages/out-1":
+				w.Write([]byte(`{"message": "deleted", "deletedAttachments": 1}`))
+			default:
+				w.Write([]byte(`{"message": "deleted"}`))
+			}
+			return
+		}
+		switch r.URL.Path {
+		case "/mail":
+			if r.URL.Query().Get("search") != "alice@example.com" || r.URL.Query().Get("includeArchived") != "true" {
+				t.Errorf("Unexpected mail query %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"emails": [
+				{"id": "email-1", "from": "Alice <Alice@example.com>", "recipient": "support@acme.test"},
+				{"id": "email-2", "from": "bob@example.com", "recipient": "alice@example.com"},
+				{"id": "email-3", "from": "carol@example.com", "recipient": "support@acme.test", "preview": "cc alice@example.com"}
+			], "pagination": {"total": 3}}`))
+		case "/threads":
+			if r.URL.Query().Get("address") != "alice@example.com" {
+				t.Errorf("Unexpected thread query %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"threads": [{"id": "thread-1"}], "pagination": {"total": 1}}`))
+		case "/threads/thread-1":
+			w.Write([]byte(`{"thread": {"id": "thread-1"}, "messages": [
+				{"id": "email-1", "type": "inbound", "from": "Alice <alice@example.com>"},
+				{"id": "out-1", "type": "outbound", "from": "support@acme.test", "to": ["Alice <alice@example.com>"]},
+				{"id": "out-2", "type": "outbound", "from": "support@acme.test", "to": ["dave@example.com"]}
+			]}`))
+		case "/contacts":
+			w.Write([]byte(`{"data": [{"id": "contact-1", "email": "ALICE@example.com"}, {"id": "contact-2", "email": "malice@example.com"}], "pagination": {"total": 2}}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	report, err := client.EraseAddressData(context.Background(), "Alice <alice@example.com>")
+	if err != nil {
+		t.Fatalf("EraseAddressData failed: %v", err)
+	}
+
+	want := &inboundgo.ErasureReport{
+		Address:        "alice@example.com",
+		Emails:         []string{"email-1", "email-2"},
+		ThreadMessages: []string{"out-1"},
+		Attachments:    3,
+		Contacts:       []string{"contact-1"},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("Expected report %+v, got %+v", want, report)
+	}
+	sort.Strings(deleted)
+	wantDeleted := []string{"/contacts/contact-1", "/mail/email-1", "/mail/email-2", "/threads/thread-1/messages/out-1"}
+	if !reflect.DeepEqual(deleted, wantDeleted) {
+		t.Errorf("Expected deletes %v, got %v", wantDeleted, deleted)
+	}
+
+	if _, err := client.EraseAddressData(context.Background(), "not-an-address"); err == nil {
+		t.Error("Expected error for invalid address")
+	}
+}
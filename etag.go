@@ -0,0 +1,96 @@
+package inboundgo
+
+import (
+	"net/http"
+	"sync"
+)
+
+// ETagCache stores response bodies keyed by request path along with the ETag
+// the server returned for them. Implementations must be safe for concurrent
+// use.
+type ETagCache interface {
+	// Get returns the stored ETag and body for key, if any.
+	Get(key string) (etag string, body []byte, ok bool)
+	// Set stores the ETag and body for key.
+	Set(key, etag string, body []byte)
+	// Delete removes key from the cache.
+	Delete(key string)
+}
+
+// MemoryETagCache is an in-process ETagCache backed by a map
+type MemoryETagCache struct {
+	mu      sync.RWMutex
+	entries map[string]etagEntry
+}
+
+type etagEntry struct {
+	etag string
+	body []byte
+}
+
+// NewMemoryETagCache creates an empty in-memory ETag cache
+func NewMemoryETagCache() *MemoryETagCache {
+	return &MemoryETagCache{entries: make(map[string]etagEntry)}
+}
+
+// Get returns the stored ETag and body for key
+func (m *MemoryETagCache) Get(key string) (string, []byte, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	e, ok := m.entries[key]
+	return e.etag, e.body, ok
+}
+
+// Set stores the ETag and body for key
+func (m *MemoryETagCache) Set(key, etag string, body []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = etagEntry{etag: etag, body: body}
+}
+
+// Delete removes key from the cache
+func (m *MemoryETagCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+}
+
+// WithETagCache enables conditional GET requests. Responses carrying an ETag
+// are stored in cache; later GETs for the same path send If-None-Match and a
+// 304 Not Modified response is served from the stored body.
+//
+// This is most useful for metadata that is read often and changes rarely,
+// such as Domain().Get and Endpoint().Get.
+func (c *Inbound) WithETagCache(cache ETagCache) *Inbound {
+	c.etagCache = cache
+	return c
+}
+
+// conditionalHeaders adds If-None-Match for cached GET requests. It returns
+// the (possibly copied) headers and the cached body to use on a 304.
+func (c *Inbound) conditionalHeaders(method, endpoint string, headers map[string]string) (map[string]string, []byte) {
+	if c.etagCache == nil || method != http.MethodGet {
+		return headers, nil
+	}
+	etag, body, ok := c.etagCache.Get(endpoint)
+	if !ok || etag == "" {
+		return headers, nil
+	}
+
+	merged := make(map[string]string, len(headers)+1)
+	for k, v := range headers {
+		merged[k] = v
+	}
+	merged["If-None-Match"] = etag
+	return merged, body
+}
+
+// storeETag records a successful GET response that carries an ETag
+func (c *Inbound) storeETag(method, endpoint string, resp *http.Response, body []byte) {
+	if c.etagCache == nil || method != http.MethodGet || resp.StatusCode != http.StatusOK {
+		return
+	}
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		c.etagCache.Set(endpoint, etag, body)
+	}
+}
@@ -0,0 +1,88 @@
+package inboundgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestETagCache(t *testing.T) {
+	var requests, notModified, conditionalWrites int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodGet && r.Header.Get("If-None-Match") != "" {
+			conditionalWrites++
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": "dom-123", "domain": "example.com", "status": "verified"}`))
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	cache := inboundgo.NewMemoryETagCache()
+	client.WithETagCache(cache)
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		resp, err := client.Domain().Get(ctx, "dom-123")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if resp.Error != "" {
+			t.Fatalf("Expected no error, got: %s", resp.Error)
+		}
+		if resp.Data == nil || resp.Data.Domain != "example.com" {
+			t.Fatalf("Expected cached domain data on request %d, got %+v", i, resp.Data)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Expected status 200 on request %d, got %d", i, resp.StatusCode)
+		}
+	}
+
+	if requests != 3 {
+		t.Errorf("Expected 3 requests, got %d", requests)
+	}
+	if notModified != 2 {
+		t.Errorf("Expected 2 conditional hits, got %d", notModified)
+	}
+
+	t.Run("entries can be invalidated", func(t *testing.T) {
+		cache.Delete("/domains/dom-123")
+		if _, err := client.Domain().Get(ctx, "dom-123"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if notModified != 2 {
+			t.Errorf("Expected unconditional request after Delete, got %d conditional hits", notModified)
+		}
+	})
+
+	t.Run("non-GET requests bypass the cache", func(t *testing.T) {
+		client.Domain().Update(ctx, "dom-123", &inboundgo.PutDomainByIDRequest{})
+		client.Domain().Create(ctx, &inboundgo.PostDomainsRequest{Domain: "example.com"})
+		if conditionalWrites != 0 {
+			t.Errorf("Expected no If-None-Match on non-GET requests, got %d", conditionalWrites)
+		}
+		if _, _, ok := cache.Get("/domains"); ok {
+			t.Error("POST response should not be stored in the cache")
+		}
+
+		cache.Delete("/domains/dom-123")
+		client.Domain().Update(ctx, "dom-123", &inboundgo.PutDomainByIDRequest{})
+		if _, _, ok := cache.Get("/domains/dom-123"); ok {
+			t.Error("PUT response should not be stored in the cache")
+		}
+	})
+}
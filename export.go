@@ -0,0 +1,215 @@
+package inboundgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// ExportFormat selects the output format of listing exports
+type ExportFormat string
+
+const (
+	// ExportFormatCSV writes a header row followed by one row per item
+	ExportFormatCSV ExportFormat = "csv"
+	// ExportFormatJSONL writes one JSON object per line
+	ExportFormatJSONL ExportFormat = "jsonl"
+)
+
+// Export writes every email matching filter to w as CSV or JSON Lines,
+// paging through the mail list. Columns are EmailItem JSON field names
+// (e.g. "id", "from", "subject", "receivedAt"); when none are given all
+// fields are exported. It returns the number of rows written.
+func (s *MailService) Export(ctx context.Context, filter *GetMailRequest, format ExportFormat, w io.Writer, columns ...string) (int, error) {
+	enc, err := newListingEncoder(reflect.TypeOf(EmailItem{}), format, w, columns)
+	if err != nil {
+		return 0, err
+	}
+	err = s.each(ctx, filter, func(item EmailItem) error {
+		return enc.write(item)
+	})
+	if flushErr := enc.flush(); err == nil {
+		err = flushErr
+	}
+	return enc.rows, err
+}
+
+// Export writes every thread matching filter to w as CSV or JSON Lines.
+// Columns are ThreadSummary JSON field names (e.g. "id", "messageCount",
+// "lastMessageAt"); nested values such as "latestMessage" are written as
+// JSON. It returns the number of rows written.
+func (s *ThreadService) Export(ctx context.Context, filter *GetThreadsRequest, format ExportFormat, w io.Writer, columns ...string) (int, error) {
+	enc, err := newListingEncoder(reflect.TypeOf(ThreadSummary{}), format, w, columns)
+	if err != nil {
+		return 0, err
+	}
+	err = s.each(ctx, filter, func(thread ThreadSummary) error {
+		return enc.write(thread)
+	})
+	if flushErr := enc.flush(); err == nil {
+		err = flushErr
+	}
+	return enc.rows, err
+}
+
+// each calls fn for every thread matching filter, fetching pages as needed
+func (s *ThreadService) each(ctx context.Context, filter *GetThreadsRequest, fn func(ThreadSummary) error) error {
+	params := GetThreadsRequest{}
+	if filter != nil {
+		params = *filter
+	}
+	if params.Limit == nil {
+		params.Limit = Int(50)
+	}
+	offset := 0
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+
+	for {
+		params.Offset = Int(offset)
+		resp, err := s.List(ctx, &params)
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return fmt.Errorf("failed to list threads: %s", resp.Error)
+		}
+		if resp.Data == nil {
+			return nil
+		}
+		for _, thread := range resp.Data.Threads {
+			if err := fn(thread); err != nil {
+				return err
+			}
+		}
+
+		offset += len(resp.Data.Threads)
+		if len(resp.Data.Threads) == 0 || (!resp.Data.Pagination.HasMore && offset >= resp.Data.Pagination.Total) {
+			return nil
+		}
+	}
+}
+
+// listingEncoder writes items as CSV rows or JSON Lines restricted to a set
+// of columns
+type listingEncoder struct {
+	format  ExportFormat
+	columns []string
+	w       io.Writer
+	csv     *csv.Writer
+	rows    int
+}
+
+func newListingEncoder(t reflect.Type, format ExportFormat, w io.Writer, columns []string) (*listingEncoder, error) {
+	available := jsonFieldNames(t)
+	if len(columns) == 0 {
+		columns = available
+	} else {
+		known := make(map[string]bool, len(available))
+		for _, c := range available {
+			known[c] = true
+		}
+		for _, c := range columns {
+			if !known[c] {
+				return nil, fmt.Errorf("unknown export column %q (available: %s)", c, strings.Join(available, ", "))
+			}
+		}
+	}
+
+	enc := &listingEncoder{format: format, columns: columns, w: w}
+	switch format {
+	case ExportFormatCSV:
+		enc.csv = csv.NewWriter(w)
+		if err := enc.csv.Write(columns); err != nil {
+			return nil, err
+		}
+	case ExportFormatJSONL:
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+	return enc, nil
+}
+
+func (e *listingEncoder) write(item any) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if e.csv != nil {
+		record := make([]string, len(e.columns))
+		for i, c := range e.columns {
+			record[i] = csvValue(fields[c])
+		}
+		if err := e.csv.Write(record); err != nil {
+			return err
+		}
+	} else {
+		var line bytes.Buffer
+		line.WriteByte('{')
+		for i, c := range e.columns {
+			if i > 0 {
+				line.WriteByte(',')
+			}
+			key, _ := json.Marshal(c)
+			line.Write(key)
+			line.WriteByte(':')
+			if v, ok := fields[c]; ok {
+				line.Write(v)
+			} else {
+				line.WriteString("null")
+			}
+		}
+		line.WriteString("}\n")
+		if _, err := e.w.Write(line.Bytes()); err != nil {
+			return err
+		}
+	}
+	e.rows++
+	return nil
+}
+
+func (e *listingEncoder) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		return e.csv.Error()
+	}
+	return nil
+}
+
+// csvValue renders a JSON value as a CSV cell: strings unquoted, null empty,
+// and everything else (numbers, bools, objects) as JSON text
+func csvValue(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// jsonFieldNames returns the JSON names of a struct's fields in declaration order
+func jsonFieldNames(t reflect.Type) []string {
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
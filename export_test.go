@@ -0,0 +1,103 @@
+package inboundgo_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestMailExportCSV(t *testing.T) {
+	server := newMailExportServer(t)
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	var buf bytes.Buffer
+	n, err := client.Mail().Export(context.Background(), nil, inboundgo.ExportFormatCSV, &buf, "id", "isRead")
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("Expected 3 rows, got %d", n)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Invalid CSV: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("Expected header plus 3 rows, got %d", len(records))
+	}
+	if strings.Join(records[0], ",") != "id,isRead" {
+		t.Errorf("Unexpected header: %v", records[0])
+	}
+	if records[3][0] != "mail-3" || records[3][1] != "false" {
+		t.Errorf("Unexpected last row: %v", records[3])
+	}
+}
+
+func TestThreadExportJSONL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/threads" {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("offset") == "0" {
+			w.Write([]byte(`{"threads": [{"id": "thr-1", "messageCount": 2, "latestMessage": {"id": "m1"}}], "pagination": {"limit": 1, "offset": 0, "total": 2, "hasMore": true}}`))
+		} else {
+			w.Write([]byte(`{"threads": [{"id": "thr-2", "messageCount": 5}], "pagination": {"limit": 1, "offset": 1, "total": 2}}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	var buf bytes.Buffer
+	n, err := client.Thread().Export(context.Background(), nil, inboundgo.ExportFormatJSONL, &buf, "messageCount", "id")
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Expected 2 rows, got %d", n)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+	if lines[0] != `{"messageCount":2,"id":"thr-1"}` {
+		t.Errorf("Unexpected first line: %s", lines[0])
+	}
+	var row map[string]any
+	if err := json.Unmarshal([]byte(lines[1]), &row); err != nil || row["id"] != "thr-2" {
+		t.Errorf("Unexpected second line: %s (%v)", lines[1], err)
+	}
+}
+
+func TestExportRejectsUnknownColumn(t *testing.T) {
+	client, err := inboundgo.NewClient("test-api-key", "http://127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := client.Mail().Export(context.Background(), nil, inboundgo.ExportFormatCSV, &buf, "nope"); err == nil {
+		t.Error("Expected error for unknown column")
+	}
+	if _, err := client.Mail().Export(context.Background(), nil, "xml", &buf); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
@@ -0,0 +1,188 @@
+package inboundgo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// requestBuilder creates a fresh request against the given base URL. It is
+// called once per attempt so each attempt gets its own body reader.
+type requestBuilder func(ctx context.Context, baseURL string) (*http.Request, error)
+
+// WithFallbackBaseURLs configures additional base URLs (for example regional
+// or failover hosts) that are tried in order when the primary base URL cannot
+// be reached.
+//
+// GET requests fail over on any connection error. Other methods only fail
+// over when the connection could not be established at all, so a request
+// that may have reached the server is never sent twice.
+func (c *Inbound) WithFallbackBaseURLs(urls ...string) *Inbound {
+	c.fallbacks = append([]string(nil), urls...)
+	return c
+}
+
+// WithHedging enables hedged GET requests: when a GET has not completed after
+// delay, a second attempt is started against the next base URL (or the same
+// one if no fallbacks are configured) and whichever responds first wins.
+// A zero delay disables hedging.
+func (c *Inbound) WithHedging(delay time.Duration) *Inbound {
+	c.hedgeDelay = delay
+	return c
+}
+
+// baseURLs returns the primary base URL followed by any fallbacks
+func (c *Inbound) baseURLs() []string {
+	return append([]string{c.baseURL}, c.fallbacks...)
+}
+
+// send performs a request, applying failover and hedging when configured
+func (c *Inbound) send(ctx context.Context, method string, build requestBuilder) (*http.Response, error) {
+	if c.hedgeDelay > 0 && method == http.MethodGet {
+		return c.sendHedged(ctx, build)
+	}
+
+	var lastErr error
+	for _, base := range c.baseURLs() {
+		req, err := build(ctx, base)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.httpClientFor(ctx).Do(req)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+		if ctx.Err() != nil || !canFailOver(method, err) {
+			break
+		}
+	}
+	return nil, lastErr
+}
+
+// streamingKey is the context key marking requests whose response body is
+// read for as long as the context lasts, such as the mail event stream
+type streamingKey struct{}
+
+// httpClientFor returns the client to send a request on ctx with. Streaming
+// requests drop the client's Timeout, which would otherwise cut them off
+// mid-stream; their callers bound them with ctx instead.
+func (c *Inbound) httpClientFor(ctx context.Context) *http.Client {
+	if ctx.Value(streamingKey{}) == nil || c.httpClient.Timeout == 0 {
+		return c.httpClient
+	}
+	client := *c.httpClient
+	client.Timeout = 0
+	return &client
+}
+
+// canFailOver reports whether a failed attempt may be retried on another host
+func canFailOver(method string, err error) bool {
+	if method == http.MethodGet || method == http.MethodHead {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+type hedgeResult struct {
+	index int
+	resp  *http.Response
+	err   error
+}
+
+// sendHedged races GET attempts across base URLs, starting a new attempt
+// each time hedgeDelay elapses or an attempt fails.
+func (c *Inbound) sendHedged(ctx context.Context, build requestBuilder) (*http.Response, error) {
+	bases := c.baseURLs()
+	maxAttempts := len(bases)
+	if maxAttempts < 2 {
+		maxAttempts = 2
+	}
+
+	results := make(chan hedgeResult, maxAttempts)
+	cancels := make([]context.CancelFunc, 0, maxAttempts)
+	launch := func() {
+		i := len(cancels)
+		attemptCtx, cancel := context.WithCancel(ctx)
+		cancels = append(cancels, cancel)
+		go func() {
+			req, err := build(attemptCtx, bases[i%len(bases)])
+			if err != nil {
+				results <- hedgeResult{index: i, err: err}
+				return
+			}
+			resp, err := c.httpClientFor(ctx).Do(req)
+			results <- hedgeResult{index: i, resp: resp, err: err}
+		}()
+	}
+
+	// abandon cancels every attempt except keep and discards late responses
+	abandon := func(keep, pending int) {
+		for i, cancel := range cancels {
+			if i != keep {
+				cancel()
+			}
+		}
+		go func() {
+			for ; pending > 0; pending-- {
+				if r := <-results; r.resp != nil {
+					r.resp.Body.Close()
+				}
+			}
+		}()
+	}
+
+	launch()
+	pending := 1
+	timer := time.NewTimer(c.hedgeDelay)
+	defer timer.Stop()
+
+	var lastErr error
+	for pending > 0 {
+		select {
+		case <-timer.C:
+			if len(cancels) < maxAttempts {
+				launch()
+				pending++
+				timer.Reset(c.hedgeDelay)
+			}
+		case r := <-results:
+			pending--
+			if r.err == nil {
+				abandon(r.index, pending)
+				r.resp.Body = &cancelOnClose{ReadCloser: r.resp.Body, cancel: cancels[r.index]}
+				return r.resp, nil
+			}
+			cancels[r.index]()
+			lastErr = r.err
+			if ctx.Err() == nil && len(cancels) < maxAttempts {
+				launch()
+				pending++
+			}
+		case <-ctx.Done():
+			abandon(-1, pending)
+			return nil, ctx.Err()
+		}
+	}
+	return nil, lastErr
+}
+
+// cancelOnClose releases a hedged attempt's context once its body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
@@ -0,0 +1,98 @@
+package inboundgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+// deadURL returns the address of a server that has already been shut down,
+// so connections to it are refused.
+func deadURL() string {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+	return url
+}
+
+func TestFallbackBaseURLs(t *testing.T) {
+	var hits int32
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "email-123", "domain": "example.com"}`))
+	}))
+	defer fallback.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", deadURL())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.WithFallbackBaseURLs(fallback.URL)
+	ctx := context.Background()
+
+	t.Run("should fail over GET requests", func(t *testing.T) {
+		resp, err := client.Domain().Get(ctx, "dom-1")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if resp.Error != "" || resp.Data == nil || resp.Data.Domain != "example.com" {
+			t.Errorf("Expected response from fallback host, got %+v", resp)
+		}
+	})
+
+	t.Run("should fail over POST requests that never connected", func(t *testing.T) {
+		resp, _ := client.Email().Send(ctx, &inboundgo.PostEmailsRequest{
+			From:    "test@example.com",
+			To:      "user@example.com",
+			Subject: "Failover",
+			Text:    inboundgo.String("hello"),
+		}, nil)
+		if resp.Error != "" || resp.Data == nil || resp.Data.ID != "email-123" {
+			t.Errorf("Expected send to succeed via fallback, got %+v", resp)
+		}
+	})
+
+	if atomic.LoadInt32(&hits) != 2 {
+		t.Errorf("Expected 2 requests on the fallback host, got %d", hits)
+	}
+}
+
+func TestHedgedRequests(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+			return
+		}
+		w.Write([]byte(`{"id": "dom-1", "domain": "slow.example.com"}`))
+	}))
+	defer slow.Close()
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": "dom-1", "domain": "fast.example.com"}`))
+	}))
+	defer fast.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", slow.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.WithFallbackBaseURLs(fast.URL).WithHedging(20 * time.Millisecond)
+
+	start := time.Now()
+	resp, err := client.Domain().Get(context.Background(), "dom-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resp.Data == nil || resp.Data.Domain != "fast.example.com" {
+		t.Errorf("Expected hedged response from the fast host, got %+v", resp)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected hedged request to finish quickly, took %v", elapsed)
+	}
+}
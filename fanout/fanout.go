@@ -0,0 +1,181 @@
+// Package fanout forwards verified Inbound webhook events to a message bus,
+// so inbound email can be buffered in existing streaming infrastructure and
+// consumed at the pace of downstream services.
+//
+// A Handler receives webhooks and hands each event to a Publisher.
+// Publishers are included for NATS (core protocol), Kafka (through a
+// Confluent-compatible REST Proxy), and RabbitMQ (through the management
+// HTTP API); anything else can implement Publisher.
+//
+//	pub, err := fanout.NewNATSPublisher("nats://localhost:4222")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	http.Handle("/webhooks/inbound", fanout.NewHandler(pub, func(r *http.Request) error {
+//		return inboundgo.VerifyWebhookHeader(r, "X-Webhook-Secret", secret)
+//	}))
+package fanout
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+// DefaultMaxBodySize bounds the size of an accepted webhook request
+const DefaultMaxBodySize = 32 << 20
+
+// Message is one event handed to a Publisher
+type Message struct {
+	// Subject is the NATS subject, Kafka topic suffix, or RabbitMQ routing
+	// key, e.g. "inbound.email.received".
+	Subject string
+	// Key identifies the event (the email ID), usable for partitioning and
+	// deduplication since webhooks may be delivered more than once.
+	Key string
+	// Data is the webhook payload exactly as received.
+	Data []byte
+	// Headers carry event metadata where the bus supports them.
+	Headers map[string]string
+}
+
+// Publisher delivers messages to a message bus. Publish returns only once
+// the bus has accepted the message, so a failed publish can be retried by
+// the webhook sender.
+type Publisher interface {
+	Publish(ctx context.Context, msg *Message) error
+}
+
+// Handler is an http.Handler that verifies webhook requests and publishes
+// their events. It responds 401 to unverified requests, 400 to malformed
+// payloads, and 503 when publishing fails so the webhook is retried.
+type Handler struct {
+	Publisher Publisher
+	// Verify authenticates a request, e.g. with inboundgo.VerifyWebhookHeader.
+	// Requests are not verified when nil.
+	Verify func(*http.Request) error
+	// Subject returns the subject for an event (default "inbound." followed
+	// by the event name, e.g. "inbound.email.received").
+	Subject func(*inboundgo.WebhookPayload) string
+	// MaxBodySize bounds accepted requests (default DefaultMaxBodySize).
+	MaxBodySize int64
+	// ErrorLog receives publish errors; the standard logger is used when nil.
+	ErrorLog *log.Logger
+}
+
+// NewHandler creates a handler that publishes verified events to p
+func NewHandler(p Publisher, verify func(*http.Request) error) *Handler {
+	return &Handler{Publisher: p, Verify: verify}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.Verify != nil {
+		if err := h.Verify(r); err != nil {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	limit := h.MaxBodySize
+	if limit <= 0 {
+		limit = DefaultMaxBodySize
+	}
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusRequestEntityTooLarge)
+		return
+	}
+	payload, err := inboundgo.ParseWebhookPayload(bytes.NewReader(data))
+	if err != nil || payload.Event == "" {
+		http.Error(w, "invalid webhook payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Publisher.Publish(r.Context(), h.message(payload, data)); err != nil {
+		h.logf("fanout: failed to publish %s %s: %v", payload.Event, payload.Email.ID, err)
+		http.Error(w, "failed to publish event", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) message(payload *inboundgo.WebhookPayload, data []byte) *Message {
+	subject := "inbound." + payload.Event
+	if h.Subject != nil {
+		subject = h.Subject(payload)
+	}
+	headers := map[string]string{
+		"Inbound-Event": payload.Event,
+		"Content-Type":  "application/json",
+	}
+	if payload.Email.ID != "" {
+		headers["Inbound-Email-Id"] = payload.Email.ID
+	}
+	if payload.Endpoint.ID != "" {
+		headers["Inbound-Endpoint-Id"] = payload.Endpoint.ID
+	}
+	if payload.Timestamp != "" {
+		headers["Inbound-Timestamp"] = payload.Timestamp
+	}
+	return &Message{Subject: subject, Key: payload.Email.ID, Data: data, Headers: headers}
+}
+
+func (h *Handler) logf(format string, args ...any) {
+	if h.ErrorLog != nil {
+		h.ErrorLog.Printf(format, args...)
+	} else {
+		log.Printf(format, args...)
+	}
+}
+
+// postJSON posts body to url and decodes a successful response into out
+func postJSON(ctx context.Context, client *http.Client, url string, body any, header http.Header, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("invalid response: %w", err)
+		}
+	}
+	return nil
+}
+
+// errNotJSON is returned by publishers that embed payloads as JSON
+var errNotJSON = errors.New("message data is not valid JSON")
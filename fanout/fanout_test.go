@@ -0,0 +1,226 @@
+package fanout_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+	"github.com/inboundemail/inbound-golang-sdk/fanout"
+)
+
+const webhookBody = `{"event": "email.received", "timestamp": "2025-01-01T00:00:00Z", "email": {"id": "email-1", "recipient": "support@acme.test"}, "endpoint": {"id": "ep-1"}}`
+
+type recordingPublisher struct {
+	messages []*fanout.Message
+	err      error
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, msg *fanout.Message) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.messages = append(p.messages, msg)
+	return nil
+}
+
+func TestHandler(t *testing.T) {
+	pub := &recordingPublisher{}
+	h := fanout.NewHandler(pub, func(r *http.Request) error {
+		return inboundgo.VerifyWebhookHeader(r, "X-Webhook-Secret", "s3cret")
+	})
+
+	post := func(body, secret string) int {
+		req := httptest.NewRequest("POST", "/webhook", strings.NewReader(body))
+		req.Header.Set("X-Webhook-Secret", secret)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := post(webhookBody, "wrong"); code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for unverified request, got %d", code)
+	}
+	if code := post(`{"nope"`, "s3cret"); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for malformed payload, got %d", code)
+	}
+	if code := post(webhookBody, "s3cret"); code != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d", code)
+	}
+	if len(pub.messages) != 1 {
+		t.Fatalf("Expected one published message, got %d", len(pub.messages))
+	}
+	msg := pub.messages[0]
+	if msg.Subject != "inbound.email.received" || msg.Key != "email-1" || string(msg.Data) != webhookBody {
+		t.Errorf("Unexpected message %+v", msg)
+	}
+	if msg.Headers["Inbound-Endpoint-Id"] != "ep-1" || msg.Headers["Inbound-Event"] != "email.received" {
+		t.Errorf("Unexpected headers %v", msg.Headers)
+	}
+
+	pub.err = errors.New("bus down")
+	h.ErrorLog = log.New(io.Discard, "", 0)
+	if code := post(webhookBody, "s3cret"); code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 when publishing fails, got %d", code)
+	}
+}
+
+// fakeNATS accepts one connection and records published messages
+func fakeNATS(t *testing.T, published chan<- string) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		fmt.Fprint(conn, "INFO {\"headers\":true}\r\n")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			fields := strings.Fields(line)
+			if len(fields) == 0 {
+				continue
+			}
+			switch fields[0] {
+			case "CONNECT":
+				var opts map[string]any
+				json.Unmarshal([]byte(strings.TrimPrefix(strings.TrimSpace(line), "CONNECT ")), &opts)
+				if opts["user"] != "bob" || opts["pass"] != "pw" {
+					fmt.Fprint(conn, "-ERR 'Authorization Violation'\r\n")
+					return
+				}
+			case "PING":
+				fmt.Fprint(conn, "PONG\r\n")
+			case "HPUB":
+				hdrLen, _ := strconv.Atoi(fields[2])
+				total, _ := strconv.Atoi(fields[3])
+				buf := make([]byte, total+2)
+				io.ReadFull(r, buf)
+				published <- fields[1] + "|" + string(buf[:hdrLen]) + "|" + string(buf[hdrLen:total])
+			}
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestNATSPublisher(t *testing.T) {
+	published := make(chan string, 1)
+	addr := fakeNATS(t, published)
+	pub, err := fanout.NewNATSPublisher("nats://bob:pw@" + addr)
+	if err != nil {
+		t.Fatalf("NewNATSPublisher failed: %v", err)
+	}
+	defer pub.Close()
+
+	err = pub.Publish(context.Background(), &fanout.Message{
+		Subject: "inbound.email.received", Key: "email-1", Data: []byte(`{"a":1}`),
+		Headers: map[string]string{"Inbound-Email-Id": "email-1"},
+	})
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	got := <-published
+	want := "inbound.email.received|NATS/1.0\r\nInbound-Email-Id: email-1\r\n\r\n|{\"a\":1}"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	if err := pub.Publish(context.Background(), &fanout.Message{Subject: "bad subject"}); err == nil {
+		t.Error("Expected error for subject with spaces")
+	}
+}
+
+func TestNATSPublisherAuthError(t *testing.T) {
+	addr := fakeNATS(t, make(chan string, 1))
+	pub, err := fanout.NewNATSPublisher("nats://bob:wrong@" + addr)
+	if err != nil {
+		t.Fatalf("NewNATSPublisher failed: %v", err)
+	}
+	err = pub.Publish(context.Background(), &fanout.Message{Subject: "x", Data: []byte("{}")})
+	if err == nil || !strings.Contains(err.Error(), "Authorization Violation") {
+		t.Errorf("Expected authorization error, got %v", err)
+	}
+}
+
+func TestKafkaRESTPublisher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/topics/inbound-mail" || r.Header.Get("Content-Type") != "application/vnd.kafka.json.v2+json" {
+			t.Errorf("Unexpected request %s %s", r.URL.Path, r.Header.Get("Content-Type"))
+		}
+		var body struct {
+			Records []struct {
+				Key   string          `json:"key"`
+				Value json.RawMessage `json:"value"`
+			} `json:"records"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body.Records) != 1 || body.Records[0].Key != "email-1" || string(body.Records[0].Value) != `{"a":1}` {
+			t.Errorf("Unexpected records %+v", body.Records)
+		}
+		w.Write([]byte(`{"offsets": [{"partition": 0, "offset": 42}]}`))
+	}))
+	defer server.Close()
+
+	pub := fanout.NewKafkaRESTPublisher(server.URL, "inbound-mail")
+	if err := pub.Publish(context.Background(), &fanout.Message{Subject: "ignored", Key: "email-1", Data: []byte(`{"a":1}`)}); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if err := pub.Publish(context.Background(), &fanout.Message{Data: []byte("not json")}); err == nil {
+		t.Error("Expected error for non-JSON data")
+	}
+}
+
+func TestRabbitMQPublisher(t *testing.T) {
+	routed := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/api/exchanges/%2F/inbound/publish" {
+			t.Errorf("Unexpected path %s", r.URL.EscapedPath())
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "guest" || pass != "guest" {
+			t.Errorf("Expected basic auth, got %q %q", user, pass)
+		}
+		var body struct {
+			RoutingKey string `json:"routing_key"`
+			Payload    string `json:"payload"`
+			Properties struct {
+				MessageID    string            `json:"message_id"`
+				DeliveryMode int               `json:"delivery_mode"`
+				Headers      map[string]string `json:"headers"`
+			} `json:"properties"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.RoutingKey != "inbound.email.received" || body.Payload != `{"a":1}` || body.Properties.MessageID != "email-1" || body.Properties.DeliveryMode != 2 {
+			t.Errorf("Unexpected publish body %+v", body)
+		}
+		fmt.Fprintf(w, `{"routed": %t}`, routed)
+	}))
+	defer server.Close()
+
+	pub := fanout.NewRabbitMQPublisher(server.URL, "/", "inbound", "guest", "guest")
+	msg := &fanout.Message{Subject: "inbound.email.received", Key: "email-1", Data: []byte(`{"a":1}`)}
+	if err := pub.Publish(context.Background(), msg); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	routed = false
+	if err := pub.Publish(context.Background(), msg); err == nil || !strings.Contains(err.Error(), "no queue is bound") {
+		t.Errorf("Expected unrouted error, got %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package fanout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// KafkaRESTPublisher produces messages to Kafka through a REST Proxy that
+// implements the Confluent v2 API. Records are keyed by Message.Key so all
+// events of one email land on the same partition. The v2 API has no record
+// headers, so Message.Headers are not sent.
+type KafkaRESTPublisher struct {
+	// Topic receives every message. When empty, Message.Subject is used as
+	// the topic.
+	Topic string
+	// Header is added to every request, e.g. for proxy authentication.
+	Header     http.Header
+	HTTPClient *http.Client
+
+	baseURL string
+}
+
+// NewKafkaRESTPublisher creates a publisher for the REST Proxy at proxyURL
+// that produces to topic
+func NewKafkaRESTPublisher(proxyURL, topic string) *KafkaRESTPublisher {
+	return &KafkaRESTPublisher{
+		Topic:      topic,
+		Header:     http.Header{},
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+		baseURL:    strings.TrimSuffix(proxyURL, "/"),
+	}
+}
+
+// Publish produces one record and waits for the proxy to report its offset
+func (p *KafkaRESTPublisher) Publish(ctx context.Context, msg *Message) error {
+	topic := p.Topic
+	if topic == "" {
+		topic = msg.Subject
+	}
+	if !json.Valid(msg.Data) {
+		return errNotJSON
+	}
+	type record struct {
+		Key   *string         `json:"key"`
+		Value json.RawMessage `json:"value"`
+	}
+	rec := record{Value: msg.Data}
+	if msg.Key != "" {
+		rec.Key = &msg.Key
+	}
+
+	header := p.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	header.Set("Accept", "application/vnd.kafka.v2+json")
+	var resp struct {
+		Offsets []struct {
+			Partition *int   `json:"partition"`
+			Offset    *int64 `json:"offset"`
+			ErrorCode *int   `json:"error_code"`
+			Error     string `json:"error"`
+		} `json:"offsets"`
+	}
+	endpoint := p.baseURL + "/topics/" + url.PathEscape(topic)
+	if err := postJSON(ctx, p.HTTPClient, endpoint, map[string]any{"records": []record{rec}}, header, &resp); err != nil {
+		return fmt.Errorf("failed to produce to %s: %w", topic, err)
+	}
+	if len(resp.Offsets) == 0 {
+		return fmt.Errorf("failed to produce to %s: no offset returned", topic)
+	}
+	if o := resp.Offsets[0]; o.ErrorCode != nil || o.Error != "" {
+		return fmt.Errorf("failed to produce to %s: %s", topic, o.Error)
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package fanout
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NATSPublisher publishes messages to a NATS server using the core client
+// protocol. Each publish is confirmed with a PING round trip, so an error
+// means the server may not have the message. The connection is opened on
+// first use and re-established after failures.
+type NATSPublisher struct {
+	// Name identifies the connection in server monitoring.
+	Name string
+	// Timeout bounds connecting and each publish (default 10s).
+	Timeout time.Duration
+	// TLSConfig is used for tls:// URLs.
+	TLSConfig *tls.Config
+
+	addr   string
+	secure bool
+	user   string
+	pass   string
+	token  string
+
+	mu      sync.Mutex
+	conn    net.Conn
+	r       *bufio.Reader
+	headers bool
+}
+
+// NewNATSPublisher creates a publisher for a nats:// or tls:// URL.
+// Credentials may be given in the URL as user:password or as a token in
+// the user part.
+func NewNATSPublisher(rawURL string) (*NATSPublisher, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		// url.Error quotes the URL, which holds the credentials
+		return nil, fmt.Errorf("invalid NATS URL: %w", err.(*url.Error).Err)
+	}
+	p := &NATSPublisher{Name: "inbound-fanout", Timeout: 10 * time.Second}
+	switch u.Scheme {
+	case "nats":
+	case "tls":
+		p.secure = true
+	default:
+		return nil, fmt.Errorf("unsupported NATS URL scheme %q", u.Scheme)
+	}
+	p.addr = u.Host
+	if u.Port() == "" {
+		p.addr = net.JoinHostPort(u.Hostname(), "4222")
+	}
+	if u.User != nil {
+		if pass, ok := u.User.Password(); ok {
+			p.user, p.pass = u.User.Username(), pass
+		} else {
+			p.token = u.User.Username()
+		}
+	}
+	return p, nil
+}
+
+// Publish sends msg.Data to msg.Subject, with msg.Headers when the server
+// supports headers
+func (p *NATSPublisher) Publish(ctx context.Context, msg *Message) error {
+	if msg.Subject == "" || strings.ContainsAny(msg.Subject, " \t\r\n") {
+		return fmt.Errorf("invalid NATS subject %q", msg.Subject)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		if err := p.connect(ctx); err != nil {
+			return fmt.Errorf("failed to connect to NATS: %w", err)
+		}
+	}
+	err := p.publish(ctx, msg)
+	if err != nil {
+		// The connection state is unknown; start over on the next publish
+		p.conn.Close()
+		p.conn = nil
+	}
+	return err
+}
+
+// Close closes the connection
+func (p *NATSPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.conn == nil {
+		return nil
+	}
+	err := p.conn.Close()
+	p.conn = nil
+	return err
+}
+
+func (p *NATSPublisher) connect(ctx context.Context) error {
+	d := net.Dialer{Timeout: p.Timeout}
+	conn, err := d.DialContext(ctx, "tcp", p.addr)
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(p.deadline(ctx))
+	r := bufio.NewReader(conn)
+
+	line, err := r.ReadString('\n')
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	info, ok := strings.CutPrefix(strings.TrimSpace(line), "INFO ")
+	if !ok {
+		conn.Close()
+		return fmt.Errorf("unexpected greeting %q", strings.TrimSpace(line))
+	}
+	var serverInfo struct {
+		Headers     bool `json:"headers"`
+		TLSRequired bool `json:"tls_required"`
+	}
+	json.Unmarshal([]byte(info), &serverInfo)
+
+	if p.secure || serverInfo.TLSRequired {
+		cfg := p.TLSConfig
+		if cfg == nil {
+			host, _, _ := net.SplitHostPort(p.addr)
+			cfg = &tls.Config{ServerName: host}
+		}
+		tlsConn := tls.Client(conn, cfg)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return err
+		}
+		conn, r = tlsConn, bufio.NewReader(tlsConn)
+	}
+
+	connect, _ := json.Marshal(map[string]any{
+		"verbose":    false,
+		"pedantic":   false,
+		"lang":       "go",
+		"name":       p.Name,
+		"protocol":   1,
+		"headers":    serverInfo.Headers,
+		"user":       p.user,
+		"pass":       p.pass,
+		"auth_token": p.token,
+	})
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\nPING\r\n", connect); err != nil {
+		conn.Close()
+		return err
+	}
+	p.conn, p.r, p.headers = conn, r, serverInfo.Headers
+	if err := p.awaitPong(); err != nil {
+		conn.Close()
+		p.conn = nil
+		return err
+	}
+	return nil
+}
+
+func (p *NATSPublisher) publish(ctx context.Context, msg *Message) error {
+	p.conn.SetDeadline(p.deadline(ctx))
+
+	var frame strings.Builder
+	if p.headers && len(msg.Headers) > 0 {
+		var hdr strings.Builder
+		hdr.WriteString("NATS/1.0\r\n")
+		keys := make([]string, 0, len(msg.Headers))
+		for k := range msg.Headers {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			v := strings.NewReplacer("\r", " ", "\n", " ").Replace(msg.Headers[k])
+			fmt.Fprintf(&hdr, "%s: %s\r\n", k, v)
+		}
+		hdr.WriteString("\r\n")
+		fmt.Fprintf(&frame, "HPUB %s %d %d\r\n%s", msg.Subject, hdr.Len(), hdr.Len()+len(msg.Data), hdr.String())
+	} else {
+		fmt.Fprintf(&frame, "PUB %s %d\r\n", msg.Subject, len(msg.Data))
+	}
+	frame.Write(msg.Data)
+	frame.WriteString("\r\nPING\r\n")
+	if _, err := p.conn.Write([]byte(frame.String())); err != nil {
+		return err
+	}
+	return p.awaitPong()
+}
+
+// awaitPong reads until the server answers our PING, answering its PINGs
+// and surfacing -ERR responses
+func (p *NATSPublisher) awaitPong() error {
+	for {
+		line, err := p.r.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := p.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.New("NATS error: " + strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "-ERR")), "'"))
+		}
+	}
+}
+
+func (p *NATSPublisher) deadline(ctx context.Context) time.Time {
+	deadline := time.Now().Add(p.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	return deadline
+}
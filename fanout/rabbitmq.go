@@ -0,0 +1,82 @@
+package fanout
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RabbitMQPublisher publishes messages to a RabbitMQ exchange through the
+// management plugin's HTTP API, using Message.Subject as the routing key.
+// Messages are persistent and carry Message.Headers as AMQP headers and
+// Message.Key as the message ID. A message that no queue is bound to
+// receive is reported as an error rather than silently dropped.
+type RabbitMQPublisher struct {
+	HTTPClient *http.Client
+
+	endpoint string
+	username string
+	password string
+}
+
+// NewRabbitMQPublisher creates a publisher for the exchange in vhost,
+// authenticating to the management API at managementURL (for example
+// "http://localhost:15672") with username and password
+func NewRabbitMQPublisher(managementURL, vhost, exchange, username, password string) *RabbitMQPublisher {
+	if vhost == "" {
+		vhost = "/"
+	}
+	if exchange == "" {
+		exchange = "amq.default"
+	}
+	return &RabbitMQPublisher{
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+		endpoint: fmt.Sprintf("%s/api/exchanges/%s/%s/publish",
+			strings.TrimSuffix(managementURL, "/"), url.PathEscape(vhost), url.PathEscape(exchange)),
+		username: username,
+		password: password,
+	}
+}
+
+// Publish publishes one message and waits for the broker to route it
+func (p *RabbitMQPublisher) Publish(ctx context.Context, msg *Message) error {
+	headers := make(map[string]string, len(msg.Headers))
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	properties := map[string]any{
+		"delivery_mode": 2,
+		"content_type":  "application/json",
+		"headers":       headers,
+	}
+	if msg.Key != "" {
+		properties["message_id"] = msg.Key
+	}
+	body := map[string]any{
+		"properties":       properties,
+		"routing_key":      msg.Subject,
+		"payload":          string(msg.Data),
+		"payload_encoding": "string",
+	}
+
+	req := http.Header{}
+	req.Set("Authorization", "Basic "+basicAuth(p.username, p.password))
+	var resp struct {
+		Routed bool `json:"routed"`
+	}
+	if err := postJSON(ctx, p.HTTPClient, p.endpoint, body, req, &resp); err != nil {
+		return fmt.Errorf("failed to publish to RabbitMQ: %w", err)
+	}
+	if !resp.Routed {
+		return fmt.Errorf("failed to publish to RabbitMQ: no queue is bound for routing key %q", msg.Subject)
+	}
+	return nil
+}
+
+func basicAuth(username, password string) string {
+	return base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+}
@@ -0,0 +1,85 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestForwardingService(t *testing.T) {
+	rule := `{"id": "fwd-1", "name": "support", "source": "*@support.example.com",
+		"destinations": ["a@team.com", "b@team.com"], "headers": {"mode": "rewrite", "from": "support@example.com"}, "enabled": true}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /forwarding-rules":
+			var req inboundgo.PostForwardingRulesRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Source != "*@support.example.com" || len(req.Destinations) != 2 || req.Headers.Mode != "rewrite" {
+				t.Errorf("Unexpected create request: %+v", req)
+			}
+			w.Write([]byte(rule))
+		case "GET /forwarding-rules":
+			if r.URL.Query().Get("source") != "*@support.example.com" {
+				t.Errorf("Unexpected query %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"data": [` + rule + `], "pagination": {"total": 1}}`))
+		case "GET /forwarding-rules/fwd-1":
+			w.Write([]byte(rule))
+		case "PUT /forwarding-rules/fwd-1":
+			var req map[string]any
+			json.NewDecoder(r.Body).Decode(&req)
+			if len(req) != 1 || len(req["destinations"].([]any)) != 1 {
+				t.Errorf("Expected partial update, got %v", req)
+			}
+			w.Write([]byte(`{"id": "fwd-1", "destinations": ["a@team.com"], "enabled": true}`))
+		case "DELETE /forwarding-rules/fwd-1":
+			w.Write([]byte(`{"message": "deleted"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+	svc := client.Forwarding()
+
+	created, err := svc.Create(ctx, &inboundgo.PostForwardingRulesRequest{
+		Name:         "support",
+		Source:       "*@support.example.com",
+		Destinations: []string{"a@team.com", "b@team.com"},
+		Headers:      &inboundgo.ForwardingHeaders{Mode: "rewrite", From: inboundgo.String("support@example.com")},
+	})
+	if err != nil || created.Error != "" || *created.Data.Headers.From != "support@example.com" {
+		t.Fatalf("Create failed: %v %+v", err, created)
+	}
+
+	list, err := svc.List(ctx, &inboundgo.GetForwardingRulesRequest{Source: "*@support.example.com"})
+	if err != nil || list.Error != "" || len(list.Data.Data) != 1 {
+		t.Fatalf("List failed: %v %+v", err, list)
+	}
+
+	got, err := svc.Get(ctx, "fwd-1")
+	if err != nil || got.Error != "" || len(got.Data.Destinations) != 2 {
+		t.Fatalf("Get failed: %v %+v", err, got)
+	}
+
+	updated, err := svc.Update(ctx, "fwd-1", &inboundgo.PutForwardingRuleByIDRequest{Destinations: []string{"a@team.com"}})
+	if err != nil || updated.Error != "" || len(updated.Data.Destinations) != 1 {
+		t.Fatalf("Update failed: %v %+v", err, updated)
+	}
+
+	deleted, err := svc.Delete(ctx, "fwd-1")
+	if err != nil || deleted.Error != "" || deleted.Data.Message != "deleted" {
+		t.Fatalf("Delete failed: %v %+v", err, deleted)
+	}
+}
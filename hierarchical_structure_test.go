@@ -220,7 +220,7 @@ func TestHierarchicalStructure(t *testing.T) {
 		}
 
 		_, err = domainService.Update(ctx, "test-id", &inboundgo.PutDomainByIDRequest{
-			IsCatchAllEnabled:  true,
+			IsCatchAllEnabled:  inboundgo.Bool(true),
 			CatchAllEndpointID: inboundgo.String("endpoint-id"),
 		})
 		if err != nil && !isNetworkError(err) {
@@ -0,0 +1,300 @@
+package imapbridge
+
+import (
+	"bytes"
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+const internalDateLayout = "02-Jan-2006 15:04:05 -0700"
+
+// fetchMacros expand the FETCH shorthand items. FULL is not supported
+// because BODY structure responses are not implemented.
+var fetchMacros = map[string][]string{
+	"ALL":  {"FLAGS", "INTERNALDATE", "RFC822.SIZE", "ENVELOPE"},
+	"FAST": {"FLAGS", "INTERNALDATE", "RFC822.SIZE"},
+}
+
+// fetchItem is a parsed FETCH data item
+type fetchItem struct {
+	name    string // UID, FLAGS, BODY, ...
+	section string // for BODY[...]: "", HEADER, TEXT, HEADER.FIELDS (...)
+	peek    bool
+	partial bool
+	offset  int
+	count   int
+}
+
+func (s *session) fetch(tag string, args []any, uid bool) {
+	if len(args) != 2 {
+		s.writeLine(tag + " BAD FETCH expects a sequence set and data items")
+		return
+	}
+	setArg, _ := args[0].(string)
+	set, err := parseSeqSet(setArg)
+	if err != nil {
+		s.writeLine(tag + " BAD " + err.Error())
+		return
+	}
+
+	var names []string
+	switch v := args[1].(type) {
+	case string:
+		if macro, ok := fetchMacros[strings.ToUpper(v)]; ok {
+			names = macro
+		} else {
+			names = []string{v}
+		}
+	case []any:
+		for _, it := range v {
+			name, ok := it.(string)
+			if !ok {
+				s.writeLine(tag + " BAD Invalid FETCH item")
+				return
+			}
+			names = append(names, name)
+		}
+	}
+
+	items := make([]fetchItem, 0, len(names)+1)
+	if uid {
+		items = append(items, fetchItem{name: "UID"})
+	}
+	for _, name := range names {
+		item, err := parseFetchItem(name)
+		if err != nil {
+			s.writeLine(tag + " BAD " + err.Error())
+			return
+		}
+		if uid && item.name == "UID" {
+			continue
+		}
+		items = append(items, item)
+	}
+
+	mbox := s.selected
+	max := uint32(len(mbox.messages))
+	for i, msg := range mbox.messages {
+		// Sequence numbers and UIDs coincide in this server
+		if !set.contains(uint32(i+1), max) {
+			continue
+		}
+		parts := make([]string, 0, len(items))
+		for _, item := range items {
+			part, err := s.fetchPart(i, msg, item)
+			if err != nil {
+				s.writeLine(tag + " NO " + err.Error())
+				return
+			}
+			parts = append(parts, part)
+		}
+		s.writeLine(fmt.Sprintf("* %d FETCH (%s)", i+1, strings.Join(parts, " ")))
+	}
+	s.writeLine(tag + " OK FETCH completed")
+}
+
+func parseFetchItem(name string) (fetchItem, error) {
+	upper := strings.ToUpper(name)
+	switch upper {
+	case "UID", "FLAGS", "INTERNALDATE", "RFC822.SIZE", "ENVELOPE", "RFC822", "RFC822.HEADER", "RFC822.TEXT":
+		return fetchItem{name: upper}, nil
+	}
+
+	item := fetchItem{name: "BODY"}
+	var rest string
+	switch {
+	case strings.HasPrefix(upper, "BODY.PEEK["):
+		item.peek = true
+		rest = upper[len("BODY.PEEK["):]
+	case strings.HasPrefix(upper, "BODY["):
+		rest = upper[len("BODY["):]
+	default:
+		return item, fmt.Errorf("unsupported FETCH item %s", name)
+	}
+
+	end := strings.LastIndexByte(rest, ']')
+	if end < 0 {
+		return item, fmt.Errorf("invalid FETCH item %s", name)
+	}
+	item.section = rest[:end]
+	switch {
+	case item.section == "", item.section == "HEADER", item.section == "TEXT":
+	case strings.HasPrefix(item.section, "HEADER.FIELDS"):
+	default:
+		return item, fmt.Errorf("unsupported body section %s", item.section)
+	}
+
+	if partial := rest[end+1:]; partial != "" {
+		if !strings.HasPrefix(partial, "<") || !strings.HasSuffix(partial, ">") {
+			return item, fmt.Errorf("invalid FETCH item %s", name)
+		}
+		offset, count, ok := strings.Cut(partial[1:len(partial)-1], ".")
+		o, err1 := strconv.Atoi(offset)
+		c, err2 := strconv.Atoi(count)
+		if !ok || err1 != nil || err2 != nil || o < 0 || c <= 0 {
+			return item, fmt.Errorf("invalid partial range %s", partial)
+		}
+		item.partial, item.offset, item.count = true, o, c
+	}
+	return item, nil
+}
+
+func (s *session) fetchPart(i int, msg inboundgo.EmailItem, item fetchItem) (string, error) {
+	switch item.name {
+	case "UID":
+		return fmt.Sprintf("UID %d", i+1), nil
+	case "FLAGS":
+		if msg.IsRead {
+			return `FLAGS (\Seen)`, nil
+		}
+		return "FLAGS ()", nil
+	case "INTERNALDATE":
+		return fmt.Sprintf("INTERNALDATE %q", msg.ReceivedAt.Format(internalDateLayout)), nil
+	case "ENVELOPE":
+		return "ENVELOPE " + envelope(msg), nil
+	}
+
+	raw, err := s.rawMessage(msg.ID)
+	if err != nil {
+		return "", err
+	}
+	header, text := splitMessage(raw)
+
+	switch item.name {
+	case "RFC822.SIZE":
+		return fmt.Sprintf("RFC822.SIZE %d", len(raw)), nil
+	case "RFC822":
+		return "RFC822 " + literal(string(raw)), nil
+	case "RFC822.HEADER":
+		return "RFC822.HEADER " + literal(string(header)), nil
+	case "RFC822.TEXT":
+		return "RFC822.TEXT " + literal(string(text)), nil
+	}
+
+	var data []byte
+	switch {
+	case item.section == "":
+		data = raw
+	case item.section == "HEADER":
+		data = header
+	case item.section == "TEXT":
+		data = text
+	default:
+		data = filterHeader(header, item.section)
+	}
+
+	name := "BODY[" + item.section + "]"
+	if item.partial {
+		name += fmt.Sprintf("<%d>", item.offset)
+		if item.offset >= len(data) {
+			data = nil
+		} else {
+			data = data[item.offset:]
+			if len(data) > item.count {
+				data = data[:item.count]
+			}
+		}
+	}
+	return name + " " + literal(string(data)), nil
+}
+
+// rawMessage returns the RFC 5322 form of an email, downloading it once per
+// selected mailbox
+func (s *session) rawMessage(id string) ([]byte, error) {
+	if raw, ok := s.selected.raw[id]; ok {
+		return raw, nil
+	}
+	var buf bytes.Buffer
+	if err := s.server.client.Mail().ExportEML(s.ctx, id, &buf); err != nil {
+		return nil, err
+	}
+	s.selected.raw[id] = buf.Bytes()
+	return buf.Bytes(), nil
+}
+
+// splitMessage splits a message into its header (including the blank
+// separator line) and body
+func splitMessage(raw []byte) (header, text []byte) {
+	if i := bytes.Index(raw, []byte("\r\n\r\n")); i >= 0 {
+		return raw[:i+4], raw[i+4:]
+	}
+	return raw, nil
+}
+
+// filterHeader implements HEADER.FIELDS (...) and HEADER.FIELDS.NOT (...)
+func filterHeader(header []byte, section string) []byte {
+	not := strings.HasPrefix(section, "HEADER.FIELDS.NOT")
+	wanted := make(map[string]bool)
+	if open, close := strings.IndexByte(section, '('), strings.LastIndexByte(section, ')'); open >= 0 && close > open {
+		for _, f := range strings.Fields(section[open+1 : close]) {
+			wanted[strings.ToUpper(strings.Trim(f, `"`))] = true
+		}
+	}
+
+	var out bytes.Buffer
+	keep := false
+	for _, line := range strings.SplitAfter(string(header), "\r\n") {
+		if line == "\r\n" || line == "" {
+			continue
+		}
+		if line[0] != ' ' && line[0] != '\t' {
+			name, _, _ := strings.Cut(line, ":")
+			keep = wanted[strings.ToUpper(strings.TrimSpace(name))] != not
+		}
+		if keep {
+			out.WriteString(line)
+		}
+	}
+	out.WriteString("\r\n")
+	return out.Bytes()
+}
+
+// envelope builds the ENVELOPE structure from listing metadata, so clients
+// can display message lists without downloading every message
+func envelope(msg inboundgo.EmailItem) string {
+	from := addressList(msg.From, msg.FromName)
+	messageID := ""
+	if msg.MessageID != nil {
+		messageID = *msg.MessageID
+	}
+	fields := []string{
+		nstring(msg.ReceivedAt.Format(time.RFC1123Z)),
+		nstring(msg.Subject),
+		from,
+		from,
+		from,
+		addressList(msg.Recipient, nil),
+		"NIL",
+		"NIL",
+		"NIL",
+		nstring(messageID),
+	}
+	return "(" + strings.Join(fields, " ") + ")"
+}
+
+func addressList(value string, name *string) string {
+	if value == "" {
+		return "NIL"
+	}
+	addrs, err := mail.ParseAddressList(value)
+	if err != nil {
+		addrs = []*mail.Address{{Address: value}}
+	}
+	if name != nil && *name != "" && len(addrs) == 1 && addrs[0].Name == "" {
+		addrs[0].Name = *name
+	}
+	parts := make([]string, 0, len(addrs))
+	for _, a := range addrs {
+		mailbox, host := a.Address, ""
+		if at := strings.LastIndexByte(a.Address, '@'); at >= 0 {
+			mailbox, host = a.Address[:at], a.Address[at+1:]
+		}
+		parts = append(parts, fmt.Sprintf("(%s NIL %s %s)", nstring(a.Name), nstring(mailbox), nstring(host)))
+	}
+	return "(" + strings.Join(parts, "") + ")"
+}
@@ -0,0 +1,279 @@
+package imapbridge
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxLineLength    = 64 << 10
+	maxLiteralLength = 64 << 10
+)
+
+var errLineTooLong = errors.New("command line too long")
+
+// syntaxError reports a malformed command; the connection stays usable
+type syntaxError string
+
+func (e syntaxError) Error() string { return string(e) }
+
+// commandReader reads IMAP command lines, resolving literals into string
+// arguments. Parsed arguments are strings (atoms, quoted strings, and
+// literals) or []any for parenthesized lists.
+type commandReader struct {
+	r    *bufio.Reader
+	line []byte
+	pos  int
+	// cont asks the client to send a synchronizing literal
+	cont func() error
+}
+
+// readCommand reads one complete command, including any literals
+func (p *commandReader) readCommand() ([]any, error) {
+	if err := p.nextLine(); err != nil {
+		return nil, err
+	}
+	return p.parseFields(0)
+}
+
+func (p *commandReader) nextLine() error {
+	var line []byte
+	for {
+		chunk, err := p.r.ReadSlice('\n')
+		line = append(line, chunk...)
+		if len(line) > maxLineLength {
+			return errLineTooLong
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		break
+	}
+	p.line = []byte(strings.TrimRight(string(line), "\r\n"))
+	p.pos = 0
+	return nil
+}
+
+func (p *commandReader) parseFields(closing byte) ([]any, error) {
+	fields := []any{}
+	for {
+		for p.pos < len(p.line) && p.line[p.pos] == ' ' {
+			p.pos++
+		}
+		if p.pos >= len(p.line) {
+			if closing != 0 {
+				return nil, syntaxError("unterminated list")
+			}
+			return fields, nil
+		}
+
+		switch c := p.line[p.pos]; c {
+		case ')':
+			if closing != ')' {
+				return nil, syntaxError("unexpected ')'")
+			}
+			p.pos++
+			return fields, nil
+		case '(':
+			p.pos++
+			list, err := p.parseFields(')')
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, list)
+		case '"':
+			s, err := p.parseQuoted()
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, s)
+		case '{':
+			s, err := p.parseLiteral()
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, s)
+		default:
+			fields = append(fields, p.parseAtom())
+		}
+	}
+}
+
+func (p *commandReader) parseQuoted() (string, error) {
+	var b strings.Builder
+	p.pos++
+	for p.pos < len(p.line) {
+		c := p.line[p.pos]
+		p.pos++
+		switch c {
+		case '\\':
+			if p.pos >= len(p.line) {
+				return "", syntaxError("unterminated quoted string")
+			}
+			b.WriteByte(p.line[p.pos])
+			p.pos++
+		case '"':
+			return b.String(), nil
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", syntaxError("unterminated quoted string")
+}
+
+// parseLiteral reads a {n} or {n+} literal. The literal must end the line;
+// parsing continues on the line that follows the literal data.
+func (p *commandReader) parseLiteral() (string, error) {
+	end := strings.IndexByte(string(p.line[p.pos:]), '}')
+	if end < 0 || p.pos+end != len(p.line)-1 {
+		return "", syntaxError("malformed literal")
+	}
+	spec := string(p.line[p.pos+1 : p.pos+end])
+	nonSync := strings.HasSuffix(spec, "+")
+	n, err := strconv.Atoi(strings.TrimSuffix(spec, "+"))
+	if err != nil || n < 0 {
+		return "", syntaxError("malformed literal")
+	}
+	if n > maxLiteralLength {
+		return "", syntaxError("literal too large")
+	}
+	if !nonSync && p.cont != nil {
+		if err := p.cont(); err != nil {
+			return "", err
+		}
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(p.r, data); err != nil {
+		return "", err
+	}
+	if err := p.nextLine(); err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// parseAtom reads up to the next space or parenthesis. Bracketed sections
+// such as BODY[HEADER.FIELDS (From To)] are kept as part of the atom.
+func (p *commandReader) parseAtom() string {
+	start := p.pos
+	depth := 0
+	for p.pos < len(p.line) {
+		c := p.line[p.pos]
+		if depth == 0 && (c == ' ' || c == '(' || c == ')') {
+			break
+		}
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			if depth > 0 {
+				depth--
+			}
+		}
+		p.pos++
+	}
+	return string(p.line[start:p.pos])
+}
+
+// quoteString encodes s as an IMAP quoted string, or as a literal when it
+// contains characters that cannot be quoted
+func quoteString(s string) string {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c == '\r' || c == '\n' || c == 0 || c >= 0x80 {
+			return literal(s)
+		}
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// nstring encodes s as a quoted string, or NIL when empty
+func nstring(s string) string {
+	if s == "" {
+		return "NIL"
+	}
+	return quoteString(s)
+}
+
+func literal(s string) string {
+	return fmt.Sprintf("{%d}\r\n%s", len(s), s)
+}
+
+// seqRange is an inclusive range of sequence numbers or UIDs. Zero stands
+// for "*", the largest number in use.
+type seqRange struct {
+	start, stop uint32
+}
+
+type seqSet []seqRange
+
+// parseSeqSet parses sets such as "1:4,7,9:*"
+func parseSeqSet(s string) (seqSet, error) {
+	var set seqSet
+	for _, part := range strings.Split(s, ",") {
+		lo, hi, isRange := strings.Cut(part, ":")
+		start, err := parseSeqNumber(lo)
+		if err != nil {
+			return nil, err
+		}
+		stop := start
+		if isRange {
+			if stop, err = parseSeqNumber(hi); err != nil {
+				return nil, err
+			}
+		}
+		set = append(set, seqRange{start: start, stop: stop})
+	}
+	return set, nil
+}
+
+func parseSeqNumber(s string) (uint32, error) {
+	if s == "*" {
+		return 0, nil
+	}
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil || n == 0 {
+		return 0, fmt.Errorf("invalid sequence number %q", s)
+	}
+	return uint32(n), nil
+}
+
+// contains reports whether n is in the set, resolving "*" to max
+func (s seqSet) contains(n, max uint32) bool {
+	for _, r := range s {
+		start, stop := r.start, r.stop
+		if start == 0 {
+			start = max
+		}
+		if stop == 0 {
+			stop = max
+		}
+		if start > stop {
+			start, stop = stop, start
+		}
+		if n >= start && n <= stop {
+			return true
+		}
+	}
+	return false
+}
+
+// isSeqSet reports whether s looks like a sequence set rather than a keyword
+func isSeqSet(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if !(c >= '0' && c <= '9') && c != '*' && c != ':' && c != ',' {
+			return false
+		}
+	}
+	return true
+}
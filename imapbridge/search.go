@@ -0,0 +1,205 @@
+package imapbridge
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+const searchDateLayout = "2-Jan-2006"
+
+// matcher reports whether the message with sequence number seq matches
+type matcher func(seq uint32, msg inboundgo.EmailItem) bool
+
+func (s *session) search(tag string, args []any, uid bool) {
+	if len(args) >= 2 {
+		if kw, _ := args[0].(string); strings.EqualFold(kw, "CHARSET") {
+			charset, _ := args[1].(string)
+			if !strings.EqualFold(charset, "UTF-8") && !strings.EqualFold(charset, "US-ASCII") {
+				s.writeLine(tag + " NO [BADCHARSET (UTF-8 US-ASCII)] Unsupported charset")
+				return
+			}
+			args = args[2:]
+		}
+	}
+	if len(args) == 0 {
+		s.writeLine(tag + " BAD SEARCH expects search keys")
+		return
+	}
+
+	max := uint32(len(s.selected.messages))
+	match, err := parseSearchKeys(args, max)
+	if err != nil {
+		s.writeLine(tag + " BAD " + err.Error())
+		return
+	}
+
+	results := []string{}
+	for i, msg := range s.selected.messages {
+		if match(uint32(i+1), msg) {
+			results = append(results, strconv.Itoa(i+1))
+		}
+	}
+	line := "* SEARCH"
+	if len(results) > 0 {
+		line += " " + strings.Join(results, " ")
+	}
+	s.writeLine(line)
+	s.writeLine(tag + " OK SEARCH completed")
+}
+
+// parseSearchKeys parses a sequence of search keys that must all match
+func parseSearchKeys(args []any, max uint32) (matcher, error) {
+	var matchers []matcher
+	for i := 0; i < len(args); {
+		m, next, err := parseSearchKey(args, i, max)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+		i = next
+	}
+	return func(seq uint32, msg inboundgo.EmailItem) bool {
+		for _, m := range matchers {
+			if !m(seq, msg) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// parseSearchKey parses the key at args[i] and returns the index after it
+func parseSearchKey(args []any, i int, max uint32) (matcher, int, error) {
+	if list, ok := args[i].([]any); ok {
+		m, err := parseSearchKeys(list, max)
+		return m, i + 1, err
+	}
+	key, _ := args[i].(string)
+	i++
+
+	stringArg := func() (string, error) {
+		if i >= len(args) {
+			return "", fmt.Errorf("%s expects an argument", key)
+		}
+		v, ok := args[i].(string)
+		if !ok {
+			return "", fmt.Errorf("%s expects a string", key)
+		}
+		i++
+		return v, nil
+	}
+	dateArg := func() (time.Time, error) {
+		v, err := stringArg()
+		if err != nil {
+			return time.Time{}, err
+		}
+		d, err := time.Parse(searchDateLayout, v)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q", v)
+		}
+		return d, nil
+	}
+	all := func(uint32, inboundgo.EmailItem) bool { return true }
+	none := func(uint32, inboundgo.EmailItem) bool { return false }
+
+	switch upper := strings.ToUpper(key); upper {
+	case "ALL", "OLD", "UNANSWERED", "UNDELETED", "UNDRAFT", "UNFLAGGED":
+		return all, i, nil
+	case "NEW", "RECENT", "ANSWERED", "DELETED", "DRAFT", "FLAGGED":
+		return none, i, nil
+	case "SEEN":
+		return func(_ uint32, m inboundgo.EmailItem) bool { return m.IsRead }, i, nil
+	case "UNSEEN":
+		return func(_ uint32, m inboundgo.EmailItem) bool { return !m.IsRead }, i, nil
+	case "FROM", "TO", "SUBJECT", "BODY", "TEXT":
+		v, err := stringArg()
+		if err != nil {
+			return nil, i, err
+		}
+		needle := strings.ToLower(v)
+		return func(_ uint32, m inboundgo.EmailItem) bool {
+			var haystack string
+			switch upper {
+			case "FROM":
+				haystack = m.From
+				if m.FromName != nil {
+					haystack += " " + *m.FromName
+				}
+			case "TO":
+				haystack = m.Recipient
+			case "SUBJECT":
+				haystack = m.Subject
+			case "BODY":
+				haystack = m.Preview
+			default:
+				haystack = strings.Join([]string{m.From, m.Recipient, m.Subject, m.Preview}, "\n")
+			}
+			return strings.Contains(strings.ToLower(haystack), needle)
+		}, i, nil
+	case "SINCE", "BEFORE", "ON", "SENTSINCE", "SENTBEFORE", "SENTON":
+		d, err := dateArg()
+		if err != nil {
+			return nil, i, err
+		}
+		return func(_ uint32, m inboundgo.EmailItem) bool {
+			y, mo, day := m.ReceivedAt.Date()
+			received := time.Date(y, mo, day, 0, 0, 0, 0, time.UTC)
+			switch strings.TrimPrefix(upper, "SENT") {
+			case "SINCE":
+				return !received.Before(d)
+			case "BEFORE":
+				return received.Before(d)
+			default:
+				return received.Equal(d)
+			}
+		}, i, nil
+	case "UID":
+		v, err := stringArg()
+		if err != nil {
+			return nil, i, err
+		}
+		set, err := parseSeqSet(v)
+		if err != nil {
+			return nil, i, err
+		}
+		return func(seq uint32, _ inboundgo.EmailItem) bool { return set.contains(seq, max) }, i, nil
+	case "NOT":
+		if i >= len(args) {
+			return nil, i, fmt.Errorf("NOT expects a search key")
+		}
+		m, next, err := parseSearchKey(args, i, max)
+		if err != nil {
+			return nil, next, err
+		}
+		return func(seq uint32, msg inboundgo.EmailItem) bool { return !m(seq, msg) }, next, nil
+	case "OR":
+		if i+1 >= len(args) {
+			return nil, i, fmt.Errorf("OR expects two search keys")
+		}
+		a, next, err := parseSearchKey(args, i, max)
+		if err != nil {
+			return nil, next, err
+		}
+		if next >= len(args) {
+			return nil, next, fmt.Errorf("OR expects two search keys")
+		}
+		b, next, err := parseSearchKey(args, next, max)
+		if err != nil {
+			return nil, next, err
+		}
+		return func(seq uint32, msg inboundgo.EmailItem) bool { return a(seq, msg) || b(seq, msg) }, next, nil
+	default:
+		if isSeqSet(key) {
+			set, err := parseSeqSet(key)
+			if err != nil {
+				return nil, i, err
+			}
+			return func(seq uint32, _ inboundgo.EmailItem) bool { return set.contains(seq, max) }, i, nil
+		}
+		return nil, i, fmt.Errorf("unsupported search key %s", key)
+	}
+}
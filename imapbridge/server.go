@@ -0,0 +1,510 @@
+// Package imapbridge is an experimental, read-only IMAP4rev1 server backed
+// by the Inbound API, so existing mail clients and tools can browse an
+// Inbound mailbox.
+//
+// Two mailboxes are exposed: INBOX (received emails that are not archived)
+// and Archive. Only a subset of the protocol is implemented: LOGIN, LIST,
+// LSUB, STATUS, SELECT, EXAMINE, FETCH, SEARCH, their UID variants, CLOSE,
+// UNSELECT, NOOP, CHECK, and LOGOUT. Commands that modify a mailbox are
+// rejected. UIDs are assigned per session in receive order, so UIDVALIDITY
+// changes on every SELECT and clients resynchronize instead of relying on
+// cached UIDs.
+//
+// LOGIN transmits the password in clear text. Listen on localhost only, or
+// pass a TLS listener from crypto/tls to Serve.
+package imapbridge
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+// mailboxNames are the mailboxes exposed to clients, in LIST order
+var mailboxNames = []string{"INBOX", "Archive"}
+
+// Server serves an Inbound mailbox over IMAP
+type Server struct {
+	client *inboundgo.Inbound
+
+	// Username and Password are the credentials accepted by LOGIN. When
+	// both are empty, any credentials are accepted.
+	Username string
+	Password string
+	// ErrorLog receives connection errors; the standard logger is used when nil.
+	ErrorLog *log.Logger
+
+	mu        sync.Mutex
+	listeners map[net.Listener]struct{}
+	conns     map[net.Conn]struct{}
+	closed    bool
+}
+
+// NewServer creates an IMAP server that reads mail through client
+func NewServer(client *inboundgo.Inbound) *Server {
+	return &Server{
+		client:    client,
+		listeners: make(map[net.Listener]struct{}),
+		conns:     make(map[net.Conn]struct{}),
+	}
+}
+
+// ListenAndServe listens on the TCP address addr and serves connections
+func (s *Server) ListenAndServe(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(l)
+}
+
+// Serve accepts connections on l until Close is called
+func (s *Server) Serve(l net.Listener) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		l.Close()
+		return net.ErrClosed
+	}
+	s.listeners[l] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.listeners, l)
+		s.mu.Unlock()
+	}()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+			if closed {
+				return net.ErrClosed
+			}
+			return err
+		}
+		go s.serveConn(conn)
+	}
+}
+
+// Close stops all listeners and closes open connections
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	for l := range s.listeners {
+		l.Close()
+	}
+	for c := range s.conns {
+		c.Close()
+	}
+	return nil
+}
+
+func (s *Server) logf(format string, args ...any) {
+	if s.ErrorLog != nil {
+		s.ErrorLog.Printf(format, args...)
+	} else {
+		log.Printf(format, args...)
+	}
+}
+
+func (s *Server) serveConn(conn net.Conn) {
+	s.mu.Lock()
+	s.conns[conn] = struct{}{}
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		conn.Close()
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+	}()
+
+	sess := &session{
+		server: s,
+		ctx:    ctx,
+		w:      bufio.NewWriter(conn),
+	}
+	sess.r = &commandReader{
+		r: bufio.NewReader(conn),
+		cont: func() error {
+			sess.writeLine("+ Ready for literal data")
+			return sess.w.Flush()
+		},
+	}
+	if err := sess.serve(); err != nil && !errors.Is(err, net.ErrClosed) {
+		s.logf("imapbridge: %v", err)
+	}
+}
+
+// mailbox is the state of a selected mailbox. Message sequence numbers and
+// UIDs are both the index into messages plus one.
+type mailbox struct {
+	name        string
+	messages    []inboundgo.EmailItem
+	uidValidity uint32
+	raw         map[string][]byte
+}
+
+type session struct {
+	server        *Server
+	ctx           context.Context
+	r             *commandReader
+	w             *bufio.Writer
+	authenticated bool
+	selected      *mailbox
+	loggedOut     bool
+}
+
+func (s *session) serve() error {
+	s.writeLine("* OK [CAPABILITY IMAP4rev1] Inbound IMAP bridge ready")
+	if err := s.w.Flush(); err != nil {
+		return err
+	}
+
+	for !s.loggedOut {
+		fields, err := s.r.readCommand()
+		var syntaxErr syntaxError
+		if errors.As(err, &syntaxErr) {
+			s.writeLine("* BAD " + syntaxErr.Error())
+			if err := s.w.Flush(); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			if err == errLineTooLong {
+				s.writeLine("* BYE Command line too long")
+				s.w.Flush()
+			}
+			if errors.Is(err, net.ErrClosed) || errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		tag, ok := fields[0].(string)
+		if !ok || tag == "" {
+			s.writeLine("* BAD Missing command tag")
+		} else if len(fields) < 2 {
+			s.writeLine(tag + " BAD Missing command")
+		} else if name, ok := fields[1].(string); !ok {
+			s.writeLine(tag + " BAD Invalid command")
+		} else {
+			s.handle(tag, strings.ToUpper(name), fields[2:])
+		}
+		if err := s.w.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *session) writeLine(line string) {
+	s.w.WriteString(line)
+	s.w.WriteString("\r\n")
+}
+
+func (s *session) handle(tag, name string, args []any) {
+	switch name {
+	case "CAPABILITY":
+		s.writeLine("* CAPABILITY IMAP4rev1")
+		s.writeLine(tag + " OK CAPABILITY completed")
+		return
+	case "NOOP", "CHECK":
+		s.writeLine(tag + " OK " + name + " completed")
+		return
+	case "LOGOUT":
+		s.writeLine("* BYE Logging out")
+		s.writeLine(tag + " OK LOGOUT completed")
+		s.loggedOut = true
+		return
+	case "LOGIN":
+		s.login(tag, args)
+		return
+	case "AUTHENTICATE":
+		s.writeLine(tag + " NO Only LOGIN is supported")
+		return
+	}
+
+	if !s.authenticated {
+		s.writeLine(tag + " NO Not authenticated")
+		return
+	}
+
+	switch name {
+	case "LIST", "LSUB":
+		s.list(tag, name, args)
+	case "STATUS":
+		s.status(tag, args)
+	case "SELECT", "EXAMINE":
+		s.selectMailbox(tag, name, args)
+	case "CLOSE", "UNSELECT":
+		if s.selected == nil {
+			s.writeLine(tag + " NO No mailbox selected")
+			return
+		}
+		s.selected = nil
+		s.writeLine(tag + " OK " + name + " completed")
+	case "FETCH", "SEARCH":
+		s.selectedCommand(tag, name, args, false)
+	case "UID":
+		if len(args) == 0 {
+			s.writeLine(tag + " BAD Missing UID command")
+			return
+		}
+		sub, _ := args[0].(string)
+		sub = strings.ToUpper(sub)
+		if sub != "FETCH" && sub != "SEARCH" {
+			s.writeLine(tag + " NO Mailbox is read-only")
+			return
+		}
+		s.selectedCommand(tag, sub, args[1:], true)
+	case "STORE", "COPY", "MOVE", "EXPUNGE", "APPEND", "CREATE", "DELETE", "RENAME", "SUBSCRIBE", "UNSUBSCRIBE":
+		s.writeLine(tag + " NO Mailbox is read-only")
+	default:
+		s.writeLine(tag + " BAD Unknown command")
+	}
+}
+
+func (s *session) selectedCommand(tag, name string, args []any, uid bool) {
+	if s.selected == nil {
+		s.writeLine(tag + " NO No mailbox selected")
+		return
+	}
+	if name == "FETCH" {
+		s.fetch(tag, args, uid)
+	} else {
+		s.search(tag, args, uid)
+	}
+}
+
+func (s *session) login(tag string, args []any) {
+	if len(args) != 2 {
+		s.writeLine(tag + " BAD LOGIN expects a username and password")
+		return
+	}
+	user, _ := args[0].(string)
+	pass, _ := args[1].(string)
+	srv := s.server
+	if srv.Username != "" || srv.Password != "" {
+		userOK := subtle.ConstantTimeCompare([]byte(user), []byte(srv.Username)) == 1
+		passOK := subtle.ConstantTimeCompare([]byte(pass), []byte(srv.Password)) == 1
+		if !userOK || !passOK {
+			s.writeLine(tag + " NO [AUTHENTICATIONFAILED] Invalid credentials")
+			return
+		}
+	}
+	s.authenticated = true
+	s.writeLine(tag + " OK LOGIN completed")
+}
+
+func (s *session) list(tag, name string, args []any) {
+	if len(args) != 2 {
+		s.writeLine(tag + " BAD " + name + " expects a reference and a mailbox pattern")
+		return
+	}
+	pattern, _ := args[1].(string)
+	if pattern == "" {
+		s.writeLine(`* ` + name + ` (\Noselect) "/" ""`)
+		s.writeLine(tag + " OK " + name + " completed")
+		return
+	}
+	for _, mbox := range mailboxNames {
+		if matchMailbox(pattern, mbox) {
+			s.writeLine(fmt.Sprintf(`* %s (\HasNoChildren) "/" %s`, name, quoteString(mbox)))
+		}
+	}
+	s.writeLine(tag + " OK " + name + " completed")
+}
+
+func (s *session) status(tag string, args []any) {
+	if len(args) != 2 {
+		s.writeLine(tag + " BAD STATUS expects a mailbox and item list")
+		return
+	}
+	name, _ := args[0].(string)
+	items, ok := args[1].([]any)
+	if !ok {
+		s.writeLine(tag + " BAD STATUS items must be a list")
+		return
+	}
+	mbox, err := s.load(name)
+	if err != nil {
+		s.writeLine(tag + " NO " + err.Error())
+		return
+	}
+
+	var parts []string
+	for _, it := range items {
+		item, _ := it.(string)
+		switch strings.ToUpper(item) {
+		case "MESSAGES":
+			parts = append(parts, fmt.Sprintf("MESSAGES %d", len(mbox.messages)))
+		case "RECENT":
+			parts = append(parts, "RECENT 0")
+		case "UIDNEXT":
+			parts = append(parts, fmt.Sprintf("UIDNEXT %d", len(mbox.messages)+1))
+		case "UIDVALIDITY":
+			parts = append(parts, fmt.Sprintf("UIDVALIDITY %d", mbox.uidValidity))
+		case "UNSEEN":
+			parts = append(parts, fmt.Sprintf("UNSEEN %d", countUnseen(mbox.messages)))
+		default:
+			s.writeLine(tag + " BAD Unknown STATUS item " + item)
+			return
+		}
+	}
+	s.writeLine(fmt.Sprintf("* STATUS %s (%s)", quoteString(mbox.name), strings.Join(parts, " ")))
+	s.writeLine(tag + " OK STATUS completed")
+}
+
+func (s *session) selectMailbox(tag, name string, args []any) {
+	s.selected = nil
+	if len(args) != 1 {
+		s.writeLine(tag + " BAD " + name + " expects a mailbox name")
+		return
+	}
+	mboxName, _ := args[0].(string)
+	mbox, err := s.load(mboxName)
+	if err != nil {
+		s.writeLine(tag + " NO " + err.Error())
+		return
+	}
+	s.selected = mbox
+
+	s.writeLine(`* FLAGS (\Seen)`)
+	s.writeLine(fmt.Sprintf("* %d EXISTS", len(mbox.messages)))
+	s.writeLine("* 0 RECENT")
+	for i, m := range mbox.messages {
+		if !m.IsRead {
+			s.writeLine(fmt.Sprintf("* OK [UNSEEN %d] First unseen message", i+1))
+			break
+		}
+	}
+	s.writeLine(fmt.Sprintf("* OK [UIDVALIDITY %d] UIDs valid", mbox.uidValidity))
+	s.writeLine(fmt.Sprintf("* OK [UIDNEXT %d] Predicted next UID", len(mbox.messages)+1))
+	s.writeLine("* OK [PERMANENTFLAGS ()] No permanent flags permitted")
+	s.writeLine(tag + " OK [READ-ONLY] " + name + " completed")
+}
+
+// load fetches every message in the named mailbox, oldest first
+func (s *session) load(name string) (*mailbox, error) {
+	canonical := ""
+	for _, mbox := range mailboxNames {
+		if strings.EqualFold(name, mbox) && (mbox == "INBOX" || name == mbox) {
+			canonical = mbox
+		}
+	}
+	if canonical == "" {
+		return nil, errors.New("[NONEXISTENT] No such mailbox")
+	}
+	archived := canonical == "Archive"
+
+	filter := &inboundgo.GetMailRequest{Limit: inboundgo.Int(100)}
+	if archived {
+		filter.IncludeArchived = inboundgo.Bool(true)
+	}
+	var messages []inboundgo.EmailItem
+	offset := 0
+	for {
+		filter.Offset = inboundgo.Int(offset)
+		resp, err := s.server.client.Mail().List(s.ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Error != "" {
+			return nil, fmt.Errorf("failed to list mail: %s", resp.Error)
+		}
+		if resp.Data == nil {
+			break
+		}
+		for _, item := range resp.Data.Emails {
+			if item.IsArchived == archived {
+				messages = append(messages, item)
+			}
+		}
+		offset += len(resp.Data.Emails)
+		if len(resp.Data.Emails) == 0 || (!resp.Data.Pagination.HasMore && offset >= resp.Data.Pagination.Total) {
+			break
+		}
+	}
+
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].ReceivedAt.Before(messages[j].ReceivedAt)
+	})
+	return &mailbox{
+		name:        canonical,
+		messages:    messages,
+		uidValidity: uint32(time.Now().Unix()),
+		raw:         make(map[string][]byte),
+	}, nil
+}
+
+func countUnseen(messages []inboundgo.EmailItem) int {
+	n := 0
+	for _, m := range messages {
+		if !m.IsRead {
+			n++
+		}
+	}
+	return n
+}
+
+// matchMailbox matches name against a LIST pattern, where "*" and "%"
+// match any run of characters (there is no hierarchy to stop "%" at).
+// INBOX is matched case-insensitively.
+func matchMailbox(pattern, name string) bool {
+	if name == "INBOX" {
+		pattern = strings.ToUpper(pattern)
+	}
+	return matchWildcard(pattern, name)
+}
+
+// matchWildcard matches iteratively, backtracking only to the most recent
+// wildcard, so it runs in O(len(pattern)*len(name)) however many wildcards
+// the pattern holds.
+func matchWildcard(pattern, name string) bool {
+	p, n := 0, 0
+	star, mark := -1, 0
+	for n < len(name) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '*' || pattern[p] == '%'):
+			// A run of wildcards matches the same as one
+			for p < len(pattern) && (pattern[p] == '*' || pattern[p] == '%') {
+				p++
+			}
+			star, mark = p, n
+		case p < len(pattern) && pattern[p] == name[n]:
+			p++
+			n++
+		case star >= 0:
+			mark++
+			p, n = star, mark
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && (pattern[p] == '*' || pattern[p] == '%') {
+		p++
+	}
+	return p == len(pattern)
+}
@@ -0,0 +1,241 @@
+package imapbridge_test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+	"github.com/inboundemail/inbound-golang-sdk/imapbridge"
+)
+
+func newAPIServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/mail":
+			w.Write([]byte(`{"emails": [
+				{"id": "mail-2", "subject": "Second", "from": "Bob <bob@example.com>", "recipient": "me@example.com", "receivedAt": "2025-01-03T10:00:00Z", "isRead": false},
+				{"id": "mail-1", "subject": "First", "from": "alice@example.com", "recipient": "me@example.com", "receivedAt": "2025-01-02T10:00:00Z", "isRead": true},
+				{"id": "mail-3", "subject": "Old", "from": "carol@example.com", "receivedAt": "2024-12-01T10:00:00Z", "isArchived": true}
+			], "pagination": {"limit": 100, "offset": 0, "total": 3}}`))
+		case strings.HasPrefix(r.URL.Path, "/mail/"):
+			id := strings.TrimPrefix(r.URL.Path, "/mail/")
+			fmt.Fprintf(w, `{"id": %q, "subject": "Body of %s", "from": "alice@example.com", "to": "me@example.com", "textBody": "Hello from %s", "receivedAt": "2025-01-02T10:00:00Z"}`, id, id, id)
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+type imapClient struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+	n    int
+}
+
+var literalSuffix = regexp.MustCompile(`\{(\d+)\}$`)
+
+// cmd sends a command and returns the untagged responses and the tagged status line
+func (c *imapClient) cmd(command string) ([]string, string) {
+	c.t.Helper()
+	c.n++
+	tag := fmt.Sprintf("a%d", c.n)
+	fmt.Fprintf(c.conn, "%s %s\r\n", tag, command)
+
+	var untagged []string
+	for {
+		line := c.readLine()
+		if strings.HasPrefix(line, tag+" ") {
+			return untagged, strings.TrimPrefix(line, tag+" ")
+		}
+		untagged = append(untagged, line)
+	}
+}
+
+// readLine reads a response line, inlining any literals it contains
+func (c *imapClient) readLine() string {
+	c.t.Helper()
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		c.t.Fatalf("Failed to read response: %v", err)
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if m := literalSuffix.FindStringSubmatch(line); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		data := make([]byte, n)
+		if _, err := io.ReadFull(c.r, data); err != nil {
+			c.t.Fatalf("Failed to read literal: %v", err)
+		}
+		line = line[:len(line)-len(m[0])] + string(data) + c.readLine()
+	}
+	return line
+}
+
+func startBridge(t *testing.T) *imapClient {
+	api := newAPIServer(t)
+	t.Cleanup(api.Close)
+
+	client, err := inboundgo.NewClient("test-api-key", api.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	srv := imapbridge.NewServer(client)
+	srv.Username = "user"
+	srv.Password = "secret"
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	go srv.Serve(l)
+	t.Cleanup(func() { srv.Close() })
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	c := &imapClient{t: t, conn: conn, r: bufio.NewReader(conn)}
+	if greeting := c.readLine(); !strings.HasPrefix(greeting, "* OK") {
+		t.Fatalf("Unexpected greeting: %s", greeting)
+	}
+	return c
+}
+
+func TestLoginRequired(t *testing.T) {
+	c := startBridge(t)
+
+	if _, status := c.cmd("SELECT INBOX"); !strings.HasPrefix(status, "NO") {
+		t.Errorf("Expected SELECT before LOGIN to fail, got %s", status)
+	}
+	if _, status := c.cmd("LOGIN user wrong"); !strings.HasPrefix(status, "NO") {
+		t.Errorf("Expected bad password to fail, got %s", status)
+	}
+	if _, status := c.cmd(`LOGIN "user" "secret"`); !strings.HasPrefix(status, "OK") {
+		t.Errorf("Expected LOGIN to succeed, got %s", status)
+	}
+}
+
+func TestSelectFetchSearch(t *testing.T) {
+	c := startBridge(t)
+	c.cmd("LOGIN user secret")
+
+	list, _ := c.cmd(`LIST "" "*"`)
+	if len(list) != 2 || !strings.Contains(list[0], "INBOX") || !strings.Contains(list[1], "Archive") {
+		t.Errorf("Unexpected LIST response: %v", list)
+	}
+
+	untagged, status := c.cmd("SELECT inbox")
+	if !strings.Contains(status, "[READ-ONLY]") {
+		t.Errorf("Expected read-only SELECT, got %s", status)
+	}
+	if !contains(untagged, "* 2 EXISTS") || !contains(untagged, "* OK [UNSEEN 2] First unseen message") {
+		t.Errorf("Unexpected SELECT response: %v", untagged)
+	}
+
+	// Messages are ordered oldest first
+	untagged, _ = c.cmd("FETCH 1:* (FLAGS ENVELOPE)")
+	if len(untagged) != 2 {
+		t.Fatalf("Expected 2 FETCH responses, got %v", untagged)
+	}
+	if !strings.HasPrefix(untagged[0], `* 1 FETCH (FLAGS (\Seen) ENVELOPE (`) || !strings.Contains(untagged[0], `"First"`) {
+		t.Errorf("Unexpected first FETCH response: %s", untagged[0])
+	}
+	if !strings.Contains(untagged[1], `(("Bob" NIL "bob" "example.com"))`) {
+		t.Errorf("Expected parsed sender address, got %s", untagged[1])
+	}
+
+	untagged, _ = c.cmd("UID FETCH 2 BODY.PEEK[]")
+	if len(untagged) != 1 || !strings.HasPrefix(untagged[0], "* 2 FETCH (UID 2 BODY[] ") || !strings.Contains(untagged[0], "Hello from mail-2") {
+		t.Errorf("Unexpected body FETCH response: %v", untagged)
+	}
+
+	untagged, _ = c.cmd("FETCH 1 BODY[HEADER.FIELDS (SUBJECT)]")
+	if len(untagged) != 1 || !strings.Contains(untagged[0], "Subject: Body of mail-1") || strings.Contains(untagged[0], "From:") {
+		t.Errorf("Unexpected header FETCH response: %v", untagged)
+	}
+
+	untagged, _ = c.cmd("SEARCH UNSEEN")
+	if !contains(untagged, "* SEARCH 2") {
+		t.Errorf("Unexpected SEARCH response: %v", untagged)
+	}
+	untagged, _ = c.cmd(`UID SEARCH OR FROM bob SUBJECT "first" SINCE 1-Jan-2025`)
+	if !contains(untagged, "* SEARCH 1 2") {
+		t.Errorf("Unexpected UID SEARCH response: %v", untagged)
+	}
+
+	if _, status := c.cmd(`STORE 1 +FLAGS (\Deleted)`); !strings.HasPrefix(status, "NO") {
+		t.Errorf("Expected STORE to be rejected, got %s", status)
+	}
+	if _, status := c.cmd("FETCH 1 BODYSTRUCTURE"); !strings.HasPrefix(status, "BAD") {
+		t.Errorf("Expected unsupported item to be rejected, got %s", status)
+	}
+
+	untagged, _ = c.cmd("SELECT Archive")
+	if !contains(untagged, "* 1 EXISTS") {
+		t.Errorf("Unexpected Archive SELECT response: %v", untagged)
+	}
+
+	untagged, status = c.cmd("LOGOUT")
+	if !contains(untagged, "* BYE Logging out") || !strings.HasPrefix(status, "OK") {
+		t.Errorf("Unexpected LOGOUT response: %v %s", untagged, status)
+	}
+}
+
+func TestListLongWildcardPattern(t *testing.T) {
+	c := startBridge(t)
+	c.cmd("LOGIN user secret")
+
+	done := make(chan []string, 1)
+	go func() {
+		list, _ := c.cmd(`LIST "" "` + strings.Repeat("*", 10000) + `q"`)
+		done <- list
+	}()
+	select {
+	case list := <-done:
+		if len(list) != 0 {
+			t.Errorf("Expected no mailboxes to match, got %v", list)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("LIST with a long wildcard pattern did not finish")
+	}
+
+	list, _ := c.cmd(`LIST "" "**Arch%%*e"`)
+	if len(list) != 1 || !strings.Contains(list[0], "Archive") {
+		t.Errorf("Unexpected LIST response: %v", list)
+	}
+}
+
+func TestLiteralArguments(t *testing.T) {
+	c := startBridge(t)
+
+	fmt.Fprintf(c.conn, "a1 LOGIN {4}\r\n")
+	if cont := c.readLine(); !strings.HasPrefix(cont, "+") {
+		t.Fatalf("Expected continuation request, got %s", cont)
+	}
+	fmt.Fprintf(c.conn, "user {6+}\r\nsecret\r\n")
+	if status := c.readLine(); status != "a1 OK LOGIN completed" {
+		t.Errorf("Unexpected LOGIN response: %s", status)
+	}
+}
+
+func contains(lines []string, want string) bool {
+	for _, l := range lines {
+		if l == want {
+			return true
+		}
+	}
+	return false
+}
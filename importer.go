@@ -0,0 +1,410 @@
+package inboundgo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ImportMessage is a raw RFC 5322 message read from an import source
+type ImportMessage struct {
+	// Source identifies where the message came from (file path or mbox index)
+	Source string
+	Raw    []byte
+}
+
+// ImportSource yields messages to import. Next returns io.EOF when there
+// are no more messages.
+type ImportSource interface {
+	Next() (*ImportMessage, error)
+}
+
+// ImportStatus is the outcome of importing a single message
+type ImportStatus string
+
+const (
+	ImportStatusImported  ImportStatus = "imported"
+	ImportStatusDuplicate ImportStatus = "duplicate"
+	ImportStatusFailed    ImportStatus = "failed"
+)
+
+// ImportResult reports what happened to one message
+type ImportResult struct {
+	Source    string
+	MessageID string
+	Status    ImportStatus
+	EmailID   string
+	Error     error
+}
+
+// ImportSummary counts import outcomes
+type ImportSummary struct {
+	Imported   int
+	Duplicates int
+	Failed     int
+}
+
+// Importer replays messages exported from other providers into an Inbound
+// mailbox, skipping messages whose Message-ID has already been imported.
+// A replayed message gets a new Message-ID, so set Ledger to recognize
+// messages imported by earlier runs.
+//
+// The API has no endpoint for ingesting existing messages, so the importer
+// sends mail: each message is sent as a new outbound email from From to To,
+// an address on an Inbound domain. That uses sending quota, creates sent
+// email records, fires delivery events and webhooks, and fails unless
+// From's domain may send. Import refuses to run until SendMail is set.
+//
+// Original recipients are never contacted: To, Cc, and Bcc are replaced,
+// the original sender becomes the Reply-To, and the original From, To,
+// Date, and Message-ID are kept in X-Original-* headers. Of the other
+// headers only In-Reply-To and References are kept, so a replayed message
+// cannot claim the original sender's identity through Sender, List-*, or
+// similar headers.
+type Importer struct {
+	client *Inbound
+	// From is the verified sender used to replay messages.
+	From string
+	// To is the Inbound address that receives the replayed messages.
+	To string
+	// SendMail confirms that messages are to be imported by sending each
+	// one as outbound mail.
+	SendMail bool
+	// SkipExisting checks Inbound mail for Message-IDs that are already
+	// present before importing (default true). It finds mail that arrived
+	// directly, not mail imported earlier, which Ledger records.
+	SkipExisting bool
+	// Ledger, if set, records each imported message so that later runs
+	// skip it.
+	Ledger ImportLedger
+	// OnResult, if set, is called after each message is processed.
+	OnResult func(ImportResult)
+
+	seen map[string]bool
+}
+
+// ImportLedger persists which messages an Importer has imported, by
+// Message-ID or, for messages without one, content hash
+type ImportLedger interface {
+	// Imported returns the keys of the messages imported so far.
+	Imported(ctx context.Context) ([]string, error)
+	// Record marks a message as imported as the email with emailID.
+	Record(ctx context.Context, key, emailID string) error
+}
+
+// FileImportLedger is an ImportLedger kept in a file with one line per
+// imported message
+type FileImportLedger struct {
+	Path string
+}
+
+func (l *FileImportLedger) Imported(ctx context.Context) ([]string, error) {
+	data, err := os.ReadFile(l.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for _, line := range strings.SplitAfter(string(data), "\n") {
+		// A line without its newline is a record torn by a crash
+		if key, _, ok := strings.Cut(strings.TrimSuffix(line, "\n"), "\t"); ok && strings.HasSuffix(line, "\n") {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (l *FileImportLedger) Record(ctx context.Context, key, emailID string) error {
+	f, err := os.OpenFile(l.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(f, "%s\t%s\n", key, emailID); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// NewImporter creates an importer that replays messages from from to to
+func NewImporter(client *Inbound, from, to string) *Importer {
+	return &Importer{client: client, From: from, To: to, SkipExisting: true}
+}
+
+// Import reads every message from src and replays it into Inbound. Failures
+// of individual messages are counted and reported through OnResult; an
+// error is returned only if the source cannot be read or the context ends.
+func (im *Importer) Import(ctx context.Context, src ImportSource) (*ImportSummary, error) {
+	if im.From == "" || im.To == "" {
+		return nil, errors.New("importer requires From and To addresses")
+	}
+	if !im.SendMail {
+		return nil, errors.New("importer sends every message as outbound mail; set SendMail to confirm")
+	}
+	if err := im.loadSeen(ctx); err != nil {
+		return nil, err
+	}
+
+	summary := &ImportSummary{}
+	for {
+		if err := ctx.Err(); err != nil {
+			return summary, err
+		}
+		msg, err := src.Next()
+		if err == io.EOF {
+			return summary, nil
+		}
+		if err != nil {
+			return summary, err
+		}
+
+		result := im.importMessage(ctx, msg)
+		switch result.Status {
+		case ImportStatusImported:
+			summary.Imported++
+		case ImportStatusDuplicate:
+			summary.Duplicates++
+		default:
+			summary.Failed++
+		}
+		if im.OnResult != nil {
+			im.OnResult(result)
+		}
+	}
+}
+
+// loadSeen collects the Message-IDs already present in the mailbox
+func (im *Importer) loadSeen(ctx context.Context) error {
+	if im.seen != nil {
+		return nil
+	}
+	im.seen = make(map[string]bool)
+	if im.Ledger != nil {
+		keys, err := im.Ledger.Imported(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to read import ledger: %w", err)
+		}
+		for _, key := range keys {
+			im.seen[key] = true
+		}
+	}
+	if !im.SkipExisting {
+		return nil
+	}
+	filter := &GetMailRequest{IncludeArchived: Bool(true)}
+	return im.client.Mail().each(ctx, filter, func(item EmailItem) error {
+		if item.MessageID != nil && *item.MessageID != "" {
+			im.seen[normalizeMessageID(*item.MessageID)] = true
+		}
+		return nil
+	})
+}
+
+func (im *Importer) importMessage(ctx context.Context, msg *ImportMessage) ImportResult {
+	result := ImportResult{Source: msg.Source}
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(msg.Raw))
+	if err != nil {
+		result.Status, result.Error = ImportStatusFailed, fmt.Errorf("failed to parse message: %w", err)
+		return result
+	}
+	result.MessageID = parsed.Header.Get("Message-Id")
+
+	// Messages without a Message-ID are deduplicated by content
+	key := normalizeMessageID(result.MessageID)
+	if key == "" {
+		sum := sha256.Sum256(msg.Raw)
+		key = "sha256:" + hex.EncodeToString(sum[:])
+	}
+	if im.seen[key] {
+		result.Status = ImportStatusDuplicate
+		return result
+	}
+
+	req, err := ParseEML(bytes.NewReader(msg.Raw))
+	if err != nil {
+		result.Status, result.Error = ImportStatusFailed, err
+		return result
+	}
+	headers := make(map[string]string)
+	for _, h := range importKeptHeaders {
+		if v, ok := req.Headers[h]; ok {
+			headers[h] = v
+		}
+	}
+	req.Headers = headers
+	for _, h := range []string{"From", "To", "Cc", "Date", "Message-Id"} {
+		if v := parsed.Header.Get(h); v != "" {
+			req.Headers["X-Original-"+h] = v
+		}
+	}
+	if req.From != "" {
+		req.ReplyTo = req.From
+	}
+	req.From = im.From
+	req.To = im.To
+	req.CC = nil
+	req.BCC = nil
+	// Imported messages are delivered as written
+	req.NoSignature = true
+
+	// The idempotency key makes re-running an interrupted import safe
+	sum := sha256.Sum256([]byte(key))
+	resp, err := im.client.Email().Send(ctx, req, &IdempotencyOptions{
+		IdempotencyKey: "import-" + hex.EncodeToString(sum[:16]),
+	})
+	if err == nil && resp.Error != "" {
+		err = errors.New(resp.Error)
+	}
+	if err != nil {
+		result.Status, result.Error = ImportStatusFailed, err
+		return result
+	}
+
+	im.seen[key] = true
+	result.Status = ImportStatusImported
+	if resp.Data != nil {
+		result.EmailID = resp.Data.ID
+	}
+	if im.Ledger != nil {
+		if err := im.Ledger.Record(ctx, key, result.EmailID); err != nil {
+			result.Error = fmt.Errorf("imported, but failed to record in the import ledger: %w", err)
+		}
+	}
+	return result
+}
+
+// importKeptHeaders are the original headers an Importer sends on; the
+// rest could misstate who sent the replayed message
+var importKeptHeaders = []string{"In-Reply-To", "References"}
+
+// normalizeMessageID strips angle brackets and whitespace so IDs compare equal
+// regardless of formatting
+func normalizeMessageID(id string) string {
+	return strings.ToLower(strings.Trim(strings.TrimSpace(id), "<>"))
+}
+
+// mboxSource reads messages from an mbox file
+type mboxSource struct {
+	r     *bufio.Reader
+	index int
+	// pending is set when the "From " line of the next message was consumed
+	pending bool
+	done    bool
+}
+
+// NewMboxSource reads messages from an mbox stream, as produced by Google
+// Takeout, Thunderbird, and most Unix mail tools. Escaped ">From " lines are
+// unescaped (mboxrd).
+func NewMboxSource(r io.Reader) ImportSource {
+	return &mboxSource{r: bufio.NewReader(r)}
+}
+
+func (s *mboxSource) Next() (*ImportMessage, error) {
+	if s.done {
+		return nil, io.EOF
+	}
+	var buf bytes.Buffer
+	started := s.pending
+	for {
+		line, err := s.r.ReadBytes('\n')
+		if len(line) > 0 {
+			if bytes.HasPrefix(line, []byte("From ")) {
+				if started && buf.Len() > 0 {
+					s.pending = true
+					s.index++
+					return &ImportMessage{Source: fmt.Sprintf("mbox:%d", s.index), Raw: trimMboxMessage(buf.Bytes())}, nil
+				}
+				started = true
+			} else if started {
+				buf.Write(unescapeMboxLine(line))
+			}
+		}
+		if err == io.EOF {
+			s.done = true
+			if buf.Len() == 0 {
+				return nil, io.EOF
+			}
+			s.index++
+			return &ImportMessage{Source: fmt.Sprintf("mbox:%d", s.index), Raw: trimMboxMessage(buf.Bytes())}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// unescapeMboxLine removes one ">" from lines matching ^>+From
+func unescapeMboxLine(line []byte) []byte {
+	trimmed := bytes.TrimLeft(line, ">")
+	if len(trimmed) < len(line) && bytes.HasPrefix(trimmed, []byte("From ")) {
+		return line[1:]
+	}
+	return line
+}
+
+// trimMboxMessage drops the blank line that separates mbox messages
+func trimMboxMessage(raw []byte) []byte {
+	raw = bytes.TrimSuffix(raw, []byte("\n"))
+	raw = bytes.TrimSuffix(raw, []byte("\r"))
+	return append(raw, '\n')
+}
+
+// fileSource reads one message per file
+type fileSource struct {
+	paths []string
+}
+
+// NewEMLSource reads one RFC 5322 message from each of paths
+func NewEMLSource(paths ...string) ImportSource {
+	return &fileSource{paths: paths}
+}
+
+// NewMaildirSource reads every message in a Maildir, including Maildir++
+// subfolders such as those exported by Dovecot or Courier
+func NewMaildirSource(dir string) (ImportSource, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if parent := filepath.Base(filepath.Dir(path)); parent == "cur" || parent == "new" {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read maildir: %w", err)
+	}
+	sort.Strings(paths)
+	return &fileSource{paths: paths}, nil
+}
+
+func (s *fileSource) Next() (*ImportMessage, error) {
+	if len(s.paths) == 0 {
+		return nil, io.EOF
+	}
+	path := s.paths[0]
+	s.paths = s.paths[1:]
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &ImportMessage{Source: path, Raw: raw}, nil
+}
@@ -0,0 +1,190 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+const testMbox = "From alice@example.com Thu Jan  2 10:00:00 2025\n" +
+	"From: Alice <alice@example.com>\n" +
+	"To: team@oldprovider.com\n" +
+	"Cc: boss@example.com\n" +
+	"Subject: First\n" +
+	"Message-ID: <one@example.com>\n" +
+	"In-Reply-To: <zero@example.com>\n" +
+	"Sender: alice@oldprovider.com\n" +
+	"List-Id: <team.oldprovider.com>\n" +
+	"\n" +
+	"Hello\n" +
+	">From the archive\n" +
+	"\n" +
+	"From bob@example.com Thu Jan  2 11:00:00 2025\n" +
+	"From: bob@example.com\n" +
+	"To: team@oldprovider.com\n" +
+	"Subject: Already imported\n" +
+	"Message-ID: <TWO@example.com>\n" +
+	"\n" +
+	"Hi\n" +
+	"\n" +
+	"From alice@example.com Thu Jan  2 12:00:00 2025\n" +
+	"From: Alice <alice@example.com>\n" +
+	"To: team@oldprovider.com\n" +
+	"Subject: First (copy)\n" +
+	"Message-ID: <one@example.com>\n" +
+	"\n" +
+	"Hello again\n"
+
+func TestImporterMbox(t *testing.T) {
+	var mu sync.Mutex
+	var sent []map[string]any
+	var keys []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/mail":
+			w.Write([]byte(`{"emails": [{"id": "existing", "messageId": "<two@example.com>"}], "pagination": {"total": 1}}`))
+		case "/emails":
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			mu.Lock()
+			sent = append(sent, body)
+			keys = append(keys, r.Header.Get("Idempotency-Key"))
+			mu.Unlock()
+			w.Write([]byte(`{"id": "email-1"}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	var results []inboundgo.ImportResult
+	im := inboundgo.NewImporter(client, "import@yourdomain.com", "archive@yourdomain.com")
+	im.OnResult = func(r inboundgo.ImportResult) { results = append(results, r) }
+
+	if _, err := im.Import(context.Background(), inboundgo.NewMboxSource(strings.NewReader(testMbox))); err == nil {
+		t.Fatal("Expected Import to refuse to send without SendMail")
+	}
+	if len(sent) != 0 {
+		t.Fatalf("Expected no sends without SendMail, got %d", len(sent))
+	}
+
+	im.SendMail = true
+	summary, err := im.Import(context.Background(), inboundgo.NewMboxSource(strings.NewReader(testMbox)))
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if summary.Imported != 1 || summary.Duplicates != 2 || summary.Failed != 0 {
+		t.Errorf("Unexpected summary: %+v (results %+v)", summary, results)
+	}
+	if len(sent) != 1 {
+		t.Fatalf("Expected 1 send, got %d", len(sent))
+	}
+
+	body := sent[0]
+	if body["from"] != "import@yourdomain.com" || body["to"] != "archive@yourdomain.com" {
+		t.Errorf("Expected replay addresses, got from=%v to=%v", body["from"], body["to"])
+	}
+	if _, ok := body["cc"]; ok {
+		t.Error("Original Cc recipients must not be contacted")
+	}
+	if body["replyTo"] != "Alice <alice@example.com>" {
+		t.Errorf("Expected original sender as Reply-To, got %v", body["replyTo"])
+	}
+	headers, _ := body["headers"].(map[string]any)
+	if headers["X-Original-Message-Id"] != "<one@example.com>" || headers["X-Original-Cc"] != "boss@example.com" {
+		t.Errorf("Unexpected X-Original headers: %v", headers)
+	}
+	if headers["In-Reply-To"] != "<zero@example.com>" {
+		t.Errorf("Expected threading headers to be kept, got %v", headers)
+	}
+	if _, ok := headers["Sender"]; ok {
+		t.Errorf("Expected Sender to be dropped, got %v", headers)
+	}
+	if _, ok := headers["List-Id"]; ok {
+		t.Errorf("Expected List-Id to be dropped, got %v", headers)
+	}
+	if text, _ := body["text"].(string); !strings.Contains(text, "\nFrom the archive") {
+		t.Errorf("Expected unescaped mbox line, got %q", text)
+	}
+	if !strings.HasPrefix(keys[0], "import-") {
+		t.Errorf("Expected deterministic idempotency key, got %q", keys[0])
+	}
+}
+
+func TestImporterLedgerSkipsEarlierRuns(t *testing.T) {
+	var sends int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/mail":
+			// Replayed mail has a new Message-ID, so the mailbox never matches
+			w.Write([]byte(`{"emails": [{"id": "replayed", "messageId": "<new-1@inbound.new>"}], "pagination": {"total": 1}}`))
+		case "/emails":
+			sends++
+			w.Write([]byte(`{"id": "email-1"}`))
+		}
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	ledger := &inboundgo.FileImportLedger{Path: filepath.Join(t.TempDir(), "import.ledger")}
+
+	for run := 1; run <= 2; run++ {
+		im := inboundgo.NewImporter(client, "import@yourdomain.com", "archive@yourdomain.com")
+		im.Ledger = ledger
+		im.SendMail = true
+		summary, err := im.Import(context.Background(), inboundgo.NewMboxSource(strings.NewReader(testMbox)))
+		if err != nil {
+			t.Fatalf("Import run %d failed: %v", run, err)
+		}
+		if run == 2 && (summary.Imported != 0 || summary.Duplicates != 3) {
+			t.Errorf("Expected the second run to skip everything, got %+v", summary)
+		}
+	}
+	if sends != 2 {
+		t.Errorf("Expected 2 sends across both runs, got %d", sends)
+	}
+}
+
+func TestMaildirSource(t *testing.T) {
+	dir := t.TempDir()
+	for _, sub := range []string{"cur", "new", "tmp", ".Archive/cur"} {
+		os.MkdirAll(filepath.Join(dir, sub), 0o700)
+	}
+	os.WriteFile(filepath.Join(dir, "cur", "1:2,S"), []byte("Subject: a\n\nA\n"), 0o600)
+	os.WriteFile(filepath.Join(dir, "new", "2"), []byte("Subject: b\n\nB\n"), 0o600)
+	os.WriteFile(filepath.Join(dir, "tmp", "3"), []byte("partial"), 0o600)
+	os.WriteFile(filepath.Join(dir, ".Archive", "cur", "4:2,S"), []byte("Subject: c\n\nC\n"), 0o600)
+
+	src, err := inboundgo.NewMaildirSource(dir)
+	if err != nil {
+		t.Fatalf("NewMaildirSource failed: %v", err)
+	}
+	count := 0
+	for {
+		msg, err := src.Next()
+		if err != nil {
+			break
+		}
+		if filepath.Base(filepath.Dir(msg.Source)) == "tmp" {
+			t.Errorf("Messages in tmp/ must be skipped: %s", msg.Source)
+		}
+		count++
+	}
+	if count != 3 {
+		t.Errorf("Expected 3 messages, got %d", count)
+	}
+}
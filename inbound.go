@@ -38,18 +38,54 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"reflect"
-	"slices"
-	"strconv"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // Inbound is the main client for the Inbound Email SDK
 type Inbound struct {
-	apiKey     string
-	baseURL    string
-	httpClient *http.Client
+	apiKey        string
+	keyMu         sync.RWMutex
+	keyProvider   APIKeyProvider
+	keyRefresher  APIKeyProvider
+	fallbackKeys  []string
+	activeKey     atomic.Int32
+	refreshMu     sync.Mutex
+	signingSecret string
+	baseURL       string
+	httpClient    *http.Client
+	etagCache     ETagCache
+	fallbacks     []string
+	hedgeDelay    time.Duration
+	cache         *responseCache
+	bodyRenderer  BodyRenderer
+	inlineCSS     bool
+	signatures    map[string]Signature
+	// duplicateGuard is set by WithDuplicateGuard
+	duplicateGuard *DuplicateGuard
+
+	// Services are created once in NewClient and shared by every caller.
+	// They must remain safe for concurrent use; any per-service state added
+	// later needs its own synchronization.
+	mail         *MailService
+	email        *EmailService
+	domain       *DomainService
+	endpoint     *EndpointService
+	thread       *ThreadService
+	attachment   *AttachmentService
+	template     *TemplateService
+	broadcast    *BroadcastService
+	contact      *ContactService
+	suppression  *SuppressionService
+	auditLog     *AuditLogService
+	ipPool       *IPPoolService
+	verify       *VerifyService
+	rule         *RuleService
+	forwarding   *ForwardingService
+	senderFilter *SenderFilterService
 }
 
 // NewClient creates a new Inbound Email client
@@ -58,16 +94,37 @@ func NewClient(apiKey string, baseURL ...string) (*Inbound, error) {
 		return nil, fmt.Errorf("API key is required")
 	}
 
-	url := "https://inbound.new/api/v2"
+	url := string(EnvironmentProduction)
 	if len(baseURL) > 0 && baseURL[0] != "" {
-		url = baseURL[0]
+		var err error
+		if url, err = validateBaseURL(baseURL[0]); err != nil {
+			return nil, err
+		}
 	}
 
-	return &Inbound{
+	c := &Inbound{
 		apiKey:     apiKey,
 		baseURL:    url,
 		httpClient: &http.Client{Timeout: 30 * time.Second},
-	}, nil
+	}
+	c.mail = NewMailService(c)
+	c.email = NewEmailService(c)
+	c.domain = NewDomainService(c)
+	c.endpoint = NewEndpointService(c)
+	c.thread = NewThreadService(c)
+	c.attachment = NewAttachmentService(c)
+	c.template = NewTemplateService(c)
+	c.broadcast = NewBroadcastService(c)
+	c.contact = NewContactService(c)
+	c.suppression = NewSuppressionService(c)
+	c.auditLog = NewAuditLogService(c)
+	c.ipPool = NewIPPoolService(c)
+	c.verify = NewVerifyService(c)
+	c.rule = NewRuleService(c)
+	c.forwarding = NewForwardingService(c)
+	c.senderFilter = NewSenderFilterService(c)
+
+	return c, nil
 }
 
 // WithHTTPClient sets a custom HTTP client
@@ -76,140 +133,212 @@ func (c *Inbound) WithHTTPClient(client *http.Client) *Inbound {
 	return c
 }
 
+// HTTPClient returns the HTTP client requests are made with, for packages
+// that open their own connections to the API
+func (c *Inbound) HTTPClient() *http.Client {
+	return c.httpClient
+}
+
+// maxPooledBufferSize caps the capacity of buffers returned to bodyPool so a
+// single very large request does not pin memory for the life of the process.
+const maxPooledBufferSize = 8 << 20
+
+// bodyBuffer is a pooled request buffer with a JSON encoder bound to it, so
+// neither is allocated per request.
+type bodyBuffer struct {
+	bytes.Buffer
+	enc *json.Encoder
+}
+
+// bodyPool holds buffers used to encode JSON request bodies.
+var bodyPool = sync.Pool{
+	New: func() any {
+		buf := new(bodyBuffer)
+		buf.enc = json.NewEncoder(&buf.Buffer)
+		return buf
+	},
+}
+
+// encodeRequestBody encodes body as JSON into a pooled buffer. The caller
+// must hand the buffer back with releaseBuffer once it is no longer read.
+func encodeRequestBody(body any) (*bodyBuffer, error) {
+	buf := bodyPool.Get().(*bodyBuffer)
+	buf.Reset()
+	if err := buf.enc.Encode(body); err != nil {
+		releaseBuffer(buf)
+		return nil, err
+	}
+	return buf, nil
+}
+
+func releaseBuffer(buf *bodyBuffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	bodyPool.Put(buf)
+}
+
+// pooledBody tracks everyone that may still read a pooled request buffer:
+// the caller, which closes the response body, and the transport for each
+// attempt, which closes that attempt's request body (possibly after Do
+// returns). The buffer goes back to the pool only once all are done.
+type pooledBody struct {
+	buf  *bodyBuffer
+	refs int32
+}
+
+func (p *pooledBody) acquire() {
+	atomic.AddInt32(&p.refs, 1)
+}
+
+func (p *pooledBody) release() {
+	if atomic.AddInt32(&p.refs, -1) == 0 {
+		releaseBuffer(p.buf)
+	}
+}
+
+// onceCloser runs release the first time Close is called.
+type onceCloser struct {
+	io.Reader
+	closer  io.Closer
+	once    sync.Once
+	release func()
+}
+
+func (c *onceCloser) Close() error {
+	var err error
+	c.once.Do(func() {
+		if c.closer != nil {
+			err = c.closer.Close()
+		}
+		c.release()
+	})
+	return err
+}
+
 // request makes an authenticated request to the API with { data, error } response pattern
 func (c *Inbound) request(ctx context.Context, method, endpoint string, body any, headers map[string]string) (*http.Response, error) {
-	url := c.baseURL + endpoint
-
-	var bodyReader io.Reader
+	var pooled *pooledBody
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		buf, err := encodeRequestBody(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		bodyReader = bytes.NewReader(jsonBody)
+		pooled = &pooledBody{buf: buf, refs: 1}
+	}
+
+	build := func(ctx context.Context, baseURL string) (*http.Request, error) {
+		return c.newRequest(ctx, method, baseURL+endpoint, pooled, headers)
+	}
+	resp, err := c.sendWithKeys(ctx, method, build)
+	if pooled != nil {
+		if err != nil {
+			pooled.release()
+		} else {
+			resp.Body = &onceCloser{Reader: resp.Body, closer: resp.Body, release: pooled.release}
+		}
+	}
+	return resp, err
+}
+
+// newRequest builds a single authenticated HTTP request. Each request built
+// from a pooled body holds its own reference to the buffer.
+func (c *Inbound) newRequest(ctx context.Context, method, url string, pooled *pooledBody, headers map[string]string) (*http.Request, error) {
+	apiKey, err := c.resolveAPIKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var bodyReader io.Reader
+	if pooled != nil {
+		bodyReader = bytes.NewReader(pooled.buf.Bytes())
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	if pooled != nil {
+		pooled.acquire()
+		req.Body = &onceCloser{Reader: req.Body, release: pooled.release}
+	}
 
 	// Set default headers
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Authorization", "Bearer "+apiKey)
 	req.Header.Set("Content-Type", "application/json")
 
 	// Set custom headers
 	for k, v := range headers {
 		req.Header.Set(k, v)
 	}
+	if c.signingSecret != "" {
+		var body []byte
+		if pooled != nil {
+			body = pooled.buf.Bytes()
+		}
+		c.signRequest(req, body)
+	}
 
-	return c.httpClient.Do(req)
+	return req, nil
 }
 
 // makeRequest is a generic helper that handles the complete request cycle
 func makeRequest[T any](c *Inbound, ctx context.Context, method, endpoint string, body any, headers map[string]string) (*ApiResponse[T], error) {
+	// The caches hold responses for the client's own key, so requests made
+	// with another key neither read nor fill them
+	shared := apiKeyOverride(ctx) == ""
+	var cachedBody []byte
+	if shared {
+		if cached, ok := c.cachedResponse(method, endpoint); ok {
+			var result T
+			if err := json.Unmarshal(cached, &result); err == nil {
+				return &ApiResponse[T]{Data: &result, StatusCode: http.StatusOK}, nil
+			}
+		}
+		headers, cachedBody = c.conditionalHeaders(method, endpoint, headers)
+	}
+
 	resp, err := c.request(ctx, method, endpoint, body, headers)
 	if err != nil {
-		return &ApiResponse[T]{Error: err.Error()}, nil
+		// Transport errors can quote URLs and, with custom transports,
+		// request headers
+		return &ApiResponse[T]{Error: c.redactError(ctx, err, headers)}, nil
 	}
 	defer resp.Body.Close()
+	served := keyIndex(resp.Request.Context())
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return &ApiResponse[T]{Error: "Failed to read response body"}, nil
+		return &ApiResponse[T]{Error: "Failed to read response body", StatusCode: resp.StatusCode, KeyIndex: served}, nil
 	}
 
-	if resp.StatusCode >= 400 {
+	status := resp.StatusCode
+	if status == http.StatusNotModified && cachedBody != nil {
+		// The cached body is still current, so report it as a normal read
+		respBody, status = cachedBody, http.StatusOK
+	} else if shared {
+		c.storeETag(method, endpoint, resp, respBody)
+	}
+	if shared {
+		c.updateCache(method, endpoint, status, respBody)
+	}
+
+	if status >= 400 {
 		var errorResp struct {
 			Error string `json:"error"`
 		}
 		if err := json.Unmarshal(respBody, &errorResp); err == nil && errorResp.Error != "" {
-			return &ApiResponse[T]{Error: errorResp.Error}, nil
+			return &ApiResponse[T]{Error: redactSecrets(c.Redact(errorResp.Error), apiKeyOverride(ctx)), StatusCode: status, KeyIndex: served}, nil
 		}
-		return &ApiResponse[T]{Error: fmt.Sprintf("HTTP %d: %s", resp.StatusCode, resp.Status)}, nil
+		return &ApiResponse[T]{Error: fmt.Sprintf("HTTP %d: %s", status, resp.Status), StatusCode: status, KeyIndex: served}, nil
 	}
 
 	var result T
 	if err := json.Unmarshal(respBody, &result); err != nil {
-		return &ApiResponse[T]{Error: "Failed to parse response"}, nil
+		return &ApiResponse[T]{Error: "Failed to parse response", StatusCode: status, KeyIndex: served}, nil
 	}
 
-	return &ApiResponse[T]{Data: &result}, nil
-}
-
-// buildQueryString builds a query string from a struct
-func buildQueryString(params any) string {
-	values := url.Values{}
-
-	if params == nil {
-		return ""
-	}
-
-	v := reflect.ValueOf(params)
-	if v.Kind() == reflect.Ptr {
-		v = v.Elem()
-	}
-
-	if v.Kind() != reflect.Struct {
-		return ""
-	}
-
-	t := v.Type()
-	for i := 0; i < v.NumField(); i++ {
-		field := v.Field(i)
-		fieldType := t.Field(i)
-
-		// Skip unexported fields
-		if !field.CanInterface() {
-			continue
-		}
-
-		// Get JSON tag
-		tag := fieldType.Tag.Get("json")
-		if tag == "" || tag == "-" {
-			continue
-		}
-
-		// Parse JSON tag
-		tagParts := strings.Split(tag, ",")
-		key := tagParts[0]
-
-		// Check for omitempty
-		omitempty := slices.Contains(tagParts[1:], "omitempty")
-
-		// Handle different field types
-		switch field.Kind() {
-		case reflect.Ptr:
-			if field.IsNil() {
-				continue
-			}
-			field = field.Elem()
-			fallthrough
-		case reflect.String:
-			val := field.String()
-			if omitempty && val == "" {
-				continue
-			}
-			values.Add(key, val)
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			val := field.Int()
-			if omitempty && val == 0 {
-				continue
-			}
-			values.Add(key, strconv.FormatInt(val, 10))
-		case reflect.Bool:
-			val := field.Bool()
-			if omitempty && !val {
-				continue
-			}
-			values.Add(key, strconv.FormatBool(val))
-		}
-	}
-
-	if len(values) == 0 {
-		return ""
-	}
-	return "?" + values.Encode()
+	return &ApiResponse[T]{Data: &result, StatusCode: status, KeyIndex: served}, nil
 }
 
 // MailService handles mail operations (inbound emails)
@@ -226,6 +355,9 @@ func NewMailService(client *Inbound) *MailService {
 //
 // API Reference: https://docs.inbound.new/api-reference/mail/list-emails
 func (s *MailService) List(ctx context.Context, params *GetMailRequest) (*ApiResponse[GetMailResponse], error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
 	endpoint := "/mail" + buildQueryString(params)
 	return makeRequest[GetMailResponse](s.client, ctx, "GET", endpoint, nil, nil)
 }
@@ -238,6 +370,12 @@ func (s *MailService) Get(ctx context.Context, id string) (*ApiResponse[GetMailB
 	return makeRequest[GetMailByIDResponse](s.client, ctx, "GET", endpoint, nil, nil)
 }
 
+// Delete permanently deletes a received email and its attachments
+func (s *MailService) Delete(ctx context.Context, id string) (*ApiResponse[DeleteMailByIDResponse], error) {
+	endpoint := fmt.Sprintf("/mail/%s", id)
+	return makeRequest[DeleteMailByIDResponse](s.client, ctx, "DELETE", endpoint, nil, nil)
+}
+
 // Thread retrieves email thread/conversation by email ID
 func (s *MailService) Thread(ctx context.Context, id string) (*ApiResponse[any], error) {
 	endpoint := fmt.Sprintf("/mail/%s/thread", id)
@@ -304,9 +442,33 @@ func NewEmailService(client *Inbound) *EmailService {
 //
 // This method supports both immediate sending and scheduled delivery.
 // If params.ScheduledAt is set, the email will be scheduled for future delivery.
+// If params.Body is set, it is rendered first (see Inbound.WithBodyRenderer),
+// and the signature configured for params.From is appended. With a
+// duplicate guard (see Inbound.WithDuplicateGuard), a blocked duplicate
+// returns an error wrapping ErrDuplicateMessage.
 //
 // API Reference: https://docs.inbound.new/api-reference/emails/send-email
 func (s *EmailService) Send(ctx context.Context, params *PostEmailsRequest, options *IdempotencyOptions) (*ApiResponse[PostEmailsResponse], error) {
+	params, err := s.client.prepareEmail(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if guard := s.client.duplicateGuard; guard != nil {
+		release, err := guard.check(params, options)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := s.send(ctx, params, options)
+		if err != nil || resp.Error != "" {
+			release()
+		}
+		return resp, err
+	}
+	return s.send(ctx, params, options)
+}
+
+// send posts a prepared email as is
+func (s *EmailService) send(ctx context.Context, params *PostEmailsRequest, options *IdempotencyOptions) (*ApiResponse[PostEmailsResponse], error) {
 	var endpoint string
 	if params.ScheduledAt != nil {
 		endpoint = "/emails/schedule"
@@ -322,6 +484,45 @@ func (s *EmailService) Send(ctx context.Context, params *PostEmailsRequest, opti
 	return makeRequest[PostEmailsResponse](s.client, ctx, "POST", endpoint, params, headers)
 }
 
+// SendTemplate sends an email rendered from a stored template, substituting
+// variables into its subject and body
+func (s *EmailService) SendTemplate(ctx context.Context, templateID string, to any, variables map[string]any, opts *TemplateSendOptions) (*ApiResponse[PostEmailsResponse], error) {
+	params, headers := templateEmailRequest(templateID, to, variables, opts)
+	return makeRequest[PostEmailsResponse](s.client, ctx, "POST", "/emails", params, headers)
+}
+
+// ScheduleTemplate schedules a templated email for a future time
+//
+// Supports both ISO 8601 dates and natural language (e.g., "in 1 hour", "tomorrow at 9am").
+func (s *EmailService) ScheduleTemplate(ctx context.Context, templateID string, to any, variables map[string]any, scheduledAt string, opts *TemplateSendOptions) (*ApiResponse[PostScheduleEmailResponse], error) {
+	params, headers := templateEmailRequest(templateID, to, variables, opts)
+	params.ScheduledAt = &scheduledAt
+	return makeRequest[PostScheduleEmailResponse](s.client, ctx, "POST", "/emails/schedule", params, headers)
+}
+
+func templateEmailRequest(templateID string, to any, variables map[string]any, opts *TemplateSendOptions) (*PostTemplateEmailRequest, map[string]string) {
+	params := &PostTemplateEmailRequest{
+		TemplateID: templateID,
+		To:         to,
+		Variables:  variables,
+	}
+	headers := make(map[string]string)
+	if opts != nil {
+		params.From = opts.From
+		params.BCC = opts.BCC
+		params.CC = opts.CC
+		params.ReplyTo = opts.ReplyTo
+		params.Headers = opts.Headers
+		params.Attachments = opts.Attachments
+		params.Tags = opts.Tags
+		params.Timezone = opts.Timezone
+		if opts.IdempotencyKey != "" {
+			headers["Idempotency-Key"] = opts.IdempotencyKey
+		}
+	}
+	return params, headers
+}
+
 // Get retrieves a sent email by ID
 //
 // API Reference: https://docs.inbound.new/api-reference/emails/get-email
@@ -330,8 +531,38 @@ func (s *EmailService) Get(ctx context.Context, id string) (*ApiResponse[GetEmai
 	return makeRequest[GetEmailByIDResponse](s.client, ctx, "GET", endpoint, nil, nil)
 }
 
+// ListEvents returns the timeline of a sent email (queued, sent, delivered,
+// opened, clicked, bounced, complained), oldest first
+func (s *EmailService) ListEvents(ctx context.Context, id string) (*ApiResponse[GetEmailEventsResponse], error) {
+	endpoint := fmt.Sprintf("/emails/%s/events", id)
+	resp, err := makeRequest[GetEmailEventsResponse](s.client, ctx, "GET", endpoint, nil, nil)
+	if err == nil && resp.Data != nil {
+		sort.SliceStable(resp.Data.Events, func(i, j int) bool {
+			return resp.Data.Events[i].Timestamp.Before(resp.Data.Events[j].Timestamp)
+		})
+	}
+	return resp, err
+}
+
+// List lists sent emails, filterable by tag and date range
+func (s *EmailService) List(ctx context.Context, params *GetEmailsRequest) (*ApiResponse[GetEmailsResponse], error) {
+	endpoint := "/emails" + buildQueryString(params)
+	return makeRequest[GetEmailsResponse](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// Latest returns the most recent event, or nil if there are none
+func (r *GetEmailEventsResponse) Latest() *EmailEvent {
+	if r == nil || len(r.Events) == 0 {
+		return nil
+	}
+	return &r.Events[len(r.Events)-1]
+}
+
 // Reply replies to an email by ID with optional attachments
 //
+// The signature configured for params.From is appended unless
+// params.NoSignature is set (see Inbound.WithSignature).
+//
 // API Reference: https://docs.inbound.new/api-reference/emails/reply-to-email
 func (s *EmailService) Reply(ctx context.Context, id string, params *PostEmailReplyRequest, options *IdempotencyOptions) (*ApiResponse[PostEmailReplyResponse], error) {
 	endpoint := fmt.Sprintf("/emails/%s/reply", id)
@@ -341,6 +572,7 @@ func (s *EmailService) Reply(ctx context.Context, id string, params *PostEmailRe
 		headers["Idempotency-Key"] = options.IdempotencyKey
 	}
 
+	params = s.client.prepareReply(params)
 	return makeRequest[PostEmailReplyResponse](s.client, ctx, "POST", endpoint, params, headers)
 }
 
@@ -362,6 +594,9 @@ func (s *EmailService) Schedule(ctx context.Context, params *PostScheduleEmailRe
 //
 // API Reference: https://docs.inbound.new/api-reference/emails/list-scheduled-emails
 func (s *EmailService) ListScheduled(ctx context.Context, params *GetScheduledEmailsRequest) (*ApiResponse[GetScheduledEmailsResponse], error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
 	endpoint := "/emails/schedule" + buildQueryString(params)
 	return makeRequest[GetScheduledEmailsResponse](s.client, ctx, "GET", endpoint, nil, nil)
 }
@@ -378,6 +613,20 @@ func (s *EmailService) Cancel(ctx context.Context, id string) (*ApiResponse[Dele
 	return makeRequest[DeleteScheduledEmailResponse](s.client, ctx, "DELETE", endpoint, nil, nil)
 }
 
+// ListBounces lists hard and soft bounces of sent emails, filterable by
+// bounce type, sending domain, and date range
+func (s *EmailService) ListBounces(ctx context.Context, params *GetBouncesRequest) (*ApiResponse[GetBouncesResponse], error) {
+	endpoint := "/emails/bounces" + buildQueryString(params)
+	return makeRequest[GetBouncesResponse](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// ListComplaints lists spam complaints reported by recipients, filterable by
+// sending domain and date range
+func (s *EmailService) ListComplaints(ctx context.Context, params *GetComplaintsRequest) (*ApiResponse[GetComplaintsResponse], error) {
+	endpoint := "/emails/complaints" + buildQueryString(params)
+	return makeRequest[GetComplaintsResponse](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
 // EmailAddressService handles email address management
 type EmailAddressService struct {
 	client *Inbound
@@ -427,6 +676,63 @@ func (s *EmailAddressService) Delete(ctx context.Context, id string) (*ApiRespon
 	return makeRequest[DeleteEmailAddressByIDResponse](s.client, ctx, "DELETE", endpoint, nil, nil)
 }
 
+// ConfigureReceiptRule creates or rebuilds the receipt rule that routes an
+// email address's incoming mail, for an address whose
+// IsReceiptRuleConfigured is false, such as after the rule set was changed
+// outside Inbound. Configuring an address that already has its rule is
+// harmless.
+func (s *EmailAddressService) ConfigureReceiptRule(ctx context.Context, id string) (*ApiResponse[ConfigureReceiptRuleResponse], error) {
+	endpoint := fmt.Sprintf("/email-addresses/%s/receipt-rule", id)
+	return makeRequest[ConfigureReceiptRuleResponse](s.client, ctx, "POST", endpoint, nil, nil)
+}
+
+// SetAutoReply creates or replaces the automatic reply of an email address.
+// Automatic replies are never sent to bounces, mailing lists, or other
+// automated mail.
+func (s *EmailAddressService) SetAutoReply(ctx context.Context, id string, params *PutAutoReplyRequest) (*ApiResponse[AutoReply], error) {
+	endpoint := fmt.Sprintf("/email-addresses/%s/auto-reply", id)
+	return makeRequest[AutoReply](s.client, ctx, "PUT", endpoint, params, nil)
+}
+
+// GetAutoReply gets the automatic reply of an email address
+func (s *EmailAddressService) GetAutoReply(ctx context.Context, id string) (*ApiResponse[AutoReply], error) {
+	endpoint := fmt.Sprintf("/email-addresses/%s/auto-reply", id)
+	return makeRequest[AutoReply](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// DeleteAutoReply turns off and removes the automatic reply of an email address
+func (s *EmailAddressService) DeleteAutoReply(ctx context.Context, id string) (*ApiResponse[any], error) {
+	endpoint := fmt.Sprintf("/email-addresses/%s/auto-reply", id)
+	return makeRequest[any](s.client, ctx, "DELETE", endpoint, nil, nil)
+}
+
+// findByAddress pages through email addresses to find the one matching
+// address, ignoring case
+func (s *EmailAddressService) findByAddress(ctx context.Context, address string) (*EmailAddressWithDomain, error) {
+	const pageSize = 100
+	for offset := 0; ; offset += pageSize {
+		resp, err := s.List(ctx, &GetEmailAddressesRequest{Limit: Int(pageSize), Offset: Int(offset)})
+		if err != nil {
+			return nil, err
+		}
+		if resp.Error != "" {
+			return nil, fmt.Errorf("failed to list email addresses: %s", resp.Error)
+		}
+		if resp.Data == nil {
+			break
+		}
+		for i := range resp.Data.Data {
+			if strings.EqualFold(resp.Data.Data[i].Address, address) {
+				return &resp.Data.Data[i], nil
+			}
+		}
+		if len(resp.Data.Data) < pageSize || (resp.Data.Pagination.Total > 0 && offset+pageSize >= resp.Data.Pagination.Total) {
+			break
+		}
+	}
+	return nil, fmt.Errorf("email address %s not found", address)
+}
+
 // DomainService handles domain management
 type DomainService struct {
 	client *Inbound
@@ -448,6 +754,9 @@ func (s *DomainService) Create(ctx context.Context, params *PostDomainsRequest)
 //
 // API Reference: https://docs.inbound.new/api-reference/domains/list-domains
 func (s *DomainService) List(ctx context.Context, params *GetDomainsRequest) (*ApiResponse[GetDomainsResponse], error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
 	endpoint := "/domains" + buildQueryString(params)
 	return makeRequest[GetDomainsResponse](s.client, ctx, "GET", endpoint, nil, nil)
 }
@@ -460,10 +769,14 @@ func (s *DomainService) Get(ctx context.Context, id string) (*ApiResponse[GetDom
 	return makeRequest[GetDomainByIDResponse](s.client, ctx, "GET", endpoint, nil, nil)
 }
 
-// Update updates domain settings (catch-all configuration)
+// Update updates domain settings (catch-all configuration). Only the
+// fields of params that are set are changed.
 //
 // API Reference: https://docs.inbound.new/api-reference/domains/update-domain
 func (s *DomainService) Update(ctx context.Context, id string, params *PutDomainByIDRequest) (*ApiResponse[PutDomainByIDResponse], error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
 	endpoint := fmt.Sprintf("/domains/%s", id)
 	return makeRequest[PutDomainByIDResponse](s.client, ctx, "PUT", endpoint, params, nil)
 }
@@ -496,6 +809,52 @@ func (s *DomainService) CheckStatus(ctx context.Context, id string) (*ApiRespons
 	return makeRequest[any](s.client, ctx, "PATCH", endpoint, nil, nil)
 }
 
+// Reputation gets delivery, bounce, and complaint rates for a domain over
+// the requested window, along with any mailbox provider reputation signals
+func (s *DomainService) Reputation(ctx context.Context, id string, params *GetDomainReputationRequest) (*ApiResponse[GetDomainReputationResponse], error) {
+	endpoint := fmt.Sprintf("/domains/%s/reputation", id) + buildQueryString(params)
+	return makeRequest[GetDomainReputationResponse](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// CreateTrackingDomain configures a custom open/click tracking hostname for a
+// sending domain. The response lists the DNS records to publish.
+func (s *DomainService) CreateTrackingDomain(ctx context.Context, id string, params *PostTrackingDomainRequest) (*ApiResponse[TrackingDomain], error) {
+	endpoint := fmt.Sprintf("/domains/%s/tracking-domain", id)
+	return makeRequest[TrackingDomain](s.client, ctx, "POST", endpoint, params, nil)
+}
+
+// GetTrackingDomain gets the tracking domain configured for a sending domain
+func (s *DomainService) GetTrackingDomain(ctx context.Context, id string) (*ApiResponse[TrackingDomain], error) {
+	endpoint := fmt.Sprintf("/domains/%s/tracking-domain", id)
+	return makeRequest[TrackingDomain](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// VerifyTrackingDomain re-checks the tracking domain's DNS records
+func (s *DomainService) VerifyTrackingDomain(ctx context.Context, id string) (*ApiResponse[TrackingDomain], error) {
+	endpoint := fmt.Sprintf("/domains/%s/tracking-domain/verify", id)
+	return makeRequest[TrackingDomain](s.client, ctx, "POST", endpoint, nil, nil)
+}
+
+// DeleteTrackingDomain removes the custom tracking domain, reverting to the
+// shared tracking host
+func (s *DomainService) DeleteTrackingDomain(ctx context.Context, id string) (*ApiResponse[any], error) {
+	endpoint := fmt.Sprintf("/domains/%s/tracking-domain", id)
+	return makeRequest[any](s.client, ctx, "DELETE", endpoint, nil, nil)
+}
+
+// SetBIMI configures the BIMI logo (and optional VMC) for a domain. The
+// response includes the TXT record to publish.
+func (s *DomainService) SetBIMI(ctx context.Context, id string, params *PutDomainBIMIRequest) (*ApiResponse[DomainBIMI], error) {
+	endpoint := fmt.Sprintf("/domains/%s/bimi", id)
+	return makeRequest[DomainBIMI](s.client, ctx, "PUT", endpoint, params, nil)
+}
+
+// GetBIMI gets the BIMI configuration and verification status of a domain
+func (s *DomainService) GetBIMI(ctx context.Context, id string) (*ApiResponse[DomainBIMI], error) {
+	endpoint := fmt.Sprintf("/domains/%s/bimi", id)
+	return makeRequest[DomainBIMI](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
 // EndpointService handles endpoint management
 type EndpointService struct {
 	client *Inbound
@@ -510,6 +869,9 @@ func NewEndpointService(client *Inbound) *EndpointService {
 //
 // API Reference: https://docs.inbound.new/api-reference/endpoints/create-endpoint
 func (s *EndpointService) Create(ctx context.Context, params *PostEndpointsRequest) (*ApiResponse[PostEndpointsResponse], error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
 	return makeRequest[PostEndpointsResponse](s.client, ctx, "POST", "/endpoints", params, nil)
 }
 
@@ -517,6 +879,9 @@ func (s *EndpointService) Create(ctx context.Context, params *PostEndpointsReque
 //
 // API Reference: https://docs.inbound.new/api-reference/endpoints/list-endpoints
 func (s *EndpointService) List(ctx context.Context, params *GetEndpointsRequest) (*ApiResponse[GetEndpointsResponse], error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
 	endpoint := "/endpoints" + buildQueryString(params)
 	return makeRequest[GetEndpointsResponse](s.client, ctx, "GET", endpoint, nil, nil)
 }
@@ -545,10 +910,10 @@ func (s *EndpointService) Delete(ctx context.Context, id string) (*ApiResponse[D
 	return makeRequest[DeleteEndpointByIDResponse](s.client, ctx, "DELETE", endpoint, nil, nil)
 }
 
-// Test tests endpoint connectivity
-func (s *EndpointService) Test(ctx context.Context, id string) (*ApiResponse[any], error) {
+// Test sends a test delivery to an endpoint and reports how it answered
+func (s *EndpointService) Test(ctx context.Context, id string) (*ApiResponse[EndpointTestResult], error) {
 	endpoint := fmt.Sprintf("/endpoints/%s/test", id)
-	return makeRequest[any](s.client, ctx, "POST", endpoint, nil, nil)
+	return makeRequest[EndpointTestResult](s.client, ctx, "POST", endpoint, nil, nil)
 }
 
 // ThreadService handles thread management
@@ -565,6 +930,9 @@ func NewThreadService(client *Inbound) *ThreadService {
 //
 // API Reference: https://docs.inbound.new/api-reference/threads/list-threads
 func (s *ThreadService) List(ctx context.Context, params *GetThreadsRequest) (*ApiResponse[GetThreadsResponse], error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
 	endpoint := "/threads" + buildQueryString(params)
 	return makeRequest[GetThreadsResponse](s.client, ctx, "GET", endpoint, nil, nil)
 }
@@ -577,6 +945,13 @@ func (s *ThreadService) Get(ctx context.Context, id string) (*ApiResponse[GetThr
 	return makeRequest[GetThreadByIDResponse](s.client, ctx, "GET", endpoint, nil, nil)
 }
 
+// DeleteMessage permanently deletes one message of a thread, inbound or
+// outbound, and its attachments
+func (s *ThreadService) DeleteMessage(ctx context.Context, threadID, messageID string) (*ApiResponse[DeleteThreadMessageResponse], error) {
+	endpoint := fmt.Sprintf("/threads/%s/messages/%s", threadID, messageID)
+	return makeRequest[DeleteThreadMessageResponse](s.client, ctx, "DELETE", endpoint, nil, nil)
+}
+
 // PerformAction performs an action on a thread (mark as read, archive, etc.)
 //
 // API Reference: https://docs.inbound.new/api-reference/threads/thread-actions
@@ -649,29 +1024,523 @@ func (s *AttachmentService) Download(ctx context.Context, emailID, filename stri
 	}, nil
 }
 
-// Add service properties to the main client
+// TemplateService handles server-side email templates
+type TemplateService struct {
+	client *Inbound
+}
+
+// NewTemplateService creates a new template service
+func NewTemplateService(client *Inbound) *TemplateService {
+	return &TemplateService{client: client}
+}
+
+// Create creates a new email template
+func (s *TemplateService) Create(ctx context.Context, params *PostTemplatesRequest) (*ApiResponse[Template], error) {
+	return makeRequest[Template](s.client, ctx, "POST", "/templates", params, nil)
+}
+
+// List lists all email templates
+func (s *TemplateService) List(ctx context.Context, params *GetTemplatesRequest) (*ApiResponse[GetTemplatesResponse], error) {
+	endpoint := "/templates" + buildQueryString(params)
+	return makeRequest[GetTemplatesResponse](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// Get gets a specific template by ID
+func (s *TemplateService) Get(ctx context.Context, id string) (*ApiResponse[Template], error) {
+	endpoint := fmt.Sprintf("/templates/%s", id)
+	return makeRequest[Template](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// Update updates a template; only the fields that are set are changed
+func (s *TemplateService) Update(ctx context.Context, id string, params *PutTemplateByIDRequest) (*ApiResponse[Template], error) {
+	endpoint := fmt.Sprintf("/templates/%s", id)
+	return makeRequest[Template](s.client, ctx, "PUT", endpoint, params, nil)
+}
+
+// Delete deletes a template
+func (s *TemplateService) Delete(ctx context.Context, id string) (*ApiResponse[DeleteTemplateByIDResponse], error) {
+	endpoint := fmt.Sprintf("/templates/%s", id)
+	return makeRequest[DeleteTemplateByIDResponse](s.client, ctx, "DELETE", endpoint, nil, nil)
+}
+
+// BroadcastService handles newsletter-style campaigns sent to an audience
+type BroadcastService struct {
+	client *Inbound
+}
+
+// NewBroadcastService creates a new broadcast service
+func NewBroadcastService(client *Inbound) *BroadcastService {
+	return &BroadcastService{client: client}
+}
+
+// Create creates a broadcast for an audience or segment using a template.
+// Broadcasts with ScheduledAt set are sent automatically; others stay in
+// draft until Send is called.
+func (s *BroadcastService) Create(ctx context.Context, params *PostBroadcastsRequest) (*ApiResponse[Broadcast], error) {
+	return makeRequest[Broadcast](s.client, ctx, "POST", "/broadcasts", params, nil)
+}
+
+// List lists broadcasts with optional status filtering
+func (s *BroadcastService) List(ctx context.Context, params *GetBroadcastsRequest) (*ApiResponse[GetBroadcastsResponse], error) {
+	endpoint := "/broadcasts" + buildQueryString(params)
+	return makeRequest[GetBroadcastsResponse](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// Get gets a specific broadcast by ID
+func (s *BroadcastService) Get(ctx context.Context, id string) (*ApiResponse[Broadcast], error) {
+	endpoint := fmt.Sprintf("/broadcasts/%s", id)
+	return makeRequest[Broadcast](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// Send starts sending a draft or scheduled broadcast immediately
+func (s *BroadcastService) Send(ctx context.Context, id string, options *IdempotencyOptions) (*ApiResponse[Broadcast], error) {
+	endpoint := fmt.Sprintf("/broadcasts/%s/send", id)
+
+	headers := make(map[string]string)
+	if options != nil && options.IdempotencyKey != "" {
+		headers["Idempotency-Key"] = options.IdempotencyKey
+	}
+
+	return makeRequest[Broadcast](s.client, ctx, "POST", endpoint, nil, headers)
+}
+
+// Pause pauses a broadcast that is currently sending
+func (s *BroadcastService) Pause(ctx context.Context, id string) (*ApiResponse[Broadcast], error) {
+	endpoint := fmt.Sprintf("/broadcasts/%s/pause", id)
+	return makeRequest[Broadcast](s.client, ctx, "POST", endpoint, nil, nil)
+}
+
+// Resume resumes sending a paused broadcast
+func (s *BroadcastService) Resume(ctx context.Context, id string) (*ApiResponse[Broadcast], error) {
+	endpoint := fmt.Sprintf("/broadcasts/%s/resume", id)
+	return makeRequest[Broadcast](s.client, ctx, "POST", endpoint, nil, nil)
+}
+
+// Cancel cancels a draft, scheduled, or paused broadcast
+func (s *BroadcastService) Cancel(ctx context.Context, id string) (*ApiResponse[Broadcast], error) {
+	endpoint := fmt.Sprintf("/broadcasts/%s/cancel", id)
+	return makeRequest[Broadcast](s.client, ctx, "POST", endpoint, nil, nil)
+}
+
+// Stats gets delivery and engagement statistics for a broadcast
+func (s *BroadcastService) Stats(ctx context.Context, id string) (*ApiResponse[BroadcastStats], error) {
+	endpoint := fmt.Sprintf("/broadcasts/%s/stats", id)
+	return makeRequest[BroadcastStats](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// ContactService handles contacts and the audiences broadcasts are sent to
+type ContactService struct {
+	client *Inbound
+}
+
+// NewContactService creates a new contact service
+func NewContactService(client *Inbound) *ContactService {
+	return &ContactService{client: client}
+}
+
+// Create creates a contact, optionally adding it to audiences
+func (s *ContactService) Create(ctx context.Context, params *PostContactsRequest) (*ApiResponse[Contact], error) {
+	return makeRequest[Contact](s.client, ctx, "POST", "/contacts", params, nil)
+}
+
+// List lists contacts, optionally filtered by audience, segment, or search term
+func (s *ContactService) List(ctx context.Context, params *GetContactsRequest) (*ApiResponse[GetContactsResponse], error) {
+	endpoint := "/contacts" + buildQueryString(params)
+	return makeRequest[GetContactsResponse](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// Get gets a specific contact by ID
+func (s *ContactService) Get(ctx context.Context, id string) (*ApiResponse[Contact], error) {
+	endpoint := fmt.Sprintf("/contacts/%s", id)
+	return makeRequest[Contact](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// Update updates a contact; attributes are merged into the existing ones
+func (s *ContactService) Update(ctx context.Context, id string, params *PutContactByIDRequest) (*ApiResponse[Contact], error) {
+	endpoint := fmt.Sprintf("/contacts/%s", id)
+	return makeRequest[Contact](s.client, ctx, "PUT", endpoint, params, nil)
+}
+
+// Delete deletes a contact
+func (s *ContactService) Delete(ctx context.Context, id string) (*ApiResponse[DeleteContactByIDResponse], error) {
+	endpoint := fmt.Sprintf("/contacts/%s", id)
+	return makeRequest[DeleteContactByIDResponse](s.client, ctx, "DELETE", endpoint, nil, nil)
+}
+
+// BulkUpsert creates or updates contacts by email address in one request
+func (s *ContactService) BulkUpsert(ctx context.Context, params *PostContactsBulkRequest) (*ApiResponse[PostContactsBulkResponse], error) {
+	return makeRequest[PostContactsBulkResponse](s.client, ctx, "POST", "/contacts/bulk", params, nil)
+}
+
+// CreateAudience creates a named audience (contact list)
+func (s *ContactService) CreateAudience(ctx context.Context, params *PostAudiencesRequest) (*ApiResponse[Audience], error) {
+	return makeRequest[Audience](s.client, ctx, "POST", "/audiences", params, nil)
+}
+
+// ListAudiences lists all audiences
+func (s *ContactService) ListAudiences(ctx context.Context) (*ApiResponse[GetAudiencesResponse], error) {
+	return makeRequest[GetAudiencesResponse](s.client, ctx, "GET", "/audiences", nil, nil)
+}
+
+// DeleteAudience deletes an audience; its contacts are kept
+func (s *ContactService) DeleteAudience(ctx context.Context, id string) (*ApiResponse[any], error) {
+	endpoint := fmt.Sprintf("/audiences/%s", id)
+	return makeRequest[any](s.client, ctx, "DELETE", endpoint, nil, nil)
+}
+
+// AddToAudience adds contacts to an audience
+func (s *ContactService) AddToAudience(ctx context.Context, audienceID string, contactIDs ...string) (*ApiResponse[any], error) {
+	endpoint := fmt.Sprintf("/audiences/%s/contacts", audienceID)
+	return makeRequest[any](s.client, ctx, "POST", endpoint, &PostAudienceContactsRequest{ContactIDs: contactIDs}, nil)
+}
+
+// RemoveFromAudience removes a contact from an audience
+func (s *ContactService) RemoveFromAudience(ctx context.Context, audienceID, contactID string) (*ApiResponse[any], error) {
+	endpoint := fmt.Sprintf("/audiences/%s/contacts/%s", audienceID, contactID)
+	return makeRequest[any](s.client, ctx, "DELETE", endpoint, nil, nil)
+}
+
+// SuppressionService manages addresses that must not receive email
+type SuppressionService struct {
+	client *Inbound
+}
+
+// NewSuppressionService creates a new suppression service
+func NewSuppressionService(client *Inbound) *SuppressionService {
+	return &SuppressionService{client: client}
+}
+
+// List lists suppressed addresses, optionally filtered by reason or domain
+func (s *SuppressionService) List(ctx context.Context, params *GetSuppressionsRequest) (*ApiResponse[GetSuppressionsResponse], error) {
+	endpoint := "/suppressions" + buildQueryString(params)
+	return makeRequest[GetSuppressionsResponse](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// Add suppresses one or more addresses
+func (s *SuppressionService) Add(ctx context.Context, params *PostSuppressionsRequest) (*ApiResponse[PostSuppressionsResponse], error) {
+	return makeRequest[PostSuppressionsResponse](s.client, ctx, "POST", "/suppressions", params, nil)
+}
+
+// Remove removes an address from the suppression list
+func (s *SuppressionService) Remove(ctx context.Context, email string) (*ApiResponse[any], error) {
+	endpoint := fmt.Sprintf("/suppressions/%s", url.PathEscape(email))
+	return makeRequest[any](s.client, ctx, "DELETE", endpoint, nil, nil)
+}
+
+// Check reports whether an address is suppressed and why
+func (s *SuppressionService) Check(ctx context.Context, email string) (*ApiResponse[SuppressionCheckResponse], error) {
+	endpoint := "/suppressions/check?email=" + url.QueryEscape(email)
+	return makeRequest[SuppressionCheckResponse](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// CheckSuppressed is a send preflight that reports whether an address is
+// suppressed, returning an error if the check itself fails
+func (s *SuppressionService) CheckSuppressed(ctx context.Context, email string) (bool, error) {
+	resp, err := s.Check(ctx, email)
+	if err != nil {
+		return false, err
+	}
+	if resp.Error != "" {
+		return false, fmt.Errorf("failed to check suppression for %s: %s", email, resp.Error)
+	}
+	return resp.Data != nil && resp.Data.Suppressed, nil
+}
+
+// AuditLogService gives read access to the account audit log
+type AuditLogService struct {
+	client *Inbound
+}
+
+// NewAuditLogService creates a new audit log service
+func NewAuditLogService(client *Inbound) *AuditLogService {
+	return &AuditLogService{client: client}
+}
+
+// List lists audit log entries, newest first, filtered by action, actor,
+// resource, and date range
+func (s *AuditLogService) List(ctx context.Context, params *GetAuditLogRequest) (*ApiResponse[GetAuditLogResponse], error) {
+	endpoint := "/audit-log" + buildQueryString(params)
+	return makeRequest[GetAuditLogResponse](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// IPPoolService manages dedicated sending IPs and the pools that group them,
+// so marketing and transactional traffic can be sent from separate IPs
+type IPPoolService struct {
+	client *Inbound
+}
+
+// NewIPPoolService creates a new IP pool service
+func NewIPPoolService(client *Inbound) *IPPoolService {
+	return &IPPoolService{client: client}
+}
+
+// ListIPs lists the dedicated IPs assigned to the account
+func (s *IPPoolService) ListIPs(ctx context.Context) (*ApiResponse[GetDedicatedIPsResponse], error) {
+	return makeRequest[GetDedicatedIPsResponse](s.client, ctx, "GET", "/ips", nil, nil)
+}
+
+// List lists IP pools
+func (s *IPPoolService) List(ctx context.Context) (*ApiResponse[GetIPPoolsResponse], error) {
+	return makeRequest[GetIPPoolsResponse](s.client, ctx, "GET", "/ip-pools", nil, nil)
+}
+
+// Create creates an IP pool
+func (s *IPPoolService) Create(ctx context.Context, params *PostIPPoolsRequest) (*ApiResponse[IPPool], error) {
+	return makeRequest[IPPool](s.client, ctx, "POST", "/ip-pools", params, nil)
+}
+
+// Get gets an IP pool by ID
+func (s *IPPoolService) Get(ctx context.Context, id string) (*ApiResponse[IPPool], error) {
+	endpoint := fmt.Sprintf("/ip-pools/%s", id)
+	return makeRequest[IPPool](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// Delete deletes an IP pool. Its IPs and domains return to the shared pool.
+func (s *IPPoolService) Delete(ctx context.Context, id string) (*ApiResponse[DeleteIPPoolByIDResponse], error) {
+	endpoint := fmt.Sprintf("/ip-pools/%s", id)
+	return makeRequest[DeleteIPPoolByIDResponse](s.client, ctx, "DELETE", endpoint, nil, nil)
+}
+
+// AttachDomains sends mail from the given domains through the pool
+func (s *IPPoolService) AttachDomains(ctx context.Context, id string, domainIDs ...string) (*ApiResponse[IPPool], error) {
+	endpoint := fmt.Sprintf("/ip-pools/%s/domains", id)
+	return makeRequest[IPPool](s.client, ctx, "POST", endpoint, &PostIPPoolDomainsRequest{DomainIDs: domainIDs}, nil)
+}
+
+// DetachDomain moves a domain back to the shared pool
+func (s *IPPoolService) DetachDomain(ctx context.Context, id, domainID string) (*ApiResponse[IPPool], error) {
+	endpoint := fmt.Sprintf("/ip-pools/%s/domains/%s", id, domainID)
+	return makeRequest[IPPool](s.client, ctx, "DELETE", endpoint, nil, nil)
+}
+
+// VerifyService checks addresses before mail is sent to them
+type VerifyService struct {
+	client *Inbound
+}
+
+// NewVerifyService creates a new verify service
+func NewVerifyService(client *Inbound) *VerifyService {
+	return &VerifyService{client: client}
+}
+
+// Address checks whether an address is likely to accept mail, without
+// sending to it
+func (s *VerifyService) Address(ctx context.Context, email string) (*ApiResponse[AddressVerification], error) {
+	endpoint := "/verify/address?email=" + url.QueryEscape(email)
+	return makeRequest[AddressVerification](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// Datasets gets the disposable-domain and role-account lists the API uses
+// for verification, for local classification with AddressClassifier
+func (s *VerifyService) Datasets(ctx context.Context) (*ApiResponse[AddressDatasets], error) {
+	return makeRequest[AddressDatasets](s.client, ctx, "GET", "/verify/datasets", nil, nil)
+}
+
+// RuleService manages server-side routing rules for received mail
+type RuleService struct {
+	client *Inbound
+}
+
+// NewRuleService creates a new rule service
+func NewRuleService(client *Inbound) *RuleService {
+	return &RuleService{client: client}
+}
+
+// Create creates a routing rule
+func (s *RuleService) Create(ctx context.Context, params *PostRulesRequest) (*ApiResponse[Rule], error) {
+	return makeRequest[Rule](s.client, ctx, "POST", "/rules", params, nil)
+}
+
+// List lists routing rules in priority order
+func (s *RuleService) List(ctx context.Context, params *GetRulesRequest) (*ApiResponse[GetRulesResponse], error) {
+	endpoint := "/rules" + buildQueryString(params)
+	return makeRequest[GetRulesResponse](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// Get gets a routing rule by ID
+func (s *RuleService) Get(ctx context.Context, id string) (*ApiResponse[Rule], error) {
+	endpoint := fmt.Sprintf("/rules/%s", id)
+	return makeRequest[Rule](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// Update updates a routing rule
+func (s *RuleService) Update(ctx context.Context, id string, params *PutRuleByIDRequest) (*ApiResponse[Rule], error) {
+	endpoint := fmt.Sprintf("/rules/%s", id)
+	return makeRequest[Rule](s.client, ctx, "PUT", endpoint, params, nil)
+}
+
+// Delete deletes a routing rule
+func (s *RuleService) Delete(ctx context.Context, id string) (*ApiResponse[DeleteRuleByIDResponse], error) {
+	endpoint := fmt.Sprintf("/rules/%s", id)
+	return makeRequest[DeleteRuleByIDResponse](s.client, ctx, "DELETE", endpoint, nil, nil)
+}
+
+// ForwardingService manages forwarding rules that relay received mail to
+// one or more external addresses
+type ForwardingService struct {
+	client *Inbound
+}
+
+// NewForwardingService creates a new forwarding service
+func NewForwardingService(client *Inbound) *ForwardingService {
+	return &ForwardingService{client: client}
+}
+
+// Create creates a forwarding rule
+func (s *ForwardingService) Create(ctx context.Context, params *PostForwardingRulesRequest) (*ApiResponse[ForwardingRule], error) {
+	return makeRequest[ForwardingRule](s.client, ctx, "POST", "/forwarding-rules", params, nil)
+}
+
+// List lists forwarding rules, optionally filtered by source
+func (s *ForwardingService) List(ctx context.Context, params *GetForwardingRulesRequest) (*ApiResponse[GetForwardingRulesResponse], error) {
+	endpoint := "/forwarding-rules" + buildQueryString(params)
+	return makeRequest[GetForwardingRulesResponse](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// Get gets a forwarding rule by ID
+func (s *ForwardingService) Get(ctx context.Context, id string) (*ApiResponse[ForwardingRule], error) {
+	endpoint := fmt.Sprintf("/forwarding-rules/%s", id)
+	return makeRequest[ForwardingRule](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// Update updates a forwarding rule
+func (s *ForwardingService) Update(ctx context.Context, id string, params *PutForwardingRuleByIDRequest) (*ApiResponse[ForwardingRule], error) {
+	endpoint := fmt.Sprintf("/forwarding-rules/%s", id)
+	return makeRequest[ForwardingRule](s.client, ctx, "PUT", endpoint, params, nil)
+}
+
+// Delete deletes a forwarding rule
+func (s *ForwardingService) Delete(ctx context.Context, id string) (*ApiResponse[DeleteForwardingRuleByIDResponse], error) {
+	endpoint := fmt.Sprintf("/forwarding-rules/%s", id)
+	return makeRequest[DeleteForwardingRuleByIDResponse](s.client, ctx, "DELETE", endpoint, nil, nil)
+}
+
+// SenderFilterService blocks or allows inbound mail from specific sender
+// addresses and domains, per receiving domain
+type SenderFilterService struct {
+	client *Inbound
+}
+
+// NewSenderFilterService creates a new sender filter service
+func NewSenderFilterService(client *Inbound) *SenderFilterService {
+	return &SenderFilterService{client: client}
+}
+
+// List lists the sender filters of a domain
+func (s *SenderFilterService) List(ctx context.Context, domainID string, params *GetSenderFiltersRequest) (*ApiResponse[GetSenderFiltersResponse], error) {
+	endpoint := fmt.Sprintf("/domains/%s/sender-filters", domainID) + buildQueryString(params)
+	return makeRequest[GetSenderFiltersResponse](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// Block rejects inbound mail from the given addresses or domains
+func (s *SenderFilterService) Block(ctx context.Context, domainID string, senders ...string) (*ApiResponse[PostSenderFiltersResponse], error) {
+	return s.Create(ctx, domainID, &PostSenderFiltersRequest{Action: "block", Senders: senders})
+}
+
+// Allow accepts inbound mail from the given addresses or domains even when
+// a broader block matches
+func (s *SenderFilterService) Allow(ctx context.Context, domainID string, senders ...string) (*ApiResponse[PostSenderFiltersResponse], error) {
+	return s.Create(ctx, domainID, &PostSenderFiltersRequest{Action: "allow", Senders: senders})
+}
+
+// Create adds block or allow filters with an optional reason
+func (s *SenderFilterService) Create(ctx context.Context, domainID string, params *PostSenderFiltersRequest) (*ApiResponse[PostSenderFiltersResponse], error) {
+	endpoint := fmt.Sprintf("/domains/%s/sender-filters", domainID)
+	return makeRequest[PostSenderFiltersResponse](s.client, ctx, "POST", endpoint, params, nil)
+}
+
+// Remove deletes a sender filter
+func (s *SenderFilterService) Remove(ctx context.Context, domainID, filterID string) (*ApiResponse[any], error) {
+	endpoint := fmt.Sprintf("/domains/%s/sender-filters/%s", domainID, filterID)
+	return makeRequest[any](s.client, ctx, "DELETE", endpoint, nil, nil)
+}
+
+// Check reports whether mail from sender would be blocked or allowed
+func (s *SenderFilterService) Check(ctx context.Context, domainID, sender string) (*ApiResponse[SenderFilterCheckResponse], error) {
+	endpoint := fmt.Sprintf("/domains/%s/sender-filters/check?sender=%s", domainID, url.QueryEscape(sender))
+	return makeRequest[SenderFilterCheckResponse](s.client, ctx, "GET", endpoint, nil, nil)
+}
+
+// Service accessors. Each returns the shared instance created by NewClient.
+
+// Mail returns the inbound mail service
 func (c *Inbound) Mail() *MailService {
-	return NewMailService(c)
+	return c.mail
 }
 
+// Email returns the outbound email service
 func (c *Inbound) Email() *EmailService {
-	return NewEmailService(c)
+	return c.email
 }
 
+// Domain returns the domain management service
 func (c *Inbound) Domain() *DomainService {
-	return NewDomainService(c)
+	return c.domain
 }
 
+// Endpoint returns the endpoint management service
 func (c *Inbound) Endpoint() *EndpointService {
-	return NewEndpointService(c)
+	return c.endpoint
 }
 
+// Thread returns the thread management service
 func (c *Inbound) Thread() *ThreadService {
-	return NewThreadService(c)
+	return c.thread
 }
 
+// Attachment returns the attachment service
 func (c *Inbound) Attachment() *AttachmentService {
-	return NewAttachmentService(c)
+	return c.attachment
+}
+
+// Template returns the email template service
+func (c *Inbound) Template() *TemplateService {
+	return c.template
+}
+
+// Broadcast returns the broadcast (campaign) service
+func (c *Inbound) Broadcast() *BroadcastService {
+	return c.broadcast
+}
+
+// Contact returns the contact and audience service
+func (c *Inbound) Contact() *ContactService {
+	return c.contact
+}
+
+// Suppression returns the suppression list service
+func (c *Inbound) Suppression() *SuppressionService {
+	return c.suppression
+}
+
+// AuditLog returns the audit log service
+func (c *Inbound) AuditLog() *AuditLogService {
+	return c.auditLog
+}
+
+// IPPool returns the dedicated IP pool service
+func (c *Inbound) IPPool() *IPPoolService {
+	return c.ipPool
+}
+
+// Verify returns the address verification service
+func (c *Inbound) Verify() *VerifyService {
+	return c.verify
+}
+
+// Rule returns the routing rule service
+func (c *Inbound) Rule() *RuleService {
+	return c.rule
+}
+
+// Forwarding returns the forwarding rule service
+func (c *Inbound) Forwarding() *ForwardingService {
+	return c.forwarding
+}
+
+// SenderFilter returns the inbound sender block/allow list service
+func (c *Inbound) SenderFilter() *SenderFilterService {
+	return c.senderFilter
 }
 
 // Convenience Methods
@@ -685,22 +1554,25 @@ func (c *Inbound) QuickReply(ctx context.Context, emailID, message, from string,
 	return c.Email().Reply(ctx, emailID, params, options)
 }
 
-// SetupDomain provides one-step domain setup with optional webhook
-func (c *Inbound) SetupDomain(ctx context.Context, domain string, webhookURL *string) (*ApiResponse[any], error) {
+// SetupDomain provides one-step domain setup with optional webhook. If the
+// domain is created but the webhook endpoint is not, the result holds the
+// domain alongside the error.
+func (c *Inbound) SetupDomain(ctx context.Context, domain string, webhookURL *string) (*ApiResponse[SetupDomainResult], error) {
 	// First create the domain
 	domainResult, err := c.Domain().Create(ctx, &PostDomainsRequest{Domain: domain})
 	if err != nil {
-		return &ApiResponse[any]{Error: err.Error()}, nil
+		return &ApiResponse[SetupDomainResult]{Error: err.Error()}, nil
 	}
 	if domainResult.Error != "" {
-		return &ApiResponse[any]{Error: domainResult.Error}, nil
+		return &ApiResponse[SetupDomainResult]{Error: domainResult.Error, StatusCode: domainResult.StatusCode}, nil
 	}
+	result := &SetupDomainResult{Domain: domainResult.Data, DNSRecords: domainResult.Data.DNSRecords}
 
 	// If webhook URL provided, create an endpoint
 	if webhookURL != nil && *webhookURL != "" {
 		endpointResult, err := c.Endpoint().Create(ctx, &PostEndpointsRequest{
 			Name: domain + " Webhook",
-			Type: "webhook",
+			Type: EndpointTypeWebhook,
 			Config: &WebhookConfig{
 				URL:           *webhookURL,
 				Timeout:       30000,
@@ -708,27 +1580,23 @@ func (c *Inbound) SetupDomain(ctx context.Context, domain string, webhookURL *st
 			},
 		})
 		if err != nil {
-			return &ApiResponse[any]{Error: err.Error()}, nil
+			return &ApiResponse[SetupDomainResult]{Data: result, Error: err.Error()}, nil
 		}
-
-		result := map[string]any{
-			"domain":   domainResult.Data,
-			"endpoint": endpointResult.Data,
+		if endpointResult.Error != "" {
+			return &ApiResponse[SetupDomainResult]{Data: result, Error: endpointResult.Error, StatusCode: endpointResult.StatusCode}, nil
 		}
-		var interfaceResult any = result
-		return &ApiResponse[any]{Data: &interfaceResult}, nil
+		result.Endpoint = endpointResult.Data
 	}
 
-	// Convert domain result to any
-	var domainData any = domainResult.Data
-	return &ApiResponse[any]{Data: &domainData}, nil
+	return &ApiResponse[SetupDomainResult]{Data: result, StatusCode: domainResult.StatusCode}, nil
 }
 
-// CreateForwarder creates a simple email forwarding setup
+// CreateForwarder creates a simple email forwarding setup. For pattern
+// sources, multiple destinations, or header rewriting, use Forwarding().
 func (c *Inbound) CreateForwarder(ctx context.Context, from, to string) (*ApiResponse[PostEndpointsResponse], error) {
 	params := &PostEndpointsRequest{
 		Name: fmt.Sprintf("Forward %s to %s", from, to),
-		Type: "email",
+		Type: EndpointTypeEmail,
 		Config: &EmailConfig{
 			Email: to,
 		},
@@ -736,6 +1604,27 @@ func (c *Inbound) CreateForwarder(ctx context.Context, from, to string) (*ApiRes
 	return c.Endpoint().Create(ctx, params)
 }
 
+// CreateAutoReply sets an out-of-office reply on an address between startsAt
+// and endsAt (zero times leave the window open-ended), replying at most once
+// a day to each sender
+func (c *Inbound) CreateAutoReply(ctx context.Context, address, subject, text string, startsAt, endsAt time.Time) (*ApiResponse[AutoReply], error) {
+	emailAddress, err := c.Email().Address.findByAddress(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	params := &PutAutoReplyRequest{
+		Subject: subject,
+		Text:    &text,
+	}
+	if !startsAt.IsZero() {
+		params.StartsAt = &startsAt
+	}
+	if !endsAt.IsZero() {
+		params.EndsAt = &endsAt
+	}
+	return c.Email().Address.SetAutoReply(ctx, emailAddress.ID, params)
+}
+
 // ScheduleReminder creates a quick scheduled email reminder
 func (c *Inbound) ScheduleReminder(ctx context.Context, to, subject, when, from string, options *IdempotencyOptions) (*ApiResponse[PostScheduleEmailResponse], error) {
 	text := fmt.Sprintf("Reminder: %s", subject)
@@ -749,6 +1638,20 @@ func (c *Inbound) ScheduleReminder(ctx context.Context, to, subject, when, from
 	return c.Email().Schedule(ctx, params, options)
 }
 
+// RealtimeToken issues a short-lived token for the realtime WebSocket API,
+// used by the realtime package
+func (c *Inbound) RealtimeToken(ctx context.Context) (*ApiResponse[PostRealtimeTokenResponse], error) {
+	return makeRequest[PostRealtimeTokenResponse](c, ctx, "POST", "/realtime/token", nil, nil)
+}
+
+// Changes returns the mail, thread, and read/archive changes since a token
+// from a previous call. A 410 response means the token has expired and the
+// mirror must be rebuilt; MailSync.Sync handles this and paging.
+func (c *Inbound) Changes(ctx context.Context, params *GetChangesRequest) (*ApiResponse[GetChangesResponse], error) {
+	endpoint := "/changes" + buildQueryString(params)
+	return makeRequest[GetChangesResponse](c, ctx, "GET", endpoint, nil, nil)
+}
+
 // Helper functions for creating pointers to basic types
 
 // String returns a pointer to the string value passed in.
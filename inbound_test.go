@@ -1,6 +1,16 @@
 package inboundgo
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -108,6 +118,56 @@ func TestServiceInitialization(t *testing.T) {
 	if emailService.Address == nil {
 		t.Error("Email address service should not be nil")
 	}
+
+	// Services are shared rather than allocated per call
+	if client.Mail() != client.Mail() {
+		t.Error("Mail() should return the same instance on every call")
+	}
+	if client.Email() != client.Email() || client.Email().Address != client.Email().Address {
+		t.Error("Email() should return the same instance on every call")
+	}
+	if client.Domain() != client.Domain() || client.Endpoint() != client.Endpoint() {
+		t.Error("Domain() and Endpoint() should return shared instances")
+	}
+	if client.Thread() != client.Thread() || client.Attachment() != client.Attachment() {
+		t.Error("Thread() and Attachment() should return shared instances")
+	}
+	if client.Template() != client.Template() || client.Broadcast() != client.Broadcast() {
+		t.Error("Template() and Broadcast() should return shared instances")
+	}
+	if client.Contact() != client.Contact() || client.Suppression() != client.Suppression() {
+		t.Error("Contact() and Suppression() should return shared instances")
+	}
+	if client.AuditLog() != client.AuditLog() {
+		t.Error("AuditLog() should return the same instance on every call")
+	}
+	if client.IPPool() != client.IPPool() {
+		t.Error("IPPool() should return the same instance on every call")
+	}
+	if client.Verify() != client.Verify() {
+		t.Error("Verify() should return the same instance on every call")
+	}
+	if client.Rule() != client.Rule() {
+		t.Error("Rule() should return the same instance on every call")
+	}
+	if client.Forwarding() != client.Forwarding() {
+		t.Error("Forwarding() should return the same instance on every call")
+	}
+	if client.SenderFilter() != client.SenderFilter() {
+		t.Error("SenderFilter() should return the same instance on every call")
+	}
+}
+
+func BenchmarkServiceAccessors(b *testing.B) {
+	client, err := NewClient("test-api-key")
+	if err != nil {
+		b.Fatalf("Failed to create client: %v", err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = client.Email().Address
+		_ = client.Mail()
+	}
 }
 
 func TestBuildQueryString(t *testing.T) {
@@ -152,3 +212,106 @@ func TestWithHTTPClient(t *testing.T) {
 		t.Error("WithHTTPClient should return the same client instance")
 	}
 }
+
+func TestPooledRequestBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req PostEmailsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(PostEmailsResponse{ID: req.Subject})
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	// Concurrent sends must never observe another request's pooled buffer
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			subject := fmt.Sprintf("subject-%d", i)
+			resp, err := client.Email().Send(context.Background(), &PostEmailsRequest{
+				From:    "test@example.com",
+				To:      "user@example.com",
+				Subject: subject,
+				Text:    String(strings.Repeat("x", i*1024)),
+			}, nil)
+			if err != nil || resp.Error != "" {
+				t.Errorf("Send failed: %v %s", err, resp.Error)
+				return
+			}
+			if resp.Data.ID != subject {
+				t.Errorf("Expected echoed subject '%s', got '%s'", subject, resp.Data.ID)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func benchmarkAttachmentRequest() *PostEmailsRequest {
+	content := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("attachment"), 100*1024))
+	return &PostEmailsRequest{
+		From:    "test@example.com",
+		To:      "user@example.com",
+		Subject: "Large attachment",
+		Attachments: []AttachmentData{
+			{Filename: "report.pdf", Content: &content},
+		},
+	}
+}
+
+func BenchmarkRequestBodyEncoding(b *testing.B) {
+	body := benchmarkAttachmentRequest()
+
+	b.Run("marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			data, err := json.Marshal(body)
+			if err != nil {
+				b.Fatal(err)
+			}
+			_ = bytes.NewReader(data)
+		}
+	})
+
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buf, err := encodeRequestBody(body)
+			if err != nil {
+				b.Fatal(err)
+			}
+			_ = bytes.NewReader(buf.Bytes())
+			releaseBuffer(buf)
+		}
+	})
+}
+
+func BenchmarkSendLargeBody(b *testing.B) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Write([]byte(`{"id": "email-123"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", server.URL)
+	if err != nil {
+		b.Fatalf("Failed to create client: %v", err)
+	}
+	body := benchmarkAttachmentRequest()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.Email().Send(context.Background(), body, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
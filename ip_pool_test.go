@@ -0,0 +1,76 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestIPPoolService(t *testing.T) {
+	pool := `{"id": "pool-1", "name": "marketing", "ips": ["203.0.113.10"], "domainIds": ["dom-1", "dom-2"]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /ips":
+			w.Write([]byte(`{"data": [{"ip": "203.0.113.10", "poolId": "pool-1", "warmupStatus": "warm"}, {"ip": "203.0.113.11", "poolId": null, "warmupStatus": "warming"}]}`))
+		case "POST /ip-pools":
+			var req inboundgo.PostIPPoolsRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Name != "marketing" || len(req.IPs) != 1 {
+				t.Errorf("Unexpected create request: %+v", req)
+			}
+			w.Write([]byte(pool))
+		case "POST /ip-pools/pool-1/domains":
+			var req inboundgo.PostIPPoolDomainsRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if len(req.DomainIDs) != 2 || req.DomainIDs[1] != "dom-2" {
+				t.Errorf("Unexpected attach request: %+v", req)
+			}
+			w.Write([]byte(pool))
+		case "DELETE /ip-pools/pool-1/domains/dom-2":
+			w.Write([]byte(`{"id": "pool-1", "name": "marketing", "ips": ["203.0.113.10"], "domainIds": ["dom-1"]}`))
+		case "DELETE /ip-pools/pool-1":
+			w.Write([]byte(`{"message": "deleted"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+	svc := client.IPPool()
+
+	ips, err := svc.ListIPs(ctx)
+	if err != nil || ips.Error != "" || len(ips.Data.Data) != 2 || ips.Data.Data[1].PoolID != nil {
+		t.Fatalf("ListIPs failed: %v %+v", err, ips)
+	}
+
+	created, err := svc.Create(ctx, &inboundgo.PostIPPoolsRequest{Name: "marketing", IPs: []string{"203.0.113.10"}})
+	if err != nil || created.Error != "" || created.Data.ID != "pool-1" {
+		t.Fatalf("Create failed: %v %+v", err, created)
+	}
+
+	attached, err := svc.AttachDomains(ctx, "pool-1", "dom-1", "dom-2")
+	if err != nil || attached.Error != "" || len(attached.Data.DomainIDs) != 2 {
+		t.Fatalf("AttachDomains failed: %v %+v", err, attached)
+	}
+
+	detached, err := svc.DetachDomain(ctx, "pool-1", "dom-2")
+	if err != nil || detached.Error != "" || len(detached.Data.DomainIDs) != 1 {
+		t.Fatalf("DetachDomain failed: %v %+v", err, detached)
+	}
+
+	deleted, err := svc.Delete(ctx, "pool-1")
+	if err != nil || deleted.Error != "" || deleted.Data.Message != "deleted" {
+		t.Fatalf("Delete failed: %v %+v", err, deleted)
+	}
+}
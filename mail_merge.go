@@ -0,0 +1,284 @@
+package inboundgo
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"net/mail"
+	"strings"
+	"text/template"
+)
+
+// MergeRecipient is one recipient of a mail merge
+type MergeRecipient struct {
+	// To is the recipient's address, optionally with a display name.
+	To string
+	// Data is what the templates are executed with: a struct, a map, or
+	// the row of a CSV file.
+	Data any
+	// IdempotencyKey makes sending to this recipient happen at most once.
+	// Send generates one when it is empty, and the results carry it so
+	// failed recipients can be retried safely.
+	IdempotencyKey string
+}
+
+// MergeRecipients builds merge recipients from a slice of your own records,
+// with to returning each record's address. The record is the template data.
+func MergeRecipients[T any](records []T, to func(T) string) []MergeRecipient {
+	recipients := make([]MergeRecipient, len(records))
+	for i, r := range records {
+		recipients[i] = MergeRecipient{To: to(r), Data: r}
+	}
+	return recipients
+}
+
+// ReadMergeCSV reads merge recipients from CSV with a header row. The
+// email column is found as in ContactService.ImportCSV, and each row's data
+// is a map from header to value: templates use {{.city}} or, for headers
+// that are not identifiers, {{index . "First Name"}}. Email, first name,
+// and last name are also available as .email, .firstName, and .lastName
+// however their columns are spelled.
+func ReadMergeCSV(r io.Reader) ([]MergeRecipient, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := make([]string, len(header))
+	emailColumn := -1
+	for i, h := range header {
+		columns[i] = contactColumn(h)
+		if columns[i] == "email" && emailColumn < 0 {
+			emailColumn = i
+		}
+	}
+	if emailColumn < 0 {
+		return nil, errors.New("CSV has no email column")
+	}
+
+	var recipients []MergeRecipient
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return recipients, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV row %d: %w", row, err)
+		}
+		data := make(map[string]string, len(header)+3)
+		for i, value := range record {
+			if i >= len(header) {
+				break
+			}
+			value = strings.TrimSpace(value)
+			data[strings.TrimSpace(header[i])] = value
+			if columns[i] != "" && columns[i] != "unsubscribed" {
+				data[columns[i]] = value
+			}
+		}
+		if data["email"] == "" {
+			return nil, fmt.Errorf("CSV row %d has no email", row)
+		}
+		recipients = append(recipients, MergeRecipient{To: data["email"], Data: data})
+	}
+}
+
+// MergeResult is the outcome of sending to one merge recipient
+type MergeResult struct {
+	// Recipient is the recipient as sent, with its idempotency key.
+	Recipient MergeRecipient
+	// ID is the sent email's ID, empty on failure.
+	ID     string
+	Status SendStatus
+	// Error is the render, API, or network error, empty on success.
+	Error string
+	// Err is Error as a typed error, as in SendResult.
+	Err        error
+	StatusCode int
+	Attempts   int
+}
+
+// MergeResults are the results of a mail merge, one per recipient
+type MergeResults []MergeResult
+
+// Count returns how many results have status
+func (r MergeResults) Count(status SendStatus) int {
+	n := 0
+	for _, result := range r {
+		if result.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Failed returns the recipients that failed, with their idempotency keys,
+// to pass to Send again. Duplicates are not included: they were sent.
+func (r MergeResults) Failed() []MergeRecipient {
+	var failed []MergeRecipient
+	for _, result := range r {
+		if result.Status == SendFailed {
+			failed = append(failed, result.Recipient)
+		}
+	}
+	return failed
+}
+
+// MailMerge sends a personalized copy of an email to each of a list of
+// recipients. The Subject and Text of the template are text/template
+// templates, and its HTML an html/template template, executed with each
+// recipient's Data; referring to a field or key the data does not have is
+// an error for that recipient rather than a "<no value>" in the email.
+// Sends go through a SenderPool, so they are concurrent, rate limited, and
+// retried as its options say.
+type MailMerge struct {
+	client  *Inbound
+	email   PostEmailsRequest
+	opts    SenderPoolOptions
+	subject *template.Template
+	text    *template.Template
+	html    *htmltemplate.Template
+}
+
+// NewMailMerge parses the templates of email, whose To is ignored, and
+// returns a merge that sends it with client. The pool's OnSuccess and
+// OnFailure callbacks receive the recipient's index as the job Metadata.
+func NewMailMerge(client *Inbound, email *PostEmailsRequest, opts SenderPoolOptions) (*MailMerge, error) {
+	m := &MailMerge{client: client, email: *email, opts: opts}
+	var err error
+	if m.subject, err = template.New("subject").Option("missingkey=error").Parse(email.Subject); err != nil {
+		return nil, fmt.Errorf("failed to parse subject template: %w", err)
+	}
+	if email.Text != nil {
+		if m.text, err = template.New("text").Option("missingkey=error").Parse(*email.Text); err != nil {
+			return nil, fmt.Errorf("failed to parse text template: %w", err)
+		}
+	}
+	if email.HTML != nil {
+		if m.html, err = htmltemplate.New("html").Option("missingkey=error").Parse(*email.HTML); err != nil {
+			return nil, fmt.Errorf("failed to parse HTML template: %w", err)
+		}
+	}
+	return m, nil
+}
+
+// Render returns the email for one recipient, for previewing a merge. A
+// Body template on the email is given the recipient's data, and is
+// rendered when the email is sent.
+func (m *MailMerge) Render(recipient MergeRecipient) (*PostEmailsRequest, error) {
+	if _, err := mail.ParseAddress(recipient.To); err != nil {
+		return nil, fmt.Errorf("invalid recipient %q", recipient.To)
+	}
+	req := m.email
+	req.To = recipient.To
+	var b strings.Builder
+	if err := m.subject.Execute(&b, recipient.Data); err != nil {
+		return nil, fmt.Errorf("failed to render subject: %w", err)
+	}
+	req.Subject = b.String()
+	if m.text != nil {
+		b.Reset()
+		if err := m.text.Execute(&b, recipient.Data); err != nil {
+			return nil, fmt.Errorf("failed to render text body: %w", err)
+		}
+		req.Text = String(b.String())
+	}
+	if m.html != nil {
+		b.Reset()
+		if err := m.html.Execute(&b, recipient.Data); err != nil {
+			return nil, fmt.Errorf("failed to render HTML body: %w", err)
+		}
+		req.HTML = String(b.String())
+	}
+	if m.email.Body != nil {
+		body := *m.email.Body
+		body.Data = recipient.Data
+		req.Body = &body
+	}
+	return &req, nil
+}
+
+// Send renders and sends the email to every recipient and returns a result
+// for each, in the order of recipients. Recipients whose email fails to
+// render are reported without being sent. If ctx is cancelled, recipients
+// not yet sent are reported as failed and ctx.Err() is returned.
+//
+// Retrying with results.Failed() sends to each failed recipient under the
+// same idempotency key, so one whose first send did reach the API is
+// reported as a duplicate instead of receiving the email twice.
+func (m *MailMerge) Send(ctx context.Context, recipients []MergeRecipient) (MergeResults, error) {
+	results := make(MergeResults, len(recipients))
+	for i, r := range recipients {
+		if r.IdempotencyKey == "" {
+			r.IdempotencyKey = newIdempotencyKey()
+		}
+		results[i] = MergeResult{Recipient: r, Status: SendFailed, Error: errNotSent.Error(), Err: errNotSent}
+	}
+
+	// Each job writes only its own result, so no lock is needed
+	record := func(result SendResult) {
+		r := &results[result.Job.Metadata.(int)]
+		r.Status, r.Error, r.Err = result.Status, result.Error, result.Err
+		r.StatusCode, r.Attempts = result.StatusCode, result.Attempts
+		if result.Response != nil {
+			r.ID = result.Response.ID
+		}
+	}
+	chain := func(callback func(SendResult)) func(SendResult) {
+		return func(result SendResult) {
+			record(result)
+			if callback != nil {
+				callback(result)
+			}
+		}
+	}
+	opts := m.opts
+	opts.OnSuccess = chain(m.opts.OnSuccess)
+	opts.OnFailure = chain(m.opts.OnFailure)
+	opts.OnDuplicate = chain(m.opts.OnDuplicate)
+	if m.opts.OnDuplicate == nil {
+		opts.OnDuplicate = chain(m.opts.OnFailure)
+	}
+
+	jobs := make(chan SendJob)
+	go func() {
+		defer close(jobs)
+		for i, r := range recipients {
+			req, err := m.Render(r)
+			if err != nil {
+				results[i].Error, results[i].Err = err.Error(), err
+				continue
+			}
+			job := SendJob{
+				Request:  req,
+				Options:  &IdempotencyOptions{IdempotencyKey: results[i].Recipient.IdempotencyKey},
+				Metadata: i,
+			}
+			select {
+			case jobs <- job:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	err := NewSenderPool(m.client, opts).Run(ctx, jobs)
+	// Drain the producer so it does not write results after we return
+	for range jobs {
+	}
+	if err != nil {
+		for i := range results {
+			if results[i].Err == errNotSent {
+				results[i].Err = fmt.Errorf("%w: %w", errNotSent, err)
+			}
+		}
+	}
+	return results, err
+}
+
+// errNotSent is the result of recipients a cancelled merge did not reach
+var errNotSent = errors.New("not sent")
@@ -0,0 +1,142 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestMailMerge(t *testing.T) {
+	var mu sync.Mutex
+	sent := map[string]inboundgo.PostEmailsRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req inboundgo.PostEmailsRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		to := req.To.(string)
+		if strings.HasPrefix(to, "reject") {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"error":"recipient rejected"}`))
+			return
+		}
+		mu.Lock()
+		sent[to] = req
+		mu.Unlock()
+		json.NewEncoder(w).Encode(inboundgo.PostEmailsResponse{ID: "email-" + to})
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+
+	recipients, err := inboundgo.ReadMergeCSV(strings.NewReader("Email Address,First Name,plan\n" +
+		"jane@example.com,Jane,pro\n" +
+		"tom@example.com,<Tom>,free\n" +
+		"reject@example.com,Rex,pro\n"))
+	if err != nil {
+		t.Fatalf("ReadMergeCSV failed: %v", err)
+	}
+	type customer struct{ Email, FirstName string }
+	recipients = append(recipients, inboundgo.MergeRecipients([]customer{{"ann@example.com", "Ann"}}, func(c customer) string { return c.Email })...)
+
+	merge, err := inboundgo.NewMailMerge(client, &inboundgo.PostEmailsRequest{
+		From:    "app@example.com",
+		Subject: "Welcome, {{.firstName}}",
+		Text:    inboundgo.String("You are on the {{.plan}} plan."),
+		HTML:    inboundgo.String(`<p>Hi {{index . "First Name"}}</p>`),
+	}, inboundgo.SenderPoolOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("NewMailMerge failed: %v", err)
+	}
+	results, err := merge.Send(context.Background(), recipients)
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	if len(results) != 4 {
+		t.Fatalf("Expected 4 results, got %d", len(results))
+	}
+	if results[0].ID != "email-jane@example.com" || results[0].Error != "" {
+		t.Errorf("Unexpected result for jane: %+v", results[0])
+	}
+	if results[2].Error != "recipient rejected" || results[2].StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected the rejection to be reported, got %+v", results[2])
+	}
+	if !strings.Contains(results[3].Error, "failed to render subject") || results[3].Attempts != 0 {
+		t.Errorf("Expected a render error for a struct without the field, got %+v", results[3])
+	}
+
+	jane := sent["jane@example.com"]
+	if jane.Subject != "Welcome, Jane" || *jane.Text != "You are on the pro plan." {
+		t.Errorf("Unexpected email for jane: %q %q", jane.Subject, *jane.Text)
+	}
+	if tom := sent["tom@example.com"]; *tom.HTML != "<p>Hi &lt;Tom&gt;</p>" {
+		t.Errorf("Expected HTML to be escaped, got %q", *tom.HTML)
+	}
+	if _, ok := sent["ann@example.com"]; ok {
+		t.Error("Expected the email that failed to render not to be sent")
+	}
+}
+
+func TestMailMergeRetryFailed(t *testing.T) {
+	var mu sync.Mutex
+	keys := map[string][]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req inboundgo.PostEmailsRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		to := req.To.(string)
+		mu.Lock()
+		keys[to] = append(keys[to], r.Header.Get("Idempotency-Key"))
+		attempt := len(keys[to])
+		mu.Unlock()
+		switch {
+		case to == "flaky@example.com" && attempt == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error":"try again"}`))
+		case to == "dup@example.com":
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error":"Idempotency key already used"}`))
+		default:
+			json.NewEncoder(w).Encode(inboundgo.PostEmailsResponse{ID: "email-" + to})
+		}
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+
+	merge, _ := inboundgo.NewMailMerge(client, &inboundgo.PostEmailsRequest{From: "app@example.com", Subject: "Hi"}, inboundgo.SenderPoolOptions{})
+	results, err := merge.Send(context.Background(), []inboundgo.MergeRecipient{
+		{To: "ok@example.com"},
+		{To: "flaky@example.com"},
+		{To: "dup@example.com", IdempotencyKey: "dup-1"},
+		{To: "not an address"},
+	})
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if results.Count(inboundgo.SendSucceeded) != 1 || results.Count(inboundgo.SendDuplicate) != 1 || results.Count(inboundgo.SendFailed) != 2 {
+		t.Errorf("Unexpected results %+v", results)
+	}
+	var apiErr *inboundgo.ApiError
+	if !errors.As(results[1].Err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected an *ApiError for the failed send, got %v", results[1].Err)
+	}
+	if keys["dup@example.com"][0] != "dup-1" {
+		t.Errorf("Expected the recipient's own key, got %v", keys["dup@example.com"])
+	}
+
+	failed := results.Failed()
+	if len(failed) != 2 || failed[0].To != "flaky@example.com" {
+		t.Fatalf("Unexpected failed recipients %+v", failed)
+	}
+	retry, _ := merge.Send(context.Background(), failed[:1])
+	if retry[0].Status != inboundgo.SendSucceeded {
+		t.Errorf("Expected the retry to succeed, got %+v", retry[0])
+	}
+	if k := keys["flaky@example.com"]; len(k) != 2 || k[0] == "" || k[0] != k[1] {
+		t.Errorf("Expected the retry to reuse the idempotency key, got %v", k)
+	}
+}
@@ -0,0 +1,142 @@
+package inboundgo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MaildirExporter writes inbound emails into a Maildir so standard mail
+// indexing and search tools (notmuch, mu, Dovecot, mutt) can be used over
+// exported data.
+//
+// Unread emails are delivered to new/. Read emails go to cur/ with the S
+// (seen) flag. Archived emails are placed in the ArchiveFolder Maildir++
+// subfolder instead of the top-level Maildir.
+type MaildirExporter struct {
+	mail *MailService
+	// Dir is the root of the Maildir; it is created if missing.
+	Dir string
+	// ArchiveFolder is the Maildir++ subfolder for archived emails
+	// (default ".Archive").
+	ArchiveFolder string
+}
+
+// NewMaildirExporter creates a Maildir exporter rooted at dir
+func NewMaildirExporter(client *Inbound, dir string) *MaildirExporter {
+	return &MaildirExporter{mail: client.Mail(), Dir: dir, ArchiveFolder: ".Archive"}
+}
+
+// Export writes every email matching filter into the Maildir and returns the
+// number of emails written. Set filter.IncludeArchived to export archived
+// emails as well.
+func (e *MaildirExporter) Export(ctx context.Context, filter *GetMailRequest) (int, error) {
+	exported := 0
+	err := e.mail.each(ctx, filter, func(item EmailItem) error {
+		if _, err := e.ExportEmail(ctx, item); err != nil {
+			return err
+		}
+		exported++
+		return nil
+	})
+	return exported, err
+}
+
+// ExportEmail writes a single email into the Maildir and returns its path.
+// File names are derived from the email ID, so exporting the same email
+// again replaces the earlier copy, moving it if its read or archived state
+// has changed.
+func (e *MaildirExporter) ExportEmail(ctx context.Context, item EmailItem) (string, error) {
+	root := e.Dir
+	if item.IsArchived {
+		folder := e.ArchiveFolder
+		if folder == "" {
+			folder = ".Archive"
+		}
+		root = filepath.Join(e.Dir, folder)
+	}
+	for _, dir := range []string{e.Dir, root} {
+		if err := ensureMaildir(dir); err != nil {
+			return "", err
+		}
+	}
+
+	base := maildirBaseName(item)
+	tmpPath := filepath.Join(root, "tmp", base)
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create maildir file: %w", err)
+	}
+	if err := e.mail.ExportEML(ctx, item.ID, f); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmpPath)
+		return "", err
+	}
+
+	var dest string
+	if item.IsRead {
+		dest = filepath.Join(root, "cur", base+":2,S")
+	} else {
+		dest = filepath.Join(root, "new", base)
+	}
+	if err := os.Rename(tmpPath, dest); err != nil {
+		os.Remove(tmpPath)
+		return "", fmt.Errorf("failed to deliver maildir file: %w", err)
+	}
+	// The old copy goes only once the new one is in place, so a failed
+	// export never loses the email
+	if err := e.removeExisting(base, dest); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
+
+// removeExisting deletes earlier exports of the same email from any folder,
+// except the copy at keep
+func (e *MaildirExporter) removeExisting(base, keep string) error {
+	folder := e.ArchiveFolder
+	if folder == "" {
+		folder = ".Archive"
+	}
+	for _, root := range []string{e.Dir, filepath.Join(e.Dir, folder)} {
+		matches, _ := filepath.Glob(filepath.Join(root, "new", base))
+		cur, _ := filepath.Glob(filepath.Join(root, "cur", base+":2,*"))
+		for _, m := range append(matches, cur...) {
+			if m == keep {
+				continue
+			}
+			if err := os.Remove(m); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ensureMaildir creates the tmp, new, and cur directories under dir
+func ensureMaildir(dir string) error {
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o700); err != nil {
+			return fmt.Errorf("failed to create maildir: %w", err)
+		}
+	}
+	return nil
+}
+
+// maildirBaseName returns a stable unique file name for an email. It starts
+// with the receive time so directory listings sort chronologically.
+func maildirBaseName(item EmailItem) string {
+	id := strings.Map(func(r rune) rune {
+		if r == '/' || r == ':' || r == os.PathSeparator {
+			return '_'
+		}
+		return r
+	}, item.ID)
+	return fmt.Sprintf("%d.%s.inbound", item.ReceivedAt.Unix(), id)
+}
@@ -0,0 +1,97 @@
+package inboundgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestMaildirExporter(t *testing.T) {
+	failing := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if failing {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "Email not found"}`))
+			return
+		}
+		if r.URL.Path == "/mail" {
+			w.Write([]byte(`{"emails": [
+				{"id": "unread-1", "receivedAt": "2025-01-01T00:00:00Z", "isRead": false},
+				{"id": "read-1", "receivedAt": "2025-01-02T00:00:00Z", "isRead": true},
+				{"id": "archived-1", "receivedAt": "2025-01-03T00:00:00Z", "isRead": true, "isArchived": true}
+			], "pagination": {"total": 3}}`))
+			return
+		}
+		id := strings.TrimPrefix(r.URL.Path, "/mail/")
+		w.Write([]byte(`{"id": "` + id + `", "subject": "Hi", "from": "a@example.com", "to": "b@example.com", "textBody": "hello"}`))
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	dir := t.TempDir()
+	exporter := inboundgo.NewMaildirExporter(client, dir)
+	n, err := exporter.Export(context.Background(), &inboundgo.GetMailRequest{IncludeArchived: inboundgo.Bool(true)})
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("Expected 3 exported emails, got %d", n)
+	}
+
+	expect := []string{
+		filepath.Join(dir, "new", "1735689600.unread-1.inbound"),
+		filepath.Join(dir, "cur", "1735776000.read-1.inbound:2,S"),
+		filepath.Join(dir, ".Archive", "cur", "1735862400.archived-1.inbound:2,S"),
+	}
+	for _, path := range expect {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Errorf("Expected maildir file %s: %v", path, err)
+			continue
+		}
+		if !strings.Contains(string(data), "Subject: Hi") {
+			t.Errorf("Expected %s to contain the message", path)
+		}
+	}
+	if entries, _ := os.ReadDir(filepath.Join(dir, "tmp")); len(entries) != 0 {
+		t.Errorf("Expected tmp/ to be empty after delivery, found %d files", len(entries))
+	}
+
+	t.Run("re-export moves messages whose state changed", func(t *testing.T) {
+		received := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		path, err := exporter.ExportEmail(context.Background(), inboundgo.EmailItem{ID: "unread-1", IsRead: true, ReceivedAt: received})
+		if err != nil {
+			t.Fatalf("ExportEmail failed: %v", err)
+		}
+		if !strings.HasSuffix(path, filepath.Join("cur", "1735689600.unread-1.inbound:2,S")) {
+			t.Errorf("Unexpected path: %s", path)
+		}
+		if _, err := os.Stat(expect[0]); !os.IsNotExist(err) {
+			t.Error("Expected the unread copy in new/ to be removed")
+		}
+	})
+
+	t.Run("failed re-export keeps the earlier copy", func(t *testing.T) {
+		failing = true
+		defer func() { failing = false }()
+		received := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+		if _, err := exporter.ExportEmail(context.Background(), inboundgo.EmailItem{ID: "read-1", ReceivedAt: received}); err == nil {
+			t.Fatal("Expected ExportEmail to fail")
+		}
+		if _, err := os.Stat(expect[1]); err != nil {
+			t.Errorf("Expected the earlier copy to survive a failed export: %v", err)
+		}
+	})
+}
@@ -0,0 +1,228 @@
+package inboundgo
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MailStream delivers received emails as they arrive, for receivers that
+// cannot host a public webhook. It reads the server-sent event stream at
+// /mail/stream, reconnecting with Last-Event-ID when the connection drops,
+// and falls back to polling the mail list with a MailWatcher when
+// streaming is unavailable.
+//
+// Only mail received after the stream starts is delivered.
+type MailStream struct {
+	// PollInterval is how often the mail list is checked when the server
+	// does not support streaming (default 10s).
+	PollInterval time.Duration
+	// ReconnectDelay is the wait before reconnecting a dropped stream
+	// (default 1s). The server may change it with a retry field.
+	ReconnectDelay time.Duration
+	// IdleTimeout is how long the stream may go without receiving
+	// anything, keepalives included, before it is replaced (default 90s).
+	// The client's HTTP Timeout does not apply to the stream.
+	IdleTimeout time.Duration
+
+	service     *MailService
+	ctx         context.Context
+	cancel      context.CancelFunc
+	body        io.ReadCloser
+	reader      *bufio.Reader
+	idle        *time.Timer
+	connCancel  context.CancelFunc
+	lastEventID string
+	polling     bool
+	watcher     *MailWatcher
+	polled      <-chan EmailItem
+}
+
+// Stream starts a stream of newly received emails. The connection is made
+// on the first call to Next. Close the stream, or cancel ctx, when done.
+func (s *MailService) Stream(ctx context.Context) *MailStream {
+	ctx, cancel := context.WithCancel(ctx)
+	return &MailStream{
+		PollInterval:   10 * time.Second,
+		ReconnectDelay: time.Second,
+		IdleTimeout:    90 * time.Second,
+		service:        s,
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+}
+
+// Next blocks until the next email arrives. It returns the context's error
+// once the stream is closed or its context ends, and other errors when the
+// API rejects the stream, such as for an invalid API key.
+func (m *MailStream) Next() (*EmailItem, error) {
+	for {
+		if err := m.ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if m.polling {
+			return m.poll()
+		}
+		if m.body == nil {
+			if err := m.connect(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		data, err := m.readEvent()
+		if err != nil {
+			// The connection dropped or timed out; resume where it left off
+			m.closeBody()
+			if err := sleepContext(m.ctx, m.ReconnectDelay); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if data == "" {
+			continue
+		}
+		var item EmailItem
+		if err := json.Unmarshal([]byte(data), &item); err != nil {
+			return nil, fmt.Errorf("failed to parse stream event: %w", err)
+		}
+		return &item, nil
+	}
+}
+
+// Close stops the stream
+func (m *MailStream) Close() error {
+	m.cancel()
+	m.closeBody()
+	return nil
+}
+
+func (m *MailStream) closeBody() {
+	if m.connCancel != nil {
+		m.idle.Stop()
+		m.connCancel()
+		m.idle, m.connCancel = nil, nil
+	}
+	if m.body != nil {
+		m.body.Close()
+		m.body, m.reader = nil, nil
+	}
+}
+
+// connect opens the event stream. Transient failures leave the stream
+// disconnected so Next retries; a server without streaming switches the
+// stream to polling.
+func (m *MailStream) connect() error {
+	headers := map[string]string{"Accept": "text/event-stream", "Cache-Control": "no-cache"}
+	if m.lastEventID != "" {
+		headers["Last-Event-ID"] = m.lastEventID
+	}
+	// The connection is dropped once it has been idle for IdleTimeout
+	ctx, cancel := context.WithCancel(context.WithValue(m.ctx, streamingKey{}, true))
+	m.idle, m.connCancel = time.AfterFunc(m.IdleTimeout, cancel), cancel
+	resp, err := m.service.client.request(ctx, "GET", "/mail/stream", nil, headers)
+	if err != nil {
+		m.closeBody()
+		if m.ctx.Err() != nil {
+			return m.ctx.Err()
+		}
+		return sleepContext(m.ctx, m.ReconnectDelay)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if resp.StatusCode == http.StatusOK && mediaType == "text/event-stream" {
+		m.body, m.reader = resp.Body, bufio.NewReader(resp.Body)
+		m.idle.Reset(m.IdleTimeout)
+		return nil
+	}
+	defer m.closeBody()
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusOK, resp.StatusCode == http.StatusNotFound,
+		resp.StatusCode == http.StatusMethodNotAllowed, resp.StatusCode == http.StatusNotImplemented:
+		m.polling = true
+		return nil
+	case isTransientStatus(resp.StatusCode):
+		return sleepContext(m.ctx, m.ReconnectDelay)
+	}
+
+	var errorResp struct {
+		Error string `json:"error"`
+	}
+	if body, _ := io.ReadAll(resp.Body); json.Unmarshal(body, &errorResp) == nil && errorResp.Error != "" {
+		return fmt.Errorf("failed to stream mail: %s", errorResp.Error)
+	}
+	return fmt.Errorf("failed to stream mail: HTTP %d", resp.StatusCode)
+}
+
+// readEvent reads one event from the stream and returns its data. It
+// returns "" for events other than received emails, such as keepalives.
+func (m *MailStream) readEvent() (string, error) {
+	var event, id string
+	var data strings.Builder
+	hasID := false
+	for {
+		line, err := m.reader.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		m.idle.Reset(m.IdleTimeout)
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "":
+			// Comment, used as a keepalive
+		case "event":
+			event = value
+		case "data":
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+		case "id":
+			id, hasID = value, true
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+				m.ReconnectDelay = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+	if hasID {
+		m.lastEventID = id
+	}
+
+	if event != "" && event != "email.received" && event != "message" {
+		return "", nil
+	}
+	return data.String(), nil
+}
+
+// poll returns the next email found by polling the mail list. The watcher
+// starts on the first call, so only mail received after that is delivered.
+func (m *MailStream) poll() (*EmailItem, error) {
+	if m.watcher == nil {
+		m.watcher = NewMailWatcher(m.service.client, nil)
+		m.watcher.Interval = m.PollInterval
+		m.polled = m.watcher.Watch(m.ctx)
+	}
+	item, ok := <-m.polled
+	if !ok {
+		if err := m.watcher.Err(); err != nil {
+			return nil, err
+		}
+		return nil, m.ctx.Err()
+	}
+	return &item, nil
+}
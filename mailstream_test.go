@@ -0,0 +1,179 @@
+package inboundgo_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestMailStreamServerSentEvents(t *testing.T) {
+	var connections int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mail/stream" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			return
+		}
+		if r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("Expected SSE Accept header, got %q", r.Header.Get("Accept"))
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		switch atomic.AddInt32(&connections, 1) {
+		case 1:
+			fmt.Fprint(w, "retry: 10\n\n: keepalive\n\n")
+			fmt.Fprint(w, "event: email.received\nid: evt-1\ndata: {\"id\": \"email-1\", \"subject\": \"First\"}\n\n")
+			fmt.Fprint(w, "event: ping\ndata: {}\n\n")
+			fmt.Fprint(w, "event: email.received\nid: evt-2\ndata: {\"id\": \"email-2\",\ndata:  \"subject\": \"Second\"}\n\n")
+			// The connection drops here
+		default:
+			if got := r.Header.Get("Last-Event-ID"); got != "evt-2" {
+				t.Errorf("Expected Last-Event-ID evt-2, got %q", got)
+			}
+			fmt.Fprint(w, "id: evt-3\ndata: {\"id\": \"email-3\"}\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream := client.Mail().Stream(ctx)
+	defer stream.Close()
+
+	for _, want := range []string{"email-1", "email-2", "email-3"} {
+		item, err := stream.Next()
+		if err != nil {
+			t.Fatalf("Next failed: %v", err)
+		}
+		if item.ID != want {
+			t.Errorf("Expected %s, got %s", want, item.ID)
+		}
+	}
+
+	stream.Close()
+	if _, err := stream.Next(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled after Close, got %v", err)
+	}
+}
+
+func TestMailStreamOutlivesClientTimeout(t *testing.T) {
+	var connections int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		switch atomic.AddInt32(&connections, 1) {
+		case 1:
+			// Keepalives hold the stream open past the client's Timeout
+			for i := 0; i < 6; i++ {
+				fmt.Fprint(w, ": keepalive\n\n")
+				w.(http.Flusher).Flush()
+				time.Sleep(25 * time.Millisecond)
+			}
+			fmt.Fprint(w, "id: evt-1\ndata: {\"id\": \"email-1\"}\n\n")
+			w.(http.Flusher).Flush()
+			// Then the stream goes silent
+		case 2:
+			fmt.Fprint(w, "id: evt-2\ndata: {\"id\": \"email-2\"}\n\n")
+			w.(http.Flusher).Flush()
+		}
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.WithHTTPClient(&http.Client{Timeout: 50 * time.Millisecond})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream := client.Mail().Stream(ctx)
+	stream.IdleTimeout = 100 * time.Millisecond
+	stream.ReconnectDelay = time.Millisecond
+	defer stream.Close()
+
+	for i, want := range []string{"email-1", "email-2"} {
+		item, err := stream.Next()
+		if err != nil {
+			t.Fatalf("Next failed: %v", err)
+		}
+		if item.ID != want {
+			t.Errorf("Expected %s, got %s", want, item.ID)
+		}
+		if got := atomic.LoadInt32(&connections); got != int32(i+1) {
+			t.Errorf("Expected %d connections after %s, got %d", i+1, want, got)
+		}
+	}
+}
+
+func TestMailStreamPollingFallback(t *testing.T) {
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/mail/stream":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "Not found"}`))
+		case "/mail":
+			switch atomic.AddInt32(&polls, 1) {
+			case 1:
+				w.Write([]byte(`{"emails": [{"id": "old-1", "receivedAt": "2024-01-01T10:00:00Z"}], "pagination": {"total": 1}}`))
+			case 2:
+				w.Write([]byte(`{"emails": [{"id": "new-2", "receivedAt": "2024-01-01T10:02:00Z"}, {"id": "new-1", "receivedAt": "2024-01-01T10:01:00Z"}, {"id": "old-1", "receivedAt": "2024-01-01T10:00:00Z"}], "pagination": {"total": 3}}`))
+			default:
+				// new-2 was deleted, so an older email moves onto the page
+				w.Write([]byte(`{"emails": [{"id": "new-3", "receivedAt": "2024-01-01T10:03:00Z"}, {"id": "new-1", "receivedAt": "2024-01-01T10:01:00Z"}, {"id": "old-1", "receivedAt": "2024-01-01T10:00:00Z"}, {"id": "old-0", "receivedAt": "2024-01-01T09:00:00Z"}], "pagination": {"total": 4}}`))
+			}
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	stream := client.Mail().Stream(context.Background())
+	stream.PollInterval = 10 * time.Millisecond
+	defer stream.Close()
+
+	for _, want := range []string{"new-1", "new-2", "new-3"} {
+		item, err := stream.Next()
+		if err != nil {
+			t.Fatalf("Next failed: %v", err)
+		}
+		if item.ID != want {
+			t.Errorf("Expected %s, got %s", want, item.ID)
+		}
+	}
+}
+
+func TestMailStreamUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": "Invalid API key"}`))
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	stream := client.Mail().Stream(context.Background())
+	defer stream.Close()
+	if _, err := stream.Next(); err == nil || err.Error() != "failed to stream mail: Invalid API key" {
+		t.Errorf("Expected unauthorized error, got %v", err)
+	}
+}
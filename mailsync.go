@@ -0,0 +1,278 @@
+package inboundgo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MailSync mirrors a mailbox, modeled after JMAP's Email/changes and
+// Email/get. Sync follows the server's change feed from a saved token to
+// learn which emails and threads were created, updated, or destroyed since
+// the last call, so each poll costs only the changes, and GetEmails and
+// GetThreads fetch just those in full.
+type MailSync struct {
+	client *Inbound
+	// Concurrency is the number of parallel requests used by GetEmails and
+	// GetThreads (default 4).
+	Concurrency int
+}
+
+// SyncChanges describes how a collection changed between two states
+type SyncChanges struct {
+	OldState  string
+	NewState  string
+	Created   []string
+	Updated   []string
+	Destroyed []string
+}
+
+// HasChanges reports whether anything changed between the two states
+func (c *SyncChanges) HasChanges() bool {
+	return len(c.Created)+len(c.Updated)+len(c.Destroyed) > 0
+}
+
+// NewMailSync creates a sync helper for the client's mailbox
+func NewMailSync(client *Inbound) *MailSync {
+	return &MailSync{client: client, Concurrency: 4}
+}
+
+// GetEmails fetches the given emails in parallel. IDs that no longer exist
+// are returned in notFound rather than as an error.
+func (s *MailSync) GetEmails(ctx context.Context, ids []string) (map[string]*GetMailByIDResponse, []string, error) {
+	return fetchBatch(ctx, ids, s.Concurrency, s.client.Mail().Get)
+}
+
+// GetThreads fetches the given threads, including their messages, in parallel.
+// IDs that no longer exist are returned in notFound rather than as an error.
+func (s *MailSync) GetThreads(ctx context.Context, ids []string) (map[string]*GetThreadByIDResponse, []string, error) {
+	return fetchBatch(ctx, ids, s.Concurrency, s.client.Thread().Get)
+}
+
+// fetchBatch calls get for every ID with bounded concurrency and stops at
+// the first error
+func fetchBatch[T any](ctx context.Context, ids []string, concurrency int, get func(context.Context, string) (*ApiResponse[T], error)) (map[string]*T, []string, error) {
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		found    = make(map[string]*T, len(ids))
+		notFound []string
+		firstErr error
+	)
+	sem := make(chan struct{}, concurrency)
+	for _, id := range ids {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(id string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			resp, err := get(ctx, id)
+			if err == nil && resp.Error != "" && resp.StatusCode != http.StatusNotFound {
+				err = fmt.Errorf("failed to fetch %s: %s", id, resp.Error)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err != nil:
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+			case resp.StatusCode == http.StatusNotFound || resp.Data == nil:
+				notFound = append(notFound, id)
+			default:
+				found[id] = resp.Data
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	if firstErr == nil && ctx.Err() != nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr != nil {
+		return nil, nil, firstErr
+	}
+	sort.Strings(notFound)
+	return found, notFound, nil
+}
+
+// ErrSyncTokenExpired is returned by MailSync.Sync when the server no
+// longer has changes for the saved token. Discard the local mirror, reset
+// the state, and sync again from scratch.
+var ErrSyncTokenExpired = errors.New("sync token expired")
+
+// SyncState is a mailbox mirror's position, persisted between runs with
+// LoadSyncState and Save
+type SyncState struct {
+	// Token is the position in the server's change feed.
+	Token    string    `json:"token,omitempty"`
+	SyncedAt time.Time `json:"syncedAt"`
+}
+
+// LoadSyncState reads a state saved with Save. A missing file returns an
+// empty state, which syncs from scratch.
+func LoadSyncState(path string) (*SyncState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &SyncState{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state SyncState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("invalid sync state file %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+// Save writes the state to path atomically
+func (s *SyncState) Save(path string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// Reset clears the state so the next sync starts from scratch
+func (s *SyncState) Reset() {
+	*s = SyncState{}
+}
+
+// MailboxChanges is the result of MailSync.Sync
+type MailboxChanges struct {
+	Emails  SyncChanges
+	Threads SyncChanges
+	// States lists read and archive changes
+	States []StateChange
+}
+
+// HasChanges reports whether anything changed
+func (c *MailboxChanges) HasChanges() bool {
+	return c.Emails.HasChanges() || c.Threads.HasChanges() || len(c.States) > 0
+}
+
+// ErrNoChangeFeed is returned by MailSync.Sync when the server does not
+// offer the changes-since endpoint
+var ErrNoChangeFeed = errors.New("change feed not available")
+
+// Sync returns everything that changed since state and advances state on
+// success. It follows the server's change feed, paging until caught up. An
+// empty state reports every email and thread as created.
+func (s *MailSync) Sync(ctx context.Context, state *SyncState) (*MailboxChanges, error) {
+	changes, token, err := s.feedChanges(ctx, state.Token)
+	if err != nil {
+		return nil, err
+	}
+	state.Token = token
+	state.SyncedAt = time.Now()
+	return changes, nil
+}
+
+func (s *MailSync) feedChanges(ctx context.Context, since string) (*MailboxChanges, string, error) {
+	emails := newChangeMerger()
+	threads := newChangeMerger()
+	changes := &MailboxChanges{}
+	token := since
+	for {
+		resp, err := s.client.Changes(ctx, &GetChangesRequest{Since: token})
+		if err != nil {
+			return nil, "", err
+		}
+		switch {
+		case resp.StatusCode == http.StatusGone:
+			return nil, "", ErrSyncTokenExpired
+		case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented:
+			return nil, "", ErrNoChangeFeed
+		case resp.Error != "":
+			return nil, "", fmt.Errorf("failed to get changes: %s", resp.Error)
+		}
+		emails.add(resp.Data.Emails)
+		threads.add(resp.Data.Threads)
+		changes.States = append(changes.States, resp.Data.States...)
+		if resp.Data.NextToken == "" || (resp.Data.NextToken == token && resp.Data.HasMore) {
+			return nil, "", errors.New("failed to get changes: response has no usable next token")
+		}
+		token = resp.Data.NextToken
+		if !resp.Data.HasMore {
+			break
+		}
+	}
+	changes.Emails = emails.result(since, token)
+	changes.Threads = threads.result(since, token)
+	return changes, token, nil
+}
+
+// changeMerger folds consecutive change pages into one net change set
+type changeMerger struct {
+	kinds map[string]string
+}
+
+func newChangeMerger() *changeMerger {
+	return &changeMerger{kinds: make(map[string]string)}
+}
+
+func (m *changeMerger) add(set ChangeSet) {
+	for _, id := range set.Created {
+		if m.kinds[id] == "destroyed" {
+			// Destroyed and recreated within the window
+			m.kinds[id] = "updated"
+		} else {
+			m.kinds[id] = "created"
+		}
+	}
+	for _, id := range set.Updated {
+		if m.kinds[id] == "" {
+			m.kinds[id] = "updated"
+		}
+	}
+	for _, id := range set.Destroyed {
+		if m.kinds[id] == "created" {
+			// Never seen by the caller
+			delete(m.kinds, id)
+		} else {
+			m.kinds[id] = "destroyed"
+		}
+	}
+}
+
+func (m *changeMerger) result(oldState, newState string) SyncChanges {
+	changes := SyncChanges{OldState: oldState, NewState: newState}
+	for id, kind := range m.kinds {
+		switch kind {
+		case "created":
+			changes.Created = append(changes.Created, id)
+		case "updated":
+			changes.Updated = append(changes.Updated, id)
+		case "destroyed":
+			changes.Destroyed = append(changes.Destroyed, id)
+		}
+	}
+	sort.Strings(changes.Created)
+	sort.Strings(changes.Updated)
+	sort.Strings(changes.Destroyed)
+	return changes
+}
@@ -0,0 +1,137 @@
+package inboundgo_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestMailSyncGetEmails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/mail/missing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "Email not found"}`))
+		case strings.HasPrefix(r.URL.Path, "/mail/"):
+			fmt.Fprintf(w, `{"id": %q, "subject": "Hello"}`, strings.TrimPrefix(r.URL.Path, "/mail/"))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	emails, notFound, err := inboundgo.NewMailSync(client).GetEmails(context.Background(), []string{"b", "c", "missing"})
+	if err != nil {
+		t.Fatalf("GetEmails failed: %v", err)
+	}
+	if len(emails) != 2 || emails["c"].ID != "c" {
+		t.Errorf("Unexpected emails: %+v", emails)
+	}
+	if !reflect.DeepEqual(notFound, []string{"missing"}) {
+		t.Errorf("Expected missing email in notFound, got %v", notFound)
+	}
+}
+
+func TestMailSyncSyncUsesChangeFeed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/changes" {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("since") {
+		case "tok_1":
+			w.Write([]byte(`{"emails": {"created": ["e2", "e3"], "updated": ["e1"]}, "threads": {"updated": ["t1"]},
+				"states": [{"id": "e1", "type": "email", "isRead": true}], "nextToken": "tok_2", "hasMore": true}`))
+		case "tok_2":
+			w.Write([]byte(`{"emails": {"destroyed": ["e3", "e0"]}, "threads": {}, "nextToken": "tok_3", "hasMore": false}`))
+		case "tok_old":
+			w.WriteHeader(http.StatusGone)
+			w.Write([]byte(`{"error": "Sync token expired"}`))
+		default:
+			t.Errorf("Unexpected since %q", r.URL.Query().Get("since"))
+		}
+	}))
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	s := inboundgo.NewMailSync(client)
+	ctx := context.Background()
+
+	state := &inboundgo.SyncState{Token: "tok_1"}
+	changes, err := s.Sync(ctx, state)
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if state.Token != "tok_3" || state.SyncedAt.IsZero() {
+		t.Errorf("Expected state to advance to tok_3, got %+v", state)
+	}
+	// e3 was created and destroyed within the window, so it is not reported
+	if !reflect.DeepEqual(changes.Emails.Created, []string{"e2"}) ||
+		!reflect.DeepEqual(changes.Emails.Updated, []string{"e1"}) ||
+		!reflect.DeepEqual(changes.Emails.Destroyed, []string{"e0"}) {
+		t.Errorf("Unexpected email changes: %+v", changes.Emails)
+	}
+	if !reflect.DeepEqual(changes.Threads.Updated, []string{"t1"}) || len(changes.States) != 1 || !*changes.States[0].IsRead {
+		t.Errorf("Unexpected thread or state changes: %+v", changes)
+	}
+
+	expired := &inboundgo.SyncState{Token: "tok_old"}
+	if _, err := s.Sync(ctx, expired); !errors.Is(err, inboundgo.ErrSyncTokenExpired) {
+		t.Errorf("Expected ErrSyncTokenExpired, got %v", err)
+	}
+	if expired.Token != "tok_old" {
+		t.Errorf("State should not change on error, got %+v", expired)
+	}
+}
+
+func TestMailSyncSyncWithoutChangeFeed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/changes" {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "Not found"}`))
+	}))
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	path := filepath.Join(t.TempDir(), "sync.json")
+	state, err := inboundgo.LoadSyncState(path)
+	if err != nil {
+		t.Fatalf("LoadSyncState failed: %v", err)
+	}
+
+	if _, err := inboundgo.NewMailSync(client).Sync(context.Background(), state); !errors.Is(err, inboundgo.ErrNoChangeFeed) {
+		t.Errorf("Expected ErrNoChangeFeed, got %v", err)
+	}
+	if state.Token != "" || !state.SyncedAt.IsZero() {
+		t.Errorf("State should not change on error, got %+v", state)
+	}
+
+	state.Token = "tok_1"
+	if err := state.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := inboundgo.LoadSyncState(path)
+	if err != nil {
+		t.Fatalf("LoadSyncState failed: %v", err)
+	}
+	if loaded.Token != state.Token {
+		t.Errorf("Saved state did not round-trip: %+v", loaded)
+	}
+}
@@ -0,0 +1,246 @@
+package inboundgo
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// MTASTSMode is the enforcement mode of an MTA-STS policy (RFC 8461)
+type MTASTSMode string
+
+const (
+	MTASTSModeEnforce MTASTSMode = "enforce"
+	MTASTSModeTesting MTASTSMode = "testing"
+	MTASTSModeNone    MTASTSMode = "none"
+)
+
+// mtaSTSMaxAge is the largest max_age RFC 8461 allows (one year)
+const mtaSTSMaxAge = 31557600 * time.Second
+
+// MTASTSPolicy describes the policy file served at
+// https://mta-sts.<domain>/.well-known/mta-sts.txt
+type MTASTSPolicy struct {
+	Mode MTASTSMode
+	// MX lists the MX hosts senders may deliver to; wildcards such as
+	// "*.example.net" are allowed.
+	MX []string
+	// MaxAge is how long senders cache the policy (default one week).
+	MaxAge time.Duration
+}
+
+// Validate checks the policy against RFC 8461
+func (p MTASTSPolicy) Validate() error {
+	switch p.Mode {
+	case MTASTSModeEnforce, MTASTSModeTesting:
+		if len(p.MX) == 0 {
+			return fmt.Errorf("mta-sts: %s mode requires at least one MX host", p.Mode)
+		}
+	case MTASTSModeNone:
+	default:
+		return fmt.Errorf("mta-sts: invalid mode %q", p.Mode)
+	}
+	if p.MaxAge < 0 || p.MaxAge > mtaSTSMaxAge {
+		return fmt.Errorf("mta-sts: max age must be between 0 and %s", mtaSTSMaxAge)
+	}
+	for _, mx := range p.MX {
+		if mx == "" || strings.ContainsAny(mx, " \r\n") {
+			return fmt.Errorf("mta-sts: invalid MX host %q", mx)
+		}
+	}
+	return nil
+}
+
+// Content returns the policy file contents
+func (p MTASTSPolicy) Content() string {
+	maxAge := p.MaxAge
+	if maxAge == 0 {
+		maxAge = 7 * 24 * time.Hour
+	}
+	var b strings.Builder
+	b.WriteString("version: STSv1\r\n")
+	fmt.Fprintf(&b, "mode: %s\r\n", p.Mode)
+	for _, mx := range p.MX {
+		fmt.Fprintf(&b, "mx: %s\r\n", strings.TrimSuffix(mx, "."))
+	}
+	fmt.Fprintf(&b, "max_age: %d\r\n", int64(maxAge/time.Second))
+	return b.String()
+}
+
+// ID returns a policy ID derived from the policy contents, so the
+// _mta-sts record changes whenever the policy does
+func (p MTASTSPolicy) ID() string {
+	sum := sha256.Sum256([]byte(p.Content()))
+	return hex.EncodeToString(sum[:10])
+}
+
+// Handler serves the policy at /.well-known/mta-sts.txt. Mount it on the
+// HTTPS server for mta-sts.<domain>.
+func (p MTASTSPolicy) Handler() http.Handler {
+	content := p.Content()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/mta-sts.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		io.WriteString(w, content)
+	})
+}
+
+// NewMTASTSRecord builds the _mta-sts TXT record announcing policy for domain
+func NewMTASTSRecord(domain string, policy MTASTSPolicy) (DNSRecord, error) {
+	if domain == "" {
+		return DNSRecord{}, errors.New("mta-sts: domain is required")
+	}
+	if err := policy.Validate(); err != nil {
+		return DNSRecord{}, err
+	}
+	return DNSRecord{
+		Type:  "TXT",
+		Name:  "_mta-sts." + strings.TrimSuffix(domain, "."),
+		Value: "v=STSv1; id=" + policy.ID(),
+	}, nil
+}
+
+// NewTLSRPTRecord builds the _smtp._tls TXT record asking senders to report
+// TLS failures to the given mailto: or https: URIs (RFC 8460)
+func NewTLSRPTRecord(domain string, reportURIs ...string) (DNSRecord, error) {
+	if domain == "" {
+		return DNSRecord{}, errors.New("tls-rpt: domain is required")
+	}
+	if len(reportURIs) == 0 {
+		return DNSRecord{}, errors.New("tls-rpt: at least one report URI is required")
+	}
+	for _, uri := range reportURIs {
+		if !strings.HasPrefix(uri, "mailto:") && !strings.HasPrefix(uri, "https://") {
+			return DNSRecord{}, fmt.Errorf("tls-rpt: report URI %q must use mailto: or https:", uri)
+		}
+		if strings.ContainsAny(uri, ",; ") {
+			return DNSRecord{}, fmt.Errorf("tls-rpt: invalid report URI %q", uri)
+		}
+	}
+	return DNSRecord{
+		Type:  "TXT",
+		Name:  "_smtp._tls." + strings.TrimSuffix(domain, "."),
+		Value: "v=TLSRPTv1; rua=" + strings.Join(reportURIs, ","),
+	}, nil
+}
+
+// TLSReport is an aggregate SMTP TLS report (RFC 8460)
+type TLSReport struct {
+	OrganizationName string `json:"organization-name"`
+	DateRange        struct {
+		Start time.Time `json:"start-datetime"`
+		End   time.Time `json:"end-datetime"`
+	} `json:"date-range"`
+	ContactInfo string            `json:"contact-info"`
+	ReportID    string            `json:"report-id"`
+	Policies    []TLSReportPolicy `json:"policies"`
+}
+
+type TLSReportPolicy struct {
+	Policy struct {
+		Type   string   `json:"policy-type"` // 'sts' | 'tlsa' | 'no-policy-found'
+		String []string `json:"policy-string"`
+		Domain string   `json:"policy-domain"`
+		MXHost []string `json:"mx-host"`
+	} `json:"policy"`
+	Summary struct {
+		TotalSuccessfulSessionCount int `json:"total-successful-session-count"`
+		TotalFailureSessionCount    int `json:"total-failure-session-count"`
+	} `json:"summary"`
+	FailureDetails []TLSReportFailure `json:"failure-details"`
+}
+
+type TLSReportFailure struct {
+	ResultType            string `json:"result-type"` // e.g. 'certificate-expired' | 'starttls-not-supported'
+	SendingMTAIP          string `json:"sending-mta-ip"`
+	ReceivingMXHostname   string `json:"receiving-mx-hostname"`
+	ReceivingMXHelo       string `json:"receiving-mx-helo"`
+	ReceivingIP           string `json:"receiving-ip"`
+	FailedSessionCount    int    `json:"failed-session-count"`
+	AdditionalInformation string `json:"additional-information"`
+	FailureReasonCode     string `json:"failure-reason-code"`
+}
+
+// FailureCount returns the number of failed sessions across all policies
+func (r *TLSReport) FailureCount() int {
+	n := 0
+	for _, p := range r.Policies {
+		n += p.Summary.TotalFailureSessionCount
+	}
+	return n
+}
+
+// ErrTLSReportTooLarge is returned by ParseTLSReport for a report that
+// decompresses to more than 10 MB
+var ErrTLSReportTooLarge = errors.New("tls-rpt: report too large")
+
+// ParseTLSReport decodes a TLS-RPT report, gzip-compressed or not, as
+// delivered in an application/tlsrpt+gzip attachment or HTTPS POST body.
+// The decompressed report is limited in size, so a small gzip bomb cannot
+// exhaust memory.
+func ParseTLSReport(r io.Reader) (*TLSReport, error) {
+	br := bufio.NewReader(r)
+	if magic, _ := br.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("tls-rpt: %w", err)
+		}
+		defer zr.Close()
+		r = zr
+	} else {
+		r = br
+	}
+	limited := &io.LimitedReader{R: r, N: maxTLSReportSize + 1}
+	var report TLSReport
+	err := json.NewDecoder(limited).Decode(&report)
+	if limited.N <= 0 {
+		return nil, ErrTLSReportTooLarge
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tls-rpt: failed to decode report: %w", err)
+	}
+	return &report, nil
+}
+
+// maxTLSReportSize bounds reports, compressed as accepted by
+// NewTLSReportHandler and decompressed as parsed by ParseTLSReport
+const maxTLSReportSize = 10 << 20
+
+// NewTLSReportHandler returns an http.Handler for an https: rua endpoint.
+// It parses each POSTed report and passes it to fn; an error from fn is
+// returned to the reporter as a 500 so it retries later.
+func NewTLSReportHandler(fn func(*TLSReport) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		report, err := ParseTLSReport(io.LimitReader(r.Body, maxTLSReportSize))
+		if errors.Is(err, ErrTLSReportTooLarge) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := fn(report); err != nil {
+			http.Error(w, "failed to process report", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
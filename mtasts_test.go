@@ -0,0 +1,156 @@
+package inboundgo_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestMTASTSPolicy(t *testing.T) {
+	policy := inboundgo.MTASTSPolicy{
+		Mode: inboundgo.MTASTSModeEnforce,
+		MX:   []string{"mx1.inbound.new.", "*.example.net"},
+	}
+	want := "version: STSv1\r\nmode: enforce\r\nmx: mx1.inbound.new\r\nmx: *.example.net\r\nmax_age: 604800\r\n"
+	if got := policy.Content(); got != want {
+		t.Errorf("Expected policy %q, got %q", want, got)
+	}
+
+	record, err := inboundgo.NewMTASTSRecord("example.com", policy)
+	if err != nil {
+		t.Fatalf("NewMTASTSRecord failed: %v", err)
+	}
+	if record.Name != "_mta-sts.example.com" || record.Value != "v=STSv1; id="+policy.ID() {
+		t.Errorf("Unexpected record: %+v", record)
+	}
+
+	changed := policy
+	changed.MaxAge = 24 * time.Hour
+	if changed.ID() == policy.ID() {
+		t.Error("Expected policy ID to change with the policy")
+	}
+
+	for _, bad := range []inboundgo.MTASTSPolicy{
+		{Mode: "strict", MX: []string{"mx.example.com"}},
+		{Mode: inboundgo.MTASTSModeEnforce},
+		{Mode: inboundgo.MTASTSModeTesting, MX: []string{"mx.example.com"}, MaxAge: 2 * 365 * 24 * time.Hour},
+	} {
+		if _, err := inboundgo.NewMTASTSRecord("example.com", bad); err == nil {
+			t.Errorf("Expected error for %+v", bad)
+		}
+	}
+
+	server := httptest.NewServer(policy.Handler())
+	defer server.Close()
+	resp, err := http.Get(server.URL + "/.well-known/mta-sts.txt")
+	if err != nil {
+		t.Fatalf("GET policy failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != want || resp.Header.Get("Content-Type") != "text/plain" {
+		t.Errorf("Unexpected policy response: %q %s", body, resp.Header.Get("Content-Type"))
+	}
+}
+
+func TestTLSRPTRecord(t *testing.T) {
+	record, err := inboundgo.NewTLSRPTRecord("example.com", "mailto:tls@example.com", "https://reports.example.com/tlsrpt")
+	if err != nil {
+		t.Fatalf("NewTLSRPTRecord failed: %v", err)
+	}
+	if record.Name != "_smtp._tls.example.com" || record.Value != "v=TLSRPTv1; rua=mailto:tls@example.com,https://reports.example.com/tlsrpt" {
+		t.Errorf("Unexpected record: %+v", record)
+	}
+	if _, err := inboundgo.NewTLSRPTRecord("example.com", "tls@example.com"); err == nil {
+		t.Error("Expected error for URI without scheme")
+	}
+}
+
+const sampleTLSReport = `{
+	"organization-name": "Company-X",
+	"date-range": {"start-datetime": "2016-04-01T00:00:00Z", "end-datetime": "2016-04-01T23:59:59Z"},
+	"contact-info": "sts-reporting@company-x.example",
+	"report-id": "5065427c-23d3-47ca-b6e0-946ea0e8c4be",
+	"policies": [{
+		"policy": {"policy-type": "sts", "policy-string": ["version: STSv1", "mode: testing"], "policy-domain": "company-y.example", "mx-host": ["*.mail.company-y.example"]},
+		"summary": {"total-successful-session-count": 5326, "total-failure-session-count": 303},
+		"failure-details": [{"result-type": "certificate-expired", "sending-mta-ip": "2001:db8:abcd:0012::1", "receiving-mx-hostname": "mx1.mail.company-y.example", "failed-session-count": 100}]
+	}]
+}`
+
+func TestParseTLSReport(t *testing.T) {
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write([]byte(sampleTLSReport))
+	zw.Close()
+
+	for name, body := range map[string][]byte{"plain": []byte(sampleTLSReport), "gzip": gz.Bytes()} {
+		report, err := inboundgo.ParseTLSReport(bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("%s: ParseTLSReport failed: %v", name, err)
+		}
+		if report.OrganizationName != "Company-X" || report.FailureCount() != 303 {
+			t.Errorf("%s: unexpected report: %+v", name, report)
+		}
+		if f := report.Policies[0].FailureDetails[0]; f.ResultType != "certificate-expired" || f.FailedSessionCount != 100 {
+			t.Errorf("%s: unexpected failure details: %+v", name, f)
+		}
+		if report.DateRange.End.Hour() != 23 {
+			t.Errorf("%s: unexpected date range: %+v", name, report.DateRange)
+		}
+	}
+}
+
+func TestParseTLSReportLimitsDecompressedSize(t *testing.T) {
+	// 20 MB of whitespace compresses to about 20 KB
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write(bytes.Repeat([]byte(" "), 20<<20))
+	zw.Write([]byte("{}"))
+	zw.Close()
+
+	if _, err := inboundgo.ParseTLSReport(bytes.NewReader(gz.Bytes())); !errors.Is(err, inboundgo.ErrTLSReportTooLarge) {
+		t.Errorf("Expected ErrTLSReportTooLarge, got %v", err)
+	}
+
+	handler := inboundgo.NewTLSReportHandler(func(*inboundgo.TLSReport) error { return nil })
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/tlsrpt", bytes.NewReader(gz.Bytes())))
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected 413, got %d", rec.Code)
+	}
+}
+
+func TestTLSReportHandler(t *testing.T) {
+	var received *inboundgo.TLSReport
+	handler := inboundgo.NewTLSReportHandler(func(r *inboundgo.TLSReport) error {
+		received = r
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/tlsrpt", strings.NewReader(sampleTLSReport)))
+	if rec.Code != http.StatusNoContent || received == nil || received.ReportID == "" {
+		t.Errorf("Unexpected result: %d %+v", rec.Code, received)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/tlsrpt", strings.NewReader("not json")))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for invalid report, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/tlsrpt", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for GET, got %d", rec.Code)
+	}
+}
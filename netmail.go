@@ -0,0 +1,157 @@
+package inboundgo
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net/mail"
+	"net/textproto"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ToMailMessage converts the webhook email into a *mail.Message so it can be
+// consumed by standard Go mail tooling. When the payload includes the raw
+// message it is parsed directly; otherwise a MIME message is rebuilt from the
+// parsed headers and bodies. Attachments are not included in rebuilt
+// messages because webhook payloads only carry their download URLs.
+func (e *WebhookEmailData) ToMailMessage() (*mail.Message, error) {
+	if e.ParsedData.Raw != nil && *e.ParsedData.Raw != "" {
+		msg, err := mail.ReadMessage(strings.NewReader(*e.ParsedData.Raw))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse raw message: %w", err)
+		}
+		return msg, nil
+	}
+
+	parsed := e.ParsedData
+	msg := &emlMessage{}
+
+	from := parsed.From
+	if from == nil {
+		from = e.From
+	}
+	to := parsed.To
+	if to == nil {
+		to = e.To
+	}
+	msg.addHeader("From", addressGroupHeader(from))
+	msg.addHeader("To", addressGroupHeader(to))
+	msg.addHeader("Cc", addressGroupHeader(parsed.Cc))
+	msg.addHeader("Reply-To", addressGroupHeader(parsed.ReplyTo))
+
+	subject := parsed.Subject
+	if subject == nil {
+		subject = e.Subject
+	}
+	if subject != nil {
+		msg.addHeader("Subject", mime.QEncoding.Encode("utf-8", *subject))
+	}
+	if parsed.Date != nil {
+		msg.addHeader("Date", formatMessageDate(*parsed.Date))
+	} else if e.ReceivedAt != "" {
+		msg.addHeader("Date", formatMessageDate(e.ReceivedAt))
+	}
+
+	messageID := parsed.MessageID
+	if messageID == nil {
+		messageID = e.MessageID
+	}
+	if messageID != nil {
+		msg.addHeader("Message-Id", *messageID)
+	}
+	if parsed.InReplyTo != nil {
+		msg.addHeader("In-Reply-To", *parsed.InReplyTo)
+	}
+	if len(parsed.References) > 0 {
+		msg.addHeader("References", strings.Join(parsed.References, " "))
+	}
+
+	// Carry over the remaining original headers in a stable order
+	extra := normalizeHeaders(parsed.Headers)
+	keys := make([]string, 0, len(extra))
+	for k := range extra {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		canonical := textproto.CanonicalMIMEHeaderKey(k)
+		if rebuiltHeaders[canonical] {
+			continue
+		}
+		for _, v := range extra[k] {
+			msg.addHeader(canonical, v)
+		}
+	}
+
+	if parsed.TextBody != nil {
+		msg.Text = *parsed.TextBody
+	} else if e.CleanedContent.Text != nil {
+		msg.Text = *e.CleanedContent.Text
+	}
+	if parsed.HTMLBody != nil {
+		msg.HTML = *parsed.HTMLBody
+	} else if e.CleanedContent.HTML != nil {
+		msg.HTML = *e.CleanedContent.HTML
+	}
+
+	return emlToMailMessage(msg)
+}
+
+// ToMailMessage converts the email into a *mail.Message with its headers and
+// text/HTML bodies. Attachments are not included; use MailService.ExportEML
+// for a self-contained message.
+func (r *GetMailByIDResponse) ToMailMessage() (*mail.Message, error) {
+	return emlToMailMessage(emlFromMailBody(r))
+}
+
+// rebuiltHeaders are set explicitly (or regenerated) when rebuilding a
+// message, so copies from the original header map are skipped.
+var rebuiltHeaders = map[string]bool{
+	"From": true, "To": true, "Cc": true, "Bcc": true, "Reply-To": true,
+	"Subject": true, "Date": true, "Message-Id": true, "In-Reply-To": true,
+	"References": true, "Mime-Version": true, "Content-Type": true,
+	"Content-Transfer-Encoding": true,
+}
+
+// emlToMailMessage serializes msg and parses it back with net/mail so the
+// header map and body are consistent with each other.
+func emlToMailMessage(msg *emlMessage) (*mail.Message, error) {
+	var buf bytes.Buffer
+	if err := writeEML(&buf, msg); err != nil {
+		return nil, err
+	}
+	return mail.ReadMessage(&buf)
+}
+
+// addressGroupHeader formats a webhook address group as a header value
+func addressGroupHeader(group *WebhookAddressGroup) string {
+	if group == nil {
+		return ""
+	}
+	parts := make([]string, 0, len(group.Addresses))
+	for _, a := range group.Addresses {
+		if a.Address == nil || *a.Address == "" {
+			continue
+		}
+		addr := mail.Address{Address: *a.Address}
+		if a.Name != nil {
+			addr.Name = *a.Name
+		}
+		parts = append(parts, addr.String())
+	}
+	if len(parts) == 0 {
+		return encodeAddressHeader(group.Text)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatMessageDate converts ISO 8601 timestamps to the RFC 5322 date format,
+// passing other values through unchanged.
+func formatMessageDate(value string) string {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t.Format(time.RFC1123Z)
+	}
+	return value
+}
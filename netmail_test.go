@@ -0,0 +1,151 @@
+package inboundgo
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhookEmailDataToMailMessageStripsInjectedHeaders(t *testing.T) {
+	injected := "<msg-1@example.com>\r\nBcc: victim@example.com"
+	email := &WebhookEmailData{ParsedData: WebhookParsedData{
+		MessageID:  &injected,
+		InReplyTo:  &injected,
+		References: []string{"<a@example.com>\r\nX-Injected: yes"},
+		TextBody:   String("Hello"),
+		Headers: map[string]any{
+			"x-custom":      "value\r\n\r\nInjected body",
+			"x-evil\r\nbcc": "victim@example.com",
+			"x-spaced name": "ignored",
+		},
+	}}
+	msg, err := email.ToMailMessage()
+	if err != nil {
+		t.Fatalf("ToMailMessage failed: %v", err)
+	}
+	if msg.Header.Get("Bcc") != "" || msg.Header.Get("X-Injected") != "" || len(msg.Header["X-Spaced Name"]) != 0 {
+		t.Errorf("Expected no injected headers, got %v", msg.Header)
+	}
+	if msg.Header.Get("X-Custom") != "value  Injected body" {
+		t.Errorf("Expected the custom header on one line, got %q", msg.Header.Get("X-Custom"))
+	}
+	if body, _ := io.ReadAll(msg.Body); strings.TrimSpace(string(body)) != "Hello" {
+		t.Errorf("Unexpected body %q", body)
+	}
+}
+
+func TestWebhookEmailDataToMailMessage(t *testing.T) {
+	payload := `{
+  "event": "email.received",
+  "email": {
+    "id": "email-1",
+    "messageId": "<msg-1@example.com>",
+    "recipient": "support@yourdomain.com",
+    "receivedAt": "2025-09-16T16:47:50.163Z",
+    "parsedData": {
+      "messageId": "<msg-1@example.com>",
+      "date": "2025-09-16T16:47:50.163Z",
+      "subject": "Help with order",
+      "from": {"text": "Customer <customer@example.com>", "addresses": [{"name": "Customer", "address": "customer@example.com"}]},
+      "to": {"text": "support@yourdomain.com", "addresses": [{"name": null, "address": "support@yourdomain.com"}]},
+      "cc": {"text": "a@example.com, b@example.com", "addresses": [{"name": null, "address": "a@example.com"}, {"name": "B", "address": "b@example.com"}]},
+      "inReplyTo": "<msg-0@yourdomain.com>",
+      "references": ["<msg-0@yourdomain.com>"],
+      "textBody": "Where is my order?",
+      "htmlBody": "<p>Where is my order?</p>",
+      "attachments": [],
+      "headers": {
+        "received": ["from mta1", "by mx"],
+        "x-priority": "1",
+        "content-type": "multipart/alternative; boundary=original"
+      }
+    },
+    "cleanedContent": {"attachments": [], "headers": {}}
+  }
+}`
+
+	webhook, err := ParseWebhookPayload(strings.NewReader(payload))
+	if err != nil {
+		t.Fatalf("Failed to parse webhook payload: %v", err)
+	}
+
+	msg, err := webhook.Email.ToMailMessage()
+	if err != nil {
+		t.Fatalf("ToMailMessage failed: %v", err)
+	}
+
+	from, err := msg.Header.AddressList("From")
+	if err != nil || len(from) != 1 || from[0].Address != "customer@example.com" || from[0].Name != "Customer" {
+		t.Errorf("Unexpected From: %v (%v)", from, err)
+	}
+	cc, err := msg.Header.AddressList("Cc")
+	if err != nil || len(cc) != 2 {
+		t.Errorf("Unexpected Cc: %v (%v)", cc, err)
+	}
+	if msg.Header.Get("Subject") != "Help with order" {
+		t.Errorf("Unexpected Subject: %s", msg.Header.Get("Subject"))
+	}
+	if msg.Header.Get("In-Reply-To") != "<msg-0@yourdomain.com>" {
+		t.Errorf("Unexpected In-Reply-To: %s", msg.Header.Get("In-Reply-To"))
+	}
+	if got := msg.Header["Received"]; len(got) != 2 {
+		t.Errorf("Expected both Received headers, got %v", got)
+	}
+	if msg.Header.Get("X-Priority") != "1" {
+		t.Errorf("Expected custom header to be carried over")
+	}
+	if strings.Contains(msg.Header.Get("Content-Type"), "original") {
+		t.Error("Expected Content-Type to be regenerated, not copied")
+	}
+	date, err := msg.Header.Date()
+	if err != nil || !date.Equal(time.Date(2025, 9, 16, 16, 47, 50, 0, time.UTC)) {
+		t.Errorf("Unexpected Date: %v (%v)", date, err)
+	}
+
+	body, _ := io.ReadAll(msg.Body)
+	if !strings.Contains(string(body), "Where is my order?") {
+		t.Errorf("Expected body to contain the text content, got %q", body)
+	}
+}
+
+func TestWebhookEmailDataToMailMessageRaw(t *testing.T) {
+	raw := "From: a@example.com\r\nSubject: Raw\r\n\r\nraw body"
+	data := WebhookEmailData{ParsedData: WebhookParsedData{Raw: &raw}}
+
+	msg, err := data.ToMailMessage()
+	if err != nil {
+		t.Fatalf("ToMailMessage failed: %v", err)
+	}
+	body, _ := io.ReadAll(msg.Body)
+	if msg.Header.Get("Subject") != "Raw" || string(body) != "raw body" {
+		t.Errorf("Expected raw message to be parsed as-is, got %v %q", msg.Header, body)
+	}
+}
+
+func TestGetMailByIDResponseToMailMessage(t *testing.T) {
+	email := &GetMailByIDResponse{
+		ID:         "mail-1",
+		Subject:    "Hello",
+		From:       "Sender <sender@example.com>",
+		To:         "me@yourdomain.com",
+		TextBody:   "Plain text",
+		ReceivedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	msg, err := email.ToMailMessage()
+	if err != nil {
+		t.Fatalf("ToMailMessage failed: %v", err)
+	}
+	if msg.Header.Get("X-Inbound-Email-Id") != "mail-1" {
+		t.Errorf("Expected email ID header, got %v", msg.Header)
+	}
+	to, err := msg.Header.AddressList("To")
+	if err != nil || to[0].Address != "me@yourdomain.com" {
+		t.Errorf("Unexpected To: %v (%v)", to, err)
+	}
+	body, _ := io.ReadAll(msg.Body)
+	if string(body) != "Plain text" {
+		t.Errorf("Unexpected body: %q", body)
+	}
+}
@@ -0,0 +1,315 @@
+package inboundgo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OutboxEntry is an email waiting in an Outbox
+type OutboxEntry struct {
+	ID      string             `json:"id"`
+	Request *PostEmailsRequest `json:"request"`
+	// IdempotencyKey is sent with every attempt, so an attempt that reached
+	// the API before a crash or timeout is not delivered twice.
+	IdempotencyKey string    `json:"idempotencyKey"`
+	Attempts       int       `json:"attempts"`
+	LastError      string    `json:"lastError,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	NextAttemptAt  time.Time `json:"nextAttemptAt"`
+}
+
+// OutboxStore persists outbox entries. Implementations backed by a
+// database (bolt, SQLite, Redis, ...) make queued mail survive restarts;
+// FileOutboxStore does the same with a directory.
+type OutboxStore interface {
+	// Enqueue adds a new entry.
+	Enqueue(ctx context.Context, entry *OutboxEntry) error
+	// Due returns up to limit entries whose NextAttemptAt is not after now,
+	// oldest first.
+	Due(ctx context.Context, now time.Time, limit int) ([]*OutboxEntry, error)
+	// Update saves an entry after a failed attempt.
+	Update(ctx context.Context, entry *OutboxEntry) error
+	// Remove deletes an entry once it is sent or abandoned. Removing an
+	// entry that does not exist is not an error.
+	Remove(ctx context.Context, id string) error
+}
+
+// MemoryOutboxStore keeps entries in memory. Queued mail is lost if the
+// process exits, so it is mainly useful in tests.
+type MemoryOutboxStore struct {
+	mu      sync.Mutex
+	entries map[string]OutboxEntry
+}
+
+func (s *MemoryOutboxStore) Enqueue(ctx context.Context, entry *OutboxEntry) error {
+	return s.Update(ctx, entry)
+}
+
+func (s *MemoryOutboxStore) Due(ctx context.Context, now time.Time, limit int) ([]*OutboxEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var due []*OutboxEntry
+	for _, entry := range s.entries {
+		if !entry.NextAttemptAt.After(now) {
+			entry := entry
+			due = append(due, &entry)
+		}
+	}
+	return oldestEntries(due, limit), nil
+}
+
+func (s *MemoryOutboxStore) Update(ctx context.Context, entry *OutboxEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.entries == nil {
+		s.entries = make(map[string]OutboxEntry)
+	}
+	s.entries[entry.ID] = *entry
+	return nil
+}
+
+func (s *MemoryOutboxStore) Remove(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, id)
+	return nil
+}
+
+// FileOutboxStore keeps each entry as a JSON file in Dir, written
+// atomically. It suits a single process sending modest volumes.
+type FileOutboxStore struct {
+	Dir string
+}
+
+func (s *FileOutboxStore) Enqueue(ctx context.Context, entry *OutboxEntry) error {
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return err
+	}
+	return s.Update(ctx, entry)
+}
+
+func (s *FileOutboxStore) Due(ctx context.Context, now time.Time, limit int) ([]*OutboxEntry, error) {
+	names, err := filepath.Glob(filepath.Join(s.Dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var due []*OutboxEntry
+	for _, name := range names {
+		data, err := os.ReadFile(name)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var entry OutboxEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, fmt.Errorf("invalid outbox entry %s: %w", name, err)
+		}
+		if !entry.NextAttemptAt.After(now) {
+			due = append(due, &entry)
+		}
+	}
+	return oldestEntries(due, limit), nil
+}
+
+func (s *FileOutboxStore) Update(ctx context.Context, entry *OutboxEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path(entry.ID), data)
+}
+
+func (s *FileOutboxStore) Remove(ctx context.Context, id string) error {
+	err := os.Remove(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *FileOutboxStore) path(id string) string {
+	return filepath.Join(s.Dir, strings.NewReplacer("/", "_", `\`, "_").Replace(id)+".json")
+}
+
+func oldestEntries(entries []*OutboxEntry, limit int) []*OutboxEntry {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries
+}
+
+// Outbox queues emails in a persistent store and sends them from a
+// background worker, giving at-least-once delivery of critical mail across
+// API outages and process restarts. Send returns as soon as the email is
+// stored; Run drains the store, retrying transient failures with backoff.
+//
+// Run one worker per store; entries are not locked against concurrent
+// workers.
+type Outbox struct {
+	client *Inbound
+	Store  OutboxStore
+	// PollInterval is how often the store is checked for due entries when
+	// idle (default 5s). Send wakes the worker immediately.
+	PollInterval time.Duration
+	// BatchSize is the number of entries read from the store at once
+	// (default 10).
+	BatchSize int
+	// RetryBackoff is the delay before the first retry; it doubles with each
+	// attempt up to MaxBackoff (defaults 1s and 10m).
+	RetryBackoff time.Duration
+	MaxBackoff   time.Duration
+	// MaxAttempts abandons an entry after this many transient failures.
+	// Zero retries until the email is sent.
+	MaxAttempts int
+	// OnSent, if set, is called after an entry is sent and removed.
+	OnSent func(entry *OutboxEntry, resp *PostEmailsResponse)
+	// OnFailed, if set, is called after an entry is abandoned and removed,
+	// either because the API rejected it or MaxAttempts was reached.
+	OnFailed func(entry *OutboxEntry)
+	// OnError, if set, is called with store errors, which are retried.
+	OnError func(error)
+
+	wake chan struct{}
+}
+
+// NewOutbox creates an outbox backed by store
+func NewOutbox(client *Inbound, store OutboxStore) *Outbox {
+	return &Outbox{
+		client:       client,
+		Store:        store,
+		PollInterval: 5 * time.Second,
+		BatchSize:    10,
+		RetryBackoff: time.Second,
+		MaxBackoff:   10 * time.Minute,
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// Send stores params for delivery and returns the entry ID. A key in
+// options is used as the idempotency key; otherwise one is generated.
+func (o *Outbox) Send(ctx context.Context, params *PostEmailsRequest, options *IdempotencyOptions) (string, error) {
+	if params == nil {
+		return "", errors.New("outbox: no request")
+	}
+	// Render now: the body template is not persisted with the entry
+	params, err := o.client.prepareEmail(ctx, params)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	entry := &OutboxEntry{
+		ID:            newIdempotencyKey(),
+		Request:       params,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}
+	entry.IdempotencyKey = entry.ID
+	if options != nil && options.IdempotencyKey != "" {
+		entry.IdempotencyKey = options.IdempotencyKey
+	}
+	if err := o.Store.Enqueue(ctx, entry); err != nil {
+		return "", fmt.Errorf("failed to enqueue email: %w", err)
+	}
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+	return entry.ID, nil
+}
+
+// Run sends due entries until ctx ends, returning ctx.Err()
+func (o *Outbox) Run(ctx context.Context) error {
+	for {
+		tried, err := o.drain(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil && o.OnError != nil {
+			o.OnError(err)
+		}
+		if tried > 0 && err == nil {
+			// There may be more due entries beyond this batch
+			continue
+		}
+		timer := time.NewTimer(o.PollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-o.wake:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// drain attempts one batch of due entries and returns how many it tried
+func (o *Outbox) drain(ctx context.Context) (int, error) {
+	entries, err := o.Store.Due(ctx, time.Now(), o.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read outbox: %w", err)
+	}
+	for _, entry := range entries {
+		if err := o.attempt(ctx, entry); err != nil {
+			return 0, err
+		}
+	}
+	return len(entries), nil
+}
+
+// attempt sends one entry and records the outcome in the store
+func (o *Outbox) attempt(ctx context.Context, entry *OutboxEntry) error {
+	entry.Attempts++
+	resp, err := o.client.Email().send(ctx, entry.Request, &IdempotencyOptions{IdempotencyKey: entry.IdempotencyKey})
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	status := 0
+	if err != nil {
+		entry.LastError = err.Error()
+	} else if resp.Error != "" {
+		entry.LastError, status = resp.Error, resp.StatusCode
+	} else {
+		if err := o.Store.Remove(ctx, entry.ID); err != nil {
+			return fmt.Errorf("failed to remove sent outbox entry %s: %w", entry.ID, err)
+		}
+		if o.OnSent != nil {
+			o.OnSent(entry, resp.Data)
+		}
+		return nil
+	}
+
+	if !isTransientStatus(status) || (o.MaxAttempts > 0 && entry.Attempts >= o.MaxAttempts) {
+		if err := o.Store.Remove(ctx, entry.ID); err != nil {
+			return fmt.Errorf("failed to remove outbox entry %s: %w", entry.ID, err)
+		}
+		if o.OnFailed != nil {
+			o.OnFailed(entry)
+		}
+		return nil
+	}
+
+	backoff := o.RetryBackoff
+	for i := 1; i < entry.Attempts && backoff < o.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	entry.NextAttemptAt = time.Now().Add(min(backoff, o.MaxBackoff))
+	if err := o.Store.Update(ctx, entry); err != nil {
+		return fmt.Errorf("failed to update outbox entry %s: %w", entry.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestOutboxRetriesWithSameIdempotencyKey(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		attempt := len(keys)
+		mu.Unlock()
+		if attempt == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error": "try again"}`))
+			return
+		}
+		w.Write([]byte(`{"id": "sent-1"}`))
+	}))
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	store := &inboundgo.MemoryOutboxStore{}
+	outbox := inboundgo.NewOutbox(client, store)
+	outbox.RetryBackoff = 10 * time.Millisecond
+	outbox.PollInterval = 5 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	sent := make(chan string, 1)
+	outbox.OnSent = func(entry *inboundgo.OutboxEntry, resp *inboundgo.PostEmailsResponse) {
+		if entry.Attempts != 2 {
+			t.Errorf("Expected 2 attempts, got %d", entry.Attempts)
+		}
+		sent <- resp.ID
+	}
+
+	id, err := outbox.Send(ctx, &inboundgo.PostEmailsRequest{From: "a@acme.test", To: "b@example.com", Subject: "Receipt"}, &inboundgo.IdempotencyOptions{IdempotencyKey: "order-42"})
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if id == "" {
+		t.Fatal("Expected an entry ID")
+	}
+
+	go outbox.Run(ctx)
+	select {
+	case got := <-sent:
+		if got != "sent-1" {
+			t.Errorf("Expected sent-1, got %s", got)
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for the email to be sent")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(keys) != 2 || keys[0] != "order-42" || keys[1] != "order-42" {
+		t.Errorf("Expected both attempts to use key order-42, got %v", keys)
+	}
+	if due, _ := store.Due(ctx, time.Now().Add(time.Hour), 0); len(due) != 0 {
+		t.Errorf("Expected the store to be empty, got %d entries", len(due))
+	}
+}
+
+func TestOutboxAbandonsRejectedEmails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "invalid from address"}`))
+	}))
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	outbox := inboundgo.NewOutbox(client, &inboundgo.MemoryOutboxStore{})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	failed := make(chan *inboundgo.OutboxEntry, 1)
+	outbox.OnFailed = func(entry *inboundgo.OutboxEntry) { failed <- entry }
+
+	outbox.Send(ctx, &inboundgo.PostEmailsRequest{From: "bad", To: "b@example.com", Subject: "x"}, nil)
+	go outbox.Run(ctx)
+	select {
+	case entry := <-failed:
+		if entry.Attempts != 1 || entry.LastError != "invalid from address" {
+			t.Errorf("Unexpected abandoned entry %+v", entry)
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for the email to be abandoned")
+	}
+}
+
+func TestFileOutboxStoreSurvivesRestart(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "outbox")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Enqueue without a worker, as if the process exited before sending
+	first := inboundgo.NewOutbox(nil, &inboundgo.FileOutboxStore{Dir: dir})
+	if _, err := first.Send(ctx, &inboundgo.PostEmailsRequest{From: "a@acme.test", To: []string{"b@example.com"}, Subject: "Invoice"}, nil); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if files, _ := filepath.Glob(filepath.Join(dir, "*.json")); len(files) != 1 {
+		t.Fatalf("Expected one queued file, got %d", len(files))
+	}
+
+	var subject string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req inboundgo.PostEmailsRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		subject = req.Subject
+		w.Write([]byte(`{"id": "sent-1"}`))
+	}))
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	second := inboundgo.NewOutbox(client, &inboundgo.FileOutboxStore{Dir: dir})
+	done := make(chan struct{})
+	second.OnSent = func(*inboundgo.OutboxEntry, *inboundgo.PostEmailsResponse) { close(done) }
+	go second.Run(ctx)
+	select {
+	case <-done:
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for the queued email to be sent")
+	}
+	if subject != "Invoice" {
+		t.Errorf("Expected queued email to be sent, got subject %q", subject)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("Expected outbox directory to be empty, got %d entries", len(entries))
+	}
+}
@@ -0,0 +1,37 @@
+package inboundgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestWithProxy(t *testing.T) {
+	var proxied *http.Request
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[],"pagination":{"limit":50,"offset":0,"total":0,"hasMore":false}}`))
+	}))
+	defer proxy.Close()
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	custom := &http.Client{}
+	client, _ := inboundgo.NewClient("test-api-key", "http://api.inbound.test/api/v2")
+	client.WithHTTPClient(custom).WithProxy(proxyURL)
+
+	resp, err := client.Mail().List(context.Background(), nil)
+	if err != nil || resp.Error != "" {
+		t.Fatalf("List failed: %v %s", err, resp.Error)
+	}
+	if proxied == nil || proxied.Host != "api.inbound.test" || proxied.URL.Path != "/api/v2/mail" {
+		t.Fatalf("Request was not sent through the proxy: %+v", proxied)
+	}
+	if custom.Transport != nil {
+		t.Error("WithProxy modified the caller's HTTP client")
+	}
+}
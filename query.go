@@ -0,0 +1,481 @@
+package inboundgo
+
+import (
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QueryEncoder is implemented by request types that know how to serialize
+// themselves into URL query parameters. buildQueryString prefers it over
+// reflection, so new request types should implement it explicitly.
+type QueryEncoder interface {
+	QueryValues() url.Values
+}
+
+// buildQueryString builds a query string from a struct
+func buildQueryString(params any) string {
+	var values url.Values
+	if enc, ok := params.(QueryEncoder); ok {
+		values = enc.QueryValues()
+	} else {
+		values = reflectQueryValues(params)
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+	return "?" + values.Encode()
+}
+
+// reflectQueryValues is the fallback encoder for structs that do not
+// implement QueryEncoder. Fields are keyed by their json tag.
+//
+// Supported field types are strings, integers, floats, bools, time.Time
+// (RFC 3339), slices of any of those, nested structs, and pointers to all of
+// them. Slices are sent as repeated parameters unless the field carries a
+// `query:"comma"` tag, in which case they are joined into one value. Nested
+// structs are flattened as parent[child]; embedded structs are inlined.
+func reflectQueryValues(params any) url.Values {
+	values := url.Values{}
+
+	if params == nil {
+		return values
+	}
+
+	v := reflect.ValueOf(params)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return values
+	}
+
+	encodeQueryStruct(values, "", v)
+	return values
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// encodeQueryStruct adds every tagged field of v to values, prefixing keys
+// for nested structs.
+func encodeQueryStruct(values url.Values, prefix string, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		fieldType := t.Field(i)
+
+		// Skip unexported fields
+		if !field.CanInterface() {
+			continue
+		}
+
+		// Get JSON tag
+		tag := fieldType.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		// Embedded structs without a tag are inlined, as in encoding/json
+		if tag == "" {
+			if fieldType.Anonymous {
+				if field.Kind() == reflect.Ptr {
+					if field.IsNil() {
+						continue
+					}
+					field = field.Elem()
+				}
+				if field.Kind() == reflect.Struct {
+					encodeQueryStruct(values, prefix, field)
+				}
+			}
+			continue
+		}
+
+		// Parse JSON tag
+		tagParts := strings.Split(tag, ",")
+		key := tagParts[0]
+		if prefix != "" {
+			key = prefix + "[" + key + "]"
+		}
+
+		// Check for omitempty
+		omitempty := slices.Contains(tagParts[1:], "omitempty")
+		comma := slices.Contains(strings.Split(fieldType.Tag.Get("query"), ","), "comma")
+
+		encodeQueryField(values, key, field, omitempty, comma)
+	}
+}
+
+// encodeQueryField adds a single field value under key.
+func encodeQueryField(values url.Values, key string, field reflect.Value, omitempty, comma bool) {
+	// Dereference pointers. A non-nil pointer is always sent, even when
+	// it points at a zero value, matching encoding/json semantics.
+	isPtr := field.Kind() == reflect.Ptr
+	if isPtr {
+		if field.IsNil() {
+			return
+		}
+		field = field.Elem()
+	}
+	omitZero := omitempty && !isPtr
+
+	if field.Type() == timeType {
+		t := field.Interface().(time.Time)
+		if omitZero && t.IsZero() {
+			return
+		}
+		values.Add(key, t.Format(time.RFC3339))
+		return
+	}
+
+	switch field.Kind() {
+	case reflect.Struct:
+		encodeQueryStruct(values, key, field)
+	case reflect.Slice, reflect.Array:
+		if field.Len() == 0 {
+			return
+		}
+		items := make([]string, 0, field.Len())
+		for i := 0; i < field.Len(); i++ {
+			if s, ok := formatQueryScalar(field.Index(i)); ok {
+				items = append(items, s)
+			}
+		}
+		if comma {
+			values.Add(key, strings.Join(items, ","))
+			return
+		}
+		for _, item := range items {
+			values.Add(key, item)
+		}
+	default:
+		if omitZero && field.IsZero() {
+			return
+		}
+		if s, ok := formatQueryScalar(field); ok {
+			values.Add(key, s)
+		}
+	}
+}
+
+// formatQueryScalar formats a scalar value (or pointer to one) for a query
+// string. It reports false for unsupported kinds.
+func formatQueryScalar(v reflect.Value) (string, bool) {
+	if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).Format(time.RFC3339), true
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32), true
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), true
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+	}
+	return "", false
+}
+
+// Query value helpers shared by the hand-written encoders below. They mirror
+// the omitempty semantics of the reflection fallback: empty strings and nil
+// pointers are skipped, while non-nil pointers are always sent.
+
+func addString(values url.Values, key, val string) {
+	if val != "" {
+		values.Add(key, val)
+	}
+}
+
+func addIntPtr(values url.Values, key string, val *int) {
+	if val != nil {
+		values.Add(key, strconv.Itoa(*val))
+	}
+}
+
+func addBoolPtr(values url.Values, key string, val *bool) {
+	if val != nil {
+		values.Add(key, strconv.FormatBool(*val))
+	}
+}
+
+func addTimePtr(values url.Values, key string, val *time.Time) {
+	if val != nil {
+		values.Add(key, val.Format(time.RFC3339))
+	}
+}
+
+// QueryValues encodes the request as URL query parameters
+func (r *GetMailRequest) QueryValues() url.Values {
+	values := url.Values{}
+	if r == nil {
+		return values
+	}
+	addIntPtr(values, "limit", r.Limit)
+	addIntPtr(values, "offset", r.Offset)
+	addString(values, "search", r.Search)
+	addString(values, "status", string(r.Status))
+	addString(values, "domain", r.Domain)
+	addString(values, "timeRange", r.TimeRange)
+	addBoolPtr(values, "includeArchived", r.IncludeArchived)
+	addString(values, "emailAddress", r.EmailAddress)
+	addString(values, "emailId", r.EmailID)
+	return values
+}
+
+// QueryValues encodes the request as URL query parameters
+func (r *GetEndpointsRequest) QueryValues() url.Values {
+	values := url.Values{}
+	if r == nil {
+		return values
+	}
+	addIntPtr(values, "limit", r.Limit)
+	addIntPtr(values, "offset", r.Offset)
+	addString(values, "type", string(r.Type))
+	addString(values, "active", r.Active)
+	return values
+}
+
+// QueryValues encodes the request as URL query parameters
+func (r *GetDomainsRequest) QueryValues() url.Values {
+	values := url.Values{}
+	if r == nil {
+		return values
+	}
+	addIntPtr(values, "limit", r.Limit)
+	addIntPtr(values, "offset", r.Offset)
+	addString(values, "status", string(r.Status))
+	addString(values, "canReceive", r.CanReceive)
+	addString(values, "check", r.Check)
+	return values
+}
+
+// QueryValues encodes the request as URL query parameters
+func (r *GetEmailAddressesRequest) QueryValues() url.Values {
+	values := url.Values{}
+	if r == nil {
+		return values
+	}
+	addIntPtr(values, "limit", r.Limit)
+	addIntPtr(values, "offset", r.Offset)
+	addString(values, "domainId", r.DomainID)
+	addString(values, "isActive", r.IsActive)
+	addString(values, "isReceiptRuleConfigured", r.IsReceiptRuleConfigured)
+	return values
+}
+
+// QueryValues encodes the request as URL query parameters
+func (r *GetScheduledEmailsRequest) QueryValues() url.Values {
+	values := url.Values{}
+	if r == nil {
+		return values
+	}
+	addIntPtr(values, "limit", r.Limit)
+	addIntPtr(values, "offset", r.Offset)
+	addString(values, "status", string(r.Status))
+	return values
+}
+
+// QueryValues encodes the request as URL query parameters
+func (r *GetThreadsRequest) QueryValues() url.Values {
+	values := url.Values{}
+	if r == nil {
+		return values
+	}
+	addIntPtr(values, "limit", r.Limit)
+	addIntPtr(values, "offset", r.Offset)
+	addString(values, "search", r.Search)
+	addBoolPtr(values, "unread", r.Unread)
+	addBoolPtr(values, "archived", r.Archived)
+	addString(values, "domain", r.Domain)
+	addString(values, "address", r.Address)
+	return values
+}
+
+// QueryValues encodes the request as URL query parameters
+func (r *GetTemplatesRequest) QueryValues() url.Values {
+	values := url.Values{}
+	if r == nil {
+		return values
+	}
+	addIntPtr(values, "limit", r.Limit)
+	addIntPtr(values, "offset", r.Offset)
+	addString(values, "search", r.Search)
+	return values
+}
+
+// QueryValues encodes the request as URL query parameters
+func (r *GetBroadcastsRequest) QueryValues() url.Values {
+	values := url.Values{}
+	if r == nil {
+		return values
+	}
+	addIntPtr(values, "limit", r.Limit)
+	addIntPtr(values, "offset", r.Offset)
+	addString(values, "status", r.Status)
+	return values
+}
+
+// QueryValues encodes the request as URL query parameters
+func (r *GetContactsRequest) QueryValues() url.Values {
+	values := url.Values{}
+	if r == nil {
+		return values
+	}
+	addIntPtr(values, "limit", r.Limit)
+	addIntPtr(values, "offset", r.Offset)
+	addString(values, "search", r.Search)
+	addString(values, "audienceId", r.AudienceID)
+	addString(values, "segmentId", r.SegmentID)
+	addBoolPtr(values, "unsubscribed", r.Unsubscribed)
+	return values
+}
+
+// QueryValues encodes the request as URL query parameters
+func (r *GetSuppressionsRequest) QueryValues() url.Values {
+	values := url.Values{}
+	if r == nil {
+		return values
+	}
+	addIntPtr(values, "limit", r.Limit)
+	addIntPtr(values, "offset", r.Offset)
+	addString(values, "reason", r.Reason)
+	addString(values, "domain", r.Domain)
+	addString(values, "search", r.Search)
+	return values
+}
+
+// QueryValues encodes the request as URL query parameters
+func (r *GetBouncesRequest) QueryValues() url.Values {
+	values := url.Values{}
+	if r == nil {
+		return values
+	}
+	addIntPtr(values, "limit", r.Limit)
+	addIntPtr(values, "offset", r.Offset)
+	addString(values, "type", r.Type)
+	addString(values, "domain", r.Domain)
+	addTimePtr(values, "since", r.Since)
+	addTimePtr(values, "until", r.Until)
+	return values
+}
+
+// QueryValues encodes the request as URL query parameters
+func (r *GetComplaintsRequest) QueryValues() url.Values {
+	values := url.Values{}
+	if r == nil {
+		return values
+	}
+	addIntPtr(values, "limit", r.Limit)
+	addIntPtr(values, "offset", r.Offset)
+	addString(values, "domain", r.Domain)
+	addTimePtr(values, "since", r.Since)
+	addTimePtr(values, "until", r.Until)
+	return values
+}
+
+// QueryValues encodes the request as URL query parameters
+func (r *GetAuditLogRequest) QueryValues() url.Values {
+	values := url.Values{}
+	if r == nil {
+		return values
+	}
+	addIntPtr(values, "limit", r.Limit)
+	addIntPtr(values, "offset", r.Offset)
+	addString(values, "action", r.Action)
+	addString(values, "actorId", r.ActorID)
+	addString(values, "resourceType", r.ResourceType)
+	addString(values, "resourceId", r.ResourceID)
+	addTimePtr(values, "since", r.Since)
+	addTimePtr(values, "until", r.Until)
+	return values
+}
+
+// QueryValues encodes the request as URL query parameters
+func (r *GetDomainReputationRequest) QueryValues() url.Values {
+	values := url.Values{}
+	if r == nil {
+		return values
+	}
+	addString(values, "window", string(r.Window))
+	return values
+}
+
+// QueryValues encodes the request as URL query parameters
+func (r *GetRulesRequest) QueryValues() url.Values {
+	values := url.Values{}
+	if r == nil {
+		return values
+	}
+	addIntPtr(values, "limit", r.Limit)
+	addIntPtr(values, "offset", r.Offset)
+	return values
+}
+
+// QueryValues encodes the request as URL query parameters
+func (r *GetForwardingRulesRequest) QueryValues() url.Values {
+	values := url.Values{}
+	if r == nil {
+		return values
+	}
+	addIntPtr(values, "limit", r.Limit)
+	addIntPtr(values, "offset", r.Offset)
+	addString(values, "source", r.Source)
+	return values
+}
+
+// QueryValues encodes the request as URL query parameters
+func (r *GetSenderFiltersRequest) QueryValues() url.Values {
+	values := url.Values{}
+	if r == nil {
+		return values
+	}
+	addIntPtr(values, "limit", r.Limit)
+	addIntPtr(values, "offset", r.Offset)
+	addString(values, "action", r.Action)
+	return values
+}
+
+// QueryValues encodes the request as URL query parameters
+func (r *GetChangesRequest) QueryValues() url.Values {
+	values := url.Values{}
+	if r == nil {
+		return values
+	}
+	addString(values, "since", r.Since)
+	addIntPtr(values, "limit", r.Limit)
+	return values
+}
+
+// QueryValues encodes the request as URL query parameters
+func (r *GetEmailsRequest) QueryValues() url.Values {
+	values := url.Values{}
+	if r == nil {
+		return values
+	}
+	addIntPtr(values, "limit", r.Limit)
+	addIntPtr(values, "offset", r.Offset)
+	if r.Tag != nil {
+		values.Add("tag[name]", r.Tag.Name)
+		values.Add("tag[value]", r.Tag.Value)
+	}
+	addTimePtr(values, "since", r.Since)
+	addTimePtr(values, "until", r.Until)
+	return values
+}
@@ -0,0 +1,271 @@
+package inboundgo
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestQueryValuesMatchReflection(t *testing.T) {
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2025, 2, 1, 12, 30, 0, 0, time.FixedZone("CET", 3600))
+
+	tests := []struct {
+		name   string
+		params QueryEncoder
+	}{
+		{
+			name: "GetMailRequest",
+			params: &GetMailRequest{
+				Limit:           Int(25),
+				Offset:          Int(0),
+				Search:          "invoice",
+				Status:          "processed",
+				Domain:          "example.com",
+				TimeRange:       "7d",
+				IncludeArchived: Bool(false),
+				EmailAddress:    "support@example.com",
+				EmailID:         "email-123",
+			},
+		},
+		{
+			name: "GetEndpointsRequest",
+			params: &GetEndpointsRequest{
+				Limit:  Int(10),
+				Type:   "webhook",
+				Active: "true",
+			},
+		},
+		{
+			name: "GetDomainsRequest",
+			params: &GetDomainsRequest{
+				Offset:     Int(50),
+				Status:     "verified",
+				CanReceive: "true",
+				Check:      "false",
+			},
+		},
+		{
+			name: "GetEmailAddressesRequest",
+			params: &GetEmailAddressesRequest{
+				DomainID:                "dom-1",
+				IsActive:                "true",
+				IsReceiptRuleConfigured: "false",
+			},
+		},
+		{
+			name:   "GetScheduledEmailsRequest",
+			params: &GetScheduledEmailsRequest{Limit: Int(5), Status: "scheduled"},
+		},
+		{
+			name: "GetThreadsRequest",
+			params: &GetThreadsRequest{
+				Search:   "hello",
+				Unread:   Bool(true),
+				Archived: Bool(false),
+				Domain:   "example.com",
+				Address:  "me@example.com",
+			},
+		},
+		{
+			name:   "GetTemplatesRequest",
+			params: &GetTemplatesRequest{Limit: Int(20), Offset: Int(40), Search: "welcome"},
+		},
+		{
+			name:   "GetBroadcastsRequest",
+			params: &GetBroadcastsRequest{Limit: Int(10), Status: "sending"},
+		},
+		{
+			name: "GetContactsRequest",
+			params: &GetContactsRequest{
+				Limit:        Int(100),
+				Search:       "ada",
+				AudienceID:   "aud-1",
+				SegmentID:    "seg-1",
+				Unsubscribed: Bool(false),
+			},
+		},
+		{
+			name:   "GetSuppressionsRequest",
+			params: &GetSuppressionsRequest{Offset: Int(10), Reason: "hard_bounce", Domain: "example.com", Search: "ada"},
+		},
+		{
+			name: "GetBouncesRequest",
+			params: &GetBouncesRequest{
+				Limit:  Int(50),
+				Type:   "hard",
+				Domain: "example.com",
+				Since:  &since,
+				Until:  &until,
+			},
+		},
+		{
+			name:   "GetComplaintsRequest",
+			params: &GetComplaintsRequest{Domain: "example.com", Since: &since},
+		},
+		{
+			name: "GetAuditLogRequest",
+			params: &GetAuditLogRequest{
+				Action:       "domain.deleted",
+				ActorID:      "user-1",
+				ResourceType: "domain",
+				ResourceID:   "dom-1",
+				Until:        &until,
+			},
+		},
+		{
+			name:   "GetDomainReputationRequest",
+			params: &GetDomainReputationRequest{Window: ReputationWindow30d},
+		},
+		{
+			name:   "GetRulesRequest",
+			params: &GetRulesRequest{Limit: Int(50), Offset: Int(100)},
+		},
+		{
+			name:   "GetForwardingRulesRequest",
+			params: &GetForwardingRulesRequest{Limit: Int(10), Source: "*@support.example.com"},
+		},
+		{
+			name:   "GetSenderFiltersRequest",
+			params: &GetSenderFiltersRequest{Limit: Int(10), Action: "block"},
+		},
+		{
+			name:   "GetChangesRequest",
+			params: &GetChangesRequest{Since: "tok_123", Limit: Int(100)},
+		},
+		{
+			name:   "GetEmailsRequest",
+			params: &GetEmailsRequest{Limit: Int(100), Tag: &EmailTag{Name: "campaign", Value: "spring-launch"}, Since: &since, Until: &until},
+		},
+		{
+			name:   "GetEmailsRequest with empty tag value",
+			params: &GetEmailsRequest{Tag: &EmailTag{Name: "campaign"}},
+		},
+		{
+			name:   "empty GetMailRequest",
+			params: &GetMailRequest{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.params.QueryValues()
+			want := reflectQueryValues(tt.params)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("QueryValues() = %v, reflection fallback = %v", got, want)
+			}
+		})
+	}
+}
+
+func TestQueryValuesNilReceiver(t *testing.T) {
+	var params *GetMailRequest
+	if got := buildQueryString(params); got != "" {
+		t.Errorf("Expected empty query string for nil request, got '%s'", got)
+	}
+	if got := params.QueryValues(); len(got) != 0 {
+		t.Errorf("Expected no values for nil request, got %v", got)
+	}
+}
+
+func TestReflectQueryValuesPointers(t *testing.T) {
+	params := struct {
+		Limit  *int   `json:"limit,omitempty"`
+		Active *bool  `json:"active,omitempty"`
+		Name   string `json:"name,omitempty"`
+	}{
+		Limit:  Int(0),
+		Active: Bool(false),
+	}
+
+	got := reflectQueryValues(params)
+	want := url.Values{"limit": {"0"}, "active": {"false"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestReflectQueryValuesRichTypes(t *testing.T) {
+	since := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	type dateRange struct {
+		From *time.Time `json:"from,omitempty"`
+		To   *time.Time `json:"to,omitempty"`
+	}
+	type Paging struct {
+		Limit *int `json:"limit,omitempty"`
+	}
+	params := struct {
+		Paging
+		Tags      []string   `json:"tags,omitempty"`
+		Statuses  []string   `json:"statuses,omitempty" query:"comma"`
+		Since     time.Time  `json:"since,omitempty"`
+		Until     *time.Time `json:"until,omitempty"`
+		MinScore  float64    `json:"minScore,omitempty"`
+		MaxScore  *float64   `json:"maxScore,omitempty"`
+		Range     dateRange  `json:"range"`
+		IDs       *[]int     `json:"ids,omitempty"`
+		Empty     []string   `json:"empty,omitempty"`
+		ZeroTime  time.Time  `json:"zeroTime,omitempty"`
+		Unhandled chan int   `json:"unhandled,omitempty"`
+	}{
+		Paging:   Paging{Limit: Int(10)},
+		Tags:     []string{"a", "b"},
+		Statuses: []string{"sent", "failed"},
+		Since:    since,
+		Until:    &since,
+		MinScore: 0.5,
+		MaxScore: func() *float64 { f := 2.0; return &f }(),
+		Range:    dateRange{From: &since},
+		IDs:      &[]int{1, 2},
+	}
+
+	got := reflectQueryValues(&params)
+	want := url.Values{
+		"limit":       {"10"},
+		"tags":        {"a", "b"},
+		"statuses":    {"sent,failed"},
+		"since":       {"2024-01-02T15:04:05Z"},
+		"until":       {"2024-01-02T15:04:05Z"},
+		"minScore":    {"0.5"},
+		"maxScore":    {"2"},
+		"range[from]": {"2024-01-02T15:04:05Z"},
+		"ids":         {"1", "2"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestBuildQueryStringUsesEncoder(t *testing.T) {
+	got := buildQueryString(&GetThreadsRequest{Limit: Int(10), Unread: Bool(false)})
+	want := "?" + url.Values{"limit": {"10"}, "unread": {"false"}}.Encode()
+	if got != want {
+		t.Errorf("Expected '%s', got '%s'", want, got)
+	}
+}
+
+func BenchmarkBuildQueryString(b *testing.B) {
+	params := &GetMailRequest{
+		Limit:     Int(25),
+		Search:    "invoice",
+		Status:    "processed",
+		Domain:    "example.com",
+		TimeRange: "7d",
+	}
+
+	b.Run("encoder", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = buildQueryString(params)
+		}
+	})
+
+	b.Run("reflection", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = reflectQueryValues(params).Encode()
+		}
+	})
+}
@@ -0,0 +1,123 @@
+package inboundgo
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// QuoteDateLayout is the date format of the attribution line of quoted
+// messages
+const QuoteDateLayout = "Mon, Jan 2, 2006 at 3:04 PM"
+
+var (
+	htmlBodyPattern  = regexp.MustCompile(`(?is)<body\b[^>]*>(.*?)(?:</body\s*>|$)`)
+	htmlBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</(?:p|div|tr|li|h[1-6]|blockquote)\s*>`)
+	htmlTagPattern   = regexp.MustCompile(`(?s)<[^>]*>`)
+	htmlSkipPattern  = regexp.MustCompile(`(?is)<(head|style|script)\b.*?</(head|style|script)\s*>|<!--.*?-->`)
+	blankRunPattern  = regexp.MustCompile(`\n{3,}`)
+)
+
+// OriginalMessage is an email being replied to, as needed to quote it
+type OriginalMessage struct {
+	// From is the sender as shown in the attribution line, such as
+	// "Ada Lovelace <ada@example.com>".
+	From string
+	Date time.Time
+	HTML string
+	Text string
+}
+
+// OriginalFromMail returns the quotable parts of a received email
+func OriginalFromMail(email *GetMailByIDResponse) *OriginalMessage {
+	return &OriginalMessage{From: email.From, Date: email.ReceivedAt, HTML: email.HTMLBody, Text: email.TextBody}
+}
+
+// Original returns the quotable parts of the email in an email.received
+// webhook. The Date header is used when present, falling back to the time
+// the email was received.
+func (w *WebhookPayload) Original() *OriginalMessage {
+	m := &OriginalMessage{From: w.GetFromAddress()}
+	if m.From == "" && w.Email.From != nil {
+		m.From = w.Email.From.Text
+	}
+	parsed := w.Email.ParsedData
+	if parsed.Date != nil {
+		m.Date, _ = parseEmailTime(*parsed.Date)
+	}
+	if m.Date.IsZero() {
+		m.Date, _ = parseEmailTime(w.Email.ReceivedAt)
+	}
+	if parsed.HTMLBody != nil {
+		m.HTML = *parsed.HTMLBody
+	}
+	if parsed.TextBody != nil {
+		m.Text = *parsed.TextBody
+	}
+	return m
+}
+
+// Attribution returns the line introducing the quote, "On <date>, <from>
+// wrote:"
+func (m *OriginalMessage) Attribution() string {
+	from := strings.TrimSpace(m.From)
+	if from == "" {
+		from = "the sender"
+	}
+	if m.Date.IsZero() {
+		return from + " wrote:"
+	}
+	return "On " + m.Date.Format(QuoteDateLayout) + ", " + from + " wrote:"
+}
+
+// Quote returns the message quoted for a reply: the HTML version in a
+// cite blockquote, and the text version with each line prefixed by ">".
+// Either version is derived from the other when the original lacks it.
+// Append them to the reply's own HTML and Text.
+func (m *OriginalMessage) Quote() (htmlQuote, textQuote string) {
+	attribution := m.Attribution()
+
+	body := m.HTML
+	if body != "" {
+		if match := htmlBodyPattern.FindStringSubmatch(body); match != nil {
+			body = match[1]
+		}
+	} else {
+		body = strings.ReplaceAll(html.EscapeString(strings.TrimRight(m.Text, "\r\n")), "\n", "<br>\n")
+	}
+	htmlQuote = `<div class="quote"><p>` + html.EscapeString(attribution) + "</p>\n" +
+		`<blockquote type="cite" style="margin:0 0 0 .8ex;border-left:1px solid #ccc;padding-left:1ex">` +
+		strings.TrimSpace(body) + "</blockquote></div>"
+
+	text := m.Text
+	if text == "" {
+		text = plainTextFromHTML(m.HTML)
+	}
+	var b strings.Builder
+	b.WriteString(attribution + "\n")
+	for _, line := range strings.Split(strings.TrimRight(strings.ReplaceAll(text, "\r\n", "\n"), "\n"), "\n") {
+		switch {
+		case line == "":
+			b.WriteString(">\n")
+		case strings.HasPrefix(line, ">"):
+			b.WriteString(">" + line + "\n")
+		default:
+			b.WriteString("> " + line + "\n")
+		}
+	}
+	return htmlQuote, b.String()
+}
+
+// plainTextFromHTML is a rough text rendering of an HTML body, good enough
+// for quoting
+func plainTextFromHTML(body string) string {
+	body = htmlSkipPattern.ReplaceAllString(body, "")
+	body = htmlBreakPattern.ReplaceAllString(body, "\n")
+	body = html.UnescapeString(htmlTagPattern.ReplaceAllString(body, ""))
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	return strings.TrimSpace(blankRunPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
@@ -0,0 +1,68 @@
+package inboundgo_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestQuoteText(t *testing.T) {
+	m := &inboundgo.OriginalMessage{
+		From: "Ada Lovelace <ada@example.com>",
+		Date: time.Date(2025, 3, 10, 14, 5, 0, 0, time.UTC),
+		Text: "Hi,\n\nCan we meet?\n> earlier quote\r\n",
+	}
+	htmlQuote, textQuote := m.Quote()
+	wantText := "On Mon, Mar 10, 2025 at 2:05 PM, Ada Lovelace <ada@example.com> wrote:\n> Hi,\n>\n> Can we meet?\n>> earlier quote\n"
+	if textQuote != wantText {
+		t.Errorf("Unexpected text quote:\n%q\nwant\n%q", textQuote, wantText)
+	}
+	for _, want := range []string{
+		"<p>On Mon, Mar 10, 2025 at 2:05 PM, Ada Lovelace &lt;ada@example.com&gt; wrote:</p>",
+		`<blockquote type="cite"`,
+		"Hi,<br>\n<br>\nCan we meet?<br>\n&gt; earlier quote</blockquote>",
+	} {
+		if !strings.Contains(htmlQuote, want) {
+			t.Errorf("Expected HTML quote to contain %q:\n%s", want, htmlQuote)
+		}
+	}
+}
+
+func TestQuoteHTML(t *testing.T) {
+	m := inboundgo.OriginalFromMail(&inboundgo.GetMailByIDResponse{
+		From:     "bob@example.com",
+		HTMLBody: `<html><head><style>p{color:red}</style></head><body class="x"><p>Hello &amp; welcome</p><p>Bob</p></body></html>`,
+	})
+	htmlQuote, textQuote := m.Quote()
+	if !strings.Contains(htmlQuote, `padding-left:1ex"><p>Hello &amp; welcome</p><p>Bob</p></blockquote>`) {
+		t.Errorf("Expected body content in the quote:\n%s", htmlQuote)
+	}
+	if textQuote != "bob@example.com wrote:\n> Hello & welcome\n> Bob\n" {
+		t.Errorf("Unexpected text quote %q", textQuote)
+	}
+}
+
+func TestWebhookPayloadOriginal(t *testing.T) {
+	payload := &inboundgo.WebhookPayload{}
+	name, address := "Ada", "ada@example.com"
+	date, text := "Mon, 10 Mar 2025 15:05:00 +0100", "Hi"
+	payload.Email.From = &inboundgo.WebhookAddressGroup{Addresses: []inboundgo.WebhookAddress{{Name: &name, Address: &address}}}
+	payload.Email.ReceivedAt = "2025-03-10T14:06:00Z"
+	payload.Email.ParsedData.Date = &date
+	payload.Email.ParsedData.TextBody = &text
+
+	m := payload.Original()
+	if m.From != "Ada <ada@example.com>" || m.Text != "Hi" || !m.Date.Equal(time.Date(2025, 3, 10, 14, 5, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected original %+v", m)
+	}
+	if got := m.Attribution(); got != "On Mon, Mar 10, 2025 at 3:05 PM, Ada <ada@example.com> wrote:" {
+		t.Errorf("Unexpected attribution %q", got)
+	}
+
+	payload.Email.ParsedData.Date = nil
+	if m := payload.Original(); !m.Date.Equal(time.Date(2025, 3, 10, 14, 6, 0, 0, time.UTC)) {
+		t.Errorf("Expected receivedAt fallback, got %v", m.Date)
+	}
+}
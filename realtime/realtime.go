@@ -0,0 +1,425 @@
+// Package realtime subscribes to Inbound mail, thread, and delivery events
+// over a WebSocket connection.
+//
+// A Subscription reconnects automatically with exponential backoff and
+// resumes from the last event it delivered, so events are not lost across
+// brief disconnects. If the server can no longer resume (the gap was too
+// long), an EventResumeExpired event is delivered and the caller should
+// resynchronize, for example with inboundgo.MailSync.
+//
+// The WebSocket is opened with the proxy, dialer, and TLS settings of the
+// client's HTTP transport, so WithProxy and custom root CAs apply to it as
+// they do to API requests. A client whose transport is not an
+// *http.Transport connects directly, or through the proxy named by the
+// HTTPS_PROXY and HTTP_PROXY environment variables; set Options.DialContext
+// to connect some other way.
+//
+//	sub, err := realtime.Subscribe(ctx, client, &realtime.Options{
+//		Topics: []realtime.Topic{realtime.TopicMail},
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for event := range sub.Events() {
+//		if event.Mail != nil {
+//			fmt.Println("New mail:", event.Mail.Subject)
+//		}
+//	}
+//	if err := sub.Err(); err != nil {
+//		log.Fatal(err)
+//	}
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+// Topic selects a group of events to subscribe to
+type Topic string
+
+const (
+	// TopicMail delivers EventMailReceived
+	TopicMail Topic = "mail"
+	// TopicThread delivers EventThreadUpdated
+	TopicThread Topic = "thread"
+	// TopicDelivery delivers EventDeliveryUpdated for sent emails
+	TopicDelivery Topic = "delivery"
+)
+
+// EventType identifies an event
+type EventType string
+
+const (
+	// EventMailReceived carries a newly received email in Event.Mail
+	EventMailReceived EventType = "mail.received"
+	// EventThreadUpdated carries a thread with new messages or changed
+	// read or archive state in Event.Thread
+	EventThreadUpdated EventType = "thread.updated"
+	// EventDeliveryUpdated carries a sent email's lifecycle event, such as
+	// delivered or bounced, in Event.Delivery
+	EventDeliveryUpdated EventType = "delivery.updated"
+	// EventResumeExpired reports that events were missed because the
+	// subscription could not be resumed. It has no payload.
+	EventResumeExpired EventType = "resume.expired"
+)
+
+// DeliveryUpdate is the payload of EventDeliveryUpdated
+type DeliveryUpdate struct {
+	EmailID string               `json:"emailId"`
+	Event   inboundgo.EmailEvent `json:"event"`
+}
+
+// Event is a realtime event. The payload field matching Type is set, and
+// Data holds the raw payload for event types this package does not know.
+type Event struct {
+	Type EventType
+	// ID is the event's resume token.
+	ID        string
+	Timestamp time.Time
+	Mail      *inboundgo.EmailItem
+	Thread    *inboundgo.ThreadSummary
+	Delivery  *DeliveryUpdate
+	Data      json.RawMessage
+}
+
+// Options configure a subscription
+type Options struct {
+	// Topics to subscribe to (default all).
+	Topics []Topic
+	// ResumeToken resumes after the event with this ID, for example one
+	// persisted by a previous process from Subscription.ResumeToken.
+	ResumeToken string
+	// ReconnectDelay is the first wait before reconnecting (default 1s). It
+	// doubles after each failed attempt up to MaxReconnectDelay (default 1m).
+	ReconnectDelay    time.Duration
+	MaxReconnectDelay time.Duration
+	// PingInterval is how often the connection is checked for liveness
+	// (default 30s). A connection silent for two intervals is replaced.
+	PingInterval time.Duration
+	// Buffer is the capacity of the events channel (default 64).
+	Buffer int
+	// OnError, if set, is called with errors that cause a reconnect.
+	OnError func(error)
+	// DialContext, if set, opens the TCP connections to the realtime server
+	// or proxy. By default the client's *http.Transport dialer is used.
+	DialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+// Subscription is a live event subscription
+type Subscription struct {
+	client *inboundgo.Inbound
+	opts   Options
+	dialer *dialer
+	events chan Event
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu          sync.Mutex
+	resumeToken string
+	err         error
+}
+
+// wireMessage is a message exchanged over the WebSocket
+type wireMessage struct {
+	Type        string          `json:"type"` // 'subscribe' | 'subscribed' | 'event' | 'error'
+	Topics      []Topic         `json:"topics,omitempty"`
+	ResumeToken string          `json:"resumeToken,omitempty"`
+	ID          string          `json:"id,omitempty"`
+	Event       EventType       `json:"event,omitempty"`
+	Timestamp   time.Time       `json:"timestamp,omitempty"`
+	Data        json.RawMessage `json:"data,omitempty"`
+	Code        string          `json:"code,omitempty"`
+	Error       string          `json:"error,omitempty"`
+}
+
+// errResumeExpired is reported by the server for resume tokens it no
+// longer has events for
+const errResumeExpired = "resume_expired"
+
+// fatalError stops a subscription instead of reconnecting
+type fatalError struct{ err error }
+
+func (e *fatalError) Error() string { return e.err.Error() }
+func (e *fatalError) Unwrap() error { return e.err }
+
+// Subscribe connects and subscribes to events. It returns once the first
+// connection is established, or with an error if it cannot be, so
+// configuration problems surface immediately. Later disconnects are retried
+// in the background until ctx ends or Close is called.
+func Subscribe(ctx context.Context, client *inboundgo.Inbound, opts *Options) (*Subscription, error) {
+	s := &Subscription{client: client}
+	if opts != nil {
+		s.opts = *opts
+	}
+	if len(s.opts.Topics) == 0 {
+		s.opts.Topics = []Topic{TopicMail, TopicThread, TopicDelivery}
+	}
+	if s.opts.ReconnectDelay <= 0 {
+		s.opts.ReconnectDelay = time.Second
+	}
+	if s.opts.MaxReconnectDelay <= 0 {
+		s.opts.MaxReconnectDelay = time.Minute
+	}
+	if s.opts.PingInterval <= 0 {
+		s.opts.PingInterval = 30 * time.Second
+	}
+	if s.opts.Buffer <= 0 {
+		s.opts.Buffer = 64
+	}
+	s.dialer = newDialer(client.HTTPClient(), s.opts.DialContext)
+	s.resumeToken = s.opts.ResumeToken
+	s.events = make(chan Event, s.opts.Buffer)
+	s.done = make(chan struct{})
+	s.ctx, s.cancel = context.WithCancel(ctx)
+
+	conn, err := s.connect()
+	if err != nil {
+		s.cancel()
+		return nil, err
+	}
+	go s.run(conn)
+	return s, nil
+}
+
+// Events returns the channel events are delivered on. It is closed when the
+// subscription ends; check Err to learn why.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Err returns the error that ended the subscription, or nil if it was
+// closed or its context ended
+func (s *Subscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// ResumeToken returns the ID of the last delivered event. Persist it to
+// resume from the same point with Options.ResumeToken.
+func (s *Subscription) ResumeToken() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.resumeToken
+}
+
+// Close ends the subscription and waits for the connection to shut down
+func (s *Subscription) Close() error {
+	s.cancel()
+	<-s.done
+	return nil
+}
+
+func (s *Subscription) run(conn *wsConn) {
+	defer close(s.done)
+	defer close(s.events)
+
+	delay := s.opts.ReconnectDelay
+	for {
+		if conn != nil {
+			delivered, err := s.serve(conn)
+			if delivered {
+				delay = s.opts.ReconnectDelay
+			}
+			conn = nil
+			if s.stop(err) {
+				return
+			}
+		}
+
+		if sleepContext(s.ctx, delay) != nil {
+			return
+		}
+		delay = min(delay*2, s.opts.MaxReconnectDelay)
+
+		var err error
+		if conn, err = s.connect(); s.stop(err) {
+			return
+		}
+	}
+}
+
+// stop reports whether the subscription should end after err, recording
+// fatal errors and reporting the rest
+func (s *Subscription) stop(err error) bool {
+	if s.ctx.Err() != nil {
+		return true
+	}
+	if err == nil {
+		return false
+	}
+	var fatal *fatalError
+	if errors.As(err, &fatal) {
+		s.mu.Lock()
+		s.err = fatal.err
+		s.mu.Unlock()
+		return true
+	}
+	if s.opts.OnError != nil {
+		s.opts.OnError(err)
+	}
+	return false
+}
+
+// connect obtains a token, opens the WebSocket, and subscribes
+func (s *Subscription) connect() (*wsConn, error) {
+	resp, err := s.client.RealtimeToken(s.ctx)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		err := fmt.Errorf("failed to get realtime token: %s", resp.Error)
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+			return nil, &fatalError{err}
+		}
+		return nil, err
+	}
+	u, err := url.Parse(resp.Data.URL)
+	if err != nil {
+		return nil, &fatalError{fmt.Errorf("invalid realtime URL: %w", err)}
+	}
+	query := u.Query()
+	query.Set("token", resp.Data.Token)
+	u.RawQuery = query.Encode()
+
+	conn, err := s.dialer.dial(s.ctx, u.String(), nil)
+	if err != nil {
+		var hs *handshakeError
+		if errors.As(err, &hs) && (hs.StatusCode == http.StatusUnauthorized || hs.StatusCode == http.StatusForbidden) {
+			return nil, &fatalError{err}
+		}
+		return nil, err
+	}
+
+	subscribe, _ := json.Marshal(wireMessage{Type: "subscribe", Topics: s.opts.Topics, ResumeToken: s.ResumeToken()})
+	if err := conn.writeMessage(subscribe); err != nil {
+		conn.conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// serve reads events until the connection fails. It reports whether any
+// event was delivered, meaning the connection was healthy.
+func (s *Subscription) serve(conn *wsConn) (delivered bool, err error) {
+	done := make(chan struct{})
+	defer func() {
+		close(done)
+		conn.close()
+	}()
+	go func() {
+		ticker := time.NewTicker(s.opts.PingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.ctx.Done():
+				// Unblock the reader
+				conn.conn.Close()
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				conn.writeFrame(opPing, nil)
+			}
+		}
+	}()
+
+	for {
+		conn.conn.SetReadDeadline(time.Now().Add(2 * s.opts.PingInterval))
+		data, err := conn.readMessage()
+		if err != nil {
+			return delivered, fmt.Errorf("realtime connection lost: %w", err)
+		}
+		var msg wireMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return delivered, fmt.Errorf("invalid realtime message: %w", err)
+		}
+
+		switch msg.Type {
+		case "event":
+			event, err := decodeEvent(&msg)
+			if err != nil {
+				return delivered, err
+			}
+			if !s.deliver(event) {
+				return delivered, nil
+			}
+			delivered = true
+		case "error":
+			if msg.Code == errResumeExpired {
+				s.setResumeToken("")
+				if !s.deliver(Event{Type: EventResumeExpired, Timestamp: time.Now()}) {
+					return delivered, nil
+				}
+				// Reconnect without a resume token
+				return true, errors.New("realtime resume token expired")
+			}
+			return delivered, fmt.Errorf("realtime error: %s", msg.Error)
+		}
+	}
+}
+
+// deliver sends an event to the caller and records its resume token. It
+// returns false if the subscription ended first.
+func (s *Subscription) deliver(event Event) bool {
+	select {
+	case s.events <- event:
+		if event.ID != "" {
+			s.setResumeToken(event.ID)
+		}
+		return true
+	case <-s.ctx.Done():
+		return false
+	}
+}
+
+func (s *Subscription) setResumeToken(token string) {
+	s.mu.Lock()
+	s.resumeToken = token
+	s.mu.Unlock()
+}
+
+func decodeEvent(msg *wireMessage) (Event, error) {
+	event := Event{Type: msg.Event, ID: msg.ID, Timestamp: msg.Timestamp, Data: msg.Data}
+	var target any
+	switch msg.Event {
+	case EventMailReceived:
+		event.Mail = &inboundgo.EmailItem{}
+		target = event.Mail
+	case EventThreadUpdated:
+		event.Thread = &inboundgo.ThreadSummary{}
+		target = event.Thread
+	case EventDeliveryUpdated:
+		event.Delivery = &DeliveryUpdate{}
+		target = event.Delivery
+	default:
+		return event, nil
+	}
+	if err := json.Unmarshal(msg.Data, target); err != nil {
+		return event, fmt.Errorf("invalid %s event: %w", msg.Event, err)
+	}
+	return event, nil
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,326 @@
+package realtime_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+	"github.com/inboundemail/inbound-golang-sdk/realtime"
+)
+
+// wsServerConn is the server side of a test WebSocket connection
+type wsServerConn struct {
+	conn net.Conn
+	rw   *bufio.ReadWriter
+}
+
+func upgrade(t *testing.T, w http.ResponseWriter, r *http.Request) *wsServerConn {
+	t.Helper()
+	if r.Header.Get("Upgrade") != "websocket" || r.Header.Get("Sec-WebSocket-Version") != "13" {
+		t.Fatalf("Not a WebSocket upgrade: %v", r.Header)
+	}
+	sum := sha1.Sum([]byte(r.Header.Get("Sec-WebSocket-Key") + "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"))
+	conn, rw, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		t.Fatalf("Hijack failed: %v", err)
+	}
+	fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n",
+		base64.StdEncoding.EncodeToString(sum[:]))
+	rw.Flush()
+	return &wsServerConn{conn: conn, rw: rw}
+}
+
+// send writes an unmasked text frame, split into two fragments to exercise
+// reassembly
+func (c *wsServerConn) send(v any) {
+	data, _ := json.Marshal(v)
+	half := len(data) / 2
+	c.writeFrame(0x1, data[:half], false)
+	c.writeFrame(0x0, data[half:], true)
+}
+
+func (c *wsServerConn) writeFrame(op byte, payload []byte, fin bool) {
+	b0 := op
+	if fin {
+		b0 |= 0x80
+	}
+	header := []byte{b0}
+	if len(payload) < 126 {
+		header = append(header, byte(len(payload)))
+	} else {
+		header = append(header, 126)
+		header = binary.BigEndian.AppendUint16(header, uint16(len(payload)))
+	}
+	c.rw.Write(header)
+	c.rw.Write(payload)
+	c.rw.Flush()
+}
+
+// receive reads one masked client frame
+func (c *wsServerConn) receive() (byte, []byte, error) {
+	var head [2]byte
+	if _, err := io.ReadFull(c.rw, head[:]); err != nil {
+		return 0, nil, err
+	}
+	if head[1]&0x80 == 0 {
+		return 0, nil, fmt.Errorf("client frame not masked")
+	}
+	n := int(head[1] & 0x7f)
+	if n == 126 {
+		var ext [2]byte
+		io.ReadFull(c.rw, ext[:])
+		n = int(binary.BigEndian.Uint16(ext[:]))
+	}
+	var mask [4]byte
+	io.ReadFull(c.rw, mask[:])
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(c.rw, payload); err != nil {
+		return 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return head[0] & 0x0f, payload, nil
+}
+
+func TestSubscribeDeliversAndResumes(t *testing.T) {
+	var (
+		mu          sync.Mutex
+		connections int
+		subscribes  []map[string]any
+	)
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/realtime/token":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"token": "tok", "url": "ws://%s/ws"}`, strings.TrimPrefix(server.URL, "http://"))
+		case "/ws":
+			if r.URL.Query().Get("token") != "tok" {
+				t.Errorf("Expected token in query, got %q", r.URL.RawQuery)
+			}
+			conn := upgrade(t, w, r)
+			defer conn.conn.Close()
+			op, data, err := conn.receive()
+			if err != nil || op != 0x1 {
+				t.Errorf("Expected subscribe message, got op %d: %v", op, err)
+				return
+			}
+			var sub map[string]any
+			json.Unmarshal(data, &sub)
+
+			mu.Lock()
+			connections++
+			n := connections
+			subscribes = append(subscribes, sub)
+			mu.Unlock()
+
+			switch n {
+			case 1:
+				// A ping must be answered before events continue
+				conn.writeFrame(0x9, []byte("hi"), true)
+				if op, data, _ := conn.receive(); op != 0xa || string(data) != "hi" {
+					t.Errorf("Expected pong, got op %d %q", op, data)
+				}
+				conn.send(map[string]any{"type": "event", "id": "evt-1", "event": "mail.received",
+					"data": map[string]any{"id": "email-1", "subject": "Hello"}})
+				conn.send(map[string]any{"type": "event", "id": "evt-2", "event": "delivery.updated",
+					"data": map[string]any{"emailId": "sent-1", "event": map[string]any{"type": "bounced"}}})
+				// Drop the connection without a close frame
+			case 2:
+				conn.send(map[string]any{"type": "error", "code": "resume_expired", "error": "cannot resume"})
+				conn.receive()
+			default:
+				conn.send(map[string]any{"type": "event", "id": "evt-9", "event": "thread.updated",
+					"data": map[string]any{"id": "thread-1", "messageCount": 3}})
+				conn.receive()
+			}
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var reconnects int
+	sub, err := realtime.Subscribe(ctx, client, &realtime.Options{
+		Topics:         []realtime.Topic{realtime.TopicMail, realtime.TopicThread, realtime.TopicDelivery},
+		ResumeToken:    "evt-0",
+		ReconnectDelay: 10 * time.Millisecond,
+		OnError:        func(error) { reconnects++ },
+	})
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	next := func() realtime.Event {
+		t.Helper()
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				t.Fatalf("Events closed early: %v", sub.Err())
+			}
+			return event
+		case <-ctx.Done():
+			t.Fatal("Timed out waiting for event")
+		}
+		return realtime.Event{}
+	}
+
+	if e := next(); e.Type != realtime.EventMailReceived || e.Mail == nil || e.Mail.Subject != "Hello" {
+		t.Errorf("Unexpected first event %+v", e)
+	}
+	if e := next(); e.Type != realtime.EventDeliveryUpdated || e.Delivery.EmailID != "sent-1" || e.Delivery.Event.Type != inboundgo.EmailEventBounced {
+		t.Errorf("Unexpected second event %+v", e)
+	}
+	if e := next(); e.Type != realtime.EventResumeExpired {
+		t.Errorf("Expected resume expired, got %+v", e)
+	}
+	if e := next(); e.Type != realtime.EventThreadUpdated || e.Thread.MessageCount != 3 {
+		t.Errorf("Unexpected thread event %+v", e)
+	}
+	if sub.ResumeToken() != "evt-9" {
+		t.Errorf("Expected resume token evt-9, got %q", sub.ResumeToken())
+	}
+
+	sub.Close()
+	if _, ok := <-sub.Events(); ok {
+		t.Error("Expected events channel to be closed")
+	}
+	if sub.Err() != nil {
+		t.Errorf("Expected no error after Close, got %v", sub.Err())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(subscribes) != 3 {
+		t.Fatalf("Expected 3 connections, got %d", len(subscribes))
+	}
+	if subscribes[0]["resumeToken"] != "evt-0" || subscribes[1]["resumeToken"] != "evt-2" || subscribes[2]["resumeToken"] != nil {
+		t.Errorf("Unexpected resume tokens: %v", subscribes)
+	}
+	if reconnects != 2 {
+		t.Errorf("Expected 2 reported reconnects, got %d", reconnects)
+	}
+}
+
+func TestSubscribeThroughProxy(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/realtime/token":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"token": "tok", "url": "ws://%s/ws"}`, strings.TrimPrefix(server.URL, "http://"))
+		case "/ws":
+			conn := upgrade(t, w, r)
+			defer conn.conn.Close()
+			conn.receive()
+			conn.send(map[string]any{"type": "event", "id": "evt-1", "event": "mail.received",
+				"data": map[string]any{"id": "email-1", "subject": "Hello"}})
+			conn.receive()
+		}
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var tunnels []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			out := r.Clone(r.Context())
+			out.RequestURI = ""
+			resp, err := http.DefaultTransport.RoundTrip(out)
+			if err != nil {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			defer resp.Body.Close()
+			for k, v := range resp.Header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(resp.StatusCode)
+			io.Copy(w, resp.Body)
+			return
+		}
+		mu.Lock()
+		tunnels = append(tunnels, r.Host)
+		mu.Unlock()
+		upstream, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer upstream.Close()
+		conn, rw, _ := w.(http.Hijacker).Hijack()
+		defer conn.Close()
+		rw.WriteString("HTTP/1.1 200 Connection established\r\n\r\n")
+		rw.Flush()
+		go io.Copy(upstream, conn)
+		io.Copy(conn, upstream)
+	}))
+	defer proxy.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	proxyURL, _ := url.Parse(proxy.URL)
+	client.WithProxy(proxyURL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	sub, err := realtime.Subscribe(ctx, client, nil)
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	defer sub.Close()
+
+	select {
+	case e := <-sub.Events():
+		if e.Mail == nil || e.Mail.Subject != "Hello" {
+			t.Errorf("Unexpected event %+v", e)
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for event")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(tunnels) != 1 || tunnels[0] != strings.TrimPrefix(server.URL, "http://") {
+		t.Errorf("Expected one tunnel to the server, got %v", tunnels)
+	}
+}
+
+func TestSubscribeUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": "Invalid API key"}`))
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if _, err := realtime.Subscribe(context.Background(), client, nil); err == nil || !strings.Contains(err.Error(), "Invalid API key") {
+		t.Errorf("Expected unauthorized error, got %v", err)
+	}
+}
@@ -0,0 +1,406 @@
+package realtime
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// websocketGUID is appended to the handshake key, per RFC 6455
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// maxMessageSize bounds a single message so a misbehaving server cannot
+// exhaust memory
+const maxMessageSize = 16 << 20
+
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+)
+
+// handshakeError reports a WebSocket upgrade rejected by the server
+type handshakeError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *handshakeError) Error() string {
+	return fmt.Sprintf("websocket handshake failed: %s", e.Status)
+}
+
+// wsConn is a minimal client side of RFC 6455: text and binary messages,
+// fragmentation, ping/pong, and close. Extensions are not negotiated.
+type wsConn struct {
+	conn net.Conn
+	br   *bufio.Reader
+	wmu  sync.Mutex
+}
+
+// dialer opens WebSocket connections the way the client's HTTP transport
+// opens API connections
+type dialer struct {
+	proxy       func(*http.Request) (*url.URL, error)
+	dialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+	tlsConfig   *tls.Config
+}
+
+// newDialer takes the proxy, dialer, and TLS settings from client's
+// transport. dial, if set, replaces the transport's dialer.
+func newDialer(client *http.Client, dial func(ctx context.Context, network, addr string) (net.Conn, error)) *dialer {
+	d := &dialer{proxy: http.ProxyFromEnvironment}
+	var transport http.RoundTripper = http.DefaultTransport
+	if client != nil && client.Transport != nil {
+		transport = client.Transport
+	}
+	if t, ok := transport.(*http.Transport); ok {
+		d.proxy, d.dialContext, d.tlsConfig = t.Proxy, t.DialContext, t.TLSClientConfig
+	}
+	if dial != nil {
+		d.dialContext = dial
+	}
+	if d.dialContext == nil {
+		d.dialContext = (&net.Dialer{}).DialContext
+	}
+	return d
+}
+
+// dial connects to a ws:// or wss:// URL, through an HTTP CONNECT tunnel
+// when the proxy settings name a proxy for it
+func (d *dialer) dial(ctx context.Context, rawURL string, header http.Header) (*wsConn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		// url.Error quotes the URL, which holds the token
+		return nil, fmt.Errorf("invalid websocket URL: %w", err.(*url.Error).Err)
+	}
+	var secure bool
+	switch u.Scheme {
+	case "ws", "http":
+	case "wss", "https":
+		secure = true
+	default:
+		return nil, fmt.Errorf("unsupported websocket scheme %q", u.Scheme)
+	}
+	addr := hostPort(u.Hostname(), u.Port(), secure)
+
+	var proxyURL *url.URL
+	if d.proxy != nil {
+		// Proxy functions select by HTTP scheme, and the query holds the token
+		scheme := "http"
+		if secure {
+			scheme = "https"
+		}
+		proxyURL, err = d.proxy(&http.Request{URL: &url.URL{Scheme: scheme, Host: u.Host}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve proxy: %w", err)
+		}
+	}
+	target := addr
+	if proxyURL != nil {
+		if proxyURL.Scheme != "http" && proxyURL.Scheme != "https" {
+			return nil, fmt.Errorf("unsupported proxy scheme %q for websocket", proxyURL.Scheme)
+		}
+		target = hostPort(proxyURL.Hostname(), proxyURL.Port(), proxyURL.Scheme == "https")
+	}
+
+	conn, err := d.dialContext(ctx, "tcp", target)
+	if err != nil {
+		return nil, err
+	}
+	// Abort the handshake if ctx ends; the returned stop releases the hook
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Unix(1, 0)) })
+	defer stop()
+
+	if proxyURL != nil {
+		if proxyURL.Scheme == "https" {
+			if conn, err = d.handshakeTLS(ctx, conn, proxyURL.Hostname()); err != nil {
+				return nil, err
+			}
+		}
+		if err := connectTunnel(conn, proxyURL, addr); err != nil {
+			conn.Close()
+			return nil, ctxErr(ctx, err)
+		}
+	}
+	if secure {
+		if conn, err = d.handshakeTLS(ctx, conn, u.Hostname()); err != nil {
+			return nil, err
+		}
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	req := &http.Request{
+		Method:     http.MethodGet,
+		URL:        u,
+		Host:       u.Host,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     header.Clone(),
+	}
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	}
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, ctxErr(ctx, err)
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		conn.Close()
+		return nil, ctxErr(ctx, err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		resp.Body.Close()
+		conn.Close()
+		return nil, &handshakeError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+	if !strings.EqualFold(resp.Header.Get("Upgrade"), "websocket") || resp.Header.Get("Sec-WebSocket-Accept") != acceptKey(key) {
+		conn.Close()
+		return nil, errors.New("websocket handshake failed: invalid upgrade response")
+	}
+
+	if !stop() {
+		// ctx ended after the handshake completed
+		conn.Close()
+		return nil, ctx.Err()
+	}
+	return &wsConn{conn: conn, br: br}, nil
+}
+
+// handshakeTLS starts TLS to serverName over conn, closing conn on failure
+func (d *dialer) handshakeTLS(ctx context.Context, conn net.Conn, serverName string) (net.Conn, error) {
+	config := &tls.Config{}
+	if d.tlsConfig != nil {
+		config = d.tlsConfig.Clone()
+		// The upgrade needs HTTP/1.1, whatever the API transport negotiates
+		config.NextProtos = nil
+	}
+	if config.ServerName == "" {
+		config.ServerName = serverName
+	}
+	tlsConn := tls.Client(conn, config)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return tlsConn, nil
+}
+
+// connectTunnel asks the HTTP proxy on conn to open a tunnel to addr
+func connectTunnel(conn net.Conn, proxyURL *url.URL, addr string) error {
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if user := proxyURL.User; user != nil {
+		password, _ := user.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		return err
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("proxy refused websocket tunnel: %s", resp.Status)
+	}
+	if br.Buffered() > 0 {
+		return errors.New("proxy sent unexpected data after opening the tunnel")
+	}
+	return nil
+}
+
+func hostPort(host, port string, secure bool) string {
+	if port == "" {
+		port = "80"
+		if secure {
+			port = "443"
+		}
+	}
+	return net.JoinHostPort(host, port)
+}
+
+func acceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func ctxErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// writeMessage sends a single-frame text message
+func (c *wsConn) writeMessage(data []byte) error {
+	return c.writeFrame(opText, data)
+}
+
+// writeFrame sends one masked frame, as required of clients
+func (c *wsConn) writeFrame(op byte, payload []byte) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	header := make([]byte, 2, 14)
+	header[0] = 0x80 | op
+	switch n := len(payload); {
+	case n < 126:
+		header[1] = 0x80 | byte(n)
+	case n <= 0xffff:
+		header[1] = 0x80 | 126
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header[1] = 0x80 | 127
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+	var mask [4]byte
+	if _, err := rand.Read(mask[:]); err != nil {
+		return err
+	}
+	header = append(header, mask[:]...)
+
+	frame := append(header, payload...)
+	masked := frame[len(header):]
+	for i := range masked {
+		masked[i] ^= mask[i%4]
+	}
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// readMessage returns the next data message, answering pings along the way.
+// It returns io.EOF when the server closes the connection.
+func (c *wsConn) readMessage() ([]byte, error) {
+	var msg []byte
+	started := false
+	for {
+		fin, op, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case opPong:
+			continue
+		case opClose:
+			// Echo the status code, then report the close
+			if len(payload) > 2 {
+				payload = payload[:2]
+			}
+			c.writeFrame(opClose, payload)
+			return nil, io.EOF
+		case opText, opBinary:
+			if started {
+				return nil, errors.New("websocket: new message before previous one finished")
+			}
+			started = true
+			msg = payload
+		case opContinuation:
+			if !started {
+				return nil, errors.New("websocket: unexpected continuation frame")
+			}
+			msg = append(msg, payload...)
+		default:
+			return nil, fmt.Errorf("websocket: unknown opcode %d", op)
+		}
+		if len(msg) > maxMessageSize {
+			return nil, errors.New("websocket: message too large")
+		}
+		if fin {
+			return msg, nil
+		}
+	}
+}
+
+func (c *wsConn) readFrame() (fin bool, op byte, payload []byte, err error) {
+	var head [2]byte
+	if _, err = io.ReadFull(c.br, head[:]); err != nil {
+		return
+	}
+	fin = head[0]&0x80 != 0
+	op = head[0] & 0x0f
+	masked := head[1]&0x80 != 0
+	length := uint64(head[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(c.br, ext[:]); err != nil {
+			return
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(c.br, ext[:]); err != nil {
+			return
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if length > maxMessageSize {
+		err = errors.New("websocket: frame too large")
+		return
+	}
+	var mask [4]byte
+	if masked {
+		if _, err = io.ReadFull(c.br, mask[:]); err != nil {
+			return
+		}
+	}
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(c.br, payload); err != nil {
+		return
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return
+}
+
+// close sends a normal closure and closes the connection
+func (c *wsConn) close() error {
+	c.conn.SetWriteDeadline(time.Now().Add(time.Second))
+	c.writeFrame(opClose, []byte{0x03, 0xe8})
+	return c.conn.Close()
+}
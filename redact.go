@@ -0,0 +1,79 @@
+package inboundgo
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// redactedPlaceholder replaces secrets in messages produced by the SDK
+const redactedPlaceholder = "[REDACTED]"
+
+var (
+	bearerPattern = regexp.MustCompile(`(?i)\b(bearer\s+)[^\s"',;]+`)
+	// secretQueryPattern matches the values of URL query parameters that
+	// commonly carry credentials
+	secretQueryPattern = regexp.MustCompile(`(?i)([?&](?:api[_-]?key|key|token|access_token|secret|signature|sig|password)=)[^&#\s"']*`)
+)
+
+// minRedactedLength keeps short values, which are unlikely to be secrets
+// and would match ordinary words, from being redacted
+const minRedactedLength = 8
+
+// Redact replaces the client's secrets in s: its API keys, signing secret,
+// bearer tokens, and credential-like URL query parameters. The SDK applies
+// it to the error messages it returns; use it when logging anything else
+// derived from requests.
+func (c *Inbound) Redact(s string) string {
+	c.keyMu.RLock()
+	secrets := append([]string{c.apiKey, c.signingSecret}, c.fallbackKeys...)
+	c.keyMu.RUnlock()
+	return redactSecrets(s, secrets...)
+}
+
+// redactSecrets replaces each secret in s, then bearer tokens and
+// credential query parameters
+func redactSecrets(s string, secrets ...string) string {
+	for _, secret := range secrets {
+		if len(secret) >= minRedactedLength {
+			s = strings.ReplaceAll(s, secret, redactedPlaceholder)
+		}
+	}
+	s = bearerPattern.ReplaceAllString(s, "${1}"+redactedPlaceholder)
+	return secretQueryPattern.ReplaceAllString(s, "${1}"+redactedPlaceholder)
+}
+
+// redactError returns the message of err with the client's secrets and the
+// values of sensitive request headers, such as the idempotency key,
+// removed
+func (c *Inbound) redactError(ctx context.Context, err error, headers map[string]string) string {
+	secrets := []string{apiKeyOverride(ctx)}
+	for name, value := range headers {
+		switch strings.ToLower(name) {
+		case "authorization", "idempotency-key", strings.ToLower(SignatureHeader):
+			secrets = append(secrets, value)
+		}
+	}
+	return redactSecrets(c.Redact(err.Error()), secrets...)
+}
+
+// String describes the client without its credentials, so logging or
+// printing it with %v does not leak them
+func (c *Inbound) String() string {
+	return "inboundgo.Inbound{baseURL: " + c.baseURL + ", apiKey: " + redactedPlaceholder + "}"
+}
+
+// GoString is used by %#v; like String, it omits credentials
+func (c *Inbound) GoString() string {
+	return c.String()
+}
+
+// String describes the configuration without the API key
+func (c InboundEmailConfig) String() string {
+	return "{ApiKey: " + redactedPlaceholder + ", BaseUrl: " + c.BaseUrl + "}"
+}
+
+// GoString is used by %#v; like String, it omits the API key
+func (c InboundEmailConfig) GoString() string {
+	return "inboundgo.InboundEmailConfig" + c.String()
+}
@@ -0,0 +1,69 @@
+package inboundgo_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+// leakyTransport fails with an error quoting the request's secrets, as a
+// careless custom transport might
+type leakyTransport struct{}
+
+func (leakyTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	return nil, fmt.Errorf("dump: Authorization=%s Idempotency-Key=%s url=%s?token=tok-123456789",
+		r.Header.Get("Authorization"), r.Header.Get("Idempotency-Key"), r.URL)
+}
+
+func TestErrorsAreRedacted(t *testing.T) {
+	client, _ := inboundgo.NewClient("sk_live_supersecret", "https://api.test/v2")
+	client.WithHTTPClient(&http.Client{Transport: leakyTransport{}})
+
+	resp, _ := client.Email().Send(context.Background(), &inboundgo.PostEmailsRequest{}, &inboundgo.IdempotencyOptions{IdempotencyKey: "order-5521-confirmation"})
+	for _, secret := range []string{"sk_live_supersecret", "order-5521-confirmation", "tok-123456789"} {
+		if strings.Contains(resp.Error, secret) {
+			t.Errorf("Error leaks %q: %s", secret, resp.Error)
+		}
+	}
+	if !strings.Contains(resp.Error, "[REDACTED]") {
+		t.Errorf("Expected redaction marker in %q", resp.Error)
+	}
+}
+
+func TestServerErrorsAreRedacted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"Invalid API key: ` + strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ") + `"}`))
+	}))
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("sk_live_supersecret", server.URL)
+	resp, _ := client.Mail().List(context.Background(), nil)
+	if resp.Error != "Invalid API key: [REDACTED]" {
+		t.Errorf("Unexpected error %q", resp.Error)
+	}
+}
+
+func TestClientFormattingHidesKey(t *testing.T) {
+	client, _ := inboundgo.NewClient("sk_live_supersecret")
+	client.WithSigningSecret("signing-secret-value")
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		if out := fmt.Sprintf(format, client); strings.Contains(out, "supersecret") {
+			t.Errorf("%s leaks the API key: %s", format, out)
+		}
+	}
+	config := inboundgo.InboundEmailConfig{ApiKey: "sk_live_supersecret"}
+	for _, format := range []string{"%v", "%+v", "%#v"} {
+		if out := fmt.Sprintf(format, config); strings.Contains(out, "supersecret") {
+			t.Errorf("%s leaks the API key: %s", format, out)
+		}
+	}
+	if got := client.Redact("key sk_live_supersecret, secret signing-secret-value"); got != "key [REDACTED], secret [REDACTED]" {
+		t.Errorf("Unexpected redaction %q", got)
+	}
+}
@@ -0,0 +1,178 @@
+package inboundgo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"os/exec"
+	"strings"
+)
+
+// BodyRenderer turns a template and its data into an email body. Either
+// return value may be empty if the renderer does not produce that part.
+type BodyRenderer interface {
+	RenderBody(ctx context.Context, tmpl string, data any) (html, text string, err error)
+}
+
+// BodyRendererFunc adapts a function to BodyRenderer
+type BodyRendererFunc func(ctx context.Context, tmpl string, data any) (html, text string, err error)
+
+func (f BodyRendererFunc) RenderBody(ctx context.Context, tmpl string, data any) (string, string, error) {
+	return f(ctx, tmpl, data)
+}
+
+// BodyTemplate is an email body rendered at send time
+type BodyTemplate struct {
+	Template string
+	Data     any
+	// Renderer overrides the client's renderer for this email.
+	Renderer BodyRenderer
+}
+
+// WithBodyRenderer sets the renderer used for emails sent with a Body
+// template
+func (c *Inbound) WithBodyRenderer(renderer BodyRenderer) *Inbound {
+	c.bodyRenderer = renderer
+	return c
+}
+
+// HTMLTemplateRenderer renders html/template templates into the HTML body
+type HTMLTemplateRenderer struct {
+	// Funcs are made available to templates.
+	Funcs template.FuncMap
+}
+
+func (r HTMLTemplateRenderer) RenderBody(ctx context.Context, tmpl string, data any) (string, string, error) {
+	t, err := template.New("body").Funcs(r.Funcs).Parse(tmpl)
+	if err != nil {
+		return "", "", err
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", "", err
+	}
+	return b.String(), "", nil
+}
+
+// MJMLCompiler compiles MJML markup to HTML
+type MJMLCompiler func(ctx context.Context, mjml string) (string, error)
+
+// MJMLRenderer renders MJML templates: the template is first executed with
+// the data by Template (an HTMLTemplateRenderer by default), then compiled
+// to responsive HTML by Compile. Compile can call the mjml CLI (see
+// MJMLCommand), a rendering service, or a Go port of MJML.
+type MJMLRenderer struct {
+	Template BodyRenderer
+	Compile  MJMLCompiler
+}
+
+func (r MJMLRenderer) RenderBody(ctx context.Context, tmpl string, data any) (string, string, error) {
+	if r.Compile == nil {
+		return "", "", errors.New("MJML renderer has no compiler")
+	}
+	pre := r.Template
+	if pre == nil {
+		pre = HTMLTemplateRenderer{}
+	}
+	mjml, text, err := pre.RenderBody(ctx, tmpl, data)
+	if err != nil {
+		return "", "", err
+	}
+	html, err := r.Compile(ctx, mjml)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to compile MJML: %w", err)
+	}
+	return html, text, nil
+}
+
+// MJMLCommand returns a compiler that pipes MJML through the mjml CLI
+// (npm install -g mjml). name defaults to "mjml" on the PATH.
+func MJMLCommand(name string, args ...string) MJMLCompiler {
+	if name == "" {
+		name = "mjml"
+	}
+	if len(args) == 0 {
+		args = []string{"--stdin", "--stdout"}
+	}
+	return func(ctx context.Context, mjml string) (string, error) {
+		var stdout, stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Stdin = strings.NewReader(mjml)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return "", fmt.Errorf("%w: %s", err, msg)
+			}
+			return "", err
+		}
+		return stdout.String(), nil
+	}
+}
+
+// prepareEmail returns the request as it will be sent: its Body template
+// rendered, the sender's signature appended, threading headers set and, if
+// enabled, its CSS inlined. params is not modified. A nil client, as in an
+// outbox that only enqueues, applies the defaults.
+func (c *Inbound) prepareEmail(ctx context.Context, params *PostEmailsRequest) (*PostEmailsRequest, error) {
+	if c == nil {
+		c = &Inbound{}
+	}
+	prepared := *params
+	prepared.Body = nil
+	prepared.InlineCSS = nil
+	if params.Body != nil {
+		if err := c.renderBody(ctx, params.Body, &prepared); err != nil {
+			return nil, err
+		}
+	}
+	if signature, ok := c.signatureFor(params.From); ok && !params.NoSignature {
+		prepared.HTML, prepared.Text = appendSignature(prepared.HTML, prepared.Text, signature)
+	}
+	prepared.NoSignature = false
+	if params.Threading != nil {
+		prepared.Headers = make(map[string]string, len(params.Headers)+2)
+		for name, value := range params.Headers {
+			if !strings.EqualFold(name, "In-Reply-To") && !strings.EqualFold(name, "References") {
+				prepared.Headers[name] = value
+			}
+		}
+		for name, value := range params.Threading.headers() {
+			prepared.Headers[name] = value
+		}
+		prepared.Threading = nil
+	}
+	inline := c.inlineCSS
+	if params.InlineCSS != nil {
+		inline = *params.InlineCSS
+	}
+	if inline && prepared.HTML != nil {
+		prepared.HTML = String(InlineCSS(*prepared.HTML))
+	}
+	return &prepared, nil
+}
+
+// renderBody renders body into the HTML and Text of prepared
+func (c *Inbound) renderBody(ctx context.Context, body *BodyTemplate, prepared *PostEmailsRequest) error {
+	renderer := body.Renderer
+	if renderer == nil {
+		renderer = c.bodyRenderer
+	}
+	if renderer == nil {
+		return errors.New("email has a body template but no renderer is configured")
+	}
+	html, text, err := renderer.RenderBody(ctx, body.Template, body.Data)
+	if err != nil {
+		return fmt.Errorf("failed to render email body: %w", err)
+	}
+	// Parts set explicitly, like a hand-written text version, are kept
+	if html != "" && prepared.HTML == nil {
+		prepared.HTML = String(html)
+	}
+	if text != "" && prepared.Text == nil {
+		prepared.Text = String(text)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestSendRendersBodyTemplate(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"email_1"}`))
+	}))
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	client.WithBodyRenderer(inboundgo.HTMLTemplateRenderer{})
+
+	req := &inboundgo.PostEmailsRequest{
+		From:    "ada@acme.test",
+		To:      "bob@example.com",
+		Subject: "Welcome",
+		Text:    inboundgo.String("Welcome, Bob"),
+		Body:    &inboundgo.BodyTemplate{Template: `<p>Welcome, {{.Name}}</p>`, Data: map[string]string{"Name": "<Bob>"}},
+	}
+	resp, err := client.Email().Send(context.Background(), req, nil)
+	if err != nil || resp.Error != "" {
+		t.Fatalf("Send failed: %v %s", err, resp.Error)
+	}
+	if got["html"] != "<p>Welcome, &lt;Bob&gt;</p>" {
+		t.Errorf("Expected rendered HTML, got %v", got["html"])
+	}
+	if got["text"] != "Welcome, Bob" {
+		t.Errorf("Expected explicit text to be kept, got %v", got["text"])
+	}
+	if _, ok := got["body"]; ok {
+		t.Error("Body template should not be sent")
+	}
+	if req.HTML != nil || req.Body == nil {
+		t.Error("Send should not modify the request")
+	}
+}
+
+func TestSendBodyTemplateWithoutRenderer(t *testing.T) {
+	client, _ := inboundgo.NewClient("test-api-key", "http://127.0.0.1:0")
+	_, err := client.Email().Send(context.Background(), &inboundgo.PostEmailsRequest{
+		Body: &inboundgo.BodyTemplate{Template: "<p>Hi</p>"},
+	}, nil)
+	if err == nil {
+		t.Error("Expected error without a renderer")
+	}
+}
+
+func TestMJMLRenderer(t *testing.T) {
+	var compiled string
+	renderer := inboundgo.MJMLRenderer{
+		Compile: func(ctx context.Context, mjml string) (string, error) {
+			compiled = mjml
+			return "<table>compiled</table>", nil
+		},
+	}
+	html, text, err := renderer.RenderBody(context.Background(), `<mjml><mj-body><mj-text>Hi {{.}}</mj-text></mj-body></mjml>`, "Ada")
+	if err != nil {
+		t.Fatalf("RenderBody failed: %v", err)
+	}
+	if !strings.Contains(compiled, "<mj-text>Hi Ada</mj-text>") {
+		t.Errorf("Expected data to be applied before compiling, got %q", compiled)
+	}
+	if html != "<table>compiled</table>" || text != "" {
+		t.Errorf("Unexpected output %q %q", html, text)
+	}
+
+	failing := inboundgo.MJMLRenderer{Compile: func(ctx context.Context, mjml string) (string, error) {
+		return "", errors.New("invalid mj-body")
+	}}
+	if _, _, err := failing.RenderBody(context.Background(), "<mjml></mjml>", nil); err == nil || !strings.Contains(err.Error(), "invalid mj-body") {
+		t.Errorf("Expected compile error, got %v", err)
+	}
+}
+
+func TestBodyTemplateRendererOverride(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"id":"email_1"}`))
+	}))
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	renderer := inboundgo.BodyRendererFunc(func(ctx context.Context, tmpl string, data any) (string, string, error) {
+		return "<b>" + tmpl + "</b>", tmpl, nil
+	})
+	_, err := client.Email().Send(context.Background(), &inboundgo.PostEmailsRequest{
+		Body: &inboundgo.BodyTemplate{Template: "hello", Renderer: renderer},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if got["html"] != "<b>hello</b>" || got["text"] != "hello" {
+		t.Errorf("Unexpected body %v", got)
+	}
+}
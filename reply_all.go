@@ -0,0 +1,140 @@
+package inboundgo
+
+import (
+	"net/mail"
+	"strings"
+)
+
+// MessageAddresses is the addressing of an email being replied to. Each
+// entry may include a display name, or be a comma-separated list.
+type MessageAddresses struct {
+	From    []string
+	ReplyTo []string
+	To      []string
+	CC      []string
+}
+
+// Addresses returns the addressing of the email in an email.received
+// webhook
+func (w *WebhookPayload) Addresses() MessageAddresses {
+	parsed := w.Email.ParsedData
+	a := MessageAddresses{
+		From:    groupAddresses(parsed.From),
+		ReplyTo: groupAddresses(parsed.ReplyTo),
+		To:      groupAddresses(parsed.To),
+		CC:      groupAddresses(parsed.Cc),
+	}
+	if len(a.From) == 0 {
+		a.From = groupAddresses(w.Email.From)
+	}
+	if len(a.To) == 0 {
+		a.To = groupAddresses(w.Email.To)
+	}
+	return a
+}
+
+// Addresses returns the addressing of a thread message. Reply-To is read
+// from its headers.
+func (m *ThreadMessage) Addresses() MessageAddresses {
+	a := MessageAddresses{From: []string{m.From}, To: m.To, CC: m.CC}
+	a.ReplyTo = headerValues(m.GetHeaders(), "Reply-To")
+	return a
+}
+
+func groupAddresses(group *WebhookAddressGroup) []string {
+	if group == nil {
+		return nil
+	}
+	var addresses []string
+	for _, addr := range group.Addresses {
+		if addr.Address == nil || *addr.Address == "" {
+			continue
+		}
+		name := ""
+		if addr.Name != nil {
+			name = *addr.Name
+		}
+		addresses = append(addresses, formatAddress(name, *addr.Address))
+	}
+	if len(addresses) == 0 && group.Text != "" {
+		addresses = append(addresses, group.Text)
+	}
+	return addresses
+}
+
+// ComputeReplyAll returns the recipients of a reply-all to original, for
+// building replies without the reply endpoint's ReplyAll option. The reply
+// goes to Reply-To, or From if there is none, and the other recipients are
+// copied. Own addresses, which may also be "@domain" to match a whole
+// domain, are removed, and each address appears once. Replying to a message
+// sent from an own address goes to its original recipients instead.
+func ComputeReplyAll(original MessageAddresses, ownAddresses []string) (to, cc []string) {
+	own := make(map[string]bool, len(ownAddresses))
+	for _, address := range ownAddresses {
+		if local, domain, ok := splitAddress(address); ok {
+			own[local+"@"+domain] = true
+		} else {
+			own[strings.ToLower(strings.TrimSpace(address))] = true
+		}
+	}
+	seen := make(map[string]bool)
+	pick := func(lists ...[]string) []string {
+		var picked []string
+		for _, list := range lists {
+			for _, address := range expandAddresses(list) {
+				local, domain, ok := splitAddress(address)
+				key := local + "@" + domain
+				if !ok || seen[key] || own[key] || own["@"+domain] {
+					continue
+				}
+				seen[key] = true
+				picked = append(picked, address)
+			}
+		}
+		return picked
+	}
+
+	replyTo := original.ReplyTo
+	if len(expandAddresses(replyTo)) == 0 {
+		replyTo = original.From
+	}
+	to = pick(replyTo)
+	if len(to) == 0 {
+		// Our own message: follow up with the people it was sent to
+		return pick(original.To), pick(original.CC)
+	}
+	return to, pick(original.To, original.CC)
+}
+
+// expandAddresses splits entries that hold several addresses
+func expandAddresses(entries []string) []string {
+	var addresses []string
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		list, err := mail.ParseAddressList(entry)
+		if err != nil {
+			addresses = append(addresses, entry)
+			continue
+		}
+		for _, addr := range list {
+			addresses = append(addresses, formatAddress(addr.Name, addr.Address))
+		}
+	}
+	return addresses
+}
+
+// formatAddress formats an address with its display name, quoting the name
+// if it has characters that would break an address list. Unlike
+// mail.Address.String it does not encode non-ASCII names.
+func formatAddress(name, address string) string {
+	if name == "" {
+		return address
+	}
+	if strings.ContainsAny(name, `,;:<>@()[]".\`) {
+		name = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(name) + `"`
+	}
+	return name + " <" + address + ">"
+}
@@ -0,0 +1,84 @@
+package inboundgo_test
+
+import (
+	"reflect"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestComputeReplyAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		original inboundgo.MessageAddresses
+		own      []string
+		wantTo   []string
+		wantCC   []string
+	}{
+		{
+			name: "replies to sender and copies others",
+			original: inboundgo.MessageAddresses{
+				From: []string{"Ada <ada@example.com>"},
+				To:   []string{"support@acme.test, bob@example.com"},
+				CC:   []string{"Carol <CAROL@example.com>", "ada@example.com"},
+			},
+			own:    []string{"Support@acme.test"},
+			wantTo: []string{"Ada <ada@example.com>"},
+			wantCC: []string{"bob@example.com", "Carol <CAROL@example.com>"},
+		},
+		{
+			name: "honors reply-to",
+			original: inboundgo.MessageAddresses{
+				From:    []string{"noreply@vendor.test"},
+				ReplyTo: []string{"tickets@vendor.test"},
+				To:      []string{"billing@acme.test"},
+			},
+			own:    []string{"@acme.test"},
+			wantTo: []string{"tickets@vendor.test"},
+		},
+		{
+			name: "own message goes to its recipients",
+			original: inboundgo.MessageAddresses{
+				From: []string{"support@acme.test"},
+				To:   []string{"ada@example.com"},
+				CC:   []string{"sales@acme.test", "bob@example.com"},
+			},
+			own:    []string{"@acme.test"},
+			wantTo: []string{"ada@example.com"},
+			wantCC: []string{"bob@example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			to, cc := inboundgo.ComputeReplyAll(tt.original, tt.own)
+			if !reflect.DeepEqual(to, tt.wantTo) || !reflect.DeepEqual(cc, tt.wantCC) {
+				t.Errorf("Got to=%q cc=%q, want to=%q cc=%q", to, cc, tt.wantTo, tt.wantCC)
+			}
+		})
+	}
+}
+
+func TestWebhookPayloadAddresses(t *testing.T) {
+	str := func(s string) *string { return &s }
+	payload := &inboundgo.WebhookPayload{}
+	payload.Email.From = &inboundgo.WebhookAddressGroup{Addresses: []inboundgo.WebhookAddress{{Name: str("Lovelace, Ada"), Address: str("ada@example.com")}}}
+	payload.Email.To = &inboundgo.WebhookAddressGroup{Addresses: []inboundgo.WebhookAddress{{Address: str("support@acme.test")}}}
+	payload.Email.ParsedData.Cc = &inboundgo.WebhookAddressGroup{Addresses: []inboundgo.WebhookAddress{{Address: str("bob@example.com")}}}
+
+	to, cc := inboundgo.ComputeReplyAll(payload.Addresses(), []string{"support@acme.test"})
+	if !reflect.DeepEqual(to, []string{`"Lovelace, Ada" <ada@example.com>`}) || !reflect.DeepEqual(cc, []string{"bob@example.com"}) {
+		t.Errorf("Unexpected recipients to=%q cc=%q", to, cc)
+	}
+}
+
+func TestThreadMessageAddresses(t *testing.T) {
+	m := &inboundgo.ThreadMessage{
+		From:    "ada@example.com",
+		To:      []string{"support@acme.test"},
+		Headers: map[string]any{"reply-to": "ada-tickets@example.com"},
+	}
+	a := m.Addresses()
+	if !reflect.DeepEqual(a.ReplyTo, []string{"ada-tickets@example.com"}) {
+		t.Errorf("Expected Reply-To from headers, got %q", a.ReplyTo)
+	}
+}
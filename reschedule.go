@@ -0,0 +1,76 @@
+package inboundgo
+
+import (
+	"context"
+	"fmt"
+)
+
+// Reschedule moves a scheduled email to a new time, given as ISO 8601 or
+// natural language like ScheduledAt. The API cannot change the time of a
+// scheduled email, so it is scheduled again with the same content and the
+// original is cancelled; the response carries the new ID. If the original
+// cannot be cancelled, for example because it has started sending, the new
+// email is cancelled instead so the message is not sent twice, and the
+// cancellation error is returned in the response.
+func (s *EmailService) Reschedule(ctx context.Context, id, scheduledAt string, timezone *string) (*ApiResponse[PostScheduleEmailResponse], error) {
+	original, err := s.GetScheduled(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if original.Error != "" {
+		return &ApiResponse[PostScheduleEmailResponse]{Error: original.Error, StatusCode: original.StatusCode}, nil
+	}
+	email := original.Data
+	if email == nil {
+		return nil, fmt.Errorf("failed to get scheduled email %s: %w", id, ErrNoData)
+	}
+	if email.Status != ScheduledStatusScheduled {
+		return nil, fmt.Errorf("scheduled email %s is %s and can no longer be rescheduled", id, email.Status)
+	}
+	if timezone == nil && email.Timezone != "" {
+		timezone = String(email.Timezone)
+	}
+
+	params := &PostScheduleEmailRequest{
+		From:        email.From,
+		To:          email.To,
+		Subject:     email.Subject,
+		Text:        email.Text,
+		HTML:        email.HTML,
+		Headers:     email.Headers,
+		Attachments: email.Attachments,
+		Tags:        email.Tags,
+		ScheduledAt: scheduledAt,
+		Timezone:    timezone,
+	}
+	if len(email.CC) > 0 {
+		params.CC = email.CC
+	}
+	if len(email.BCC) > 0 {
+		params.BCC = email.BCC
+	}
+	if len(email.ReplyTo) > 0 {
+		params.ReplyTo = email.ReplyTo
+	}
+	// The key makes a retried reschedule to the same time reuse the copy
+	options := &IdempotencyOptions{IdempotencyKey: "reschedule-" + id + "-" + scheduledAt}
+	scheduled, err := s.Schedule(ctx, params, options)
+	if err != nil || scheduled.Error != "" || scheduled.Data == nil {
+		return scheduled, err
+	}
+
+	cancelled, err := s.Cancel(ctx, id)
+	if err == nil && cancelled.Error == "" {
+		return scheduled, nil
+	}
+	reason, status := "", 0
+	if err != nil {
+		reason = err.Error()
+	} else {
+		reason, status = cancelled.Error, cancelled.StatusCode
+	}
+	if rollback, err := s.Cancel(ctx, scheduled.Data.ID); err != nil || rollback.Error != "" {
+		return nil, fmt.Errorf("failed to cancel scheduled email %s (%s) or its replacement %s; both may be sent", id, reason, scheduled.Data.ID)
+	}
+	return &ApiResponse[PostScheduleEmailResponse]{Error: "failed to cancel the original: " + reason, StatusCode: status}, nil
+}
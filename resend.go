@@ -0,0 +1,138 @@
+package inboundgo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// ResendOfHeader and ResendOfTag carry the ID of the email a resent copy
+// replaces, so the two can be matched in logs and campaign stats
+const (
+	ResendOfHeader = "X-Resend-Of"
+	ResendOfTag    = "resend_of"
+)
+
+// ResendOverrides changes a resent email. Zero fields keep the original.
+type ResendOverrides struct {
+	// To replaces the recipients, such as with a corrected address. CC and
+	// BCC are dropped when it is set, so only the corrected recipients get
+	// the copy.
+	To []string
+	// From and Subject replace the original's.
+	From    string
+	Subject string
+	// Attachments are sent with the copy. The API does not return the
+	// attachments of a sent email, so without them the copy has none.
+	Attachments []AttachmentData
+	// Force resends an email that did not fail or bounce.
+	Force bool
+	// IdempotencyKey defaults to one derived from the ID and recipients, so
+	// retrying a resend sends one copy.
+	IdempotencyKey string
+}
+
+// Resend sends a failed or bounced email again, optionally to a corrected
+// address, keeping its content, headers, and tags. The copy carries the
+// original's ID in the ResendOfHeader header and the ResendOfTag tag.
+// Resending an email that was delivered, or has not finished, is an error
+// unless overrides.Force is set.
+func (s *EmailService) Resend(ctx context.Context, id string, overrides *ResendOverrides) (*ApiResponse[PostEmailsResponse], error) {
+	if overrides == nil {
+		overrides = &ResendOverrides{}
+	}
+	original, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if original.Error != "" {
+		return &ApiResponse[PostEmailsResponse]{Error: original.Error, StatusCode: original.StatusCode}, nil
+	}
+	email := original.Data
+	if email == nil {
+		return nil, fmt.Errorf("failed to get email %s: %w", id, ErrNoData)
+	}
+	if !overrides.Force {
+		outcome, err := s.deliveryOutcome(ctx, id, email)
+		if err != nil {
+			return nil, err
+		}
+		if outcome != DeliveryFailed && outcome != DeliveryBounced {
+			return nil, fmt.Errorf("email %s is %s, not failed or bounced; set Force to resend it anyway", id, outcome)
+		}
+	}
+
+	params := &PostEmailsRequest{
+		From:        email.From,
+		To:          email.To,
+		Subject:     email.Subject,
+		Text:        optionalString(email.Text),
+		HTML:        optionalString(email.HTML),
+		Attachments: overrides.Attachments,
+		Headers:     map[string]string{ResendOfHeader: id},
+	}
+	for name, value := range email.Headers {
+		if !strings.EqualFold(name, ResendOfHeader) {
+			params.Headers[name] = value
+		}
+	}
+	for _, tag := range email.Tags {
+		if tag.Name != ResendOfTag {
+			params.Tags = append(params.Tags, tag)
+		}
+	}
+	params.Tags = append(params.Tags, EmailTag{Name: ResendOfTag, Value: id})
+	if len(email.ReplyTo) > 0 {
+		params.ReplyTo = email.ReplyTo
+	}
+	if len(overrides.To) > 0 {
+		params.To = overrides.To
+	} else {
+		if len(email.CC) > 0 {
+			params.CC = email.CC
+		}
+		if len(email.BCC) > 0 {
+			params.BCC = email.BCC
+		}
+	}
+	if overrides.From != "" {
+		params.From = overrides.From
+	}
+	if overrides.Subject != "" {
+		params.Subject = overrides.Subject
+	}
+
+	key := overrides.IdempotencyKey
+	if key == "" {
+		to := params.To.([]string)
+		sum := sha256.Sum256([]byte(strings.Join(to, ",")))
+		key = "resend-" + id + "-" + hex.EncodeToString(sum[:4])
+	}
+	// The copy is sent as stored, without a signature appended again
+	params.NoSignature = true
+	return s.Send(ctx, params, &IdempotencyOptions{IdempotencyKey: key})
+}
+
+// deliveryOutcome returns the final state of a sent email, from its event
+// timeline or, where that is unavailable, its last event
+func (s *EmailService) deliveryOutcome(ctx context.Context, id string, email *GetEmailByIDResponse) (DeliveryOutcome, error) {
+	result := &DeliveryResult{EmailID: id, Outcome: DeliveryPending}
+	available, err := s.checkDeliveryEvents(ctx, result)
+	if err != nil {
+		return "", err
+	}
+	if outcome, ok := lastEventOutcome(email.LastEvent); !available && ok {
+		result.Outcome = outcome
+	}
+	return result.Outcome, nil
+}
+
+// optionalString returns a pointer to s, or nil if it is empty
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
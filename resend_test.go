@@ -0,0 +1,73 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestResend(t *testing.T) {
+	var sent []inboundgo.PostEmailsRequest
+	var keys []string
+	events := `{"email_id":"email_1","events":[
+		{"type":"sent","timestamp":"2025-01-16T10:00:00Z"},
+		{"type":"bounced","timestamp":"2025-01-16T10:00:05Z","bounce_type":"hard"}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/emails/email_1":
+			w.Write([]byte(`{"id":"email_1","from":"billing@example.com","to":["jane@exmaple.com"],"cc":["accounts@example.com"],
+				"subject":"Your invoice","html":"<p>Invoice</p>","headers":{"X-Invoice":"42"},
+				"tags":[{"name":"category","value":"invoice"}],"last_event":"bounced"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/emails/email_1/events":
+			w.Write([]byte(events))
+		case r.Method == http.MethodPost && r.URL.Path == "/emails":
+			var req inboundgo.PostEmailsRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			sent = append(sent, req)
+			keys = append(keys, r.Header.Get("Idempotency-Key"))
+			w.Write([]byte(`{"id":"email_2"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	ctx := context.Background()
+
+	resp, err := inboundgo.Unwrap(client.Email().Resend(ctx, "email_1", &inboundgo.ResendOverrides{To: []string{"jane@example.com"}}))
+	if err != nil {
+		t.Fatalf("Resend failed: %v", err)
+	}
+	if resp.ID != "email_2" || len(sent) != 1 {
+		t.Fatalf("Unexpected response %+v", resp)
+	}
+	req := sent[0]
+	if to, _ := req.To.([]any); len(to) != 1 || to[0] != "jane@example.com" || req.CC != nil {
+		t.Errorf("Expected only the corrected recipient, got %v %v", req.To, req.CC)
+	}
+	if req.Subject != "Your invoice" || *req.HTML != "<p>Invoice</p>" || req.Headers["X-Invoice"] != "42" || req.Headers[inboundgo.ResendOfHeader] != "email_1" {
+		t.Errorf("Expected the original content and a resend-of header, got %+v", req)
+	}
+	if len(req.Tags) != 2 || req.Tags[0].Value != "invoice" || req.Tags[1] != (inboundgo.EmailTag{Name: inboundgo.ResendOfTag, Value: "email_1"}) {
+		t.Errorf("Unexpected tags %v", req.Tags)
+	}
+	if !strings.HasPrefix(keys[0], "resend-email_1-") {
+		t.Errorf("Unexpected idempotency key %q", keys[0])
+	}
+
+	events = `{"email_id":"email_1","events":[{"type":"delivered","timestamp":"2025-01-16T10:00:05Z"}]}`
+	if _, err := client.Email().Resend(ctx, "email_1", nil); err == nil || !strings.Contains(err.Error(), "is delivered") {
+		t.Errorf("Expected a delivered email to be refused, got %v", err)
+	}
+	if _, err := inboundgo.Unwrap(client.Email().Resend(ctx, "email_1", &inboundgo.ResendOverrides{Force: true})); err != nil {
+		t.Errorf("Expected Force to resend, got %v", err)
+	}
+	if len(sent) != 2 || sent[1].CC == nil {
+		t.Errorf("Expected the forced copy to keep CC, got %+v", sent)
+	}
+}
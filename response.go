@@ -0,0 +1,52 @@
+package inboundgo
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoData is returned by Unwrap for a successful response without data
+var ErrNoData = errors.New("API response has no data")
+
+// ApiError is an error reported by the API, or a request that never got a
+// response, as returned by Unwrap
+type ApiError struct {
+	// StatusCode is the HTTP status, or 0 when there was no response.
+	StatusCode int
+	Message    string
+}
+
+func (e *ApiError) Error() string {
+	if e.StatusCode == 0 {
+		return "API request failed: " + e.Message
+	}
+	return fmt.Sprintf("API error (HTTP %d): %s", e.StatusCode, e.Message)
+}
+
+// Unwrap returns the response data, or the response error as an *ApiError.
+// A response with neither returns ErrNoData.
+func (r *ApiResponse[T]) Unwrap() (T, error) {
+	var zero T
+	if r == nil {
+		return zero, ErrNoData
+	}
+	if r.Error != "" {
+		return zero, &ApiError{StatusCode: r.StatusCode, Message: r.Error}
+	}
+	if r.Data == nil {
+		return zero, ErrNoData
+	}
+	return *r.Data, nil
+}
+
+// Unwrap collapses a service call into its data and a single error, for
+// call sites that don't need the rest of the response:
+//
+//	mail, err := inboundgo.Unwrap(client.Mail().List(ctx, nil))
+func Unwrap[T any](resp *ApiResponse[T], err error) (T, error) {
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return resp.Unwrap()
+}
@@ -0,0 +1,43 @@
+package inboundgo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestApiResponseUnwrap(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/domains/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Domain not found"}`))
+			return
+		}
+		w.Write([]byte(`{"emails":[{"id":"email_1"}],"pagination":{"limit":50,"offset":0,"total":1}}`))
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	ctx := context.Background()
+
+	mail, err := inboundgo.Unwrap(client.Mail().List(ctx, nil))
+	if err != nil || len(mail.Emails) != 1 || mail.Emails[0].ID != "email_1" {
+		t.Fatalf("Unexpected result %+v, %v", mail, err)
+	}
+
+	_, err = inboundgo.Unwrap(client.Domain().Get(ctx, "missing"))
+	var apiErr *inboundgo.ApiError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || apiErr.Message != "Domain not found" {
+		t.Fatalf("Expected a 404 ApiError, got %v", err)
+	}
+	if err.Error() != "API error (HTTP 404): Domain not found" {
+		t.Errorf("Unexpected message %q", err)
+	}
+
+	if _, err := (&inboundgo.ApiResponse[string]{}).Unwrap(); !errors.Is(err, inboundgo.ErrNoData) {
+		t.Errorf("Expected ErrNoData, got %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package inboundgo
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// RuleEvaluation is the combined outcome of evaluating rules against an email
+type RuleEvaluation struct {
+	// Matched lists the rules that matched, in the order they ran.
+	Matched []Rule
+	// EndpointID is the endpoint chosen by the first matching rule that sets
+	// one, or nil to use the address default.
+	EndpointID *string
+	// Tags is the union of tags added by matching rules.
+	Tags    []string
+	Archive bool
+}
+
+// EvaluateRules runs rules against a received email the way the server
+// does: enabled rules in priority order, stopping after a matching rule
+// with StopProcessing. Use it to test rules against sample payloads before
+// creating them.
+func EvaluateRules(rules []Rule, payload *WebhookPayload) *RuleEvaluation {
+	ordered := make([]Rule, 0, len(rules))
+	for _, r := range rules {
+		if r.Enabled {
+			ordered = append(ordered, r)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Priority < ordered[j].Priority })
+
+	eval := &RuleEvaluation{}
+	seenTags := make(map[string]bool)
+	for _, r := range ordered {
+		if !r.Conditions.Matches(payload) {
+			continue
+		}
+		eval.Matched = append(eval.Matched, r)
+		if eval.EndpointID == nil && r.Actions.EndpointID != nil {
+			eval.EndpointID = r.Actions.EndpointID
+		}
+		for _, tag := range r.Actions.Tags {
+			if !seenTags[tag] {
+				seenTags[tag] = true
+				eval.Tags = append(eval.Tags, tag)
+			}
+		}
+		eval.Archive = eval.Archive || r.Actions.Archive
+		if r.StopProcessing {
+			break
+		}
+	}
+	return eval
+}
+
+// Matches reports whether the email in payload meets every set condition
+func (c *RuleConditions) Matches(payload *WebhookPayload) bool {
+	email := &payload.Email
+	if c.Recipient != nil {
+		recipients := []string{email.Recipient}
+		if email.To != nil {
+			for _, a := range email.To.Addresses {
+				if a.Address != nil {
+					recipients = append(recipients, *a.Address)
+				}
+			}
+		}
+		if !matchAnyGlob(*c.Recipient, recipients) {
+			return false
+		}
+	}
+	if c.RecipientTag != nil {
+		if !matchAnyGlob(*c.RecipientTag, []string{payload.RecipientTag()}) {
+			return false
+		}
+	}
+	if c.Sender != nil {
+		var senders []string
+		if email.From != nil {
+			for _, a := range email.From.Addresses {
+				if a.Address != nil {
+					senders = append(senders, *a.Address)
+				}
+			}
+		}
+		if !matchAnyGlob(*c.Sender, senders) {
+			return false
+		}
+	}
+	if c.SubjectContains != nil {
+		subject := ""
+		if email.Subject != nil {
+			subject = *email.Subject
+		}
+		if !strings.Contains(strings.ToLower(subject), strings.ToLower(*c.SubjectContains)) {
+			return false
+		}
+	}
+	if c.HasAttachment != nil {
+		has := len(email.ParsedData.Attachments) > 0 || len(email.CleanedContent.Attachments) > 0
+		if has != *c.HasAttachment {
+			return false
+		}
+	}
+	return true
+}
+
+// matchAnyGlob reports whether any value matches pattern, ignoring case.
+// Malformed patterns match nothing.
+func matchAnyGlob(pattern string, values []string) bool {
+	pattern = strings.ToLower(pattern)
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		if ok, err := path.Match(pattern, strings.ToLower(v)); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
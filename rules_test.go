@@ -0,0 +1,129 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestRuleService(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /rules":
+			var req inboundgo.PostRulesRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if *req.Conditions.Recipient != "billing+*@example.com" || *req.Actions.EndpointID != "ep-billing" {
+				t.Errorf("Unexpected create request: %+v", req)
+			}
+			w.Write([]byte(`{"id": "rule-1", "name": "billing", "priority": 10, "enabled": true,
+				"conditions": {"recipient": "billing+*@example.com"}, "actions": {"endpointId": "ep-billing"}}`))
+		case "GET /rules":
+			if r.URL.Query().Get("limit") != "20" {
+				t.Errorf("Unexpected query %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"data": [{"id": "rule-1", "name": "billing", "enabled": true}], "pagination": {"total": 1}}`))
+		case "PUT /rules/rule-1":
+			var req map[string]any
+			json.NewDecoder(r.Body).Decode(&req)
+			if len(req) != 1 || req["enabled"] != false {
+				t.Errorf("Expected partial update, got %v", req)
+			}
+			w.Write([]byte(`{"id": "rule-1", "name": "billing", "enabled": false}`))
+		case "DELETE /rules/rule-1":
+			w.Write([]byte(`{"message": "deleted"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+	svc := client.Rule()
+
+	created, err := svc.Create(ctx, &inboundgo.PostRulesRequest{
+		Name:       "billing",
+		Priority:   inboundgo.Int(10),
+		Conditions: inboundgo.RuleConditions{Recipient: inboundgo.String("billing+*@example.com")},
+		Actions:    inboundgo.RuleActions{EndpointID: inboundgo.String("ep-billing")},
+	})
+	if err != nil || created.Error != "" || created.Data.ID != "rule-1" {
+		t.Fatalf("Create failed: %v %+v", err, created)
+	}
+
+	list, err := svc.List(ctx, &inboundgo.GetRulesRequest{Limit: inboundgo.Int(20)})
+	if err != nil || list.Error != "" || len(list.Data.Data) != 1 {
+		t.Fatalf("List failed: %v %+v", err, list)
+	}
+
+	updated, err := svc.Update(ctx, "rule-1", &inboundgo.PutRuleByIDRequest{Enabled: inboundgo.Bool(false)})
+	if err != nil || updated.Error != "" || updated.Data.Enabled {
+		t.Fatalf("Update failed: %v %+v", err, updated)
+	}
+
+	deleted, err := svc.Delete(ctx, "rule-1")
+	if err != nil || deleted.Error != "" || deleted.Data.Message != "deleted" {
+		t.Fatalf("Delete failed: %v %+v", err, deleted)
+	}
+}
+
+func TestEvaluateRules(t *testing.T) {
+	payload := &inboundgo.WebhookPayload{}
+	payload.Email.Recipient = "Billing+EU@example.com"
+	payload.Email.From = &inboundgo.WebhookAddressGroup{Addresses: []inboundgo.WebhookAddress{{Address: inboundgo.String("invoices@vendor.com")}}}
+	payload.Email.Subject = inboundgo.String("Invoice #1234")
+	payload.Email.ParsedData.Attachments = []inboundgo.WebhookAttachment{{Filename: inboundgo.String("invoice.pdf")}}
+
+	rules := []inboundgo.Rule{
+		{
+			ID: "archive-vendor", Priority: 20, Enabled: true,
+			Conditions: inboundgo.RuleConditions{Sender: inboundgo.String("*@vendor.com")},
+			Actions:    inboundgo.RuleActions{Tags: []string{"vendor", "finance"}, Archive: true},
+		},
+		{
+			ID: "billing", Priority: 10, Enabled: true,
+			Conditions: inboundgo.RuleConditions{
+				Recipient:       inboundgo.String("billing+*@example.com"),
+				SubjectContains: inboundgo.String("invoice"),
+				HasAttachment:   inboundgo.Bool(true),
+			},
+			Actions: inboundgo.RuleActions{EndpointID: inboundgo.String("ep-billing"), Tags: []string{"finance"}},
+		},
+		{
+			ID: "disabled", Priority: 0, Enabled: false,
+			Actions: inboundgo.RuleActions{EndpointID: inboundgo.String("ep-disabled")},
+		},
+		{
+			ID: "no-attachments", Priority: 5, Enabled: true,
+			Conditions: inboundgo.RuleConditions{HasAttachment: inboundgo.Bool(false)},
+			Actions:    inboundgo.RuleActions{EndpointID: inboundgo.String("ep-other")},
+		},
+	}
+
+	eval := inboundgo.EvaluateRules(rules, payload)
+	if len(eval.Matched) != 2 || eval.Matched[0].ID != "billing" || eval.Matched[1].ID != "archive-vendor" {
+		t.Fatalf("Unexpected matches: %+v", eval.Matched)
+	}
+	if *eval.EndpointID != "ep-billing" || !eval.Archive {
+		t.Errorf("Unexpected actions: %+v", eval)
+	}
+	if strings.Join(eval.Tags, ",") != "finance,vendor" {
+		t.Errorf("Expected deduplicated tags, got %v", eval.Tags)
+	}
+
+	rules[1].StopProcessing = true
+	eval = inboundgo.EvaluateRules(rules, payload)
+	if len(eval.Matched) != 1 || eval.Archive {
+		t.Errorf("Expected StopProcessing to skip later rules, got %+v", eval)
+	}
+}
@@ -2,6 +2,7 @@ package inboundgo_test
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -578,3 +579,62 @@ func TestConvenienceSchedulingMethods(t *testing.T) {
 		}
 	})
 }
+
+func TestReschedule(t *testing.T) {
+	var cancelled []string
+	cancelFails := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/emails/schedule/sched_1":
+			w.Write([]byte(`{"id": "sched_1", "from": "app@example.com", "to": ["user@example.com"], "cc": ["boss@example.com"],
+				"subject": "Reminder", "text": "Don't forget", "scheduled_at": "2025-01-17T09:00:00Z", "timezone": "Europe/Berlin", "status": "scheduled"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/emails/schedule":
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["scheduled_at"] != "monday 10am" || body["timezone"] != "Europe/Berlin" || body["subject"] != "Reminder" || body["cc"] == nil {
+				t.Errorf("Unexpected copy %v", body)
+			}
+			if r.Header.Get("Idempotency-Key") != "reschedule-sched_1-monday 10am" {
+				t.Errorf("Unexpected idempotency key %q", r.Header.Get("Idempotency-Key"))
+			}
+			w.Write([]byte(`{"id": "sched_2", "scheduled_at": "2025-01-20T10:00:00+01:00", "status": "scheduled", "timezone": "Europe/Berlin"}`))
+		case r.Method == http.MethodDelete:
+			id := strings.TrimPrefix(r.URL.Path, "/emails/schedule/")
+			if id == "sched_1" && cancelFails {
+				w.WriteHeader(http.StatusConflict)
+				w.Write([]byte(`{"error": "Email is already sending"}`))
+				return
+			}
+			cancelled = append(cancelled, id)
+			w.Write([]byte(`{"id": "` + id + `", "status": "cancelled", "cancelled_at": "2025-01-16T10:00:00Z"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	resp, err := client.Email().Reschedule(ctx, "sched_1", "monday 10am", nil)
+	if err != nil || resp.Error != "" {
+		t.Fatalf("Reschedule failed: %v %+v", err, resp)
+	}
+	if resp.Data.ID != "sched_2" || len(cancelled) != 1 || cancelled[0] != "sched_1" {
+		t.Errorf("Unexpected result %+v, cancelled %v", resp.Data, cancelled)
+	}
+
+	// When the original cannot be cancelled, the copy is
+	cancelled, cancelFails = nil, true
+	resp, err = client.Email().Reschedule(ctx, "sched_1", "monday 10am", nil)
+	if err != nil || !strings.Contains(resp.Error, "already sending") || resp.StatusCode != http.StatusConflict {
+		t.Fatalf("Expected the cancellation error, got %v %+v", err, resp)
+	}
+	if len(cancelled) != 1 || cancelled[0] != "sched_2" {
+		t.Errorf("Expected the copy to be cancelled, got %v", cancelled)
+	}
+}
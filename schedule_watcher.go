@@ -0,0 +1,182 @@
+package inboundgo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ScheduledStatusChange is a status transition of a watched scheduled email
+type ScheduledStatusChange struct {
+	ID string
+	// From is the previously seen status, empty on the first observation.
+	From ScheduledStatus
+	To   ScheduledStatus
+	// Email is the scheduled email as last fetched; SentEmailID is set once
+	// it has been sent.
+	Email *GetScheduledEmailResponse
+}
+
+// Final reports whether the email will not change status again
+func (c ScheduledStatusChange) Final() bool {
+	return isFinalScheduledStatus(c.To)
+}
+
+func isFinalScheduledStatus(status ScheduledStatus) bool {
+	return status == ScheduledStatusSent || status == ScheduledStatusFailed || status == ScheduledStatusCancelled
+}
+
+// ScheduleWatcher tracks scheduled emails and reports their status
+// transitions (scheduled, sending, then sent, failed, or cancelled), so
+// workflows can advance when a scheduled message actually goes out.
+type ScheduleWatcher struct {
+	client *Inbound
+	// Interval is the time between polls (default 15s).
+	Interval time.Duration
+	// OnError, if set, is called with errors that are retried, and for
+	// emails that no longer exist and are dropped.
+	OnError func(error)
+
+	mu       sync.Mutex
+	statuses map[string]ScheduledStatus
+	err      error
+}
+
+// NewScheduleWatcher creates a watcher tracking the given scheduled emails
+func NewScheduleWatcher(client *Inbound, ids ...string) *ScheduleWatcher {
+	w := &ScheduleWatcher{client: client, Interval: 15 * time.Second, statuses: make(map[string]ScheduledStatus)}
+	w.Add(ids...)
+	return w
+}
+
+// Add tracks more scheduled emails, including while watching
+func (w *ScheduleWatcher) Add(ids ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, id := range ids {
+		if _, ok := w.statuses[id]; !ok {
+			w.statuses[id] = ""
+		}
+	}
+}
+
+// Remove stops tracking a scheduled email
+func (w *ScheduleWatcher) Remove(id string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.statuses, id)
+}
+
+// Watch polls until every tracked email reaches a final status or ctx
+// ends, and returns the channel transitions are sent on. The first
+// observation of each email is reported with an empty From. The channel is
+// closed when watching stops; Err reports why if it was not ctx ending or
+// all emails finishing.
+func (w *ScheduleWatcher) Watch(ctx context.Context) <-chan ScheduledStatusChange {
+	out := make(chan ScheduledStatusChange)
+	go func() {
+		defer close(out)
+		if err := w.run(ctx, out); err != nil && ctx.Err() == nil {
+			w.mu.Lock()
+			w.err = err
+			w.mu.Unlock()
+		}
+	}()
+	return out
+}
+
+// Err returns the error that stopped the watcher, if any
+func (w *ScheduleWatcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+func (w *ScheduleWatcher) run(ctx context.Context, out chan<- ScheduledStatusChange) error {
+	for {
+		pending := w.pending()
+		if len(pending) == 0 {
+			return nil
+		}
+		for _, id := range pending {
+			change, err := w.check(ctx, id)
+			if err != nil {
+				return err
+			}
+			if change == nil {
+				continue
+			}
+			select {
+			case out <- *change:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if len(w.pending()) == 0 {
+			return nil
+		}
+		if err := sleepContext(ctx, w.Interval); err != nil {
+			return err
+		}
+	}
+}
+
+// pending returns the tracked emails without a final status, in a stable
+// order
+func (w *ScheduleWatcher) pending() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var ids []string
+	for id, status := range w.statuses {
+		if !isFinalScheduledStatus(status) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// check fetches one email and returns its transition, if it changed.
+// Transient failures are reported to OnError and skipped until the next
+// poll; other API errors stop the watcher.
+func (w *ScheduleWatcher) check(ctx context.Context, id string) (*ScheduledStatusChange, error) {
+	resp, err := w.client.Email().GetScheduled(ctx, id)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		w.report(err)
+		return nil, nil
+	}
+	if resp.Error != "" {
+		err := fmt.Errorf("failed to get scheduled email %s: %s", id, resp.Error)
+		switch {
+		case resp.StatusCode == http.StatusNotFound:
+			w.Remove(id)
+			w.report(err)
+			return nil, nil
+		case isTransientStatus(resp.StatusCode):
+			w.report(err)
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	previous, tracked := w.statuses[id]
+	if !tracked || previous == resp.Data.Status {
+		return nil, nil
+	}
+	w.statuses[id] = resp.Data.Status
+	return &ScheduledStatusChange{ID: id, From: previous, To: resp.Data.Status, Email: resp.Data}, nil
+}
+
+func (w *ScheduleWatcher) report(err error) {
+	if w.OnError != nil {
+		w.OnError(err)
+	}
+}
@@ -0,0 +1,97 @@
+package inboundgo_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestScheduleWatcher(t *testing.T) {
+	var mu sync.Mutex
+	polls := map[string]int{}
+	timelines := map[string][]string{
+		"sched-1": {"scheduled", "sending", "sent"},
+		"sched-2": {"scheduled", "scheduled", "cancelled"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/emails/schedule/")
+		mu.Lock()
+		defer mu.Unlock()
+		timeline, ok := timelines[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "Scheduled email not found"}`))
+			return
+		}
+		status := timeline[min(polls[id], len(timeline)-1)]
+		polls[id]++
+		sent := ""
+		if status == "sent" {
+			sent = `, "sent_email_id": "email-9"`
+		}
+		fmt.Fprintf(w, `{"id": %q, "status": %q%s}`, id, status, sent)
+	}))
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	watcher := inboundgo.NewScheduleWatcher(client, "sched-1", "sched-2", "sched-gone")
+	watcher.Interval = 10 * time.Millisecond
+	var errs []error
+	watcher.OnError = func(err error) { errs = append(errs, err) }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var got []string
+	var sentEmailID string
+	for change := range watcher.Watch(ctx) {
+		got = append(got, fmt.Sprintf("%s:%s>%s", change.ID, change.From, change.To))
+		if change.ID == "sched-1" && change.Final() && change.Email.SentEmailID != nil {
+			sentEmailID = *change.Email.SentEmailID
+		}
+	}
+	if ctx.Err() != nil {
+		t.Fatal("Timed out before all scheduled emails finished")
+	}
+	if err := watcher.Err(); err != nil {
+		t.Fatalf("Unexpected watcher error: %v", err)
+	}
+
+	want := []string{
+		"sched-1:>scheduled", "sched-2:>scheduled",
+		"sched-1:scheduled>sending",
+		"sched-1:sending>sent", "sched-2:scheduled>cancelled",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected transitions %v, got %v", want, got)
+	}
+	if sentEmailID != "email-9" {
+		t.Errorf("Expected sent email ID email-9, got %q", sentEmailID)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "sched-gone") {
+		t.Errorf("Expected one not-found error for sched-gone, got %v", errs)
+	}
+}
+
+func TestScheduleWatcherStopsOnAuthError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": "Invalid API key"}`))
+	}))
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	watcher := inboundgo.NewScheduleWatcher(client, "sched-1")
+	for range watcher.Watch(context.Background()) {
+		t.Error("Expected no transitions")
+	}
+	if err := watcher.Err(); err == nil || !strings.Contains(err.Error(), "Invalid API key") {
+		t.Errorf("Expected auth error, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package inboundgo
+
+import "strings"
+
+// MatchSenderFilter returns the filter that decides whether mail from
+// sender is accepted, or nil if none applies. It follows the server's
+// precedence: address filters beat domain filters, more specific domains
+// beat their parents, and allow beats block at the same level. Use it to
+// check many senders against a cached List result without a request each.
+func MatchSenderFilter(filters []SenderFilter, sender string) *SenderFilter {
+	a, ok := ParseSubAddress(sender)
+	if !ok {
+		return nil
+	}
+	// Tags do not change who the sender is
+	address := strings.ToLower(a.Base())
+
+	if f := pickSenderFilter(filters, "address", address); f != nil {
+		return f
+	}
+	domain := a.Domain
+	for {
+		if f := pickSenderFilter(filters, "domain", domain); f != nil {
+			return f
+		}
+		_, parent, found := strings.Cut(domain, ".")
+		if !found || !strings.Contains(parent, ".") {
+			return nil
+		}
+		domain = parent
+	}
+}
+
+// pickSenderFilter finds the filter of the given type for value, preferring
+// allow over block
+func pickSenderFilter(filters []SenderFilter, typ, value string) *SenderFilter {
+	var match *SenderFilter
+	for i := range filters {
+		f := &filters[i]
+		if f.Type != typ || strings.ToLower(strings.TrimSuffix(f.Value, ".")) != value {
+			continue
+		}
+		if f.Action == "allow" {
+			return f
+		}
+		if match == nil {
+			match = f
+		}
+	}
+	return match
+}
@@ -0,0 +1,90 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestSenderFilterService(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /domains/dom-1/sender-filters":
+			var req inboundgo.PostSenderFiltersRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Action != "block" || len(req.Senders) != 2 {
+				t.Errorf("Unexpected create request: %+v", req)
+			}
+			w.Write([]byte(`{"data": [{"id": "sf-1", "action": "block", "type": "domain", "value": "spam.example"},
+				{"id": "sf-2", "action": "block", "type": "address", "value": "bad@example.net"}]}`))
+		case "GET /domains/dom-1/sender-filters":
+			if r.URL.Query().Get("action") != "block" {
+				t.Errorf("Unexpected query %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"data": [{"id": "sf-1", "action": "block", "type": "domain", "value": "spam.example"}], "pagination": {"total": 1}}`))
+		case "GET /domains/dom-1/sender-filters/check":
+			if r.URL.Query().Get("sender") != "x+y@spam.example" {
+				t.Errorf("Unexpected sender %q", r.URL.Query().Get("sender"))
+			}
+			w.Write([]byte(`{"sender": "x+y@spam.example", "action": "block", "filter": {"id": "sf-1", "action": "block", "type": "domain", "value": "spam.example"}}`))
+		case "DELETE /domains/dom-1/sender-filters/sf-1":
+			w.Write([]byte(`{"message": "deleted"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+	svc := client.SenderFilter()
+
+	blocked, err := svc.Block(ctx, "dom-1", "spam.example", "bad@example.net")
+	if err != nil || blocked.Error != "" || len(blocked.Data.Data) != 2 {
+		t.Fatalf("Block failed: %v %+v", err, blocked)
+	}
+	list, err := svc.List(ctx, "dom-1", &inboundgo.GetSenderFiltersRequest{Action: "block"})
+	if err != nil || list.Error != "" || len(list.Data.Data) != 1 {
+		t.Fatalf("List failed: %v %+v", err, list)
+	}
+	check, err := svc.Check(ctx, "dom-1", "x+y@spam.example")
+	if err != nil || check.Error != "" || check.Data.Action != "block" || check.Data.Filter.ID != "sf-1" {
+		t.Fatalf("Check failed: %v %+v", err, check)
+	}
+	if _, err := svc.Remove(ctx, "dom-1", "sf-1"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+}
+
+func TestMatchSenderFilter(t *testing.T) {
+	filters := []inboundgo.SenderFilter{
+		{ID: "block-domain", Action: "block", Type: "domain", Value: "example.net"},
+		{ID: "allow-sub", Action: "allow", Type: "domain", Value: "billing.example.net"},
+		{ID: "block-addr", Action: "block", Type: "address", Value: "ceo@billing.example.net"},
+		{ID: "allow-addr", Action: "allow", Type: "address", Value: "alerts@example.org"},
+		{ID: "block-addr-dup", Action: "block", Type: "address", Value: "alerts@example.org"},
+	}
+	tests := map[string]string{
+		"someone@example.net":          "block-domain",
+		"someone@mx.example.net":       "block-domain",
+		"invoices@billing.example.net": "allow-sub",
+		"CEO+tag@Billing.Example.net":  "block-addr",
+		"Alerts <alerts@example.org>":  "allow-addr",
+		"friend@example.com":           "",
+	}
+	for sender, want := range tests {
+		got := inboundgo.MatchSenderFilter(filters, sender)
+		if (got == nil && want != "") || (got != nil && got.ID != want) {
+			t.Errorf("MatchSenderFilter(%q) = %+v, want %q", sender, got, want)
+		}
+	}
+}
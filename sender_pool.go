@@ -0,0 +1,282 @@
+package inboundgo
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// SendJob is a single email queued on a SenderPool
+type SendJob struct {
+	Request *PostEmailsRequest
+	Options *IdempotencyOptions
+	// Metadata is passed through untouched to the result callbacks so
+	// callers can correlate results with their own records.
+	Metadata any
+}
+
+// ErrDuplicateSend is reported for a send whose idempotency key was already
+// used: the API did not send the email again
+var ErrDuplicateSend = errors.New("email already sent with this idempotency key")
+
+// SendStatus classifies the outcome of a send
+type SendStatus string
+
+const (
+	SendSucceeded SendStatus = "succeeded"
+	// SendDuplicate means the API rejected the idempotency key as already
+	// used, or the client's DuplicateGuard blocked the email, so it went
+	// out once already (or is going out).
+	SendDuplicate SendStatus = "duplicate"
+	SendFailed    SendStatus = "failed"
+)
+
+// SendResult reports the outcome of a SendJob
+type SendResult struct {
+	Job      SendJob
+	Response *PostEmailsResponse
+	Status   SendStatus
+	// Error is the last API or network error, empty on success.
+	Error string
+	// Err is Error as a typed error: an *ApiError for API errors, wrapping
+	// ErrDuplicateSend or ErrDuplicateMessage for duplicates, or the
+	// context's error.
+	Err        error
+	StatusCode int
+	Attempts   int
+	// IdempotencyKey is the key the job was sent with. Retry a failed job
+	// with the same key so it cannot be delivered twice.
+	IdempotencyKey string
+}
+
+// SenderPoolOptions configures a SenderPool
+type SenderPoolOptions struct {
+	// Concurrency is the number of sends in flight at once (default 4).
+	Concurrency int
+	// RatePerSecond caps how many send attempts start per second. Zero
+	// disables rate limiting.
+	RatePerSecond float64
+	// MaxRetries is the number of retries for transient failures
+	// (network errors, 429, and 5xx). Zero disables retries.
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry; it doubles on each
+	// subsequent attempt (default 500ms).
+	RetryBackoff time.Duration
+	// Attachments, when set, shares identical attachments across the
+	// jobs, and uploads them once if it has a Store.
+	Attachments *AttachmentDeduper
+	// OnSuccess, OnDuplicate, and OnFailure are invoked from worker
+	// goroutines and must be safe for concurrent use. Duplicates are
+	// reported to OnFailure when OnDuplicate is nil.
+	OnSuccess   func(SendResult)
+	OnDuplicate func(SendResult)
+	OnFailure   func(SendResult)
+}
+
+// SenderPool sends emails from a channel with bounded concurrency, an
+// optional per-second rate cap, and retries for transient failures.
+//
+// Every job is sent with an idempotency key (generated when the job does not
+// carry one) so retries never deliver the same email twice.
+type SenderPool struct {
+	client  *Inbound
+	opts    SenderPoolOptions
+	limiter *rateLimiter
+}
+
+// NewSenderPool creates a sender pool backed by client
+func NewSenderPool(client *Inbound, opts SenderPoolOptions) *SenderPool {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 4
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = 500 * time.Millisecond
+	}
+	return &SenderPool{
+		client:  client,
+		opts:    opts,
+		limiter: newRateLimiter(opts.RatePerSecond),
+	}
+}
+
+// Run consumes jobs until the channel is closed and all in-flight sends have
+// finished, or until ctx is cancelled. It returns ctx.Err() on cancellation.
+func (p *SenderPool) Run(ctx context.Context, jobs <-chan SendJob) error {
+	var wg sync.WaitGroup
+	for i := 0; i < p.opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job, ok := <-jobs:
+					if !ok {
+						return
+					}
+					p.report(p.send(ctx, job))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// send delivers a single job, retrying transient failures
+func (p *SenderPool) send(ctx context.Context, job SendJob) SendResult {
+	result := SendResult{Job: job, Status: SendFailed}
+	if job.Request == nil {
+		result.fail(errors.New("send job has no request"))
+		return result
+	}
+
+	options := job.Options
+	if options == nil || options.IdempotencyKey == "" {
+		options = &IdempotencyOptions{IdempotencyKey: newIdempotencyKey()}
+	}
+	result.IdempotencyKey = options.IdempotencyKey
+
+	req := job.Request
+	if p.opts.Attachments != nil && len(req.Attachments) > 0 {
+		attachments, err := p.opts.Attachments.Dedupe(ctx, req.Attachments)
+		if err != nil {
+			result.fail(err)
+			return result
+		}
+		deduped := *req
+		deduped.Attachments = attachments
+		req = &deduped
+	}
+
+	backoff := p.opts.RetryBackoff
+	for {
+		if err := p.limiter.wait(ctx); err != nil {
+			result.fail(err)
+			return result
+		}
+
+		result.Attempts++
+		resp, err := p.client.Email().Send(ctx, req, options)
+		if err != nil {
+			result.fail(err)
+			if errors.Is(err, ErrDuplicateMessage) {
+				result.Status = SendDuplicate
+			}
+			return result
+		}
+		result.StatusCode = resp.StatusCode
+		if resp.Error == "" {
+			result.Response = resp.Data
+			result.Status, result.Error, result.Err = SendSucceeded, "", nil
+			return result
+		}
+		apiErr := &ApiError{StatusCode: resp.StatusCode, Message: resp.Error}
+		if resp.StatusCode == http.StatusConflict {
+			result.fail(fmt.Errorf("%w: %w", ErrDuplicateSend, apiErr))
+			result.Error = resp.Error
+			result.Status = SendDuplicate
+			return result
+		}
+		result.fail(apiErr)
+		result.Error = resp.Error
+
+		if result.Attempts > p.opts.MaxRetries || !isTransientStatus(resp.StatusCode) || ctx.Err() != nil {
+			return result
+		}
+		if err := sleepContext(ctx, backoff); err != nil {
+			return result
+		}
+		backoff *= 2
+	}
+}
+
+// fail records err as the job's failure
+func (r *SendResult) fail(err error) {
+	r.Status, r.Err, r.Error = SendFailed, err, err.Error()
+}
+
+func (p *SenderPool) report(result SendResult) {
+	callback := p.opts.OnFailure
+	switch result.Status {
+	case SendSucceeded:
+		callback = p.opts.OnSuccess
+	case SendDuplicate:
+		if p.opts.OnDuplicate != nil {
+			callback = p.opts.OnDuplicate
+		}
+	}
+	if callback != nil {
+		callback(result)
+	}
+}
+
+// isTransientStatus reports whether a failed response is worth retrying.
+// A zero status means no response was received at all.
+func isTransientStatus(status int) bool {
+	return status == 0 || status == http.StatusTooManyRequests || status >= 500
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// newIdempotencyKey returns a random key suitable for the Idempotency-Key header
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand does not fail on supported platforms; fall back to
+		// the clock so a key is still unique within this process.
+		return "inbound-go-" + time.Now().Format("20060102150405.000000000")
+	}
+	return hex.EncodeToString(b)
+}
+
+// rateLimiter spaces events evenly at a fixed rate. A nil limiter never waits.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(perSecond float64) *rateLimiter {
+	if perSecond <= 0 {
+		return nil
+	}
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / perSecond)}
+}
+
+// wait blocks until the next slot is available or ctx is done
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	if d := time.Until(slot); d > 0 {
+		return sleepContext(ctx, d)
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,209 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestSenderPool(t *testing.T) {
+	t.Run("should send all jobs with bounded concurrency", func(t *testing.T) {
+		var inFlight, maxInFlight int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				m := atomic.LoadInt32(&maxInFlight)
+				if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+					break
+				}
+			}
+			if r.Header.Get("Idempotency-Key") == "" {
+				t.Error("Expected an idempotency key on every pooled send")
+			}
+			time.Sleep(10 * time.Millisecond)
+			var req inboundgo.PostEmailsRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(inboundgo.PostEmailsResponse{ID: req.Subject})
+		}))
+		defer server.Close()
+
+		client, err := inboundgo.NewClient("test-api-key", server.URL)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+
+		var mu sync.Mutex
+		sent := map[string]bool{}
+		pool := inboundgo.NewSenderPool(client, inboundgo.SenderPoolOptions{
+			Concurrency: 3,
+			OnSuccess: func(r inboundgo.SendResult) {
+				mu.Lock()
+				defer mu.Unlock()
+				sent[r.Response.ID] = true
+			},
+			OnFailure: func(r inboundgo.SendResult) {
+				t.Errorf("Unexpected failure: %s", r.Error)
+			},
+		})
+
+		jobs := make(chan inboundgo.SendJob)
+		go func() {
+			for i := 0; i < 12; i++ {
+				jobs <- inboundgo.SendJob{Request: &inboundgo.PostEmailsRequest{
+					From:    "test@example.com",
+					To:      "user@example.com",
+					Subject: fmt.Sprintf("msg-%d", i),
+				}}
+			}
+			close(jobs)
+		}()
+
+		if err := pool.Run(context.Background(), jobs); err != nil {
+			t.Fatalf("Run returned error: %v", err)
+		}
+		if len(sent) != 12 {
+			t.Errorf("Expected 12 successful sends, got %d", len(sent))
+		}
+		if maxInFlight > 3 {
+			t.Errorf("Expected at most 3 concurrent sends, got %d", maxInFlight)
+		}
+	})
+
+	t.Run("should retry transient failures with the same idempotency key", func(t *testing.T) {
+		var attempts int32
+		var keys sync.Map
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			keys.Store(r.Header.Get("Idempotency-Key"), true)
+			if atomic.AddInt32(&attempts, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"id": "email-123"}`))
+		}))
+		defer server.Close()
+
+		client, _ := inboundgo.NewClient("test-api-key", server.URL)
+		var result inboundgo.SendResult
+		pool := inboundgo.NewSenderPool(client, inboundgo.SenderPoolOptions{
+			Concurrency:  1,
+			MaxRetries:   3,
+			RetryBackoff: time.Millisecond,
+			OnSuccess:    func(r inboundgo.SendResult) { result = r },
+		})
+
+		jobs := make(chan inboundgo.SendJob, 1)
+		jobs <- inboundgo.SendJob{Request: &inboundgo.PostEmailsRequest{From: "a@example.com", To: "b@example.com"}, Metadata: "job-1"}
+		close(jobs)
+		pool.Run(context.Background(), jobs)
+
+		if result.Attempts != 3 {
+			t.Errorf("Expected 3 attempts, got %d", result.Attempts)
+		}
+		if result.Job.Metadata != "job-1" {
+			t.Errorf("Expected metadata to be passed through, got %v", result.Job.Metadata)
+		}
+		n := 0
+		keys.Range(func(_, _ any) bool { n++; return true })
+		if n != 1 {
+			t.Errorf("Expected retries to reuse one idempotency key, got %d keys", n)
+		}
+	})
+
+	t.Run("should not retry client errors", func(t *testing.T) {
+		var attempts int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": "Invalid recipient"}`))
+		}))
+		defer server.Close()
+
+		client, _ := inboundgo.NewClient("test-api-key", server.URL)
+		var result inboundgo.SendResult
+		pool := inboundgo.NewSenderPool(client, inboundgo.SenderPoolOptions{
+			MaxRetries:   3,
+			RetryBackoff: time.Millisecond,
+			OnFailure:    func(r inboundgo.SendResult) { result = r },
+		})
+
+		jobs := make(chan inboundgo.SendJob, 1)
+		jobs <- inboundgo.SendJob{Request: &inboundgo.PostEmailsRequest{From: "a@example.com", To: "b@example.com"}}
+		close(jobs)
+		pool.Run(context.Background(), jobs)
+
+		if attempts != 1 {
+			t.Errorf("Expected 1 attempt, got %d", attempts)
+		}
+		if result.Error != "Invalid recipient" || result.StatusCode != http.StatusBadRequest {
+			t.Errorf("Unexpected result: %+v", result)
+		}
+	})
+
+	t.Run("should report a reused idempotency key as a duplicate", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "Idempotency key already used"}`))
+		}))
+		defer server.Close()
+
+		client, _ := inboundgo.NewClient("test-api-key", server.URL)
+		var result inboundgo.SendResult
+		pool := inboundgo.NewSenderPool(client, inboundgo.SenderPoolOptions{
+			OnDuplicate: func(r inboundgo.SendResult) { result = r },
+			OnFailure:   func(r inboundgo.SendResult) { t.Errorf("Unexpected failure: %+v", r) },
+		})
+
+		jobs := make(chan inboundgo.SendJob, 1)
+		jobs <- inboundgo.SendJob{
+			Request: &inboundgo.PostEmailsRequest{From: "a@example.com", To: "b@example.com"},
+			Options: &inboundgo.IdempotencyOptions{IdempotencyKey: "order-42"},
+		}
+		close(jobs)
+		pool.Run(context.Background(), jobs)
+
+		var apiErr *inboundgo.ApiError
+		if result.Status != inboundgo.SendDuplicate || result.IdempotencyKey != "order-42" {
+			t.Errorf("Unexpected result: %+v", result)
+		}
+		if !errors.Is(result.Err, inboundgo.ErrDuplicateSend) || !errors.As(result.Err, &apiErr) || apiErr.StatusCode != http.StatusConflict {
+			t.Errorf("Expected a typed duplicate error, got %v", result.Err)
+		}
+	})
+
+	t.Run("should respect the rate cap", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"id": "email-123"}`))
+		}))
+		defer server.Close()
+
+		client, _ := inboundgo.NewClient("test-api-key", server.URL)
+		pool := inboundgo.NewSenderPool(client, inboundgo.SenderPoolOptions{
+			Concurrency:   4,
+			RatePerSecond: 50,
+		})
+
+		jobs := make(chan inboundgo.SendJob, 6)
+		for i := 0; i < 6; i++ {
+			jobs <- inboundgo.SendJob{Request: &inboundgo.PostEmailsRequest{From: "a@example.com", To: "b@example.com"}}
+		}
+		close(jobs)
+
+		start := time.Now()
+		pool.Run(context.Background(), jobs)
+		// 6 sends at 50/s need at least 5 intervals of 20ms
+		if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+			t.Errorf("Expected rate limiting to take at least 100ms, took %v", elapsed)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package inboundgo
+
+import (
+	"strings"
+)
+
+// Signature is appended to the body of emails sent from an address
+type Signature struct {
+	HTML string
+	Text string
+}
+
+// WithSignature configures the signature appended by Email().Send and
+// Email().Reply to emails from address. address may also be "@domain" to
+// cover every address of a domain; an exact address takes precedence. An
+// empty signature removes the configuration. Set NoSignature on a request
+// to send it without one.
+func (c *Inbound) WithSignature(address string, signature Signature) *Inbound {
+	key := strings.ToLower(strings.TrimSpace(address))
+	if local, domain, ok := splitAddress(address); ok {
+		key = local + "@" + domain
+	}
+	if signature == (Signature{}) {
+		delete(c.signatures, key)
+		return c
+	}
+	if c.signatures == nil {
+		c.signatures = make(map[string]Signature)
+	}
+	c.signatures[key] = signature
+	return c
+}
+
+// signatureFor returns the signature configured for a From address, which
+// may include a display name
+func (c *Inbound) signatureFor(from string) (Signature, bool) {
+	local, domain, ok := splitAddress(from)
+	if !ok || len(c.signatures) == 0 {
+		return Signature{}, false
+	}
+	if signature, ok := c.signatures[local+"@"+domain]; ok {
+		return signature, true
+	}
+	signature, ok := c.signatures["@"+domain]
+	return signature, ok
+}
+
+// appendSignature adds signature to the parts of a body that are present.
+// The text signature follows the "-- " delimiter mail clients recognize;
+// the HTML signature goes before </body> if there is one.
+func appendSignature(html, text *string, signature Signature) (*string, *string) {
+	if html != nil && signature.HTML != "" {
+		block := `<div class="signature">` + signature.HTML + `</div>`
+		body := *html
+		if i := strings.LastIndex(strings.ToLower(body), "</body>"); i >= 0 {
+			body = body[:i] + block + body[i:]
+		} else {
+			body += block
+		}
+		html = String(body)
+	}
+	if text != nil && signature.Text != "" {
+		text = String(strings.TrimRight(*text, "\r\n") + "\n\n-- \n" + signature.Text)
+	}
+	return html, text
+}
+
+// prepareReply returns the reply as it will be sent, with the sender's
+// signature appended. params is not modified.
+func (c *Inbound) prepareReply(params *PostEmailReplyRequest) *PostEmailReplyRequest {
+	prepared := *params
+	prepared.NoSignature = false
+	if signature, ok := c.signatureFor(params.From); ok && !params.NoSignature {
+		prepared.HTML, prepared.Text = appendSignature(prepared.HTML, prepared.Text, signature)
+	}
+	return &prepared
+}
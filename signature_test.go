@@ -0,0 +1,81 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestSendAppendsSignature(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = nil
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"id":"email_1"}`))
+	}))
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	client.WithSignature("Support@Acme.test", inboundgo.Signature{HTML: "<b>Acme Support</b>", Text: "Acme Support"}).
+		WithSignature("@acme.test", inboundgo.Signature{Text: "Acme"})
+	ctx := context.Background()
+
+	req := &inboundgo.PostEmailsRequest{
+		From: "Acme <support@acme.test>",
+		HTML: inboundgo.String("<html><body><p>Hi</p></body></html>"),
+		Text: inboundgo.String("Hi\n"),
+	}
+	client.Email().Send(ctx, req, nil)
+	if got["html"] != `<html><body><p>Hi</p><div class="signature"><b>Acme Support</b></div></body></html>` {
+		t.Errorf("Unexpected HTML %v", got["html"])
+	}
+	if got["text"] != "Hi\n\n-- \nAcme Support" {
+		t.Errorf("Unexpected text %q", got["text"])
+	}
+	if *req.Text != "Hi\n" {
+		t.Error("Send should not modify the request")
+	}
+
+	client.Email().Send(ctx, &inboundgo.PostEmailsRequest{From: "sales@acme.test", Text: inboundgo.String("Hi")}, nil)
+	if got["text"] != "Hi\n\n-- \nAcme" {
+		t.Errorf("Expected domain signature, got %q", got["text"])
+	}
+
+	client.Email().Send(ctx, &inboundgo.PostEmailsRequest{From: "support@acme.test", Text: inboundgo.String("Hi"), NoSignature: true}, nil)
+	if got["text"] != "Hi" {
+		t.Errorf("Expected no signature, got %q", got["text"])
+	}
+
+	client.Email().Send(ctx, &inboundgo.PostEmailsRequest{From: "ada@example.com", Text: inboundgo.String("Hi")}, nil)
+	if got["text"] != "Hi" {
+		t.Errorf("Expected no signature for other senders, got %q", got["text"])
+	}
+}
+
+func TestReplyAppendsSignature(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = nil
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"id":"email_2"}`))
+	}))
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	client.WithSignature("support@acme.test", inboundgo.Signature{Text: "Acme Support"})
+
+	client.Email().Reply(context.Background(), "email_1", &inboundgo.PostEmailReplyRequest{From: "support@acme.test", Text: inboundgo.String("Thanks!")}, nil)
+	if got["text"] != "Thanks!\n\n-- \nAcme Support" {
+		t.Errorf("Unexpected text %q", got["text"])
+	}
+
+	client.WithSignature("support@acme.test", inboundgo.Signature{})
+	client.Email().Reply(context.Background(), "email_1", &inboundgo.PostEmailReplyRequest{From: "support@acme.test", Text: inboundgo.String("Thanks!")}, nil)
+	if got["text"] != "Thanks!" {
+		t.Errorf("Expected removed signature, got %q", got["text"])
+	}
+}
@@ -0,0 +1,84 @@
+package inboundgo
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Request signing headers. The signature is "v1=" followed by the hex
+// HMAC-SHA256, keyed with the signing secret, of
+// "<timestamp>.<METHOD>.<request URI>.<body>".
+const (
+	SignatureHeader          = "X-Inbound-Signature"
+	SignatureTimestampHeader = "X-Inbound-Timestamp"
+)
+
+// ErrInvalidRequestSignature is returned by VerifyRequestSignature when a
+// request is unsigned, tampered with, or too old
+var ErrInvalidRequestSignature = errors.New("invalid request signature")
+
+// WithSigningSecret signs every request body with secret, adding a
+// timestamp and an HMAC of the method, path, and body. This protects the
+// integrity of requests beyond the bearer token for accounts that enforce
+// signed requests. An empty secret turns signing off.
+func (c *Inbound) WithSigningSecret(secret string) *Inbound {
+	c.signingSecret = secret
+	return c
+}
+
+// SignRequest returns the signature header value for a request
+func SignRequest(secret string, timestamp time.Time, method, requestURI string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.%s.%s.", timestamp.Unix(), strings.ToUpper(method), requestURI)
+	mac.Write(body)
+	return "v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// signRequest adds the signing headers to req
+func (c *Inbound) signRequest(req *http.Request, body []byte) {
+	now := time.Now()
+	req.Header.Set(SignatureTimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(SignatureHeader, SignRequest(c.signingSecret, now, req.Method, req.URL.RequestURI(), body))
+}
+
+// VerifyRequestSignature checks the signature of a request signed with
+// WithSigningSecret, for gateways and proxies that sit in front of the API.
+// Requests whose timestamp is more than tolerance away from now are
+// rejected to prevent replays. The body is restored so it can be read
+// again.
+func VerifyRequestSignature(r *http.Request, secret string, tolerance time.Duration) error {
+	if secret == "" {
+		return fmt.Errorf("%w: no secret configured", ErrInvalidRequestSignature)
+	}
+	unix, err := strconv.ParseInt(r.Header.Get(SignatureTimestampHeader), 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: missing timestamp", ErrInvalidRequestSignature)
+	}
+	timestamp := time.Unix(unix, 0)
+	if age := time.Since(timestamp); age > tolerance || age < -tolerance {
+		return fmt.Errorf("%w: timestamp outside tolerance", ErrInvalidRequestSignature)
+	}
+
+	var body []byte
+	if r.Body != nil {
+		if body, err = io.ReadAll(r.Body); err != nil {
+			return err
+		}
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	want := SignRequest(secret, timestamp, r.Method, r.URL.RequestURI(), body)
+	if !hmac.Equal([]byte(r.Header.Get(SignatureHeader)), []byte(want)) {
+		return ErrInvalidRequestSignature
+	}
+	return nil
+}
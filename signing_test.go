@@ -0,0 +1,69 @@
+package inboundgo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestWithSigningSecret(t *testing.T) {
+	var verifyErr error
+	var signed bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signed = r.Header.Get(inboundgo.SignatureHeader) != ""
+		verifyErr = inboundgo.VerifyRequestSignature(r, "s3cret", time.Minute)
+		w.Write([]byte(`{"id":"email_1"}`))
+	}))
+	defer server.Close()
+
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	ctx := context.Background()
+	req := &inboundgo.PostEmailsRequest{From: "a@acme.test", To: "b@example.com", Subject: "Hi"}
+
+	client.Email().Send(ctx, req, nil)
+	if signed {
+		t.Error("Requests should not be signed by default")
+	}
+
+	client.WithSigningSecret("s3cret")
+	client.Email().Send(ctx, req, nil)
+	if verifyErr != nil {
+		t.Errorf("Expected a valid signature, got %v", verifyErr)
+	}
+	client.Mail().List(ctx, &inboundgo.GetMailRequest{Limit: inboundgo.Int(5)})
+	if verifyErr != nil {
+		t.Errorf("Expected a valid signature for a GET with query, got %v", verifyErr)
+	}
+
+	client.WithSigningSecret("wrong")
+	client.Email().Send(ctx, req, nil)
+	if !errors.Is(verifyErr, inboundgo.ErrInvalidRequestSignature) {
+		t.Errorf("Expected invalid signature, got %v", verifyErr)
+	}
+}
+
+func TestVerifyRequestSignature(t *testing.T) {
+	body := `{"subject":"Hi"}`
+	old := time.Now().Add(-10 * time.Minute)
+	r := httptest.NewRequest("POST", "/api/v2/emails", strings.NewReader(body))
+	r.Header.Set(inboundgo.SignatureTimestampHeader, strconv.FormatInt(old.Unix(), 10))
+	r.Header.Set(inboundgo.SignatureHeader, inboundgo.SignRequest("s3cret", old, "POST", "/api/v2/emails", []byte(body)))
+	if err := inboundgo.VerifyRequestSignature(r, "s3cret", time.Minute); !errors.Is(err, inboundgo.ErrInvalidRequestSignature) {
+		t.Errorf("Expected stale timestamp to be rejected, got %v", err)
+	}
+
+	now := time.Now()
+	r = httptest.NewRequest("POST", "/api/v2/emails", strings.NewReader(`{"subject":"Tampered"}`))
+	r.Header.Set(inboundgo.SignatureTimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	r.Header.Set(inboundgo.SignatureHeader, inboundgo.SignRequest("s3cret", now, "POST", "/api/v2/emails", []byte(body)))
+	if err := inboundgo.VerifyRequestSignature(r, "s3cret", time.Minute); !errors.Is(err, inboundgo.ErrInvalidRequestSignature) {
+		t.Errorf("Expected tampered body to be rejected, got %v", err)
+	}
+}
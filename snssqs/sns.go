@@ -0,0 +1,332 @@
+// Package snssqs ingests Inbound webhook payloads delivered through Amazon
+// SNS and SQS, for deployments where webhooks are published to a topic or
+// buffered in a queue instead of reaching an application directly.
+//
+// Payloads are dispatched through an inboundgo.WebhookHandler, so the same
+// typed event handlers serve HTTP webhooks, SNS subscriptions, and SQS
+// queues. SNS envelopes are unwrapped and their signatures validated
+// against the AWS signing certificate before dispatch.
+//
+//	webhooks := inboundgo.NewWebhookHandler()
+//	webhooks.OnEmailReceived(func(ctx context.Context, p *inboundgo.WebhookPayload) error {
+//		log.Println("New mail:", p.Email.ID)
+//		return nil
+//	})
+//
+//	// An SQS queue subscribed to the topic
+//	consumer, err := snssqs.NewConsumer(queueURL, webhooks)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	log.Fatal(consumer.Run(ctx))
+//
+//	// Or an HTTPS subscription to the topic
+//	http.Handle("/sns", snssqs.NewHandler(webhooks, snssqs.NewVerifier(topicARN)))
+package snssqs
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+// SNS message types
+const (
+	TypeNotification             = "Notification"
+	TypeSubscriptionConfirmation = "SubscriptionConfirmation"
+	TypeUnsubscribeConfirmation  = "UnsubscribeConfirmation"
+)
+
+// ErrInvalidSignature is returned for SNS messages whose signature does not
+// verify
+var ErrInvalidSignature = errors.New("invalid SNS message signature")
+
+// certHostPattern matches the hosts SNS serves signing certificates from
+var certHostPattern = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// Message is an SNS message envelope
+type Message struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	Token            string `json:"Token,omitempty"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject,omitempty"`
+	Message          string `json:"Message"`
+	Timestamp        string `json:"Timestamp"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+	SubscribeURL     string `json:"SubscribeURL,omitempty"`
+	UnsubscribeURL   string `json:"UnsubscribeURL,omitempty"`
+}
+
+// ParseMessage parses an SNS message envelope
+func ParseMessage(data []byte) (*Message, error) {
+	var msg Message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, fmt.Errorf("failed to parse SNS message: %w", err)
+	}
+	if msg.Type == "" || msg.TopicArn == "" {
+		return nil, errors.New("not an SNS message")
+	}
+	return &msg, nil
+}
+
+// stringToSign builds the canonical form SNS signs
+func (m *Message) stringToSign() (string, error) {
+	var fields [][2]string
+	switch m.Type {
+	case TypeNotification:
+		fields = [][2]string{{"Message", m.Message}, {"MessageId", m.MessageID}}
+		if m.Subject != "" {
+			fields = append(fields, [2]string{"Subject", m.Subject})
+		}
+		fields = append(fields, [2]string{"Timestamp", m.Timestamp}, [2]string{"TopicArn", m.TopicArn}, [2]string{"Type", m.Type})
+	case TypeSubscriptionConfirmation, TypeUnsubscribeConfirmation:
+		fields = [][2]string{
+			{"Message", m.Message}, {"MessageId", m.MessageID}, {"SubscribeURL", m.SubscribeURL},
+			{"Timestamp", m.Timestamp}, {"Token", m.Token}, {"TopicArn", m.TopicArn}, {"Type", m.Type},
+		}
+	default:
+		return "", fmt.Errorf("unknown SNS message type %q", m.Type)
+	}
+	var b strings.Builder
+	for _, f := range fields {
+		b.WriteString(f[0] + "\n" + f[1] + "\n")
+	}
+	return b.String(), nil
+}
+
+// Verifier validates SNS message signatures. Signing certificates are
+// downloaded from amazonaws.com over HTTPS and cached.
+type Verifier struct {
+	// TopicARNs restricts accepted messages to these topics. Any topic is
+	// accepted when empty, which lets anyone with an AWS account publish to
+	// the endpoint; set it in production.
+	TopicARNs []string
+	// HTTPClient downloads certificates (default http.DefaultClient).
+	HTTPClient *http.Client
+	// Certificate, if set, replaces downloading the signing certificate.
+	Certificate func(ctx context.Context, certURL string) (*x509.Certificate, error)
+
+	mu    sync.Mutex
+	certs map[string]*x509.Certificate
+}
+
+// NewVerifier creates a verifier accepting messages from the given topics
+func NewVerifier(topicARNs ...string) *Verifier {
+	return &Verifier{TopicARNs: topicARNs}
+}
+
+// Verify checks msg's topic and signature
+func (v *Verifier) Verify(ctx context.Context, msg *Message) error {
+	if len(v.TopicARNs) > 0 {
+		allowed := false
+		for _, arn := range v.TopicARNs {
+			if arn == msg.TopicArn {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("SNS topic %s is not allowed", msg.TopicArn)
+		}
+	}
+
+	var hash crypto.Hash
+	switch msg.SignatureVersion {
+	case "1":
+		hash = crypto.SHA1
+	case "2":
+		hash = crypto.SHA256
+	default:
+		return fmt.Errorf("unsupported SNS signature version %q", msg.SignatureVersion)
+	}
+	signature, err := base64.StdEncoding.DecodeString(msg.Signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	canonical, err := msg.stringToSign()
+	if err != nil {
+		return err
+	}
+	cert, err := v.certificate(ctx, msg.SigningCertURL)
+	if err != nil {
+		return err
+	}
+	key, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("SNS signing certificate does not hold an RSA key")
+	}
+
+	var digest []byte
+	if hash == crypto.SHA1 {
+		sum := sha1.Sum([]byte(canonical))
+		digest = sum[:]
+	} else {
+		sum := sha256.Sum256([]byte(canonical))
+		digest = sum[:]
+	}
+	if err := rsa.VerifyPKCS1v15(key, hash, digest, signature); err != nil {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (v *Verifier) certificate(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	if v.Certificate != nil {
+		return v.Certificate(ctx, certURL)
+	}
+	u, err := url.Parse(certURL)
+	if err != nil || u.Scheme != "https" || !certHostPattern.MatchString(u.Hostname()) || !strings.HasSuffix(u.Path, ".pem") {
+		return nil, fmt.Errorf("untrusted SNS signing certificate URL %q", certURL)
+	}
+
+	v.mu.Lock()
+	cert := v.certs[certURL]
+	v.mu.Unlock()
+	if cert != nil {
+		return cert, nil
+	}
+
+	client := v.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, certURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download SNS signing certificate: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download SNS signing certificate: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("invalid SNS signing certificate")
+	}
+	cert, err = x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SNS signing certificate: %w", err)
+	}
+
+	v.mu.Lock()
+	if v.certs == nil {
+		v.certs = make(map[string]*x509.Certificate)
+	}
+	v.certs[certURL] = cert
+	v.mu.Unlock()
+	return cert, nil
+}
+
+// Handler is an http.Handler for an SNS HTTP or HTTPS subscription. It
+// confirms the subscription and dispatches notifications to Webhooks.
+type Handler struct {
+	Webhooks *inboundgo.WebhookHandler
+	// Verifier validates messages. It is required; requests that fail
+	// verification get 403.
+	Verifier *Verifier
+	// AutoConfirm visits the SubscribeURL of subscription confirmations
+	// (default true).
+	AutoConfirm bool
+	// HTTPClient confirms subscriptions (default http.DefaultClient).
+	HTTPClient *http.Client
+}
+
+// NewHandler creates a handler that dispatches verified notifications to
+// webhooks and confirms subscriptions automatically
+func NewHandler(webhooks *inboundgo.WebhookHandler, verifier *Verifier) *Handler {
+	return &Handler{Webhooks: webhooks, Verifier: verifier, AutoConfirm: true}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 1<<20))
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusRequestEntityTooLarge)
+		return
+	}
+	msg, err := ParseMessage(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if h.Verifier == nil {
+		http.Error(w, "no SNS verifier configured", http.StatusInternalServerError)
+		return
+	}
+	if err := h.Verifier.Verify(r.Context(), msg); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	switch msg.Type {
+	case TypeSubscriptionConfirmation:
+		if h.AutoConfirm {
+			if err := h.confirm(r.Context(), msg); err != nil {
+				http.Error(w, err.Error(), http.StatusBadGateway)
+				return
+			}
+		}
+	case TypeNotification:
+		if err := h.Webhooks.Dispatch(r.Context(), []byte(msg.Message)); err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, inboundgo.ErrInvalidWebhookPayload) {
+				status = http.StatusBadRequest
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// confirm visits the subscription's SubscribeURL. The URL is covered by the
+// verified signature.
+func (h *Handler) confirm(ctx context.Context, msg *Message) error {
+	client := h.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, msg.SubscribeURL, nil)
+	if err != nil {
+		return fmt.Errorf("invalid SubscribeURL: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to confirm SNS subscription: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to confirm SNS subscription: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,298 @@
+package snssqs_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+	"github.com/inboundemail/inbound-golang-sdk/snssqs"
+)
+
+const (
+	topicARN       = "arn:aws:sns:us-east-1:123456789012:inbound"
+	certURL        = "https://sns.us-east-1.amazonaws.com/SimpleNotificationService-test.pem"
+	webhookPayload = `{"event": "email.received", "email": {"id": "email-1"}}`
+)
+
+type signer struct {
+	key  *rsa.PrivateKey
+	cert *x509.Certificate
+}
+
+func newSigner(t *testing.T) *signer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate failed: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &signer{key: key, cert: cert}
+}
+
+func (s *signer) verifier(topics ...string) *snssqs.Verifier {
+	v := snssqs.NewVerifier(topics...)
+	v.Certificate = func(ctx context.Context, url string) (*x509.Certificate, error) {
+		if url != certURL {
+			return nil, fmt.Errorf("unexpected certificate URL %s", url)
+		}
+		return s.cert, nil
+	}
+	return v
+}
+
+// sign fills in the signature fields of msg following the SNS scheme
+func (s *signer) sign(t *testing.T, msg *snssqs.Message, version string) {
+	msg.SignatureVersion = version
+	msg.SigningCertURL = certURL
+	var b strings.Builder
+	add := func(k, v string) { b.WriteString(k + "\n" + v + "\n") }
+	add("Message", msg.Message)
+	add("MessageId", msg.MessageID)
+	if msg.Type == snssqs.TypeNotification {
+		if msg.Subject != "" {
+			add("Subject", msg.Subject)
+		}
+		add("Timestamp", msg.Timestamp)
+	} else {
+		add("SubscribeURL", msg.SubscribeURL)
+		add("Timestamp", msg.Timestamp)
+		add("Token", msg.Token)
+	}
+	add("TopicArn", msg.TopicArn)
+	add("Type", msg.Type)
+
+	var sig []byte
+	var err error
+	if version == "1" {
+		sum := sha1.Sum([]byte(b.String()))
+		sig, err = rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA1, sum[:])
+	} else {
+		sum := sha256.Sum256([]byte(b.String()))
+		sig, err = rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, sum[:])
+	}
+	if err != nil {
+		t.Fatalf("SignPKCS1v15 failed: %v", err)
+	}
+	msg.Signature = base64.StdEncoding.EncodeToString(sig)
+}
+
+func notification(t *testing.T, s *signer, version string) *snssqs.Message {
+	msg := &snssqs.Message{
+		Type:      snssqs.TypeNotification,
+		MessageID: "msg-1",
+		TopicArn:  topicARN,
+		Message:   webhookPayload,
+		Timestamp: "2025-01-15T10:30:00.000Z",
+	}
+	s.sign(t, msg, version)
+	return msg
+}
+
+func TestVerifier(t *testing.T) {
+	s := newSigner(t)
+	ctx := context.Background()
+
+	for _, version := range []string{"1", "2"} {
+		if err := s.verifier(topicARN).Verify(ctx, notification(t, s, version)); err != nil {
+			t.Errorf("Expected version %s signature to verify, got %v", version, err)
+		}
+	}
+
+	tampered := notification(t, s, "2")
+	tampered.Message = `{"event": "email.received", "email": {"id": "forged"}}`
+	if err := s.verifier().Verify(ctx, tampered); !errors.Is(err, snssqs.ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature, got %v", err)
+	}
+
+	if err := s.verifier("arn:aws:sns:us-east-1:123456789012:other").Verify(ctx, notification(t, s, "2")); err == nil {
+		t.Error("Expected error for topic not in the allow list")
+	}
+
+	untrusted := notification(t, s, "2")
+	untrusted.SigningCertURL = "https://evil.example.com/cert.pem"
+	if err := snssqs.NewVerifier().Verify(ctx, untrusted); err == nil || !strings.Contains(err.Error(), "untrusted") {
+		t.Errorf("Expected untrusted certificate URL error, got %v", err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	s := newSigner(t)
+	confirmed := false
+	confirmServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		confirmed = r.URL.Query().Get("Token") == "tok"
+	}))
+	defer confirmServer.Close()
+
+	var received []string
+	webhooks := inboundgo.NewWebhookHandler()
+	webhooks.OnEmailReceived(func(ctx context.Context, p *inboundgo.WebhookPayload) error {
+		received = append(received, p.Email.ID)
+		return nil
+	})
+	h := snssqs.NewHandler(webhooks, s.verifier(topicARN))
+
+	post := func(msg *snssqs.Message) int {
+		body, _ := json.Marshal(msg)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("POST", "/sns", strings.NewReader(string(body))))
+		return rec.Code
+	}
+
+	confirmation := &snssqs.Message{
+		Type:         snssqs.TypeSubscriptionConfirmation,
+		MessageID:    "msg-0",
+		Token:        "tok",
+		TopicArn:     topicARN,
+		Message:      "You have chosen to subscribe",
+		SubscribeURL: confirmServer.URL + "/?Action=ConfirmSubscription&Token=tok",
+		Timestamp:    "2025-01-15T10:29:00.000Z",
+	}
+	s.sign(t, confirmation, "1")
+	if code := post(confirmation); code != http.StatusNoContent || !confirmed {
+		t.Errorf("Expected subscription to be confirmed, got %d (confirmed=%v)", code, confirmed)
+	}
+
+	if code := post(notification(t, s, "1")); code != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d", code)
+	}
+	if len(received) != 1 || received[0] != "email-1" {
+		t.Errorf("Expected email-1 to be dispatched, got %v", received)
+	}
+
+	forged := notification(t, s, "1")
+	forged.Signature = base64.StdEncoding.EncodeToString([]byte("nope"))
+	if code := post(forged); code != http.StatusForbidden {
+		t.Errorf("Expected 403 for forged message, got %d", code)
+	}
+	if len(received) != 1 {
+		t.Errorf("Forged message should not be dispatched")
+	}
+}
+
+func TestConsumer(t *testing.T) {
+	s := newSigner(t)
+	envelope, _ := json.Marshal(notification(t, s, "2"))
+
+	var mu sync.Mutex
+	var deleted []string
+	received := 0
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") ||
+			!strings.Contains(r.Header.Get("Authorization"), "/us-east-1/sqs/aws4_request") {
+			t.Errorf("Unexpected Authorization header %q", r.Header.Get("Authorization"))
+		}
+		var params map[string]any
+		json.NewDecoder(r.Body).Decode(&params)
+		if !strings.HasSuffix(params["QueueUrl"].(string), "/123456789012/inbound") {
+			t.Errorf("Unexpected QueueUrl %v", params["QueueUrl"])
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Header.Get("X-Amz-Target") {
+		case "AmazonSQS.ReceiveMessage":
+			received++
+			if received > 1 {
+				cancel()
+				w.Write([]byte(`{}`))
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"Messages": []map[string]string{
+				{"MessageId": "m1", "ReceiptHandle": "rh-1", "Body": string(envelope)},
+				{"MessageId": "m2", "ReceiptHandle": "rh-2", "Body": `{"event": "email.received", "email": {"id": "email-2"}}`},
+				{"MessageId": "m3", "ReceiptHandle": "rh-3", "Body": `{"event": "email.received", "email": {"id": "fail"}}`},
+			}})
+		case "AmazonSQS.DeleteMessage":
+			deleted = append(deleted, params["ReceiptHandle"].(string))
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("Unexpected target %q", r.Header.Get("X-Amz-Target"))
+		}
+	}))
+	defer server.Close()
+
+	var ids []string
+	webhooks := inboundgo.NewWebhookHandler()
+	webhooks.OnEmailReceived(func(ctx context.Context, p *inboundgo.WebhookPayload) error {
+		if p.Email.ID == "fail" {
+			return errors.New("handler failed")
+		}
+		ids = append(ids, p.Email.ID)
+		return nil
+	})
+
+	consumer, err := snssqs.NewConsumer(server.URL+"/123456789012/inbound", webhooks)
+	if err != nil {
+		t.Fatalf("NewConsumer failed: %v", err)
+	}
+	consumer.Region = "us-east-1"
+	consumer.Credentials = snssqs.Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"}
+	consumer.Verifier = s.verifier(topicARN)
+	consumer.WaitTime = 0
+	var processErrs []error
+	consumer.OnError = func(err error) { processErrs = append(processErrs, err) }
+
+	if err := consumer.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if strings.Join(ids, ",") != "email-1,email-2" {
+		t.Errorf("Expected email-1 and email-2 to be dispatched, got %v", ids)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if strings.Join(deleted, ",") != "rh-1,rh-2" {
+		t.Errorf("Expected only processed messages to be deleted, got %v", deleted)
+	}
+	if len(processErrs) != 1 || !strings.Contains(processErrs[0].Error(), "m3") {
+		t.Errorf("Expected one processing error for m3, got %v", processErrs)
+	}
+}
+
+func TestConsumerAccessDenied(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"__type": "com.amazonaws.sqs#AccessDenied", "message": "Access to the resource is denied."}`))
+	}))
+	defer server.Close()
+
+	consumer, err := snssqs.NewConsumer(server.URL+"/123456789012/inbound", inboundgo.NewWebhookHandler())
+	if err != nil {
+		t.Fatalf("NewConsumer failed: %v", err)
+	}
+	consumer.Region = "us-east-1"
+	consumer.Credentials = snssqs.Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"}
+
+	err = consumer.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Errorf("Expected AccessDenied error, got %v", err)
+	}
+}
@@ -0,0 +1,323 @@
+package snssqs
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+// Credentials are AWS credentials used to sign SQS requests
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	// SessionToken is set for temporary credentials.
+	SessionToken string
+}
+
+// EnvCredentials reads credentials from AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY, and AWS_SESSION_TOKEN
+func EnvCredentials() Credentials {
+	return Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+}
+
+// Consumer receives webhook payloads from an SQS queue and dispatches them
+// to Webhooks. Messages may be SNS envelopes, from a queue subscribed to a
+// topic, or raw payloads; envelopes are unwrapped after their signature is
+// verified. A message is deleted once its handlers succeed. Otherwise it is
+// left on the queue to be received again after its visibility timeout, so
+// configure a redrive policy to move messages that keep failing to a
+// dead-letter queue.
+type Consumer struct {
+	QueueURL    string
+	Region      string
+	Credentials Credentials
+	Webhooks    *inboundgo.WebhookHandler
+	// Verifier validates SNS envelopes. When nil, envelopes are unwrapped
+	// without verification; only do this when the queue policy already
+	// restricts who can send to it.
+	Verifier *Verifier
+	// MaxMessages is received per request, 1 to 10 (default 10).
+	MaxMessages int
+	// WaitTime is the long-polling wait, up to 20s (default 20s).
+	WaitTime time.Duration
+	// VisibilityTimeout overrides the queue's visibility timeout for
+	// received messages when set.
+	VisibilityTimeout time.Duration
+	// MaxBackoff caps the wait after failed receives, which doubles from
+	// one second (default 1m).
+	MaxBackoff time.Duration
+	// OnError, if set, is called with receive errors that are retried and
+	// with messages that could not be processed.
+	OnError func(error)
+	// HTTPClient sends SQS requests (default http.DefaultClient).
+	HTTPClient *http.Client
+
+	endpoint string
+}
+
+// NewConsumer creates a consumer for queueURL, taking the region from the
+// URL and credentials from the environment. SNS envelopes are verified
+// with a Verifier that accepts any topic.
+func NewConsumer(queueURL string, webhooks *inboundgo.WebhookHandler) (*Consumer, error) {
+	u, err := url.Parse(queueURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid SQS queue URL %q", queueURL)
+	}
+	region := os.Getenv("AWS_REGION")
+	// sqs.us-east-1.amazonaws.com or the legacy us-east-1.queue.amazonaws.com
+	if rest, ok := strings.CutPrefix(u.Hostname(), "sqs."); ok {
+		region, _, _ = strings.Cut(rest, ".")
+	} else if r, _, ok := strings.Cut(u.Hostname(), ".queue."); ok {
+		region = r
+	}
+	return &Consumer{
+		QueueURL:    queueURL,
+		Region:      region,
+		Credentials: EnvCredentials(),
+		Webhooks:    webhooks,
+		Verifier:    NewVerifier(),
+		MaxMessages: 10,
+		WaitTime:    20 * time.Second,
+		MaxBackoff:  time.Minute,
+		endpoint:    u.Scheme + "://" + u.Host + "/",
+	}, nil
+}
+
+// sqsMessage is a message returned by ReceiveMessage
+type sqsMessage struct {
+	MessageID     string `json:"MessageId"`
+	ReceiptHandle string `json:"ReceiptHandle"`
+	Body          string `json:"Body"`
+}
+
+// sqsError is an error response from SQS
+type sqsError struct {
+	status  int
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+func (e *sqsError) Error() string {
+	code := e.Type
+	if i := strings.LastIndex(code, "#"); i >= 0 {
+		code = code[i+1:]
+	}
+	return fmt.Sprintf("SQS error (%d %s): %s", e.status, code, e.Message)
+}
+
+// Run receives and dispatches messages until ctx ends, returning
+// ctx.Err(). Authentication failures and missing queues stop it with an
+// error; other receive errors are retried with backoff.
+func (c *Consumer) Run(ctx context.Context) error {
+	if c.Region == "" {
+		return errors.New("SQS region is not set")
+	}
+	if c.Credentials.AccessKeyID == "" || c.Credentials.SecretAccessKey == "" {
+		return errors.New("AWS credentials are not set")
+	}
+
+	backoff := time.Second
+	for {
+		messages, err := c.receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			var sqsErr *sqsError
+			if errors.As(err, &sqsErr) && sqsErr.status >= 400 && sqsErr.status < 500 && !strings.Contains(sqsErr.Type, "Throttl") {
+				return err
+			}
+			if c.OnError != nil {
+				c.OnError(err)
+			}
+			if err := sleepContext(ctx, backoff); err != nil {
+				return err
+			}
+			backoff = min(backoff*2, c.MaxBackoff)
+			continue
+		}
+		backoff = time.Second
+
+		for _, msg := range messages {
+			if err := c.process(ctx, msg); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				if c.OnError != nil {
+					c.OnError(fmt.Errorf("failed to process SQS message %s: %w", msg.MessageID, err))
+				}
+				continue
+			}
+			if err := c.call(ctx, "DeleteMessage", map[string]any{"QueueUrl": c.QueueURL, "ReceiptHandle": msg.ReceiptHandle}, nil); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				if c.OnError != nil {
+					c.OnError(fmt.Errorf("failed to delete SQS message %s: %w", msg.MessageID, err))
+				}
+			}
+		}
+	}
+}
+
+func (c *Consumer) receive(ctx context.Context) ([]sqsMessage, error) {
+	params := map[string]any{
+		"QueueUrl":            c.QueueURL,
+		"MaxNumberOfMessages": c.MaxMessages,
+		"WaitTimeSeconds":     int(c.WaitTime / time.Second),
+	}
+	if c.VisibilityTimeout > 0 {
+		params["VisibilityTimeout"] = int(c.VisibilityTimeout / time.Second)
+	}
+	var out struct {
+		Messages []sqsMessage `json:"Messages"`
+	}
+	if err := c.call(ctx, "ReceiveMessage", params, &out); err != nil {
+		return nil, err
+	}
+	return out.Messages, nil
+}
+
+// process unwraps and dispatches one message body
+func (c *Consumer) process(ctx context.Context, msg sqsMessage) error {
+	data := []byte(msg.Body)
+	if envelope, err := ParseMessage(data); err == nil {
+		if c.Verifier != nil {
+			if err := c.Verifier.Verify(ctx, envelope); err != nil {
+				return err
+			}
+		}
+		if envelope.Type != TypeNotification {
+			// Subscription confirmations are handled in the SNS console
+			return nil
+		}
+		data = []byte(envelope.Message)
+	}
+	return c.Webhooks.Dispatch(ctx, data)
+}
+
+// call invokes an SQS action with the JSON protocol
+func (c *Consumer) call(ctx context.Context, action string, params, out any) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+	req.Header.Set("X-Amz-Target", "AmazonSQS."+action)
+	c.sign(req, body)
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		sqsErr := &sqsError{status: resp.StatusCode}
+		if json.Unmarshal(respBody, sqsErr) != nil || sqsErr.Message == "" {
+			sqsErr.Message = strings.TrimSpace(string(respBody))
+		}
+		return sqsErr
+	}
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("invalid SQS response: %w", err)
+		}
+	}
+	return nil
+}
+
+// sign adds an AWS Signature Version 4 Authorization header
+func (c *Consumer) sign(req *http.Request, body []byte) {
+	t := time.Now().UTC()
+	amzDate := t.Format("20060102T150405Z")
+	date := t.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if c.Credentials.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", c.Credentials.SessionToken)
+	}
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	bodyHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method, path, req.URL.RawQuery, canonicalHeaders.String(), signedHeaders, hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+
+	scope := date + "/" + c.Region + "/sqs/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+c.Credentials.SecretAccessKey), date)
+	key = hmacSHA256(key, c.Region)
+	key = hmacSHA256(key, "sqs")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.Credentials.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
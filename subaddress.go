@@ -0,0 +1,114 @@
+package inboundgo
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// SubAddress is an address split into its plus-addressing parts:
+// user+tag@domain
+type SubAddress struct {
+	User   string
+	Tag    string // Empty when the address has no tag
+	Domain string
+}
+
+// ParseSubAddress splits address, which may include a display name, into
+// user, tag, and domain. The domain is lowercased; user and tag keep their
+// case since tags often carry IDs.
+func ParseSubAddress(address string) (SubAddress, bool) {
+	address = strings.TrimSpace(address)
+	if parsed, err := mail.ParseAddress(address); err == nil {
+		address = parsed.Address
+	}
+	at := strings.LastIndexByte(address, '@')
+	if at <= 0 || at == len(address)-1 {
+		return SubAddress{}, false
+	}
+	local := address[:at]
+	user, tag, _ := strings.Cut(local, "+")
+	if user == "" {
+		return SubAddress{}, false
+	}
+	return SubAddress{User: user, Tag: tag, Domain: strings.ToLower(address[at+1:])}, true
+}
+
+// Base returns the address without its tag
+func (a SubAddress) Base() string {
+	return a.User + "@" + a.Domain
+}
+
+// String returns the full address, including the tag if set
+func (a SubAddress) String() string {
+	if a.Tag == "" {
+		return a.Base()
+	}
+	return a.User + "+" + a.Tag + "@" + a.Domain
+}
+
+// TaggedAddress adds tag to address, replacing any existing tag, e.g. for a
+// per-customer reply address: TaggedAddress("reply@example.com", "cust-42")
+// returns "reply+cust-42@example.com".
+func TaggedAddress(address, tag string) (string, error) {
+	a, ok := ParseSubAddress(address)
+	if !ok {
+		return "", fmt.Errorf("invalid address %q", address)
+	}
+	if !isValidAddressTag(tag) {
+		return "", fmt.Errorf("invalid address tag %q", tag)
+	}
+	a.Tag = tag
+	return a.String(), nil
+}
+
+// isValidAddressTag reports whether tag uses only characters that are safe
+// in an unquoted local part (RFC 5322 atext, minus "+")
+func isValidAddressTag(tag string) bool {
+	if tag == "" || strings.HasPrefix(tag, ".") || strings.HasSuffix(tag, ".") || strings.Contains(tag, "..") {
+		return false
+	}
+	for _, c := range tag {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.ContainsRune("!#$%&'*-/=?^_`{|}~.", c):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// RecipientTag returns the plus-address tag of the address the email was
+// delivered to, or "" if it has none
+func (w *WebhookPayload) RecipientTag() string {
+	a, _ := ParseSubAddress(w.Email.Recipient)
+	return a.Tag
+}
+
+// SubAddresses returns the recipient and every To and Cc address, split
+// into plus-addressing parts, without duplicates
+func (w *WebhookPayload) SubAddresses() []SubAddress {
+	var out []SubAddress
+	seen := make(map[string]bool)
+	add := func(address string) {
+		a, ok := ParseSubAddress(address)
+		if !ok || seen[strings.ToLower(a.String())] {
+			return
+		}
+		seen[strings.ToLower(a.String())] = true
+		out = append(out, a)
+	}
+	add(w.Email.Recipient)
+	for _, group := range []*WebhookAddressGroup{w.Email.To, w.Email.ParsedData.Cc} {
+		if group == nil {
+			continue
+		}
+		for _, addr := range group.Addresses {
+			if addr.Address != nil {
+				add(*addr.Address)
+			}
+		}
+	}
+	return out
+}
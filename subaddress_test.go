@@ -0,0 +1,77 @@
+package inboundgo_test
+
+import (
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestParseSubAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		want    inboundgo.SubAddress
+		ok      bool
+	}{
+		{"reply+Cust-42@Example.com", inboundgo.SubAddress{User: "reply", Tag: "Cust-42", Domain: "example.com"}, true},
+		{"Support <support@example.com>", inboundgo.SubAddress{User: "support", Domain: "example.com"}, true},
+		{"a+b+c@example.com", inboundgo.SubAddress{User: "a", Tag: "b+c", Domain: "example.com"}, true},
+		{"+tag@example.com", inboundgo.SubAddress{}, false},
+		{"not-an-address", inboundgo.SubAddress{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := inboundgo.ParseSubAddress(tt.address)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("ParseSubAddress(%q) = %+v, %v; want %+v, %v", tt.address, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	a, _ := inboundgo.ParseSubAddress("reply+42@example.com")
+	if a.Base() != "reply@example.com" || a.String() != "reply+42@example.com" {
+		t.Errorf("Unexpected formatting: %s %s", a.Base(), a.String())
+	}
+}
+
+func TestTaggedAddress(t *testing.T) {
+	got, err := inboundgo.TaggedAddress("reply+old@example.com", "cust-42")
+	if err != nil || got != "reply+cust-42@example.com" {
+		t.Errorf("TaggedAddress = %q, %v", got, err)
+	}
+	for _, tag := range []string{"", "has space", "a@b", ".lead", "x..y"} {
+		if _, err := inboundgo.TaggedAddress("reply@example.com", tag); err == nil {
+			t.Errorf("Expected error for tag %q", tag)
+		}
+	}
+}
+
+func TestWebhookPayloadSubAddresses(t *testing.T) {
+	payload := &inboundgo.WebhookPayload{}
+	payload.Email.Recipient = "reply+cust-42@example.com"
+	payload.Email.To = &inboundgo.WebhookAddressGroup{Addresses: []inboundgo.WebhookAddress{
+		{Address: inboundgo.String("Reply+cust-42@example.com")},
+		{Address: inboundgo.String("sales@example.com")},
+	}}
+	payload.Email.ParsedData.Cc = &inboundgo.WebhookAddressGroup{Addresses: []inboundgo.WebhookAddress{
+		{Address: inboundgo.String("ops+alerts@example.com")},
+	}}
+
+	if tag := payload.RecipientTag(); tag != "cust-42" {
+		t.Errorf("Expected recipient tag cust-42, got %q", tag)
+	}
+	subs := payload.SubAddresses()
+	if len(subs) != 3 || subs[1].User != "sales" || subs[2].Tag != "alerts" {
+		t.Errorf("Unexpected sub-addresses: %+v", subs)
+	}
+
+	rules := []inboundgo.Rule{{
+		ID: "customers", Enabled: true,
+		Conditions: inboundgo.RuleConditions{RecipientTag: inboundgo.String("cust-*")},
+		Actions:    inboundgo.RuleActions{EndpointID: inboundgo.String("ep-crm")},
+	}}
+	if eval := inboundgo.EvaluateRules(rules, payload); eval.EndpointID == nil || *eval.EndpointID != "ep-crm" {
+		t.Errorf("Expected tag rule to match, got %+v", eval)
+	}
+	payload.Email.Recipient = "reply@example.com"
+	if eval := inboundgo.EvaluateRules(rules, payload); len(eval.Matched) != 0 {
+		t.Errorf("Expected untagged recipient not to match, got %+v", eval.Matched)
+	}
+}
@@ -0,0 +1,135 @@
+package inboundgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// SuppressionSync adds recipients to the suppression list as bounce and
+// complaint webhooks arrive. Which events suppress is decided by bounce
+// classification: by default hard bounces and complaints suppress, while
+// soft bounces, blocks, and automatic replies are left alone since the
+// recipient itself is fine.
+//
+// SuppressionSync is an http.Handler for an email.bounced or
+// email.complained webhook endpoint. It responds with 500 when the
+// suppression list cannot be updated so the webhook is retried.
+type SuppressionSync struct {
+	client *Inbound
+	// SuppressBounceTypes lists the bounce types that suppress the recipient
+	// (default hard bounces only).
+	SuppressBounceTypes []BounceType
+	// SuppressComplaints suppresses recipients who report mail as spam
+	// (default true).
+	SuppressComplaints bool
+	// IgnoreFeedbackTypes lists complaint feedback types that never
+	// suppress (default "not-spam").
+	IgnoreFeedbackTypes []string
+	// PerDomain scopes each suppression to the sending domain instead of
+	// the whole account.
+	PerDomain bool
+	// OnSuppress, if set, is called after an address is suppressed.
+	OnSuppress func(ctx context.Context, req *PostSuppressionsRequest)
+}
+
+// NewSuppressionSync creates a sync with the default classification rules
+func NewSuppressionSync(client *Inbound) *SuppressionSync {
+	return &SuppressionSync{
+		client:              client,
+		SuppressBounceTypes: []BounceType{BounceTypeHard},
+		SuppressComplaints:  true,
+		IgnoreFeedbackTypes: []string{"not-spam"},
+	}
+}
+
+// Decide returns the suppression to add for an event, or nil if the event
+// should not suppress anyone
+func (s *SuppressionSync) Decide(payload *DeliveryWebhookPayload) *PostSuppressionsRequest {
+	var req *PostSuppressionsRequest
+	switch {
+	case payload.Bounce != nil:
+		b := payload.Bounce
+		t := b.Classify()
+		if !s.suppressesBounce(t) || b.Recipient == "" {
+			return nil
+		}
+		details := fmt.Sprintf("%s bounce: %s", t, b.Reason)
+		if b.DiagnosticCode != nil && *b.DiagnosticCode != "" {
+			details = fmt.Sprintf("%s bounce: %s", t, *b.DiagnosticCode)
+		}
+		req = &PostSuppressionsRequest{Emails: []string{b.Recipient}, Reason: "hard_bounce", Details: &details}
+		if s.PerDomain && b.Domain != "" {
+			req.Domain = String(b.Domain)
+		}
+	case payload.Complaint != nil:
+		c := payload.Complaint
+		if !s.SuppressComplaints || c.Recipient == "" {
+			return nil
+		}
+		details := "complaint"
+		if c.FeedbackType != nil && *c.FeedbackType != "" {
+			for _, ignored := range s.IgnoreFeedbackTypes {
+				if strings.EqualFold(*c.FeedbackType, ignored) {
+					return nil
+				}
+			}
+			details = "complaint: " + *c.FeedbackType
+		}
+		req = &PostSuppressionsRequest{Emails: []string{c.Recipient}, Reason: "complaint", Details: &details}
+		if s.PerDomain && c.Domain != "" {
+			req.Domain = String(c.Domain)
+		}
+	}
+	return req
+}
+
+// Process suppresses the recipient of an event if the rules call for it. It
+// returns the suppression that was added, or nil if the event was ignored.
+func (s *SuppressionSync) Process(ctx context.Context, payload *DeliveryWebhookPayload) (*PostSuppressionsRequest, error) {
+	req := s.Decide(payload)
+	if req == nil {
+		return nil, nil
+	}
+	resp, err := s.client.Suppression().Add(ctx, req)
+	if err == nil && resp.Error != "" {
+		err = errors.New(resp.Error)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to suppress %s: %w", req.Emails[0], err)
+	}
+	if s.OnSuppress != nil {
+		s.OnSuppress(ctx, req)
+	}
+	return req, nil
+}
+
+// ServeHTTP processes a delivery webhook request
+func (s *SuppressionSync) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	payload, err := ParseDeliveryWebhookPayload(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := s.Process(r.Context(), payload); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *SuppressionSync) suppressesBounce(t BounceType) bool {
+	for _, st := range s.SuppressBounceTypes {
+		if st == t {
+			return true
+		}
+	}
+	return false
+}
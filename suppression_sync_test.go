@@ -0,0 +1,116 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestSuppressionSyncDecide(t *testing.T) {
+	client, err := inboundgo.NewClient("test-api-key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	sync := inboundgo.NewSuppressionSync(client)
+	sync.PerDomain = true
+
+	hard := &inboundgo.DeliveryWebhookPayload{Event: "email.bounced", Bounce: &inboundgo.Bounce{
+		Recipient: "gone@example.com", Type: "hard", Domain: "mail.acme.test",
+		DiagnosticCode: inboundgo.String("550 5.1.1 <gone@example.com>: user unknown"),
+	}}
+	req := sync.Decide(hard)
+	if req == nil || req.Reason != "hard_bounce" || req.Emails[0] != "gone@example.com" {
+		t.Fatalf("Expected hard bounce suppression, got %+v", req)
+	}
+	if req.Domain == nil || *req.Domain != "mail.acme.test" {
+		t.Errorf("Expected per-domain suppression, got %v", req.Domain)
+	}
+
+	// The API reports policy blocks as hard bounces, but the recipient is fine
+	block := &inboundgo.DeliveryWebhookPayload{Event: "email.bounced", Bounce: &inboundgo.Bounce{
+		Recipient: "ok@example.com", Type: "hard",
+		DiagnosticCode: inboundgo.String("554 5.7.1 Service unavailable; listed by Spamhaus"),
+	}}
+	if req := sync.Decide(block); req != nil {
+		t.Errorf("Expected block not to suppress, got %+v", req)
+	}
+	soft := &inboundgo.DeliveryWebhookPayload{Event: "email.bounced", Bounce: &inboundgo.Bounce{
+		Recipient: "full@example.com", Type: "soft", Reason: "mailbox full",
+	}}
+	if req := sync.Decide(soft); req != nil {
+		t.Errorf("Expected soft bounce not to suppress, got %+v", req)
+	}
+	sync.SuppressBounceTypes = append(sync.SuppressBounceTypes, inboundgo.BounceTypeSoft)
+	if req := sync.Decide(soft); req == nil {
+		t.Error("Expected soft bounce to suppress once configured")
+	}
+
+	complaint := &inboundgo.DeliveryWebhookPayload{Event: "email.complained", Complaint: &inboundgo.Complaint{
+		Recipient: "angry@example.com", FeedbackType: inboundgo.String("abuse"),
+	}}
+	if req := sync.Decide(complaint); req == nil || req.Reason != "complaint" || req.Domain != nil {
+		t.Errorf("Expected account-wide complaint suppression, got %+v", req)
+	}
+	complaint.Complaint.FeedbackType = inboundgo.String("not-spam")
+	if req := sync.Decide(complaint); req != nil {
+		t.Errorf("Expected not-spam feedback to be ignored, got %+v", req)
+	}
+}
+
+func TestSuppressionSyncServeHTTP(t *testing.T) {
+	var added []inboundgo.PostSuppressionsRequest
+	fail := false
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != "POST" || r.URL.Path != "/suppressions" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error": "internal error"}`))
+			return
+		}
+		var req inboundgo.PostSuppressionsRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		added = append(added, req)
+		w.Write([]byte(`{"added": 1, "skipped": 0}`))
+	}))
+	defer api.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", api.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	sync := inboundgo.NewSuppressionSync(client)
+	var notified int
+	sync.OnSuppress = func(context.Context, *inboundgo.PostSuppressionsRequest) { notified++ }
+
+	post := func(body string) int {
+		rec := httptest.NewRecorder()
+		sync.ServeHTTP(rec, httptest.NewRequest("POST", "/webhooks/delivery", strings.NewReader(body)))
+		return rec.Code
+	}
+
+	if code := post(`{"event": "email.complained", "complaint": {"recipient": "angry@example.com", "feedbackType": "abuse"}}`); code != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d", code)
+	}
+	if code := post(`{"event": "email.bounced", "bounce": {"recipient": "full@example.com", "type": "soft", "reason": "mailbox full"}}`); code != http.StatusNoContent {
+		t.Errorf("Expected 204 for ignored bounce, got %d", code)
+	}
+	if len(added) != 1 || added[0].Emails[0] != "angry@example.com" || notified != 1 {
+		t.Errorf("Expected one complaint suppression, got %+v (notified %d)", added, notified)
+	}
+	if code := post(`not json`); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for malformed payload, got %d", code)
+	}
+
+	fail = true
+	if code := post(`{"event": "email.bounced", "bounce": {"recipient": "gone@example.com", "type": "hard", "reason": "user unknown"}}`); code != http.StatusInternalServerError {
+		t.Errorf("Expected 500 so the webhook is retried, got %d", code)
+	}
+}
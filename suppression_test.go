@@ -0,0 +1,83 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestSuppressionService(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /suppressions":
+			if r.URL.Query().Get("reason") != "complaint" {
+				t.Errorf("Expected reason filter, got %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"data": [{"email": "angry@example.com", "reason": "complaint", "emailId": "email-9", "createdAt": "2025-01-01T00:00:00Z"}], "pagination": {"total": 1}}`))
+		case "POST /suppressions":
+			var req inboundgo.PostSuppressionsRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if len(req.Emails) != 2 || req.Reason != "manual" {
+				t.Errorf("Unexpected add request: %+v", req)
+			}
+			w.Write([]byte(`{"added": 1, "skipped": 1}`))
+		case "DELETE /suppressions/a+b@example.com":
+			w.Write([]byte(`{"removed": true}`))
+		case "GET /suppressions/check":
+			email := r.URL.Query().Get("email")
+			if email == "angry@example.com" {
+				w.Write([]byte(`{"email": "angry@example.com", "suppressed": true, "suppression": {"email": "angry@example.com", "reason": "complaint"}}`))
+			} else if email == "broken@example.com" {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"error": "internal error"}`))
+			} else {
+				w.Write([]byte(`{"email": "` + email + `", "suppressed": false}`))
+			}
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+	svc := client.Suppression()
+
+	list, err := svc.List(ctx, &inboundgo.GetSuppressionsRequest{Reason: "complaint"})
+	if err != nil || list.Error != "" || len(list.Data.Data) != 1 || *list.Data.Data[0].EmailID != "email-9" {
+		t.Fatalf("List failed: %v %+v", err, list)
+	}
+
+	added, err := svc.Add(ctx, &inboundgo.PostSuppressionsRequest{Emails: []string{"a@example.com", "b@example.com"}, Reason: "manual"})
+	if err != nil || added.Error != "" || added.Data.Added != 1 || added.Data.Skipped != 1 {
+		t.Fatalf("Add failed: %v %+v", err, added)
+	}
+
+	if removed, err := svc.Remove(ctx, "a+b@example.com"); err != nil || removed.Error != "" {
+		t.Errorf("Remove failed: %v %+v", err, removed)
+	}
+
+	check, err := svc.Check(ctx, "angry@example.com")
+	if err != nil || check.Error != "" || !check.Data.Suppressed || check.Data.Suppression.Reason != "complaint" {
+		t.Errorf("Check failed: %v %+v", err, check)
+	}
+
+	if suppressed, err := svc.CheckSuppressed(ctx, "angry@example.com"); err != nil || !suppressed {
+		t.Errorf("Expected angry@example.com to be suppressed, got %v %v", suppressed, err)
+	}
+	if suppressed, err := svc.CheckSuppressed(ctx, "happy@example.com"); err != nil || suppressed {
+		t.Errorf("Expected happy@example.com not to be suppressed, got %v %v", suppressed, err)
+	}
+	if _, err := svc.CheckSuppressed(ctx, "broken@example.com"); err == nil {
+		t.Error("Expected error when the check fails")
+	}
+}
@@ -0,0 +1,161 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestTemplateService(t *testing.T) {
+	const templateJSON = `{
+		"id": "tpl-1",
+		"name": "welcome",
+		"subject": "Welcome {{name}}",
+		"html": "<p>Hi {{name}}</p>",
+		"text": null,
+		"variables": [{"name": "name", "type": "string", "required": true}],
+		"createdAt": "2025-01-01T00:00:00Z",
+		"updatedAt": "2025-01-02T00:00:00Z"
+	}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /templates":
+			var req inboundgo.PostTemplatesRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("Failed to decode request: %v", err)
+			}
+			if req.Name != "welcome" || len(req.Variables) != 1 || !req.Variables[0].Required {
+				t.Errorf("Unexpected create request: %+v", req)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(templateJSON))
+		case "GET /templates":
+			if got := r.URL.Query().Get("search"); got != "welcome" {
+				t.Errorf("Expected search=welcome, got %q", got)
+			}
+			w.Write([]byte(`{"data": [` + templateJSON + `], "pagination": {"limit": 50, "offset": 0, "total": 1}}`))
+		case "GET /templates/tpl-1":
+			w.Write([]byte(templateJSON))
+		case "PUT /templates/tpl-1":
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			if _, ok := body["name"]; ok || body["subject"] != "Hello {{name}}" {
+				t.Errorf("Expected only subject in update, got %v", body)
+			}
+			w.Write([]byte(templateJSON))
+		case "DELETE /templates/tpl-1":
+			w.Write([]byte(`{"message": "Template deleted"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	created, err := client.Template().Create(ctx, &inboundgo.PostTemplatesRequest{
+		Name:      "welcome",
+		Subject:   "Welcome {{name}}",
+		HTML:      inboundgo.String("<p>Hi {{name}}</p>"),
+		Variables: []inboundgo.TemplateVariable{{Name: "name", Type: "string", Required: true}},
+	})
+	if err != nil || created.Error != "" {
+		t.Fatalf("Create failed: %v %s", err, created.Error)
+	}
+	if created.Data.ID != "tpl-1" || created.Data.Variables[0].Name != "name" {
+		t.Errorf("Unexpected template: %+v", created.Data)
+	}
+
+	list, err := client.Template().List(ctx, &inboundgo.GetTemplatesRequest{Search: "welcome"})
+	if err != nil || list.Error != "" || len(list.Data.Data) != 1 {
+		t.Fatalf("List failed: %v %+v", err, list)
+	}
+
+	got, err := client.Template().Get(ctx, "tpl-1")
+	if err != nil || got.Error != "" || got.Data.Subject != "Welcome {{name}}" {
+		t.Fatalf("Get failed: %v %+v", err, got)
+	}
+
+	updated, err := client.Template().Update(ctx, "tpl-1", &inboundgo.PutTemplateByIDRequest{Subject: inboundgo.String("Hello {{name}}")})
+	if err != nil || updated.Error != "" {
+		t.Fatalf("Update failed: %v %+v", err, updated)
+	}
+
+	deleted, err := client.Template().Delete(ctx, "tpl-1")
+	if err != nil || deleted.Error != "" || deleted.Data.Message != "Template deleted" {
+		t.Fatalf("Delete failed: %v %+v", err, deleted)
+	}
+}
+
+func TestSendTemplate(t *testing.T) {
+	var paths []string
+	var bodies []map[string]any
+	var keys []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		paths = append(paths, r.URL.Path)
+		bodies = append(bodies, body)
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/emails/schedule" {
+			w.Write([]byte(`{"id": "sched-1", "scheduled_at": "2025-01-01T09:00:00Z", "status": "scheduled", "timezone": "UTC"}`))
+			return
+		}
+		w.Write([]byte(`{"id": "email-1"}`))
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+	vars := map[string]any{"name": "Ada", "credits": 3}
+
+	sent, err := client.Email().SendTemplate(ctx, "tpl-1", "ada@example.com", vars, &inboundgo.TemplateSendOptions{
+		From:           "hello@yourdomain.com",
+		IdempotencyKey: "welcome-ada",
+	})
+	if err != nil || sent.Error != "" || sent.Data.ID != "email-1" {
+		t.Fatalf("SendTemplate failed: %v %+v", err, sent)
+	}
+
+	scheduled, err := client.Email().ScheduleTemplate(ctx, "tpl-1", []string{"a@example.com", "b@example.com"}, nil, "tomorrow at 9am", &inboundgo.TemplateSendOptions{From: "hello@yourdomain.com"})
+	if err != nil || scheduled.Error != "" || scheduled.Data.Status != "scheduled" {
+		t.Fatalf("ScheduleTemplate failed: %v %+v", err, scheduled)
+	}
+
+	if paths[0] != "/emails" || paths[1] != "/emails/schedule" {
+		t.Errorf("Unexpected paths: %v", paths)
+	}
+	first := bodies[0]
+	if first["templateId"] != "tpl-1" || first["from"] != "hello@yourdomain.com" || first["to"] != "ada@example.com" {
+		t.Errorf("Unexpected send body: %v", first)
+	}
+	if variables, _ := first["variables"].(map[string]any); variables["name"] != "Ada" || variables["credits"] != float64(3) {
+		t.Errorf("Unexpected variables: %v", first["variables"])
+	}
+	if _, ok := first["scheduled_at"]; ok {
+		t.Error("Immediate send must not include scheduled_at")
+	}
+	if keys[0] != "welcome-ada" || keys[1] != "" {
+		t.Errorf("Unexpected idempotency keys: %v", keys)
+	}
+	if bodies[1]["scheduled_at"] != "tomorrow at 9am" {
+		t.Errorf("Unexpected schedule body: %v", bodies[1])
+	}
+}
@@ -0,0 +1,42 @@
+package inboundgo
+
+import (
+	"strings"
+)
+
+// GetHeaders converts the message headers to a standard map[string][]string
+// format, like WebhookPayload.GetHeaders
+func (m *ThreadMessage) GetHeaders() map[string][]string {
+	return normalizeHeaders(m.Headers)
+}
+
+// MessageIDHeader returns the Message-ID header, falling back to the
+// MessageID field, or "" if neither is set
+func (m *ThreadMessage) MessageIDHeader() string {
+	if id := headerValue(m.GetHeaders(), "Message-ID"); id != "" {
+		return id
+	}
+	if m.MessageID != nil {
+		return *m.MessageID
+	}
+	return ""
+}
+
+// AuthenticationResults returns the Authentication-Results headers, the
+// topmost (added by the receiving server) first. Only that first one can be
+// trusted; later ones may have been added by earlier hops.
+func (m *ThreadMessage) AuthenticationResults() []string {
+	return headerValues(m.GetHeaders(), "Authentication-Results")
+}
+
+// headerValues returns every value of the named header, matched
+// case-insensitively
+func headerValues(headers map[string][]string, name string) []string {
+	var values []string
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			values = append(values, v...)
+		}
+	}
+	return values
+}
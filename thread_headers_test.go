@@ -0,0 +1,41 @@
+package inboundgo_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestThreadMessageHeaders(t *testing.T) {
+	var m inboundgo.ThreadMessage
+	err := json.Unmarshal([]byte(`{
+		"id": "msg_1",
+		"messageId": "<fallback@example.com>",
+		"headers": {
+			"message-id": "<abc@mail.example.com>",
+			"authentication-results": ["mx.inbound.new; spf=pass; dkim=pass", "relay.example.com; spf=fail"],
+			"dkim-signature": {"value": "v=1; a=rsa-sha256", "params": {"d": "example.com"}}
+		}
+	}`), &m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	headers := m.GetHeaders()
+	if got := headers["dkim-signature"]; !reflect.DeepEqual(got, []string{"v=1; a=rsa-sha256"}) {
+		t.Errorf("Unexpected dkim-signature %v", got)
+	}
+	if got := m.MessageIDHeader(); got != "<abc@mail.example.com>" {
+		t.Errorf("Unexpected Message-ID %q", got)
+	}
+	if got := m.AuthenticationResults(); len(got) != 2 || got[0] != "mx.inbound.new; spf=pass; dkim=pass" {
+		t.Errorf("Unexpected Authentication-Results %v", got)
+	}
+
+	m.Headers = nil
+	if got := m.MessageIDHeader(); got != "<fallback@example.com>" {
+		t.Errorf("Expected the MessageID field as fallback, got %q", got)
+	}
+}
@@ -0,0 +1,73 @@
+package inboundgo
+
+import (
+	"strings"
+)
+
+// maxReferences caps the References header. The first ID (the thread root)
+// is always kept, then the most recent ones, as RFC 5322 suggests for long
+// threads.
+const maxReferences = 20
+
+// Threading makes an email sent with Email().Send continue a conversation,
+// so mail clients group it with the earlier messages
+type Threading struct {
+	// InReplyTo is the Message-ID of the message being followed up.
+	InReplyTo string
+	// References are the Message-IDs of the conversation so far, oldest
+	// first. InReplyTo is added at the end if missing.
+	References []string
+}
+
+// ReplyingTo continues the conversation of the message with messageID,
+// whose own references may be given oldest first
+func ReplyingTo(messageID string, references ...string) *Threading {
+	return &Threading{InReplyTo: messageID, References: references}
+}
+
+// Threading returns the threading that continues the conversation after m
+func (m *ThreadMessage) Threading() *Threading {
+	t := &Threading{References: m.References}
+	if m.MessageID != nil {
+		t.InReplyTo = *m.MessageID
+	}
+	return t
+}
+
+// headers returns the In-Reply-To and References headers
+func (t *Threading) headers() map[string]string {
+	headers := make(map[string]string)
+	parent := bracketMessageID(t.InReplyTo)
+	if parent != "" {
+		headers["In-Reply-To"] = parent
+	}
+
+	var refs []string
+	seen := make(map[string]bool)
+	for _, ref := range append(append([]string(nil), t.References...), parent) {
+		// Stored References headers may hold several IDs in one string
+		for _, id := range strings.Fields(ref) {
+			id = bracketMessageID(id)
+			if id != "" && !seen[id] {
+				seen[id] = true
+				refs = append(refs, id)
+			}
+		}
+	}
+	if len(refs) > maxReferences {
+		refs = append(refs[:1], refs[len(refs)-maxReferences+1:]...)
+	}
+	if len(refs) > 0 {
+		headers["References"] = strings.Join(refs, " ")
+	}
+	return headers
+}
+
+// bracketMessageID returns id in angle brackets, or "" if it is empty
+func bracketMessageID(id string) string {
+	id = strings.Trim(strings.TrimSpace(id), "<>")
+	if id == "" {
+		return ""
+	}
+	return "<" + id + ">"
+}
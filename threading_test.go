@@ -0,0 +1,75 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestSendWithThreading(t *testing.T) {
+	var got struct {
+		Headers map[string]string `json:"headers"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got.Headers = nil
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"id":"email_1"}`))
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+
+	req := &inboundgo.PostEmailsRequest{
+		From:      "support@acme.test",
+		Headers:   map[string]string{"X-Ticket": "42", "in-reply-to": "<stale@x>"},
+		Threading: inboundgo.ReplyingTo("msg-2@mail.test", "<msg-0@mail.test> <msg-1@mail.test>"),
+	}
+	if _, err := client.Email().Send(context.Background(), req, nil); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	want := map[string]string{
+		"X-Ticket":    "42",
+		"In-Reply-To": "<msg-2@mail.test>",
+		"References":  "<msg-0@mail.test> <msg-1@mail.test> <msg-2@mail.test>",
+	}
+	if fmt.Sprint(got.Headers) != fmt.Sprint(want) {
+		t.Errorf("Got headers %v, want %v", got.Headers, want)
+	}
+	if len(req.Headers) != 2 {
+		t.Error("Send should not modify the request headers")
+	}
+
+	messageID := "<msg-3@mail.test>"
+	thread := &inboundgo.ThreadMessage{MessageID: &messageID, References: []string{"<msg-0@mail.test>", "<msg-3@mail.test>"}}
+	client.Email().Send(context.Background(), &inboundgo.PostEmailsRequest{Threading: thread.Threading()}, nil)
+	if got.Headers["In-Reply-To"] != messageID || got.Headers["References"] != "<msg-0@mail.test> <msg-3@mail.test>" {
+		t.Errorf("Unexpected headers %v", got.Headers)
+	}
+}
+
+func TestThreadingCapsReferences(t *testing.T) {
+	var refs []string
+	for i := 0; i < 30; i++ {
+		refs = append(refs, fmt.Sprintf("<m%d@x>", i))
+	}
+	var got struct {
+		Headers map[string]string `json:"headers"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"id":"email_1"}`))
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+
+	client.Email().Send(context.Background(), &inboundgo.PostEmailsRequest{Threading: inboundgo.ReplyingTo("<m30@x>", refs...)}, nil)
+	ids := strings.Fields(got.Headers["References"])
+	if len(ids) != 20 || ids[0] != "<m0@x>" || ids[1] != "<m12@x>" || ids[19] != "<m30@x>" {
+		t.Errorf("Unexpected references %v", ids)
+	}
+}
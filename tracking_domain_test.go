@@ -0,0 +1,90 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestTrackingDomain(t *testing.T) {
+	verifyCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /domains/dom-1/tracking-domain":
+			var req inboundgo.PostTrackingDomainRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Hostname != "track.example.com" {
+				t.Errorf("Unexpected hostname %q", req.Hostname)
+			}
+			w.Write([]byte(`{"domainId": "dom-1", "hostname": "track.example.com", "status": "pending",
+				"dnsRecords": [{"type": "CNAME", "name": "track.example.com", "value": "t.inbound.new"}]}`))
+		case "POST /domains/dom-1/tracking-domain/verify":
+			verifyCalls++
+			switch verifyCalls {
+			case 1:
+				w.Write([]byte(`{"domainId": "dom-1", "hostname": "track.example.com", "status": "failed"}`))
+			case 2:
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte(`{"error": "dns lookup timed out"}`))
+			default:
+				w.Write([]byte(`{"domainId": "dom-1", "hostname": "track.example.com", "status": "verified",
+					"dnsRecords": [{"type": "CNAME", "name": "track.example.com", "value": "t.inbound.new", "isVerified": true}]}`))
+			}
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	created, err := client.Domain().CreateTrackingDomain(ctx, "dom-1", &inboundgo.PostTrackingDomainRequest{Hostname: "track.example.com"})
+	if err != nil || created.Error != "" {
+		t.Fatalf("CreateTrackingDomain failed: %v %+v", err, created)
+	}
+	if len(created.Data.DNSRecords) != 1 || created.Data.DNSRecords[0].Type != "CNAME" {
+		t.Errorf("Unexpected DNS records: %+v", created.Data.DNSRecords)
+	}
+
+	td, err := client.Domain().WaitForTrackingDomain(ctx, "dom-1", time.Millisecond)
+	if err != nil {
+		t.Fatalf("WaitForTrackingDomain failed: %v", err)
+	}
+	if td.Status != "verified" || !td.DNSRecords[0].IsVerified || verifyCalls != 3 {
+		t.Errorf("Unexpected result after %d calls: %+v", verifyCalls, td)
+	}
+}
+
+func TestWaitForTrackingDomainContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"domainId": "dom-1", "hostname": "track.example.com", "status": "pending"}`))
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	td, err := client.Domain().WaitForTrackingDomain(ctx, "dom-1", 5*time.Millisecond)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("Expected deadline exceeded, got %v", err)
+	}
+	if td == nil || td.Status != "pending" {
+		t.Errorf("Expected last known state, got %+v", td)
+	}
+}
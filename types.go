@@ -15,6 +15,12 @@ type InboundEmailConfig struct {
 type ApiResponse[T any] struct {
 	Data  *T     `json:"data,omitempty"`
 	Error string `json:"error,omitempty"`
+	// StatusCode is the HTTP status of the response, or 0 when the request
+	// never received one (network failure, cancelled context).
+	StatusCode int `json:"-"`
+	// KeyIndex is the API key that served the request: 0 for the primary
+	// key, then 1 and up for the keys passed to WithFallbackAPIKeys.
+	KeyIndex int `json:"-"`
 }
 
 // Pagination interface
@@ -31,6 +37,25 @@ type IdempotencyOptions struct {
 }
 
 // Mail API Types
+
+// MailStatus filters received emails by processing outcome
+type MailStatus string
+
+const (
+	MailStatusAll       MailStatus = "all"
+	MailStatusProcessed MailStatus = "processed"
+	MailStatusFailed    MailStatus = "failed"
+)
+
+// Valid reports whether s is a known mail status
+func (s MailStatus) Valid() bool {
+	switch s {
+	case MailStatusAll, MailStatusProcessed, MailStatusFailed:
+		return true
+	}
+	return false
+}
+
 type EmailItem struct {
 	ID              string     `json:"id"`
 	EmailID         string     `json:"emailId"`
@@ -53,15 +78,15 @@ type EmailItem struct {
 }
 
 type GetMailRequest struct {
-	Limit           *int   `json:"limit,omitempty"`
-	Offset          *int   `json:"offset,omitempty"`
-	Search          string `json:"search,omitempty"`
-	Status          string `json:"status,omitempty"` // 'all' | 'processed' | 'failed'
-	Domain          string `json:"domain,omitempty"`
-	TimeRange       string `json:"timeRange,omitempty"` // '24h' | '7d' | '30d' | '90d'
-	IncludeArchived *bool  `json:"includeArchived,omitempty"`
-	EmailAddress    string `json:"emailAddress,omitempty"`
-	EmailID         string `json:"emailId,omitempty"`
+	Limit           *int       `json:"limit,omitempty"`
+	Offset          *int       `json:"offset,omitempty"`
+	Search          string     `json:"search,omitempty"`
+	Status          MailStatus `json:"status,omitempty"`
+	Domain          string     `json:"domain,omitempty"`
+	TimeRange       string     `json:"timeRange,omitempty"` // '24h' | '7d' | '30d' | '90d'
+	IncludeArchived *bool      `json:"includeArchived,omitempty"`
+	EmailAddress    string     `json:"emailAddress,omitempty"`
+	EmailID         string     `json:"emailId,omitempty"`
 }
 
 type GetMailResponse struct {
@@ -91,9 +116,35 @@ type GetMailByIDResponse struct {
 	HTMLBody    string    `json:"htmlBody"`
 	ReceivedAt  time.Time `json:"receivedAt"`
 	Attachments []any     `json:"attachments"`
+	// Verdicts holds SPF/DKIM/DMARC and spam results when the API provides them
+	Verdicts *AuthVerdicts `json:"verdicts,omitempty"`
+}
+
+type DeleteMailByIDResponse struct {
+	Message            string `json:"message"`
+	DeletedAttachments int    `json:"deletedAttachments"`
 }
 
 // Endpoints API Types
+
+// EndpointType is the kind of destination an endpoint delivers to
+type EndpointType string
+
+const (
+	EndpointTypeWebhook    EndpointType = "webhook"
+	EndpointTypeEmail      EndpointType = "email"
+	EndpointTypeEmailGroup EndpointType = "email_group"
+)
+
+// Valid reports whether t is a known endpoint type
+func (t EndpointType) Valid() bool {
+	switch t {
+	case EndpointTypeWebhook, EndpointTypeEmail, EndpointTypeEmailGroup:
+		return true
+	}
+	return false
+}
+
 type WebhookConfig struct {
 	URL           string            `json:"url"`
 	Timeout       int               `json:"timeout"`
@@ -119,7 +170,7 @@ type DeliveryStats struct {
 type EndpointWithStats struct {
 	ID            string        `json:"id"`
 	Name          string        `json:"name"`
-	Type          string        `json:"type"`   // 'webhook' | 'email' | 'email_group'
+	Type          EndpointType  `json:"type"`
 	Config        any           `json:"config"` // WebhookConfig | EmailConfig | EmailGroupConfig
 	IsActive      bool          `json:"isActive"`
 	Description   *string       `json:"description"`
@@ -131,10 +182,10 @@ type EndpointWithStats struct {
 }
 
 type GetEndpointsRequest struct {
-	Limit  *int   `json:"limit,omitempty"`
-	Offset *int   `json:"offset,omitempty"`
-	Type   string `json:"type,omitempty"`   // 'webhook' | 'email' | 'email_group'
-	Active string `json:"active,omitempty"` // 'true' | 'false'
+	Limit  *int         `json:"limit,omitempty"`
+	Offset *int         `json:"offset,omitempty"`
+	Type   EndpointType `json:"type,omitempty"`
+	Active string       `json:"active,omitempty"` // 'true' | 'false'
 }
 
 type GetEndpointsResponse struct {
@@ -143,35 +194,72 @@ type GetEndpointsResponse struct {
 }
 
 type PostEndpointsRequest struct {
-	Name        string  `json:"name"`
-	Type        string  `json:"type"` // 'webhook' | 'email' | 'email_group'
-	Description *string `json:"description,omitempty"`
-	Config      any     `json:"config"` // WebhookConfig | EmailConfig | EmailGroupConfig
+	Name        string       `json:"name"`
+	Type        EndpointType `json:"type"`
+	Description *string      `json:"description,omitempty"`
+	Config      any          `json:"config"` // WebhookConfig | EmailConfig | EmailGroupConfig
 }
 
 type PostEndpointsResponse struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Type        string    `json:"type"`
-	Config      any       `json:"config"`
-	IsActive    bool      `json:"isActive"`
-	Description *string   `json:"description"`
-	CreatedAt   time.Time `json:"createdAt"`
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Type        EndpointType `json:"type"`
+	Config      any          `json:"config"`
+	IsActive    bool         `json:"isActive"`
+	Description *string      `json:"description"`
+	CreatedAt   time.Time    `json:"createdAt"`
 }
 
 type GetEndpointByIDResponse struct {
-	ID               string        `json:"id"`
-	Name             string        `json:"name"`
-	Type             string        `json:"type"`
-	Config           any           `json:"config"`
-	IsActive         bool          `json:"isActive"`
-	Description      *string       `json:"description"`
-	DeliveryStats    DeliveryStats `json:"deliveryStats"`
-	RecentDeliveries []any         `json:"recentDeliveries"`
-	AssociatedEmails []any         `json:"associatedEmails"`
-	CatchAllDomains  []any         `json:"catchAllDomains"`
-	CreatedAt        time.Time     `json:"createdAt"`
-	UpdatedAt        time.Time     `json:"updatedAt"`
+	ID               string                   `json:"id"`
+	Name             string                   `json:"name"`
+	Type             EndpointType             `json:"type"`
+	Config           any                      `json:"config"`
+	IsActive         bool                     `json:"isActive"`
+	Description      *string                  `json:"description"`
+	DeliveryStats    DeliveryStats            `json:"deliveryStats"`
+	RecentDeliveries []EndpointDelivery       `json:"recentDeliveries"`
+	AssociatedEmails []EndpointEmailAddress   `json:"associatedEmails"`
+	CatchAllDomains  []EndpointCatchAllDomain `json:"catchAllDomains"`
+	CreatedAt        time.Time                `json:"createdAt"`
+	UpdatedAt        time.Time                `json:"updatedAt"`
+}
+
+// EndpointDelivery is a recent attempt to deliver an email to an endpoint
+type EndpointDelivery struct {
+	ID            string     `json:"id"`
+	EmailID       string     `json:"emailId"`
+	DeliveryType  string     `json:"deliveryType"` // 'webhook' | 'email'
+	Status        string     `json:"status"`       // 'pending' | 'success' | 'failed'
+	Attempts      int        `json:"attempts"`
+	LastAttemptAt *time.Time `json:"lastAttemptAt"`
+	ResponseData  any        `json:"responseData"` // Webhook response status and body, if any
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+// EndpointTestResult is the outcome of a test delivery to an endpoint
+type EndpointTestResult struct {
+	Success      bool    `json:"success"`
+	Message      string  `json:"message"`
+	ResponseTime int     `json:"responseTime"` // milliseconds
+	StatusCode   *int    `json:"statusCode,omitempty"`
+	ResponseBody *string `json:"responseBody,omitempty"`
+	Error        *string `json:"error,omitempty"`
+}
+
+// EndpointEmailAddress is an email address that routes to an endpoint
+type EndpointEmailAddress struct {
+	ID        string    `json:"id"`
+	Address   string    `json:"address"`
+	IsActive  bool      `json:"isActive"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// EndpointCatchAllDomain is a domain whose catch-all routes to an endpoint
+type EndpointCatchAllDomain struct {
+	ID     string       `json:"id"`
+	Domain string       `json:"domain"`
+	Status DomainStatus `json:"status"`
 }
 
 type PutEndpointByIDRequest struct {
@@ -191,23 +279,60 @@ type PutEndpointByIDResponse struct {
 }
 
 type DeleteEndpointByIDResponse struct {
-	Message string `json:"message"`
-	Cleanup struct {
-		EmailAddressesUpdated int   `json:"emailAddressesUpdated"`
-		DomainsUpdated        int   `json:"domainsUpdated"`
-		GroupEmailsDeleted    int   `json:"groupEmailsDeleted"`
-		DeliveriesDeleted     int   `json:"deliveriesDeleted"`
-		EmailAddresses        []any `json:"emailAddresses"`
-		Domains               []any `json:"domains"`
-	} `json:"cleanup"`
+	Message string          `json:"message"`
+	Cleanup EndpointCleanup `json:"cleanup"`
+}
+
+// EndpointCleanup describes the routes changed by deleting an endpoint. The
+// email addresses and catch-all domains that used it no longer route
+// anywhere.
+type EndpointCleanup struct {
+	EmailAddressesUpdated int                `json:"emailAddressesUpdated"`
+	DomainsUpdated        int                `json:"domainsUpdated"`
+	GroupEmailsDeleted    int                `json:"groupEmailsDeleted"`
+	DeliveriesDeleted     int                `json:"deliveriesDeleted"`
+	EmailAddresses        []CleanedUpAddress `json:"emailAddresses"`
+	Domains               []CleanedUpDomain  `json:"domains"`
+}
+
+// CleanedUpAddress is an email address unrouted by deleting an endpoint
+type CleanedUpAddress struct {
+	ID      string `json:"id"`
+	Address string `json:"address"`
+}
+
+// CleanedUpDomain is a domain whose catch-all was unrouted by deleting an
+// endpoint
+type CleanedUpDomain struct {
+	ID     string `json:"id"`
+	Domain string `json:"domain"`
 }
 
 // Domains API Types
+
+// DomainStatus is the verification state of a domain
+type DomainStatus string
+
+const (
+	DomainStatusPending  DomainStatus = "pending"
+	DomainStatusVerified DomainStatus = "verified"
+	DomainStatusFailed   DomainStatus = "failed"
+)
+
+// Valid reports whether s is a known domain status
+func (s DomainStatus) Valid() bool {
+	switch s {
+	case DomainStatusPending, DomainStatusVerified, DomainStatusFailed:
+		return true
+	}
+	return false
+}
+
 type CatchAllEndpoint struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	Type     string `json:"type"`
-	IsActive bool   `json:"isActive"`
+	ID       string       `json:"id"`
+	Name     string       `json:"name"`
+	Type     EndpointType `json:"type"`
+	IsActive bool         `json:"isActive"`
 }
 
 type DNSRecord struct {
@@ -235,7 +360,7 @@ type DomainStats struct {
 type DomainWithStats struct {
 	ID                 string             `json:"id"`
 	Domain             string             `json:"domain"`
-	Status             string             `json:"status"`
+	Status             DomainStatus       `json:"status"`
 	CanReceiveEmails   bool               `json:"canReceiveEmails"`
 	HasMXRecords       bool               `json:"hasMxRecords"`
 	DomainProvider     *string            `json:"domainProvider"`
@@ -254,11 +379,11 @@ type DomainWithStats struct {
 }
 
 type GetDomainsRequest struct {
-	Limit      *int   `json:"limit,omitempty"`
-	Offset     *int   `json:"offset,omitempty"`
-	Status     string `json:"status,omitempty"`     // 'pending' | 'verified' | 'failed'
-	CanReceive string `json:"canReceive,omitempty"` // 'true' | 'false'
-	Check      string `json:"check,omitempty"`      // 'true' | 'false'
+	Limit      *int         `json:"limit,omitempty"`
+	Offset     *int         `json:"offset,omitempty"`
+	Status     DomainStatus `json:"status,omitempty"`
+	CanReceive string       `json:"canReceive,omitempty"` // 'true' | 'false'
+	Check      string       `json:"check,omitempty"`      // 'true' | 'false'
 }
 
 type GetDomainsResponse struct {
@@ -277,17 +402,27 @@ type PostDomainsRequest struct {
 }
 
 type PostDomainsResponse struct {
-	ID         string      `json:"id"`
-	Domain     string      `json:"domain"`
-	Status     string      `json:"status"`
-	DNSRecords []DNSRecord `json:"dnsRecords"`
-	CreatedAt  time.Time   `json:"createdAt"`
+	ID         string       `json:"id"`
+	Domain     string       `json:"domain"`
+	Status     DomainStatus `json:"status"`
+	DNSRecords []DNSRecord  `json:"dnsRecords"`
+	CreatedAt  time.Time    `json:"createdAt"`
+}
+
+// SetupDomainResult is the outcome of Inbound.SetupDomain
+type SetupDomainResult struct {
+	Domain *PostDomainsResponse
+	// Endpoint is the webhook endpoint, if a webhook URL was given.
+	Endpoint *PostEndpointsResponse
+	// DNSRecords are the records to add at the DNS provider to verify the
+	// domain.
+	DNSRecords []DNSRecord
 }
 
 type GetDomainByIDResponse struct {
 	ID                 string            `json:"id"`
 	Domain             string            `json:"domain"`
-	Status             string            `json:"status"`
+	Status             DomainStatus      `json:"status"`
 	CanReceiveEmails   bool              `json:"canReceiveEmails"`
 	IsCatchAllEnabled  bool              `json:"isCatchAllEnabled"`
 	CatchAllEndpointID *string           `json:"catchAllEndpointId"`
@@ -297,9 +432,13 @@ type GetDomainByIDResponse struct {
 	UpdatedAt          time.Time         `json:"updatedAt"`
 }
 
+// PutDomainByIDRequest changes only the settings that are set, so updates
+// of different settings do not undo each other
 type PutDomainByIDRequest struct {
-	IsCatchAllEnabled  bool    `json:"isCatchAllEnabled"`
-	CatchAllEndpointID *string `json:"catchAllEndpointId"`
+	IsCatchAllEnabled  *bool   `json:"isCatchAllEnabled,omitempty"`
+	CatchAllEndpointID *string `json:"catchAllEndpointId,omitempty"`
+	// ClearCatchAllEndpoint removes the catch-all endpoint.
+	ClearCatchAllEndpoint bool `json:"-"`
 }
 
 type PutDomainByIDResponse struct {
@@ -311,11 +450,83 @@ type PutDomainByIDResponse struct {
 	UpdatedAt          time.Time         `json:"updatedAt"`
 }
 
+// ReputationWindow selects the period domain reputation metrics cover
+type ReputationWindow string
+
+const (
+	ReputationWindow24h ReputationWindow = "24h"
+	ReputationWindow7d  ReputationWindow = "7d"
+	ReputationWindow30d ReputationWindow = "30d"
+)
+
+type GetDomainReputationRequest struct {
+	Window ReputationWindow `json:"window,omitempty"` // Defaults to 7d on the server
+}
+
+type ReputationSignal struct {
+	Provider  string     `json:"provider"` // e.g. 'gmail' | 'microsoft' | 'yahoo'
+	Status    string     `json:"status"`   // 'good' | 'fair' | 'poor' | 'unknown'
+	Score     *float64   `json:"score"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+}
+
+type GetDomainReputationResponse struct {
+	DomainID      string             `json:"domainId"`
+	Domain        string             `json:"domain"`
+	Window        ReputationWindow   `json:"window"`
+	PeriodStart   time.Time          `json:"periodStart"`
+	PeriodEnd     time.Time          `json:"periodEnd"`
+	Sent          int                `json:"sent"`
+	Delivered     int                `json:"delivered"`
+	Bounced       int                `json:"bounced"`
+	Complained    int                `json:"complained"`
+	DeliveryRate  float64            `json:"deliveryRate"`  // 0-1
+	BounceRate    float64            `json:"bounceRate"`    // 0-1
+	ComplaintRate float64            `json:"complaintRate"` // 0-1
+	Signals       []ReputationSignal `json:"signals"`
+}
+
+// TrackingDomain is a custom hostname used for open and click tracking links
+// instead of the shared Inbound tracking host
+type TrackingDomain struct {
+	DomainID      string      `json:"domainId"`
+	Hostname      string      `json:"hostname"` // e.g. 'track.example.com'
+	Status        string      `json:"status"`   // 'pending' | 'verified' | 'failed'
+	DNSRecords    []DNSRecord `json:"dnsRecords"`
+	LastCheckedAt *time.Time  `json:"lastCheckedAt"`
+	CreatedAt     time.Time   `json:"createdAt"`
+}
+
+type PostTrackingDomainRequest struct {
+	Hostname string `json:"hostname"`
+}
+
+// DomainBIMI is the BIMI (brand logo) configuration of a domain
+type DomainBIMI struct {
+	DomainID  string    `json:"domainId"`
+	Selector  string    `json:"selector"`
+	LogoURL   string    `json:"logoUrl"`
+	VMCURL    *string   `json:"vmcUrl"`
+	Status    string    `json:"status"` // 'pending' | 'verified' | 'failed'
+	DNSRecord DNSRecord `json:"dnsRecord"`
+	// DMARCCompliant is false when the domain's DMARC policy is weaker than
+	// quarantine, which mailbox providers require before displaying a logo
+	DMARCCompliant bool       `json:"dmarcCompliant"`
+	Errors         []string   `json:"errors,omitempty"`
+	LastCheckedAt  *time.Time `json:"lastCheckedAt"`
+}
+
+type PutDomainBIMIRequest struct {
+	LogoURL  string  `json:"logoUrl"`            // HTTPS URL of an SVG Tiny PS logo
+	VMCURL   *string `json:"vmcUrl,omitempty"`   // HTTPS URL of the Verified Mark Certificate (PEM)
+	Selector string  `json:"selector,omitempty"` // Defaults to 'default'
+}
+
 // Email Addresses API Types
 type DomainInfo struct {
-	ID     string `json:"id"`
-	Name   string `json:"name"`
-	Status string `json:"status"`
+	ID     string       `json:"id"`
+	Name   string       `json:"name"`
+	Status DomainStatus `json:"status"`
 }
 
 type RoutingInfo struct {
@@ -393,6 +604,14 @@ type PutEmailAddressByIDRequest struct {
 	WebhookID  *string `json:"webhookId,omitempty"`
 }
 
+type ConfigureReceiptRuleResponse struct {
+	ID                      string    `json:"id"`
+	Address                 string    `json:"address"`
+	IsReceiptRuleConfigured bool      `json:"isReceiptRuleConfigured"`
+	ReceiptRuleName         *string   `json:"receiptRuleName"`
+	UpdatedAt               time.Time `json:"updatedAt"`
+}
+
 type PutEmailAddressByIDResponse struct {
 	ID        string      `json:"id"`
 	Address   string      `json:"address"`
@@ -411,6 +630,33 @@ type DeleteEmailAddressByIDResponse struct {
 	} `json:"cleanup"`
 }
 
+// AutoReply is the automatic reply (vacation responder) of an email address
+type AutoReply struct {
+	EmailAddressID string     `json:"emailAddressId"`
+	Enabled        bool       `json:"enabled"`
+	Subject        string     `json:"subject"`
+	Text           *string    `json:"text"`
+	HTML           *string    `json:"html"`
+	TemplateID     *string    `json:"templateId"` // Rendered with the original sender and subject as variables
+	StartsAt       *time.Time `json:"startsAt"`   // Replies are only sent inside the active window
+	EndsAt         *time.Time `json:"endsAt"`
+	// SenderCooldownHours caps replies to at most one per sender in this
+	// many hours
+	SenderCooldownHours int       `json:"senderCooldownHours"`
+	UpdatedAt           time.Time `json:"updatedAt"`
+}
+
+type PutAutoReplyRequest struct {
+	Enabled             *bool      `json:"enabled,omitempty"` // Defaults to true
+	Subject             string     `json:"subject"`
+	Text                *string    `json:"text,omitempty"`
+	HTML                *string    `json:"html,omitempty"`
+	TemplateID          *string    `json:"templateId,omitempty"`
+	StartsAt            *time.Time `json:"startsAt,omitempty"`
+	EndsAt              *time.Time `json:"endsAt,omitempty"`
+	SenderCooldownHours *int       `json:"senderCooldownHours,omitempty"` // Defaults to 24
+}
+
 // Enhanced attachment interface supporting both remote and base64 content
 type AttachmentData struct {
 	Path        *string `json:"path,omitempty"`        // Remote file URL
@@ -440,6 +686,18 @@ type PostEmailsRequest struct {
 	Tags        []EmailTag        `json:"tags,omitempty"`
 	ScheduledAt *string           `json:"scheduled_at,omitempty"` // Schedule email to be sent later
 	Timezone    *string           `json:"timezone,omitempty"`     // User's timezone for natural language parsing
+
+	// Body, when set, is rendered into HTML and Text by the client's
+	// BodyRenderer before sending.
+	Body *BodyTemplate `json:"-"`
+	// InlineCSS overrides the client's CSS inlining setting for this email.
+	InlineCSS *bool `json:"-"`
+	// NoSignature sends the email without the signature configured for
+	// From.
+	NoSignature bool `json:"-"`
+	// Threading sets In-Reply-To and References to continue a
+	// conversation, replacing any set in Headers.
+	Threading *Threading `json:"-"`
 }
 
 type PostEmailsResponse struct {
@@ -451,18 +709,67 @@ type PostEmailsResponse struct {
 }
 
 type GetEmailByIDResponse struct {
-	Object    string    `json:"object"`
-	ID        string    `json:"id"`
-	From      string    `json:"from"`
-	To        []string  `json:"to"`
-	CC        []string  `json:"cc"`
-	BCC       []string  `json:"bcc"`
-	ReplyTo   []string  `json:"reply_to"`
-	Subject   string    `json:"subject"`
-	Text      string    `json:"text"`
-	HTML      string    `json:"html"`
-	CreatedAt time.Time `json:"created_at"`
-	LastEvent string    `json:"last_event"` // 'pending' | 'delivered' | 'failed'
+	Object  string   `json:"object"`
+	ID      string   `json:"id"`
+	From    string   `json:"from"`
+	To      []string `json:"to"`
+	CC      []string `json:"cc"`
+	BCC     []string `json:"bcc"`
+	ReplyTo []string `json:"reply_to"`
+	Subject string   `json:"subject"`
+	Text    string   `json:"text"`
+	HTML    string   `json:"html"`
+	// Headers and Tags are the custom headers and tags the email was sent
+	// with, when the API returns them
+	Headers   map[string]string `json:"headers,omitempty"`
+	Tags      []EmailTag        `json:"tags,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	// Deprecated: LastEvent only reports the most recent status; use
+	// EmailService.ListEvents for the full event timeline.
+	LastEvent string `json:"last_event"` // 'pending' | 'delivered' | 'failed'
+}
+
+// EmailEventType identifies a step in a sent email's lifecycle
+type EmailEventType string
+
+const (
+	EmailEventQueued     EmailEventType = "queued"
+	EmailEventSent       EmailEventType = "sent"
+	EmailEventDelivered  EmailEventType = "delivered"
+	EmailEventOpened     EmailEventType = "opened"
+	EmailEventClicked    EmailEventType = "clicked"
+	EmailEventBounced    EmailEventType = "bounced"
+	EmailEventComplained EmailEventType = "complained"
+	EmailEventFailed     EmailEventType = "failed"
+)
+
+type EmailEvent struct {
+	Type       EmailEventType `json:"type"`
+	Timestamp  time.Time      `json:"timestamp"`
+	Recipient  *string        `json:"recipient"`
+	URL        *string        `json:"url"`         // Clicked link (clicked)
+	UserAgent  *string        `json:"user_agent"`  // (opened, clicked)
+	IPAddress  *string        `json:"ip_address"`  // (opened, clicked)
+	BounceType *string        `json:"bounce_type"` // 'hard' | 'soft' (bounced)
+	Reason     *string        `json:"reason"`      // Bounce, complaint, or failure reason
+}
+
+type GetEmailEventsResponse struct {
+	EmailID string       `json:"email_id"`
+	Events  []EmailEvent `json:"events"` // Oldest first
+}
+
+type GetEmailsRequest struct {
+	Limit  *int       `json:"limit,omitempty"`
+	Offset *int       `json:"offset,omitempty"`
+	Tag    *EmailTag  `json:"tag,omitempty"` // Emails sent with this tag
+	Since  *time.Time `json:"since,omitempty"`
+	Until  *time.Time `json:"until,omitempty"`
+}
+
+type GetEmailsResponse struct {
+	Data       []GetEmailByIDResponse `json:"data"` // Newest first
+	Pagination Pagination             `json:"pagination"`
 }
 
 // Reply API Types
@@ -482,6 +789,10 @@ type PostEmailReplyRequest struct {
 	IncludeOriginal *bool             `json:"includeOriginal,omitempty"`
 	ReplyAll        *bool             `json:"replyAll,omitempty"`
 	Simple          *bool             `json:"simple,omitempty"`
+
+	// NoSignature sends the reply without the signature configured for
+	// From.
+	NoSignature bool `json:"-"`
 }
 
 type PostEmailReplyResponse struct {
@@ -494,6 +805,27 @@ type PostEmailReplyResponse struct {
 }
 
 // Email Scheduling API Types
+
+// ScheduledStatus is the state of a scheduled email
+type ScheduledStatus string
+
+const (
+	ScheduledStatusScheduled ScheduledStatus = "scheduled"
+	ScheduledStatusSending   ScheduledStatus = "sending"
+	ScheduledStatusSent      ScheduledStatus = "sent"
+	ScheduledStatusFailed    ScheduledStatus = "failed"
+	ScheduledStatusCancelled ScheduledStatus = "cancelled"
+)
+
+// Valid reports whether s is a known scheduled email status
+func (s ScheduledStatus) Valid() bool {
+	switch s {
+	case ScheduledStatusScheduled, ScheduledStatusSending, ScheduledStatusSent, ScheduledStatusFailed, ScheduledStatusCancelled:
+		return true
+	}
+	return false
+}
+
 type PostScheduleEmailRequest struct {
 	From        string            `json:"from"`
 	To          any               `json:"to"` // string or []string
@@ -511,29 +843,29 @@ type PostScheduleEmailRequest struct {
 }
 
 type PostScheduleEmailResponse struct {
-	ID          string `json:"id"`
-	ScheduledAt string `json:"scheduled_at"` // Normalized ISO 8601 timestamp
-	Status      string `json:"status"`       // 'scheduled'
-	Timezone    string `json:"timezone"`
+	ID          string          `json:"id"`
+	ScheduledAt string          `json:"scheduled_at"` // Normalized ISO 8601 timestamp
+	Status      ScheduledStatus `json:"status"`
+	Timezone    string          `json:"timezone"`
 }
 
 type GetScheduledEmailsRequest struct {
-	Limit  *int   `json:"limit,omitempty"`
-	Offset *int   `json:"offset,omitempty"`
-	Status string `json:"status,omitempty"` // Filter by status
+	Limit  *int            `json:"limit,omitempty"`
+	Offset *int            `json:"offset,omitempty"`
+	Status ScheduledStatus `json:"status,omitempty"`
 }
 
 type ScheduledEmailItem struct {
-	ID          string   `json:"id"`
-	From        string   `json:"from"`
-	To          []string `json:"to"`
-	Subject     string   `json:"subject"`
-	ScheduledAt string   `json:"scheduled_at"`
-	Status      string   `json:"status"`
-	Timezone    string   `json:"timezone"`
-	CreatedAt   string   `json:"created_at"`
-	Attempts    int      `json:"attempts"`
-	LastError   *string  `json:"last_error,omitempty"`
+	ID          string          `json:"id"`
+	From        string          `json:"from"`
+	To          []string        `json:"to"`
+	Subject     string          `json:"subject"`
+	ScheduledAt string          `json:"scheduled_at"`
+	Status      ScheduledStatus `json:"status"`
+	Timezone    string          `json:"timezone"`
+	CreatedAt   string          `json:"created_at"`
+	Attempts    int             `json:"attempts"`
+	LastError   *string         `json:"last_error,omitempty"`
 }
 
 type GetScheduledEmailsResponse struct {
@@ -556,7 +888,7 @@ type GetScheduledEmailResponse struct {
 	Tags        []EmailTag        `json:"tags,omitempty"`
 	ScheduledAt string            `json:"scheduled_at"`
 	Timezone    string            `json:"timezone"`
-	Status      string            `json:"status"`
+	Status      ScheduledStatus   `json:"status"`
 	Attempts    int               `json:"attempts"`
 	MaxAttempts int               `json:"max_attempts"`
 	NextRetryAt *string           `json:"next_retry_at,omitempty"`
@@ -568,9 +900,9 @@ type GetScheduledEmailResponse struct {
 }
 
 type DeleteScheduledEmailResponse struct {
-	ID          string `json:"id"`
-	Status      string `json:"status"` // 'cancelled'
-	CancelledAt string `json:"cancelled_at"`
+	ID          string          `json:"id"`
+	Status      ScheduledStatus `json:"status"`
+	CancelledAt string          `json:"cancelled_at"`
 }
 
 // Threads API Types
@@ -608,12 +940,28 @@ type GetThreadsRequest struct {
 	Address  string `json:"address,omitempty"`
 }
 
+// GetThreadsFilters echoes the filters a thread list was made with. Its
+// fields have the types of the matching GetThreadsRequest fields.
 type GetThreadsFilters struct {
-	Search       *string `json:"search,omitempty"`
-	UnreadOnly   *bool   `json:"unreadOnly,omitempty"`
-	ArchivedOnly *bool   `json:"archivedOnly,omitempty"`
-	Domain       *string `json:"domain,omitempty"`
-	Address      *string `json:"address,omitempty"`
+	Search       string `json:"search,omitempty"`
+	UnreadOnly   *bool  `json:"unreadOnly,omitempty"`
+	ArchivedOnly *bool  `json:"archivedOnly,omitempty"`
+	Domain       string `json:"domain,omitempty"`
+	Address      string `json:"address,omitempty"`
+}
+
+// Request returns a list request with the same filters, for fetching
+// another page of the same listing
+func (f GetThreadsFilters) Request(limit, offset int) *GetThreadsRequest {
+	return &GetThreadsRequest{
+		Limit:    Int(limit),
+		Offset:   Int(offset),
+		Search:   f.Search,
+		Unread:   f.UnreadOnly,
+		Archived: f.ArchivedOnly,
+		Domain:   f.Domain,
+		Address:  f.Address,
+	}
 }
 
 type GetThreadsResponse struct {
@@ -688,6 +1036,11 @@ type PostThreadActionsResponse struct {
 	Message          string `json:"message"`
 }
 
+type DeleteThreadMessageResponse struct {
+	Message            string `json:"message"`
+	DeletedAttachments int    `json:"deletedAttachments"`
+}
+
 type ThreadDistribution struct {
 	SingleMessageThreads int `json:"singleMessageThreads"`
 	ShortThreads         int `json:"shortThreads"`
@@ -724,6 +1077,626 @@ type GetThreadStatsResponse struct {
 	UnreadStats              ThreadUnreadStats    `json:"unreadStats"`
 }
 
+// Templates API Types
+type TemplateVariable struct {
+	Name        string  `json:"name"`
+	Type        string  `json:"type,omitempty"` // 'string' | 'number' | 'boolean' | 'object' | 'array'
+	Required    bool    `json:"required,omitempty"`
+	Default     any     `json:"default,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+type Template struct {
+	ID        string             `json:"id"`
+	Name      string             `json:"name"`
+	Subject   string             `json:"subject"`
+	HTML      *string            `json:"html"`
+	Text      *string            `json:"text"`
+	Variables []TemplateVariable `json:"variables"`
+	CreatedAt time.Time          `json:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+}
+
+type GetTemplatesRequest struct {
+	Limit  *int   `json:"limit,omitempty"`
+	Offset *int   `json:"offset,omitempty"`
+	Search string `json:"search,omitempty"`
+}
+
+type GetTemplatesResponse struct {
+	Data       []Template `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
+type PostTemplatesRequest struct {
+	Name      string             `json:"name"`
+	Subject   string             `json:"subject"`
+	HTML      *string            `json:"html,omitempty"`
+	Text      *string            `json:"text,omitempty"`
+	Variables []TemplateVariable `json:"variables,omitempty"`
+}
+
+type PutTemplateByIDRequest struct {
+	Name      *string            `json:"name,omitempty"`
+	Subject   *string            `json:"subject,omitempty"`
+	HTML      *string            `json:"html,omitempty"`
+	Text      *string            `json:"text,omitempty"`
+	Variables []TemplateVariable `json:"variables,omitempty"`
+}
+
+type DeleteTemplateByIDResponse struct {
+	Message string `json:"message"`
+}
+
+// TemplateSendOptions are the optional fields of a templated email. From is
+// required by the API.
+type TemplateSendOptions struct {
+	From           string
+	BCC            any // string or []string
+	CC             any // string or []string
+	ReplyTo        any // string or []string
+	Headers        map[string]string
+	Attachments    []AttachmentData
+	Tags           []EmailTag
+	Timezone       *string // User's timezone for natural language scheduling
+	IdempotencyKey string
+}
+
+type PostTemplateEmailRequest struct {
+	TemplateID  string            `json:"templateId"`
+	From        string            `json:"from"`
+	To          any               `json:"to"` // string or []string
+	Variables   map[string]any    `json:"variables,omitempty"`
+	BCC         any               `json:"bcc,omitempty"`     // string or []string
+	CC          any               `json:"cc,omitempty"`      // string or []string
+	ReplyTo     any               `json:"replyTo,omitempty"` // string or []string
+	Headers     map[string]string `json:"headers,omitempty"`
+	Attachments []AttachmentData  `json:"attachments,omitempty"`
+	Tags        []EmailTag        `json:"tags,omitempty"`
+	ScheduledAt *string           `json:"scheduled_at,omitempty"`
+	Timezone    *string           `json:"timezone,omitempty"`
+}
+
+// Broadcasts API Types
+type Broadcast struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	AudienceID  *string        `json:"audienceId"`
+	SegmentID   *string        `json:"segmentId"`
+	TemplateID  string         `json:"templateId"`
+	From        string         `json:"from"`
+	Subject     *string        `json:"subject"` // Overrides the template subject
+	ReplyTo     any            `json:"replyTo"`
+	Variables   map[string]any `json:"variables"`
+	Status      string         `json:"status"` // 'draft' | 'scheduled' | 'sending' | 'paused' | 'sent' | 'cancelled'
+	ScheduledAt *string        `json:"scheduled_at"`
+	SentAt      *time.Time     `json:"sentAt"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
+
+type GetBroadcastsRequest struct {
+	Limit  *int   `json:"limit,omitempty"`
+	Offset *int   `json:"offset,omitempty"`
+	Status string `json:"status,omitempty"` // 'draft' | 'scheduled' | 'sending' | 'paused' | 'sent' | 'cancelled'
+}
+
+type GetBroadcastsResponse struct {
+	Data       []Broadcast `json:"data"`
+	Pagination Pagination  `json:"pagination"`
+}
+
+type PostBroadcastsRequest struct {
+	Name        string         `json:"name"`
+	AudienceID  *string        `json:"audienceId,omitempty"`
+	SegmentID   *string        `json:"segmentId,omitempty"`
+	TemplateID  string         `json:"templateId"`
+	From        string         `json:"from"`
+	Subject     *string        `json:"subject,omitempty"`
+	ReplyTo     any            `json:"replyTo,omitempty"` // string or []string
+	Variables   map[string]any `json:"variables,omitempty"`
+	ScheduledAt *string        `json:"scheduled_at,omitempty"` // ISO 8601 or natural language; leave unset to keep as draft
+	Timezone    *string        `json:"timezone,omitempty"`
+}
+
+type BroadcastStats struct {
+	Recipients   int     `json:"recipients"`
+	Sent         int     `json:"sent"`
+	Delivered    int     `json:"delivered"`
+	Opened       int     `json:"opened"`
+	Clicked      int     `json:"clicked"`
+	Bounced      int     `json:"bounced"`
+	Complained   int     `json:"complained"`
+	Unsubscribed int     `json:"unsubscribed"`
+	Failed       int     `json:"failed"`
+	OpenRate     float64 `json:"openRate"`
+	ClickRate    float64 `json:"clickRate"`
+	BounceRate   float64 `json:"bounceRate"`
+}
+
+// Contacts API Types
+type Contact struct {
+	ID           string         `json:"id"`
+	Email        string         `json:"email"`
+	FirstName    *string        `json:"firstName"`
+	LastName     *string        `json:"lastName"`
+	Attributes   map[string]any `json:"attributes"` // Custom attributes, usable as template variables
+	Unsubscribed bool           `json:"unsubscribed"`
+	AudienceIDs  []string       `json:"audienceIds"`
+	SegmentIDs   []string       `json:"segmentIds"`
+	CreatedAt    time.Time      `json:"createdAt"`
+	UpdatedAt    time.Time      `json:"updatedAt"`
+}
+
+type GetContactsRequest struct {
+	Limit        *int   `json:"limit,omitempty"`
+	Offset       *int   `json:"offset,omitempty"`
+	Search       string `json:"search,omitempty"`
+	AudienceID   string `json:"audienceId,omitempty"`
+	SegmentID    string `json:"segmentId,omitempty"`
+	Unsubscribed *bool  `json:"unsubscribed,omitempty"`
+}
+
+type GetContactsResponse struct {
+	Data       []Contact  `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
+type PostContactsRequest struct {
+	Email        string         `json:"email"`
+	FirstName    *string        `json:"firstName,omitempty"`
+	LastName     *string        `json:"lastName,omitempty"`
+	Attributes   map[string]any `json:"attributes,omitempty"`
+	Unsubscribed *bool          `json:"unsubscribed,omitempty"`
+	AudienceIDs  []string       `json:"audienceIds,omitempty"`
+}
+
+type PutContactByIDRequest struct {
+	Email        *string        `json:"email,omitempty"`
+	FirstName    *string        `json:"firstName,omitempty"`
+	LastName     *string        `json:"lastName,omitempty"`
+	Attributes   map[string]any `json:"attributes,omitempty"` // Merged into existing attributes; null values remove keys
+	Unsubscribed *bool          `json:"unsubscribed,omitempty"`
+}
+
+type DeleteContactByIDResponse struct {
+	Message string `json:"message"`
+}
+
+type PostContactsBulkRequest struct {
+	Contacts   []PostContactsRequest `json:"contacts"`
+	AudienceID *string               `json:"audienceId,omitempty"`
+}
+
+type ContactImportError struct {
+	Row   int    `json:"row,omitempty"` // CSV row (1-based, header is row 1) for client-side errors
+	Email string `json:"email"`
+	Error string `json:"error"`
+}
+
+type PostContactsBulkResponse struct {
+	Created int                  `json:"created"`
+	Updated int                  `json:"updated"`
+	Failed  []ContactImportError `json:"failed"`
+}
+
+type Audience struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	ContactCount int       `json:"contactCount"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+type GetAudiencesResponse struct {
+	Data       []Audience `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
+type PostAudiencesRequest struct {
+	Name string `json:"name"`
+}
+
+type PostAudienceContactsRequest struct {
+	ContactIDs []string `json:"contactIds"`
+}
+
+// Suppressions API Types
+type Suppression struct {
+	Email     string    `json:"email"`
+	Reason    string    `json:"reason"`  // 'hard_bounce' | 'complaint' | 'unsubscribe' | 'manual'
+	Domain    *string   `json:"domain"`  // Sending domain the suppression applies to (nil for all domains)
+	EmailID   *string   `json:"emailId"` // Email that caused the suppression, if any
+	Details   *string   `json:"details"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type GetSuppressionsRequest struct {
+	Limit  *int   `json:"limit,omitempty"`
+	Offset *int   `json:"offset,omitempty"`
+	Reason string `json:"reason,omitempty"` // 'hard_bounce' | 'complaint' | 'unsubscribe' | 'manual'
+	Domain string `json:"domain,omitempty"`
+	Search string `json:"search,omitempty"`
+}
+
+type GetSuppressionsResponse struct {
+	Data       []Suppression `json:"data"`
+	Pagination Pagination    `json:"pagination"`
+}
+
+type PostSuppressionsRequest struct {
+	Emails  []string `json:"emails"`
+	Reason  string   `json:"reason,omitempty"` // Defaults to 'manual'
+	Domain  *string  `json:"domain,omitempty"`
+	Details *string  `json:"details,omitempty"`
+}
+
+type PostSuppressionsResponse struct {
+	Added   int `json:"added"`
+	Skipped int `json:"skipped"` // Already suppressed
+}
+
+type SuppressionCheckResponse struct {
+	Email       string       `json:"email"`
+	Suppressed  bool         `json:"suppressed"`
+	Suppression *Suppression `json:"suppression,omitempty"`
+}
+
+// Bounce and Complaint API Types
+type Bounce struct {
+	ID             string    `json:"id"`
+	EmailID        string    `json:"emailId"` // Sent email that bounced
+	Recipient      string    `json:"recipient"`
+	Type           string    `json:"type"`    // 'hard' | 'soft'
+	SubType        *string   `json:"subType"` // e.g. 'general' | 'no_email' | 'mailbox_full' | 'message_too_large'
+	Reason         string    `json:"reason"`
+	DiagnosticCode *string   `json:"diagnosticCode"` // SMTP diagnostic from the receiving server
+	Domain         string    `json:"domain"`         // Sending domain
+	BouncedAt      time.Time `json:"bouncedAt"`
+}
+
+type GetBouncesRequest struct {
+	Limit  *int       `json:"limit,omitempty"`
+	Offset *int       `json:"offset,omitempty"`
+	Type   string     `json:"type,omitempty"` // 'hard' | 'soft'
+	Domain string     `json:"domain,omitempty"`
+	Since  *time.Time `json:"since,omitempty"`
+	Until  *time.Time `json:"until,omitempty"`
+}
+
+type GetBouncesResponse struct {
+	Data       []Bounce   `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
+type Complaint struct {
+	ID           string    `json:"id"`
+	EmailID      string    `json:"emailId"` // Sent email that was reported
+	Recipient    string    `json:"recipient"`
+	FeedbackType *string   `json:"feedbackType"` // e.g. 'abuse' | 'fraud' | 'not-spam' | 'virus'
+	UserAgent    *string   `json:"userAgent"`    // Reporting mailbox provider
+	Domain       string    `json:"domain"`       // Sending domain
+	ComplainedAt time.Time `json:"complainedAt"`
+}
+
+type GetComplaintsRequest struct {
+	Limit  *int       `json:"limit,omitempty"`
+	Offset *int       `json:"offset,omitempty"`
+	Domain string     `json:"domain,omitempty"`
+	Since  *time.Time `json:"since,omitempty"`
+	Until  *time.Time `json:"until,omitempty"`
+}
+
+type GetComplaintsResponse struct {
+	Data       []Complaint `json:"data"`
+	Pagination Pagination  `json:"pagination"`
+}
+
+// Audit Log API Types
+type AuditActor struct {
+	ID    string  `json:"id"`
+	Type  string  `json:"type"` // 'user' | 'api_key' | 'system'
+	Name  *string `json:"name"`
+	Email *string `json:"email"`
+}
+
+type AuditResource struct {
+	Type string  `json:"type"` // e.g. 'domain' | 'endpoint' | 'email_address' | 'api_key'
+	ID   string  `json:"id"`
+	Name *string `json:"name"`
+}
+
+type AuditLogEntry struct {
+	ID        string         `json:"id"`
+	Actor     AuditActor     `json:"actor"`
+	Action    string         `json:"action"` // e.g. 'domain.created' | 'endpoint.updated' | 'api_key.revoked'
+	Resource  AuditResource  `json:"resource"`
+	Timestamp time.Time      `json:"timestamp"`
+	IPAddress *string        `json:"ipAddress"`
+	UserAgent *string        `json:"userAgent"`
+	Metadata  map[string]any `json:"metadata"` // Action-specific details such as changed fields
+}
+
+type GetAuditLogRequest struct {
+	Limit        *int       `json:"limit,omitempty"`
+	Offset       *int       `json:"offset,omitempty"`
+	Action       string     `json:"action,omitempty"`
+	ActorID      string     `json:"actorId,omitempty"`
+	ResourceType string     `json:"resourceType,omitempty"`
+	ResourceID   string     `json:"resourceId,omitempty"`
+	Since        *time.Time `json:"since,omitempty"`
+	Until        *time.Time `json:"until,omitempty"`
+}
+
+type GetAuditLogResponse struct {
+	Data       []AuditLogEntry `json:"data"`
+	Pagination Pagination      `json:"pagination"`
+}
+
+// IP Pool API Types
+type DedicatedIP struct {
+	IP           string    `json:"ip"`
+	PoolID       *string   `json:"poolId"`
+	WarmupStatus string    `json:"warmupStatus"` // 'warming' | 'warm'
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+type GetDedicatedIPsResponse struct {
+	Data []DedicatedIP `json:"data"`
+}
+
+type IPPool struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description"`
+	IPs         []string  `json:"ips"`
+	DomainIDs   []string  `json:"domainIds"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type GetIPPoolsResponse struct {
+	Data []IPPool `json:"data"`
+}
+
+type PostIPPoolsRequest struct {
+	Name        string   `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	IPs         []string `json:"ips,omitempty"` // Dedicated IPs to move into the pool
+}
+
+type PostIPPoolDomainsRequest struct {
+	DomainIDs []string `json:"domainIds"`
+}
+
+type DeleteIPPoolByIDResponse struct {
+	Message string `json:"message"`
+}
+
+// Address Verification API Types
+type AddressVerification struct {
+	Email         string  `json:"email"`
+	Result        string  `json:"result"` // 'deliverable' | 'undeliverable' | 'risky' | 'unknown'
+	ValidSyntax   bool    `json:"validSyntax"`
+	HasMX         bool    `json:"hasMx"`
+	MailboxStatus string  `json:"mailboxStatus"` // 'exists' | 'not_found' | 'unknown'
+	IsRoleAccount bool    `json:"isRoleAccount"`
+	IsDisposable  bool    `json:"isDisposable"`
+	IsCatchAll    bool    `json:"isCatchAll"`
+	Suggestion    *string `json:"suggestion"` // Likely intended address when the domain looks like a typo
+	Reason        *string `json:"reason"`
+}
+
+// Rejectable reports whether a signup flow should refuse the address: bad
+// syntax, no mail server, a mailbox known not to exist, or a disposable
+// domain. Role accounts and unknown mailboxes are left to the caller.
+func (v *AddressVerification) Rejectable() bool {
+	return !v.ValidSyntax || !v.HasMX || v.MailboxStatus == "not_found" || v.IsDisposable
+}
+
+// AddressDatasets are the disposable-domain and role-account lists used by
+// AddressClassifier.UpdateFrom
+type AddressDatasets struct {
+	DisposableDomains []string  `json:"disposableDomains"`
+	RoleAccounts      []string  `json:"roleAccounts"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+// Routing Rule API Types
+
+// RuleConditions select the emails a rule applies to. All set conditions
+// must match. Recipient and sender patterns are case-insensitive globs
+// where * matches any run of characters, e.g. "billing+*@example.com".
+type RuleConditions struct {
+	Recipient       *string `json:"recipient,omitempty"`
+	Sender          *string `json:"sender,omitempty"`
+	RecipientTag    *string `json:"recipientTag,omitempty"`    // Glob on the plus-address tag, e.g. 'cust-*'
+	SubjectContains *string `json:"subjectContains,omitempty"` // Case-insensitive substring
+	HasAttachment   *bool   `json:"hasAttachment,omitempty"`
+}
+
+// RuleActions are applied to emails matching a rule
+type RuleActions struct {
+	EndpointID *string  `json:"endpointId,omitempty"` // Deliver to this endpoint instead of the address default
+	Tags       []string `json:"tags,omitempty"`
+	Archive    bool     `json:"archive,omitempty"`
+}
+
+type Rule struct {
+	ID         string         `json:"id"`
+	Name       string         `json:"name"`
+	Priority   int            `json:"priority"` // Lower runs first
+	Enabled    bool           `json:"enabled"`
+	Conditions RuleConditions `json:"conditions"`
+	Actions    RuleActions    `json:"actions"`
+	// StopProcessing skips lower-priority rules once this one matches
+	StopProcessing bool      `json:"stopProcessing"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type GetRulesRequest struct {
+	Limit  *int `json:"limit,omitempty"`
+	Offset *int `json:"offset,omitempty"`
+}
+
+type GetRulesResponse struct {
+	Data       []Rule     `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
+type PostRulesRequest struct {
+	Name           string         `json:"name"`
+	Priority       *int           `json:"priority,omitempty"`
+	Enabled        *bool          `json:"enabled,omitempty"` // Defaults to true
+	Conditions     RuleConditions `json:"conditions"`
+	Actions        RuleActions    `json:"actions"`
+	StopProcessing bool           `json:"stopProcessing,omitempty"`
+}
+
+type PutRuleByIDRequest struct {
+	Name           *string         `json:"name,omitempty"`
+	Priority       *int            `json:"priority,omitempty"`
+	Enabled        *bool           `json:"enabled,omitempty"`
+	Conditions     *RuleConditions `json:"conditions,omitempty"`
+	Actions        *RuleActions    `json:"actions,omitempty"`
+	StopProcessing *bool           `json:"stopProcessing,omitempty"`
+}
+
+type DeleteRuleByIDResponse struct {
+	Message string `json:"message"`
+}
+
+// Forwarding Rule API Types
+
+// ForwardingHeaders controls how headers change when mail is forwarded. In
+// preserve mode the original headers are kept and the envelope sender is
+// rewritten (SRS) so SPF still passes; in rewrite mode the From header is
+// replaced as well, which keeps DMARC aligned for strict senders.
+type ForwardingHeaders struct {
+	Mode          string            `json:"mode"`                    // 'preserve' | 'rewrite'
+	From          *string           `json:"from,omitempty"`          // Replacement From in rewrite mode
+	SubjectPrefix *string           `json:"subjectPrefix,omitempty"` // e.g. '[Fwd] '
+	Add           map[string]string `json:"add,omitempty"`
+	Remove        []string          `json:"remove,omitempty"`
+}
+
+type ForwardingRule struct {
+	ID           string            `json:"id"`
+	Name         string            `json:"name"`
+	Source       string            `json:"source"` // Address or glob pattern, e.g. '*@support.example.com'
+	Destinations []string          `json:"destinations"`
+	Headers      ForwardingHeaders `json:"headers"`
+	Enabled      bool              `json:"enabled"`
+	CreatedAt    time.Time         `json:"createdAt"`
+	UpdatedAt    time.Time         `json:"updatedAt"`
+}
+
+type GetForwardingRulesRequest struct {
+	Limit  *int   `json:"limit,omitempty"`
+	Offset *int   `json:"offset,omitempty"`
+	Source string `json:"source,omitempty"`
+}
+
+type GetForwardingRulesResponse struct {
+	Data       []ForwardingRule `json:"data"`
+	Pagination Pagination       `json:"pagination"`
+}
+
+type PostForwardingRulesRequest struct {
+	Name         string             `json:"name"`
+	Source       string             `json:"source"`
+	Destinations []string           `json:"destinations"`
+	Headers      *ForwardingHeaders `json:"headers,omitempty"` // Defaults to preserve
+	Enabled      *bool              `json:"enabled,omitempty"` // Defaults to true
+}
+
+type PutForwardingRuleByIDRequest struct {
+	Name         *string            `json:"name,omitempty"`
+	Source       *string            `json:"source,omitempty"`
+	Destinations []string           `json:"destinations,omitempty"`
+	Headers      *ForwardingHeaders `json:"headers,omitempty"`
+	Enabled      *bool              `json:"enabled,omitempty"`
+}
+
+type DeleteForwardingRuleByIDResponse struct {
+	Message string `json:"message"`
+}
+
+// Sender Filter API Types
+type SenderFilter struct {
+	ID        string    `json:"id"`
+	DomainID  string    `json:"domainId"`
+	Action    string    `json:"action"` // 'block' | 'allow'
+	Type      string    `json:"type"`   // 'address' | 'domain'
+	Value     string    `json:"value"`  // e.g. 'spammer@example.net' or 'example.net' (includes subdomains)
+	Reason    *string   `json:"reason"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type GetSenderFiltersRequest struct {
+	Limit  *int   `json:"limit,omitempty"`
+	Offset *int   `json:"offset,omitempty"`
+	Action string `json:"action,omitempty"` // 'block' | 'allow'
+}
+
+type GetSenderFiltersResponse struct {
+	Data       []SenderFilter `json:"data"`
+	Pagination Pagination     `json:"pagination"`
+}
+
+type PostSenderFiltersRequest struct {
+	Action  string   `json:"action"`  // 'block' | 'allow'
+	Senders []string `json:"senders"` // Addresses or domains
+	Reason  *string  `json:"reason,omitempty"`
+}
+
+type PostSenderFiltersResponse struct {
+	Data []SenderFilter `json:"data"`
+}
+
+type SenderFilterCheckResponse struct {
+	Sender string        `json:"sender"`
+	Action string        `json:"action"` // 'block' | 'allow' | 'none'
+	Filter *SenderFilter `json:"filter"` // The filter that decided the action
+}
+
+// Realtime API Types
+type PostRealtimeTokenResponse struct {
+	Token     string    `json:"token"`
+	URL       string    `json:"url"` // WebSocket URL to connect to, e.g. 'wss://realtime.inbound.new/v1'
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Changes API Types
+type GetChangesRequest struct {
+	Since string `json:"since,omitempty"` // Token from a previous response; empty reports everything as created
+	Limit *int   `json:"limit,omitempty"` // Maximum changes per page
+}
+
+// ChangeSet lists the IDs created, updated, or destroyed in one collection
+type ChangeSet struct {
+	Created   []string `json:"created"`
+	Updated   []string `json:"updated"`
+	Destroyed []string `json:"destroyed"`
+}
+
+// StateChange is a change to an email's or thread's read or archive state
+type StateChange struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"` // 'email' | 'thread'
+	IsRead     *bool     `json:"isRead,omitempty"`
+	IsArchived *bool     `json:"isArchived,omitempty"`
+	ChangedAt  time.Time `json:"changedAt"`
+}
+
+type GetChangesResponse struct {
+	Emails    ChangeSet     `json:"emails"`
+	Threads   ChangeSet     `json:"threads"`
+	States    []StateChange `json:"states"`
+	NextToken string        `json:"nextToken"` // Pass as Since to continue
+	HasMore   bool          `json:"hasMore"`
+}
+
 // Webhook Payload Types - for incoming email.received webhooks
 type WebhookPayload struct {
 	Event     string             `json:"event"`
@@ -771,6 +1744,7 @@ type WebhookParsedData struct {
 	Attachments []WebhookAttachment  `json:"attachments"`
 	Headers     map[string]any       `json:"headers"`
 	Priority    any                  `json:"priority,omitempty"` // Can be string | false | undefined
+	Verdicts    *AuthVerdicts        `json:"verdicts,omitempty"` // Use WebhookPayload.GetVerdicts to fall back to headers
 }
 
 type WebhookCleanedContent struct {
@@ -797,6 +1771,16 @@ type WebhookEndpointRef struct {
 	Type string `json:"type"`
 }
 
+// Delivery Webhook Payload Types - for outgoing email.bounced and
+// email.complained webhooks
+type DeliveryWebhookPayload struct {
+	Event     string             `json:"event"` // 'email.bounced' | 'email.complained'
+	Timestamp string             `json:"timestamp"`
+	Bounce    *Bounce            `json:"bounce,omitempty"`    // Set for email.bounced
+	Complaint *Complaint         `json:"complaint,omitempty"` // Set for email.complained
+	Endpoint  WebhookEndpointRef `json:"endpoint"`
+}
+
 // ---- Attachment Types ----
 
 // AttachmentDownloadResponse represents the response from downloading an attachment.
@@ -0,0 +1,291 @@
+package inboundgo
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrMissingEmailField is wrapped by UnmarshalEmail errors for required
+// fields that are absent from the email
+var ErrMissingEmailField = errors.New("missing required email field")
+
+var (
+	webhookAttachmentType = reflect.TypeOf(WebhookAttachment{})
+	emailTimeLayouts      = []string{time.RFC3339, time.RFC1123Z, time.RFC1123, time.DateTime, time.DateOnly}
+)
+
+// UnmarshalEmail binds parts of a received email into the struct pointed to
+// by v, according to `email` struct tags:
+//
+//	type Expense struct {
+//		Ticket   int                `email:"subject,token=1"`        // "EXPENSE 42 ..." -> 42
+//		Amount   float64            `email:"field=Amount,required"`  // "Amount: 12.50" line in the body
+//		Date     time.Time          `email:"field=Date"`
+//		Tags     []string           `email:"field=Tags"`             // comma-separated
+//		Receipt  *WebhookAttachment `email:"attachment=*.pdf"`
+//		Sender   string             `email:"from"`
+//		Notes    string             `email:"body"`
+//	}
+//
+// Sources are subject (after Re:/Fwd: prefixes are stripped), subject with
+// token=N (zero-based word), field=Name (a "Name: value" line in the reply
+// text, matched case-insensitively), attachment=glob (by filename), from,
+// to, and body (the reply text with quoted history removed). Fields may be
+// strings, numbers, bools, time.Time, slices of those, pointers to any of
+// them, or WebhookAttachment values. Absent fields are left unchanged
+// unless tagged required.
+func UnmarshalEmail(payload *WebhookPayload, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("UnmarshalEmail requires a non-nil pointer to a struct")
+	}
+	src := newEmailSource(payload)
+
+	rv = rv.Elem()
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag, ok := field.Tag.Lookup("email")
+		if !ok || tag == "-" || !field.IsExported() {
+			continue
+		}
+		source, options, _ := strings.Cut(tag, ",")
+		required := false
+		token := -1
+		for _, opt := range strings.Split(options, ",") {
+			switch {
+			case opt == "required":
+				required = true
+			case strings.HasPrefix(opt, "token="):
+				n, err := strconv.Atoi(strings.TrimPrefix(opt, "token="))
+				if err != nil || n < 0 {
+					return fmt.Errorf("field %s: invalid token option %q", field.Name, opt)
+				}
+				token = n
+			case opt == "":
+			default:
+				return fmt.Errorf("field %s: unknown option %q", field.Name, opt)
+			}
+		}
+
+		fv := rv.Field(i)
+		var found bool
+		var err error
+		if name, ok := strings.CutPrefix(source, "attachment="); ok {
+			found, err = setAttachmentField(fv, src.attachments(name))
+		} else {
+			var values []string
+			values, err = src.lookup(source, token)
+			if err == nil && len(values) > 0 {
+				found = true
+				err = setEmailField(fv, values)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if !found && required {
+			return fmt.Errorf("field %s (%s): %w", field.Name, source, ErrMissingEmailField)
+		}
+	}
+	return nil
+}
+
+// emailSource holds the parts of an email that tags can refer to
+type emailSource struct {
+	payload *WebhookPayload
+	subject string
+	body    string
+	fields  map[string]string
+}
+
+func newEmailSource(payload *WebhookPayload) *emailSource {
+	s := &emailSource{payload: payload, fields: make(map[string]string)}
+	if payload.Email.Subject != nil {
+		s.subject = stripSubjectPrefixes(*payload.Email.Subject)
+	}
+	body := ""
+	if payload.Email.ParsedData.TextBody != nil {
+		body = *payload.Email.ParsedData.TextBody
+	} else if payload.Email.CleanedContent.Text != nil {
+		body = *payload.Email.CleanedContent.Text
+	}
+	s.body = StripQuotedReply(body, DefaultReplyMarker)
+	for _, line := range strings.Split(s.body, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if !ok || key == "" {
+			continue
+		}
+		if _, exists := s.fields[key]; !exists {
+			s.fields[key] = strings.TrimSpace(value)
+		}
+	}
+	return s
+}
+
+// lookup returns the raw values for a source. Multi-valued sources
+// (comma-separated fields) are split for slice targets by setEmailField.
+func (s *emailSource) lookup(source string, token int) ([]string, error) {
+	var value string
+	switch {
+	case source == "subject":
+		value = s.subject
+		if token >= 0 {
+			tokens := strings.Fields(s.subject)
+			if token >= len(tokens) {
+				return nil, nil
+			}
+			value = tokens[token]
+		}
+	case source == "from":
+		value = s.payload.GetFromAddress()
+	case source == "to":
+		value = s.payload.Email.Recipient
+	case source == "body":
+		value = s.body
+	case strings.HasPrefix(source, "field="):
+		value = s.fields[strings.ToLower(strings.TrimPrefix(source, "field="))]
+	default:
+		return nil, fmt.Errorf("unknown email source %q", source)
+	}
+	if value == "" {
+		return nil, nil
+	}
+	return []string{value}, nil
+}
+
+// attachments returns the attachments whose filename matches pattern
+func (s *emailSource) attachments(pattern string) []WebhookAttachment {
+	all := s.payload.Email.ParsedData.Attachments
+	if len(all) == 0 {
+		all = s.payload.Email.CleanedContent.Attachments
+	}
+	var matched []WebhookAttachment
+	for _, a := range all {
+		if a.Filename == nil {
+			continue
+		}
+		if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(*a.Filename)); ok {
+			matched = append(matched, a)
+		}
+	}
+	return matched
+}
+
+func setAttachmentField(fv reflect.Value, matched []WebhookAttachment) (bool, error) {
+	if len(matched) == 0 {
+		return false, nil
+	}
+	switch {
+	case fv.Type() == webhookAttachmentType:
+		fv.Set(reflect.ValueOf(matched[0]))
+	case fv.Type() == reflect.PointerTo(webhookAttachmentType):
+		a := matched[0]
+		fv.Set(reflect.ValueOf(&a))
+	case fv.Type() == reflect.SliceOf(webhookAttachmentType):
+		fv.Set(reflect.ValueOf(matched))
+	default:
+		return false, fmt.Errorf("attachment sources require a WebhookAttachment field, not %s", fv.Type())
+	}
+	return true, nil
+}
+
+// setEmailField converts value into fv's type
+func setEmailField(fv reflect.Value, values []string) error {
+	if fv.Kind() == reflect.Pointer {
+		elem := reflect.New(fv.Type().Elem())
+		if err := setEmailField(elem.Elem(), values); err != nil {
+			return err
+		}
+		fv.Set(elem)
+		return nil
+	}
+	if fv.Kind() == reflect.Slice {
+		var parts []string
+		for _, v := range values {
+			for _, p := range strings.Split(v, ",") {
+				if p = strings.TrimSpace(p); p != "" {
+					parts = append(parts, p)
+				}
+			}
+		}
+		slice := reflect.MakeSlice(fv.Type(), len(parts), len(parts))
+		for i, p := range parts {
+			if err := setEmailScalar(slice.Index(i), p); err != nil {
+				return err
+			}
+		}
+		fv.Set(slice)
+		return nil
+	}
+	return setEmailScalar(fv, values[0])
+}
+
+func setEmailScalar(fv reflect.Value, value string) error {
+	if fv.Type() == timeType {
+		t, err := parseEmailTime(value)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(t))
+		return nil
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.ToLower(value))
+		if err != nil {
+			switch strings.ToLower(value) {
+			case "yes", "y", "on":
+				b, err = true, nil
+			case "no", "n", "off":
+				b, err = false, nil
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("invalid bool %q", value)
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimPrefix(value, "#"), 10, fv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(strings.TrimPrefix(value, "#"), 10, fv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", ""), fv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		fv.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+	return nil
+}
+
+func parseEmailTime(value string) (time.Time, error) {
+	for _, layout := range emailTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	if t, err := mail.ParseDate(value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", value)
+}
@@ -0,0 +1,87 @@
+package inboundgo_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+type expenseEmail struct {
+	Ticket    int                           `email:"subject,token=1"`
+	Subject   string                        `email:"subject"`
+	Amount    float64                       `email:"field=Amount,required"`
+	Date      time.Time                     `email:"field=Date"`
+	Tags      []string                      `email:"field=Tags"`
+	Urgent    *bool                         `email:"field=Urgent"`
+	Missing   *string                       `email:"field=Cost Center"`
+	Receipt   *inboundgo.WebhookAttachment  `email:"attachment=*.pdf"`
+	Images    []inboundgo.WebhookAttachment `email:"attachment=*.png"`
+	Sender    string                        `email:"from"`
+	Recipient string                        `email:"to"`
+	Notes     string                        `email:"body"`
+	Ignored   string
+}
+
+func TestUnmarshalEmail(t *testing.T) {
+	payload := &inboundgo.WebhookPayload{}
+	payload.Email.Subject = inboundgo.String("Re: EXPENSE #42 taxi")
+	payload.Email.Recipient = "expenses@example.com"
+	payload.Email.From = &inboundgo.WebhookAddressGroup{Addresses: []inboundgo.WebhookAddress{{Address: inboundgo.String("jane@example.com")}}}
+	payload.Email.ParsedData.TextBody = inboundgo.String("Amount: 1,234.50\nDate: 2025-03-04\nTags: travel, client\nurgent: yes\n\nOn Mon, Mar 3, 2025 Bob wrote:\n> Amount: 1")
+	payload.Email.ParsedData.Attachments = []inboundgo.WebhookAttachment{
+		{Filename: inboundgo.String("photo.PNG")},
+		{Filename: inboundgo.String("receipt.pdf"), DownloadUrl: "https://example.com/receipt"},
+		{Filename: inboundgo.String("map.png")},
+	}
+
+	var e expenseEmail
+	if err := inboundgo.UnmarshalEmail(payload, &e); err != nil {
+		t.Fatalf("UnmarshalEmail failed: %v", err)
+	}
+	if e.Ticket != 42 || e.Subject != "EXPENSE #42 taxi" || e.Amount != 1234.5 {
+		t.Errorf("Unexpected subject or amount: %+v", e)
+	}
+	if !e.Date.Equal(time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)) || len(e.Tags) != 2 || e.Tags[1] != "client" {
+		t.Errorf("Unexpected date or tags: %v %v", e.Date, e.Tags)
+	}
+	if e.Urgent == nil || !*e.Urgent || e.Missing != nil {
+		t.Errorf("Unexpected pointers: %v %v", e.Urgent, e.Missing)
+	}
+	if e.Receipt == nil || e.Receipt.DownloadUrl != "https://example.com/receipt" || len(e.Images) != 2 {
+		t.Errorf("Unexpected attachments: %+v %+v", e.Receipt, e.Images)
+	}
+	if e.Sender != "jane@example.com" || e.Recipient != "expenses@example.com" {
+		t.Errorf("Unexpected addresses: %s %s", e.Sender, e.Recipient)
+	}
+	if e.Notes == "" || e.Notes[len(e.Notes)-1] != 's' {
+		t.Errorf("Expected quoted history to be stripped from body, got %q", e.Notes)
+	}
+}
+
+func TestUnmarshalEmailErrors(t *testing.T) {
+	payload := &inboundgo.WebhookPayload{}
+	payload.Email.ParsedData.TextBody = inboundgo.String("Amount: lots")
+
+	var e expenseEmail
+	if err := inboundgo.UnmarshalEmail(payload, &e); err == nil {
+		t.Error("Expected error for invalid number")
+	}
+
+	payload.Email.ParsedData.TextBody = inboundgo.String("Nothing here")
+	if err := inboundgo.UnmarshalEmail(payload, &e); !errors.Is(err, inboundgo.ErrMissingEmailField) {
+		t.Errorf("Expected ErrMissingEmailField, got %v", err)
+	}
+
+	if err := inboundgo.UnmarshalEmail(payload, e); err == nil {
+		t.Error("Expected error for non-pointer target")
+	}
+
+	var bad struct {
+		X string `email:"header=X"`
+	}
+	if err := inboundgo.UnmarshalEmail(payload, &bad); err == nil {
+		t.Error("Expected error for unknown source")
+	}
+}
@@ -0,0 +1,83 @@
+package inboundgo
+
+import (
+	"fmt"
+)
+
+// Validate checks the request's filters before it is sent
+func (r *GetMailRequest) Validate() error {
+	if r != nil && r.Status != "" && !r.Status.Valid() {
+		return fmt.Errorf("invalid mail status %q", r.Status)
+	}
+	return nil
+}
+
+// Validate checks the request's filters before it is sent
+func (r *GetEndpointsRequest) Validate() error {
+	if r != nil && r.Type != "" && !r.Type.Valid() {
+		return fmt.Errorf("invalid endpoint type %q", r.Type)
+	}
+	return nil
+}
+
+// Validate checks the endpoint's name and type before it is created
+func (r *PostEndpointsRequest) Validate() error {
+	if r == nil {
+		return fmt.Errorf("endpoint request is required")
+	}
+	if r.Name == "" {
+		return fmt.Errorf("endpoint name is required")
+	}
+	if !r.Type.Valid() {
+		return fmt.Errorf("invalid endpoint type %q", r.Type)
+	}
+	return nil
+}
+
+// Validate checks the request's filters before it is sent
+func (r *GetDomainsRequest) Validate() error {
+	if r != nil && r.Status != "" && !r.Status.Valid() {
+		return fmt.Errorf("invalid domain status %q", r.Status)
+	}
+	return nil
+}
+
+// Validate checks the update does not both set and clear the endpoint
+func (r *PutDomainByIDRequest) Validate() error {
+	if r != nil && r.ClearCatchAllEndpoint && r.CatchAllEndpointID != nil {
+		return fmt.Errorf("catch-all endpoint cannot be both set and cleared")
+	}
+	return nil
+}
+
+// Validate checks the request's filters before it is sent
+func (r *GetScheduledEmailsRequest) Validate() error {
+	if r != nil && r.Status != "" && !r.Status.Valid() {
+		return fmt.Errorf("invalid scheduled email status %q", r.Status)
+	}
+	return nil
+}
+
+// Thread list page size bounds
+const (
+	minThreadsLimit = 1
+	maxThreadsLimit = 100
+)
+
+// Validate checks the request's paging and filters before it is sent.
+// Unread threads are never archived, so asking for both matches nothing.
+func (r *GetThreadsRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+	if r.Limit != nil && (*r.Limit < minThreadsLimit || *r.Limit > maxThreadsLimit) {
+		return fmt.Errorf("thread limit %d is outside %d-%d", *r.Limit, minThreadsLimit, maxThreadsLimit)
+	}
+	if r.Offset != nil && *r.Offset < 0 {
+		return fmt.Errorf("thread offset %d is negative", *r.Offset)
+	}
+	if r.Unread != nil && *r.Unread && r.Archived != nil && *r.Archived {
+		return fmt.Errorf("unread and archived thread filters are mutually exclusive")
+	}
+	return nil
+}
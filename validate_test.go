@@ -0,0 +1,108 @@
+package inboundgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestRequestValidation(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if got := r.URL.Query().Get("status"); r.URL.Path == "/domains" && got != "verified" {
+			t.Errorf("Expected status=verified, got %q", got)
+		}
+		w.Write([]byte(`{"data":[],"pagination":{"limit":50,"offset":0,"total":0}}`))
+	}))
+	defer server.Close()
+	client, _ := inboundgo.NewClient("test-api-key", server.URL)
+	ctx := context.Background()
+
+	if _, err := client.Domain().List(ctx, &inboundgo.GetDomainsRequest{Status: inboundgo.DomainStatusVerified}); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+
+	invalid := map[string]func() error{
+		"mail status": func() error {
+			_, err := client.Mail().List(ctx, &inboundgo.GetMailRequest{Status: "done"})
+			return err
+		},
+		"domain status": func() error {
+			_, err := client.Domain().List(ctx, &inboundgo.GetDomainsRequest{Status: "Verified"})
+			return err
+		},
+		"endpoint type": func() error {
+			_, err := client.Endpoint().List(ctx, &inboundgo.GetEndpointsRequest{Type: "slack"})
+			return err
+		},
+		"endpoint name": func() error {
+			_, err := client.Endpoint().Create(ctx, &inboundgo.PostEndpointsRequest{Type: inboundgo.EndpointTypeWebhook})
+			return err
+		},
+		"scheduled email status": func() error {
+			_, err := client.Email().ListScheduled(ctx, &inboundgo.GetScheduledEmailsRequest{Status: "queued"})
+			return err
+		},
+	}
+	for name, call := range invalid {
+		if err := call(); err == nil || !strings.Contains(err.Error(), name) {
+			t.Errorf("Expected an invalid %s error, got %v", name, err)
+		}
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("Invalid requests were sent: %d requests", n)
+	}
+}
+
+func TestEnumValid(t *testing.T) {
+	if !inboundgo.EndpointTypeEmailGroup.Valid() || inboundgo.EndpointType("").Valid() {
+		t.Error("EndpointType.Valid is wrong")
+	}
+	if !inboundgo.ScheduledStatusCancelled.Valid() || inboundgo.ScheduledStatus("canceled").Valid() {
+		t.Error("ScheduledStatus.Valid is wrong")
+	}
+	if !inboundgo.MailStatusProcessed.Valid() || !inboundgo.DomainStatusPending.Valid() {
+		t.Error("Valid rejects known values")
+	}
+}
+
+func TestGetThreadsRequestValidate(t *testing.T) {
+	valid := []*inboundgo.GetThreadsRequest{
+		nil,
+		{},
+		{Limit: inboundgo.Int(100), Offset: inboundgo.Int(0), Unread: inboundgo.Bool(true), Archived: inboundgo.Bool(false)},
+	}
+	for _, r := range valid {
+		if err := r.Validate(); err != nil {
+			t.Errorf("Expected %+v to be valid, got %v", r, err)
+		}
+	}
+	invalid := []*inboundgo.GetThreadsRequest{
+		{Limit: inboundgo.Int(0)},
+		{Limit: inboundgo.Int(101)},
+		{Offset: inboundgo.Int(-1)},
+		{Unread: inboundgo.Bool(true), Archived: inboundgo.Bool(true)},
+	}
+	for _, r := range invalid {
+		if err := r.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", r)
+		}
+	}
+}
+
+func TestGetThreadsFiltersRequest(t *testing.T) {
+	filters := inboundgo.GetThreadsFilters{Search: "invoice", UnreadOnly: inboundgo.Bool(true), Domain: "example.com"}
+	r := filters.Request(25, 50)
+	if *r.Limit != 25 || *r.Offset != 50 || r.Search != "invoice" || !*r.Unread || r.Archived != nil || r.Domain != "example.com" {
+		t.Errorf("Unexpected request %+v", r)
+	}
+	if err := r.Validate(); err != nil {
+		t.Errorf("Request is invalid: %v", err)
+	}
+}
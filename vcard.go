@@ -0,0 +1,114 @@
+package inboundgo
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// VCardContact is a person or organization shared as a vCard, e.g. in an
+// email signature
+type VCardContact struct {
+	// FullName is the display name. It defaults to FirstName and LastName,
+	// then Organization.
+	FullName     string
+	FirstName    string
+	LastName     string
+	Organization string
+	Title        string
+	// Emails and Phones are listed in order of preference.
+	Emails []string
+	Phones []string
+	URL    string
+	Note   string
+}
+
+// VCardFromContact returns the vCard details of an audience contact
+func VCardFromContact(c *Contact) *VCardContact {
+	card := &VCardContact{Emails: []string{c.Email}}
+	if c.FirstName != nil {
+		card.FirstName = *c.FirstName
+	}
+	if c.LastName != nil {
+		card.LastName = *c.LastName
+	}
+	return card
+}
+
+// name returns the FN value, or "" if the contact has no name at all
+func (c *VCardContact) name() string {
+	if name := strings.TrimSpace(c.FullName); name != "" {
+		return name
+	}
+	if name := strings.TrimSpace(c.FirstName + " " + c.LastName); name != "" {
+		return name
+	}
+	return strings.TrimSpace(c.Organization)
+}
+
+// VCard renders the contact as a vCard 4.0 (RFC 6350) object
+func (c *VCardContact) VCard() ([]byte, error) {
+	name := c.name()
+	if name == "" {
+		return nil, errors.New("vCard contact has no name or organization")
+	}
+
+	var b strings.Builder
+	line := func(s string) { writeContentLine(&b, s) }
+	line("BEGIN:VCARD")
+	line("VERSION:4.0")
+	line("PRODID:-//Inbound//inbound-golang-sdk//EN")
+	line("FN:" + escapeContentText(name))
+	if c.FirstName != "" || c.LastName != "" {
+		line("N:" + escapeContentText(c.LastName) + ";" + escapeContentText(c.FirstName) + ";;;")
+	}
+	if c.Organization != "" {
+		line("ORG:" + escapeContentText(c.Organization))
+	}
+	if c.Title != "" {
+		line("TITLE:" + escapeContentText(c.Title))
+	}
+	for i, email := range c.Emails {
+		line("EMAIL" + vcardPref(i) + ":" + escapeContentText(email))
+	}
+	for i, phone := range c.Phones {
+		// tel: URIs allow visual separators but not spaces
+		line("TEL;VALUE=uri" + vcardPref(i) + ":tel:" + strings.Join(strings.Fields(phone), "-"))
+	}
+	if c.URL != "" {
+		line("URL:" + c.URL)
+	}
+	if c.Note != "" {
+		line("NOTE:" + escapeContentText(c.Note))
+	}
+	line("END:VCARD")
+	return []byte(b.String()), nil
+}
+
+// vcardPref marks the first of several values as preferred
+func vcardPref(i int) string {
+	if i == 0 {
+		return ";PREF=1"
+	}
+	return ""
+}
+
+// AttachmentFromVCard returns the contact as a text/vcard attachment named
+// after the contact, which mail clients offer to add to the address book
+func AttachmentFromVCard(contact *VCardContact) (AttachmentData, error) {
+	card, err := contact.VCard()
+	if err != nil {
+		return AttachmentData{}, err
+	}
+	filename := strings.Join(strings.FieldsFunc(contact.name(), func(r rune) bool {
+		return r < ' ' || strings.ContainsRune(`/\:*?"<>|`, r)
+	}), "")
+	if filename == "" {
+		filename = "contact"
+	}
+	return AttachmentData{
+		Content:     String(base64.StdEncoding.EncodeToString(card)),
+		Filename:    filename + ".vcf",
+		ContentType: String("text/vcard; charset=utf-8"),
+	}, nil
+}
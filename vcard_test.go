@@ -0,0 +1,75 @@
+package inboundgo_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestAttachmentFromVCard(t *testing.T) {
+	contact := &inboundgo.VCardContact{
+		FirstName:    "Ada",
+		LastName:     "Lovelace",
+		Organization: "Acme, Inc.",
+		Title:        "Engineer",
+		Emails:       []string{"ada@acme.test", "ada@example.com"},
+		Phones:       []string{"+1 555 0100"},
+		URL:          "https://acme.test",
+		Note:         "Met at\nthe conference",
+	}
+	a, err := inboundgo.AttachmentFromVCard(contact)
+	if err != nil {
+		t.Fatalf("AttachmentFromVCard failed: %v", err)
+	}
+	if a.Filename != "Ada Lovelace.vcf" || *a.ContentType != "text/vcard; charset=utf-8" {
+		t.Errorf("Unexpected attachment %+v", a)
+	}
+	data, err := base64.StdEncoding.DecodeString(*a.Content)
+	if err != nil {
+		t.Fatalf("Invalid attachment content: %v", err)
+	}
+	text := string(data)
+	for _, want := range []string{
+		"BEGIN:VCARD\r\nVERSION:4.0\r\n",
+		"FN:Ada Lovelace\r\n",
+		"N:Lovelace;Ada;;;\r\n",
+		`ORG:Acme\, Inc.` + "\r\n",
+		"TITLE:Engineer\r\n",
+		"EMAIL;PREF=1:ada@acme.test\r\n",
+		"EMAIL:ada@example.com\r\n",
+		"TEL;VALUE=uri;PREF=1:tel:+1-555-0100\r\n",
+		`NOTE:Met at\nthe conference` + "\r\n",
+		"END:VCARD\r\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected vCard to contain %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestVCardRequiresName(t *testing.T) {
+	if _, err := inboundgo.AttachmentFromVCard(&inboundgo.VCardContact{Emails: []string{"ada@acme.test"}}); err == nil {
+		t.Error("Expected error for contact without a name")
+	}
+
+	card, err := (&inboundgo.VCardContact{Organization: "Acme/Support"}).VCard()
+	if err != nil {
+		t.Fatalf("VCard failed: %v", err)
+	}
+	if !strings.Contains(string(card), "FN:Acme/Support\r\n") || strings.Contains(string(card), "\r\nN:") {
+		t.Errorf("Unexpected organization vCard:\n%s", card)
+	}
+	a, _ := inboundgo.AttachmentFromVCard(&inboundgo.VCardContact{Organization: "Acme/Support"})
+	if a.Filename != "AcmeSupport.vcf" {
+		t.Errorf("Expected unsafe characters removed from filename, got %q", a.Filename)
+	}
+}
+
+func TestVCardFromContact(t *testing.T) {
+	card := inboundgo.VCardFromContact(&inboundgo.Contact{Email: "ada@acme.test", FirstName: inboundgo.String("Ada")})
+	if card.FirstName != "Ada" || card.LastName != "" || len(card.Emails) != 1 || card.Emails[0] != "ada@acme.test" {
+		t.Errorf("Unexpected card %+v", card)
+	}
+}
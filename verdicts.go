@@ -0,0 +1,144 @@
+package inboundgo
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// AuthResult is the outcome of an SPF, DKIM, DMARC, spam, or virus check
+type AuthResult string
+
+const (
+	AuthResultPass      AuthResult = "pass"
+	AuthResultFail      AuthResult = "fail"
+	AuthResultSoftFail  AuthResult = "softfail"
+	AuthResultNeutral   AuthResult = "neutral"
+	AuthResultNone      AuthResult = "none"
+	AuthResultTempError AuthResult = "temperror"
+	AuthResultPermError AuthResult = "permerror"
+	// AuthResultGray is reported by spam and virus scanners that could not
+	// reach a verdict
+	AuthResultGray AuthResult = "gray"
+)
+
+// AuthVerdicts are the sender authentication and content checks run on a
+// received email
+type AuthVerdicts struct {
+	SPF   AuthResult `json:"spf"`
+	DKIM  AuthResult `json:"dkim"`
+	DMARC AuthResult `json:"dmarc"`
+	Spam  AuthResult `json:"spam"`
+	Virus AuthResult `json:"virus"`
+	// SpamScore is the numeric spam score, if the scanner reported one
+	SpamScore *float64 `json:"spamScore,omitempty"`
+}
+
+// Suspicious reports whether the email failed DMARC, failed both SPF and
+// DKIM, or was flagged as spam or a virus, which is a reasonable default
+// for quarantining
+func (v AuthVerdicts) Suspicious() bool {
+	return v.DMARC == AuthResultFail ||
+		(v.SPF == AuthResultFail && v.DKIM == AuthResultFail) ||
+		v.Spam == AuthResultFail ||
+		v.Virus == AuthResultFail
+}
+
+// GetVerdicts returns the authentication and spam verdicts for the email,
+// using those provided by the API when present and otherwise parsing the
+// Authentication-Results, Received-SPF, and spam scanner headers
+func (w *WebhookPayload) GetVerdicts() AuthVerdicts {
+	if w.Email.ParsedData.Verdicts != nil {
+		return *w.Email.ParsedData.Verdicts
+	}
+	return parseVerdictHeaders(w.GetHeaders())
+}
+
+// GetVerdicts returns the verdicts provided by the API, with every check
+// reported as none when they are unavailable
+func (r *GetMailByIDResponse) GetVerdicts() AuthVerdicts {
+	if r.Verdicts != nil {
+		return *r.Verdicts
+	}
+	return AuthVerdicts{SPF: AuthResultNone, DKIM: AuthResultNone, DMARC: AuthResultNone, Spam: AuthResultNone, Virus: AuthResultNone}
+}
+
+var (
+	authResultsPattern = regexp.MustCompile(`(?i)\b(spf|dkim|dmarc)\s*=\s*([a-z]+)`)
+	spamScorePattern   = regexp.MustCompile(`(?i)\bscore\s*=\s*(-?\d+(?:\.\d+)?)`)
+)
+
+// parseVerdictHeaders extracts verdicts from the headers added by the
+// receiving server. Only the first Authentication-Results header is used,
+// since later ones may have been added by untrusted hops.
+func parseVerdictHeaders(headers map[string][]string) AuthVerdicts {
+	v := AuthVerdicts{SPF: AuthResultNone, DKIM: AuthResultNone, DMARC: AuthResultNone, Spam: AuthResultNone, Virus: AuthResultNone}
+
+	for _, m := range authResultsPattern.FindAllStringSubmatch(headerValue(headers, "Authentication-Results"), -1) {
+		result := AuthResult(strings.ToLower(m[2]))
+		switch strings.ToLower(m[1]) {
+		case "spf":
+			if v.SPF == AuthResultNone {
+				v.SPF = result
+			}
+		case "dkim":
+			// A message may carry several signatures; one passing is enough
+			if v.DKIM == AuthResultNone || result == AuthResultPass {
+				v.DKIM = result
+			}
+		case "dmarc":
+			if v.DMARC == AuthResultNone {
+				v.DMARC = result
+			}
+		}
+	}
+	if v.SPF == AuthResultNone {
+		if fields := strings.Fields(headerValue(headers, "Received-SPF")); len(fields) > 0 {
+			v.SPF = AuthResult(strings.ToLower(fields[0]))
+		}
+	}
+
+	if s := headerValue(headers, "X-SES-Spam-Verdict"); s != "" {
+		v.Spam = sesVerdict(s)
+	}
+	if s := headerValue(headers, "X-SES-Virus-Verdict"); s != "" {
+		v.Virus = sesVerdict(s)
+	}
+	if s := strings.TrimSpace(headerValue(headers, "X-Spam-Score")); s != "" {
+		if score, err := strconv.ParseFloat(s, 64); err == nil {
+			v.SpamScore = &score
+		}
+	}
+	if status := headerValue(headers, "X-Spam-Status"); status != "" {
+		if v.SpamScore == nil {
+			if m := spamScorePattern.FindStringSubmatch(status); m != nil {
+				if score, err := strconv.ParseFloat(m[1], 64); err == nil {
+					v.SpamScore = &score
+				}
+			}
+		}
+		if v.Spam == AuthResultNone {
+			if strings.HasPrefix(strings.ToLower(strings.TrimSpace(status)), "yes") {
+				v.Spam = AuthResultFail
+			} else {
+				v.Spam = AuthResultPass
+			}
+		}
+	}
+	return v
+}
+
+// sesVerdict maps SES verdict values (PASS, FAIL, GRAY, PROCESSING_FAILED)
+func sesVerdict(s string) AuthResult {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "PASS":
+		return AuthResultPass
+	case "FAIL":
+		return AuthResultFail
+	case "GRAY":
+		return AuthResultGray
+	case "PROCESSING_FAILED":
+		return AuthResultTempError
+	}
+	return AuthResultNone
+}
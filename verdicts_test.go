@@ -0,0 +1,70 @@
+package inboundgo_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestWebhookPayloadGetVerdictsFromHeaders(t *testing.T) {
+	payload := &inboundgo.WebhookPayload{}
+	payload.Email.ParsedData.Headers = map[string]any{
+		"authentication-results": []any{
+			"amazonses.com; spf=pass (spfCheck: domain of example.com designates 1.2.3.4 as permitted sender) smtp.mailfrom=a@example.com; dkim=fail header.i=@old.example.com; dkim=pass header.i=@example.com; dmarc=pass header.from=example.com;",
+			"attacker.example; dmarc=fail",
+		},
+		"x-ses-spam-verdict":  "PASS",
+		"x-ses-virus-verdict": "GRAY",
+		"x-spam-status":       "No, score=1.3 required=5.0",
+	}
+
+	v := payload.GetVerdicts()
+	if v.SPF != inboundgo.AuthResultPass || v.DKIM != inboundgo.AuthResultPass || v.DMARC != inboundgo.AuthResultPass {
+		t.Errorf("Unexpected authentication verdicts: %+v", v)
+	}
+	if v.Spam != inboundgo.AuthResultPass || v.Virus != inboundgo.AuthResultGray {
+		t.Errorf("Unexpected content verdicts: %+v", v)
+	}
+	if v.SpamScore == nil || *v.SpamScore != 1.3 {
+		t.Errorf("Expected spam score 1.3, got %v", v.SpamScore)
+	}
+	if v.Suspicious() {
+		t.Error("Expected message not to be suspicious")
+	}
+}
+
+func TestWebhookPayloadGetVerdictsSuspicious(t *testing.T) {
+	payload := &inboundgo.WebhookPayload{}
+	payload.Email.ParsedData.Headers = map[string]any{
+		"Received-SPF":  "Fail (mx.example.com: domain does not designate 5.6.7.8)",
+		"X-Spam-Score":  "9.8",
+		"X-Spam-Status": "Yes, score=9.8",
+	}
+	v := payload.GetVerdicts()
+	if v.SPF != inboundgo.AuthResultFail || v.DKIM != inboundgo.AuthResultNone || v.Spam != inboundgo.AuthResultFail || *v.SpamScore != 9.8 {
+		t.Errorf("Unexpected verdicts: %+v", v)
+	}
+	if !v.Suspicious() {
+		t.Error("Expected spam to be suspicious")
+	}
+}
+
+func TestGetVerdictsFromAPI(t *testing.T) {
+	var payload inboundgo.WebhookPayload
+	err := json.Unmarshal([]byte(`{"email": {"parsedData": {
+		"headers": {"authentication-results": "x; dmarc=pass"},
+		"verdicts": {"spf": "pass", "dkim": "fail", "dmarc": "fail", "spam": "pass", "virus": "pass", "spamScore": 0.5}
+	}}}`), &payload)
+	if err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if v := payload.GetVerdicts(); v.DMARC != inboundgo.AuthResultFail || !v.Suspicious() {
+		t.Errorf("Expected API verdicts to take precedence, got %+v", v)
+	}
+
+	var mail inboundgo.GetMailByIDResponse
+	if v := mail.GetVerdicts(); v.SPF != inboundgo.AuthResultNone || v.Suspicious() {
+		t.Errorf("Expected none verdicts without API data, got %+v", v)
+	}
+}
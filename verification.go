@@ -0,0 +1,82 @@
+package inboundgo
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// defaultVerificationInterval is used when a poller is given no interval.
+// DNS changes take minutes to propagate, so polling faster only adds load.
+const defaultVerificationInterval = 30 * time.Second
+
+// pollVerification calls check immediately and then every interval until it
+// reports done, returns an error, or ctx ends
+func pollVerification(ctx context.Context, interval time.Duration, check func(context.Context) (bool, error)) error {
+	if interval <= 0 {
+		interval = defaultVerificationInterval
+	}
+	for {
+		done, err := check(ctx)
+		if err != nil || done {
+			return err
+		}
+		if err := sleepContext(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// WaitForTrackingDomain re-verifies the tracking domain every interval
+// until its DNS records check out. It keeps polling while the status is
+// "failed", since records are often still propagating; bound the wait with
+// ctx. The last known state is returned along with any error.
+func (s *DomainService) WaitForTrackingDomain(ctx context.Context, id string, interval time.Duration) (*TrackingDomain, error) {
+	var last *TrackingDomain
+	err := pollVerification(ctx, interval, func(ctx context.Context) (bool, error) {
+		resp, err := s.VerifyTrackingDomain(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		if resp.Error != "" {
+			if isTransientStatus(resp.StatusCode) {
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to verify tracking domain: %s", resp.Error)
+		}
+		last = resp.Data
+		return last != nil && last.Status == "verified", nil
+	})
+	return last, err
+}
+
+// WaitForDomain re-checks the domain's verification every interval until
+// its status is "verified", for waiting on DNS changes after Create. Like
+// WaitForTrackingDomain it keeps polling while verification fails; bound
+// the wait with ctx. The last known state is returned along with any
+// error.
+func (s *DomainService) WaitForDomain(ctx context.Context, id string, interval time.Duration) (*GetDomainByIDResponse, error) {
+	var last *GetDomainByIDResponse
+	err := pollVerification(ctx, interval, func(ctx context.Context) (bool, error) {
+		check, err := s.CheckStatus(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		if check.Error != "" && !isTransientStatus(check.StatusCode) {
+			return false, fmt.Errorf("failed to check domain verification: %s", check.Error)
+		}
+		resp, err := s.Get(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		if resp.Error != "" {
+			if isTransientStatus(resp.StatusCode) {
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to get domain: %s", resp.Error)
+		}
+		last = resp.Data
+		return last != nil && last.Status == DomainStatusVerified, nil
+	})
+	return last, err
+}
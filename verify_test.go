@@ -0,0 +1,52 @@
+package inboundgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestVerifyAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/verify/address" {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("email") {
+		case "user+tag@gmial.com":
+			w.Write([]byte(`{"email": "user+tag@gmial.com", "result": "undeliverable", "validSyntax": true, "hasMx": false,
+				"mailboxStatus": "unknown", "suggestion": "user+tag@gmail.com", "reason": "domain has no MX records"}`))
+		case "support@example.com":
+			w.Write([]byte(`{"email": "support@example.com", "result": "risky", "validSyntax": true, "hasMx": true,
+				"mailboxStatus": "exists", "isRoleAccount": true}`))
+		default:
+			t.Errorf("Unexpected email %q", r.URL.Query().Get("email"))
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	typo, err := client.Verify().Address(ctx, "user+tag@gmial.com")
+	if err != nil || typo.Error != "" {
+		t.Fatalf("Address failed: %v %+v", err, typo)
+	}
+	if !typo.Data.Rejectable() || *typo.Data.Suggestion != "user+tag@gmail.com" {
+		t.Errorf("Unexpected verdict: %+v", typo.Data)
+	}
+
+	role, err := client.Verify().Address(ctx, "support@example.com")
+	if err != nil || role.Error != "" {
+		t.Fatalf("Address failed: %v %+v", err, role)
+	}
+	if role.Data.Rejectable() || !role.Data.IsRoleAccount || role.Data.Result != "risky" {
+		t.Errorf("Unexpected verdict: %+v", role.Data)
+	}
+}
@@ -0,0 +1,228 @@
+package inboundgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// maxWarmupDeferDays bounds how far ahead Warmup looks for capacity
+const maxWarmupDeferDays = 365
+
+// WarmupCurve returns the maximum number of sends allowed on a given day of
+// warmup, where day 0 is the first day
+type WarmupCurve func(day int) int
+
+// WarmupSchedule returns a curve that follows limits day by day and stays at
+// the last limit afterwards
+func WarmupSchedule(limits ...int) WarmupCurve {
+	return func(day int) int {
+		if len(limits) == 0 {
+			return 0
+		}
+		if day >= len(limits) {
+			return limits[len(limits)-1]
+		}
+		return limits[day]
+	}
+}
+
+// LinearWarmup starts at start sends per day and adds step each day up to max
+func LinearWarmup(start, step, max int) WarmupCurve {
+	return func(day int) int {
+		return min(start+step*day, max)
+	}
+}
+
+// ExponentialWarmup starts at start sends per day and multiplies by factor
+// each day up to max
+func ExponentialWarmup(start int, factor float64, max int) WarmupCurve {
+	return func(day int) int {
+		limit := float64(start) * math.Pow(factor, float64(day))
+		if limit >= float64(max) {
+			return max
+		}
+		return int(limit)
+	}
+}
+
+// DefaultWarmupCurve roughly doubles volume every few days over about two
+// weeks, in line with common mailbox provider guidance for new domains and
+// dedicated IPs
+var DefaultWarmupCurve = WarmupSchedule(50, 100, 200, 400, 750, 1000, 1500, 2000, 3000, 5000, 7500, 10000, 15000, 20000, 30000, 50000)
+
+// WarmupStore records how many sends each warmup key used per day. Use a
+// shared implementation (e.g. backed by Redis or a database) when several
+// processes send for the same domain.
+type WarmupStore interface {
+	// Count returns the number of sends reserved for key on day (YYYY-MM-DD).
+	Count(ctx context.Context, key, day string) (int, error)
+	// Reserve atomically claims the next send slot for key on day if fewer
+	// than limit are taken, returning the zero-based slot.
+	Reserve(ctx context.Context, key, day string, limit int) (slot int, ok bool, err error)
+}
+
+// MemoryWarmupStore is an in-process WarmupStore
+type MemoryWarmupStore struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// NewMemoryWarmupStore creates an empty in-process store
+func NewMemoryWarmupStore() *MemoryWarmupStore {
+	return &MemoryWarmupStore{counts: make(map[string]int)}
+}
+
+// Count implements WarmupStore
+func (s *MemoryWarmupStore) Count(ctx context.Context, key, day string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.counts[key+"|"+day], nil
+}
+
+// Reserve implements WarmupStore
+func (s *MemoryWarmupStore) Reserve(ctx context.Context, key, day string, limit int) (int, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k := key + "|" + day
+	n := s.counts[k]
+	if n >= limit {
+		return 0, false, nil
+	}
+	s.counts[k] = n + 1
+	return n, true, nil
+}
+
+// Warmup ramps daily send volume for a new domain or dedicated IP. Sends
+// within the day's limit go out immediately; overflow is deferred through
+// the schedule endpoint to the next day with capacity, spread evenly
+// across that day.
+//
+// Reservations are not released when a send fails, so limits err on the
+// side of sending less.
+type Warmup struct {
+	client *Inbound
+	// Key identifies what is being warmed up, such as a domain or IP.
+	Key string
+	// Start is the first day of warmup.
+	Start time.Time
+	// Curve gives the daily limit (default DefaultWarmupCurve).
+	Curve WarmupCurve
+	// Store tracks daily usage (default: a new MemoryWarmupStore).
+	Store WarmupStore
+	// Location sets day boundaries (default UTC).
+	Location *time.Location
+}
+
+// WarmupResult describes how Warmup handled a send
+type WarmupResult struct {
+	EmailID string
+	// Deferred is true when the email was scheduled for a later day.
+	Deferred bool
+	// Day is the warmup day the send counts against.
+	Day         int
+	ScheduledAt time.Time
+}
+
+// NewWarmup creates a warmup for key that began on start
+func NewWarmup(client *Inbound, key string, start time.Time) *Warmup {
+	return &Warmup{
+		client:   client,
+		Key:      key,
+		Start:    start,
+		Curve:    DefaultWarmupCurve,
+		Store:    NewMemoryWarmupStore(),
+		Location: time.UTC,
+	}
+}
+
+// Limit returns the send limit for the day containing t
+func (w *Warmup) Limit(t time.Time) int {
+	return w.Curve(w.dayIndex(t))
+}
+
+// Remaining returns how many sends are left today
+func (w *Warmup) Remaining(ctx context.Context) (int, error) {
+	now := time.Now()
+	used, err := w.Store.Count(ctx, w.Key, w.dayStart(now).Format(time.DateOnly))
+	if err != nil {
+		return 0, err
+	}
+	return max(w.Limit(now)-used, 0), nil
+}
+
+// Send sends params now if today's limit allows, and otherwise schedules it
+// for the next day with capacity. If params is already scheduled with an
+// RFC 3339 time, capacity is taken from that day onwards.
+func (w *Warmup) Send(ctx context.Context, params *PostEmailsRequest, options *IdempotencyOptions) (*WarmupResult, error) {
+	if params == nil {
+		return nil, errors.New("warmup: params are required")
+	}
+	from := time.Now()
+	if params.ScheduledAt != nil {
+		t, err := time.Parse(time.RFC3339, *params.ScheduledAt)
+		if err != nil {
+			return nil, fmt.Errorf("warmup: scheduled_at must be RFC 3339: %w", err)
+		}
+		if t.After(from) {
+			from = t
+		}
+	}
+
+	day := w.dayStart(from)
+	for i := 0; i <= maxWarmupDeferDays; i++ {
+		limit := w.Limit(day)
+		slot, ok, err := w.Store.Reserve(ctx, w.Key, day.Format(time.DateOnly), limit)
+		if err != nil {
+			return nil, fmt.Errorf("warmup: failed to reserve send: %w", err)
+		}
+		if !ok {
+			day = day.AddDate(0, 0, 1)
+			continue
+		}
+
+		result := &WarmupResult{Day: w.dayIndex(day), Deferred: i > 0}
+		req := *params
+		if result.Deferred {
+			// Spread the day's sends evenly instead of bursting at midnight
+			result.ScheduledAt = day.Add(time.Duration(slot) * 24 * time.Hour / time.Duration(limit))
+			req.ScheduledAt = String(result.ScheduledAt.Format(time.RFC3339))
+			req.Timezone = nil
+		}
+		resp, err := w.client.Email().Send(ctx, &req, options)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Error != "" {
+			return nil, fmt.Errorf("warmup: send failed: %s", resp.Error)
+		}
+		if resp.Data != nil {
+			result.EmailID = resp.Data.ID
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("warmup: no capacity within %d days", maxWarmupDeferDays)
+}
+
+func (w *Warmup) location() *time.Location {
+	if w.Location == nil {
+		return time.UTC
+	}
+	return w.Location
+}
+
+// dayStart returns midnight of the day containing t
+func (w *Warmup) dayStart(t time.Time) time.Time {
+	y, m, d := t.In(w.location()).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, w.location())
+}
+
+// dayIndex returns the warmup day containing t, counting from Start.
+// Rounding absorbs the 23 and 25 hour days around DST changes.
+func (w *Warmup) dayIndex(t time.Time) int {
+	days := math.Round(w.dayStart(t).Sub(w.dayStart(w.Start)).Hours() / 24)
+	return max(int(days), 0)
+}
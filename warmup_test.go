@@ -0,0 +1,102 @@
+package inboundgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestWarmupCurves(t *testing.T) {
+	schedule := inboundgo.WarmupSchedule(10, 20, 40)
+	if schedule(0) != 10 || schedule(2) != 40 || schedule(30) != 40 {
+		t.Errorf("Unexpected schedule: %d %d %d", schedule(0), schedule(2), schedule(30))
+	}
+	linear := inboundgo.LinearWarmup(100, 50, 300)
+	if linear(0) != 100 || linear(3) != 250 || linear(10) != 300 {
+		t.Errorf("Unexpected linear curve: %d %d %d", linear(0), linear(3), linear(10))
+	}
+	exp := inboundgo.ExponentialWarmup(50, 2, 1000)
+	if exp(0) != 50 || exp(3) != 400 || exp(10) != 1000 {
+		t.Errorf("Unexpected exponential curve: %d %d %d", exp(0), exp(3), exp(10))
+	}
+}
+
+func TestWarmupDefersOverflow(t *testing.T) {
+	var scheduled []string
+	immediate := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req inboundgo.PostEmailsRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/emails":
+			immediate++
+			w.Write([]byte(`{"id": "sent"}`))
+		case "/emails/schedule":
+			scheduled = append(scheduled, *req.ScheduledAt)
+			w.Write([]byte(`{"id": "scheduled", "status": "scheduled"}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	now := time.Now().UTC()
+	warmup := inboundgo.NewWarmup(client, "example.com", now)
+	warmup.Curve = inboundgo.WarmupSchedule(2, 3)
+	ctx := context.Background()
+
+	var results []*inboundgo.WarmupResult
+	for i := 0; i < 5; i++ {
+		res, err := warmup.Send(ctx, &inboundgo.PostEmailsRequest{From: "a@example.com", To: "b@example.com", Subject: "hi"}, nil)
+		if err != nil {
+			t.Fatalf("Send %d failed: %v", i, err)
+		}
+		results = append(results, res)
+	}
+
+	if immediate != 2 || len(scheduled) != 3 {
+		t.Fatalf("Expected 2 immediate and 3 scheduled sends, got %d and %d", immediate, len(scheduled))
+	}
+	if results[0].Deferred || !results[2].Deferred || results[2].Day != 1 || results[2].EmailID != "scheduled" {
+		t.Errorf("Unexpected results: %+v %+v", results[0], results[2])
+	}
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	want := []string{
+		tomorrow.Format(time.RFC3339),
+		tomorrow.Add(8 * time.Hour).Format(time.RFC3339),
+		tomorrow.Add(16 * time.Hour).Format(time.RFC3339),
+	}
+	for i := range want {
+		if scheduled[i] != want[i] {
+			t.Errorf("Expected sends spread over %v, got %v", want, scheduled)
+			break
+		}
+	}
+
+	remaining, err := warmup.Remaining(ctx)
+	if err != nil || remaining != 0 {
+		t.Errorf("Expected no remaining sends today, got %d %v", remaining, err)
+	}
+}
+
+func TestWarmupRejectsNaturalLanguageSchedule(t *testing.T) {
+	client, err := inboundgo.NewClient("test-api-key", "http://127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	warmup := inboundgo.NewWarmup(client, "example.com", time.Now())
+	_, err = warmup.Send(context.Background(), &inboundgo.PostEmailsRequest{ScheduledAt: inboundgo.String("tomorrow at 9am")}, nil)
+	if err == nil {
+		t.Error("Expected error for non-RFC 3339 scheduled_at")
+	}
+}
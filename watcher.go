@@ -0,0 +1,317 @@
+package inboundgo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MailWatermark is a MailWatcher's position: the newest receive time
+// emitted, plus the IDs emitted within the lookback window so mail that is
+// listed late is emitted exactly once
+type MailWatermark struct {
+	ReceivedAt time.Time            `json:"receivedAt"`
+	Seen       map[string]time.Time `json:"seen"`
+}
+
+// WatermarkStore persists a MailWatcher's watermark between runs. Load
+// returns a nil watermark when none has been saved.
+type WatermarkStore interface {
+	Load(ctx context.Context) (*MailWatermark, error)
+	Save(ctx context.Context, wm *MailWatermark) error
+}
+
+// MemoryWatermarkStore keeps the watermark in memory, for watchers that do
+// not need to resume after a restart
+type MemoryWatermarkStore struct {
+	mu sync.Mutex
+	wm *MailWatermark
+}
+
+func (s *MemoryWatermarkStore) Load(ctx context.Context) (*MailWatermark, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return cloneWatermark(s.wm), nil
+}
+
+func (s *MemoryWatermarkStore) Save(ctx context.Context, wm *MailWatermark) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.wm = cloneWatermark(wm)
+	return nil
+}
+
+// FileWatermarkStore keeps the watermark in a JSON file, replaced
+// atomically on every save
+type FileWatermarkStore struct {
+	Path string
+}
+
+func (s *FileWatermarkStore) Load(ctx context.Context) (*MailWatermark, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var wm MailWatermark
+	if err := json.Unmarshal(data, &wm); err != nil {
+		return nil, fmt.Errorf("invalid watermark file %s: %w", s.Path, err)
+	}
+	return &wm, nil
+}
+
+func (s *FileWatermarkStore) Save(ctx context.Context, wm *MailWatermark) error {
+	data, err := json.Marshal(wm)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.Path, data)
+}
+
+// writeFileAtomic replaces path with data through a temporary file, so
+// readers never see a partial write
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func cloneWatermark(wm *MailWatermark) *MailWatermark {
+	if wm == nil {
+		return nil
+	}
+	clone := &MailWatermark{ReceivedAt: wm.ReceivedAt, Seen: make(map[string]time.Time, len(wm.Seen))}
+	for id, t := range wm.Seen {
+		clone.Seen[id] = t
+	}
+	return clone
+}
+
+// MailWatcher polls the mail list and emits newly received emails, for
+// environments that cannot receive webhooks. Its watermark is saved after
+// every emitted email, so a restarted watcher continues where it stopped;
+// an email emitted just before a crash may be emitted again.
+type MailWatcher struct {
+	client *Inbound
+	// Store persists the watermark (default in memory).
+	Store WatermarkStore
+	// Filter restricts which emails are watched (nil watches all mail).
+	Filter *GetMailRequest
+	// Interval is the time between polls (default 30s).
+	Interval time.Duration
+	// MaxBackoff caps the wait between polls after errors, which doubles
+	// from Interval (default 5m).
+	MaxBackoff time.Duration
+	// Lookback is how far before the watermark to look for mail that was
+	// listed late (default 5m).
+	Lookback time.Duration
+	// Since is where a watcher without a saved watermark starts; earlier
+	// mail is never emitted. When zero, a new watcher emits only mail
+	// received after its first poll.
+	Since time.Time
+	// OnError, if set, is called with errors that are retried.
+	OnError func(error)
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewMailWatcher creates a watcher that saves its watermark to store
+func NewMailWatcher(client *Inbound, store WatermarkStore) *MailWatcher {
+	if store == nil {
+		store = &MemoryWatermarkStore{}
+	}
+	return &MailWatcher{
+		client:     client,
+		Store:      store,
+		Interval:   30 * time.Second,
+		MaxBackoff: 5 * time.Minute,
+		Lookback:   5 * time.Minute,
+	}
+}
+
+// Watch polls until ctx ends and returns the channel new emails are sent
+// on, oldest first. The channel is closed when watching stops; Err reports
+// why if it was not ctx ending. Authentication and other non-transient
+// errors stop the watcher; transient ones are retried with backoff.
+func (w *MailWatcher) Watch(ctx context.Context) <-chan EmailItem {
+	out := make(chan EmailItem)
+	go func() {
+		defer close(out)
+		if err := w.run(ctx, out); err != nil && ctx.Err() == nil {
+			w.mu.Lock()
+			w.err = err
+			w.mu.Unlock()
+		}
+	}()
+	return out
+}
+
+// Err returns the error that stopped the watcher, if any
+func (w *MailWatcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+func (w *MailWatcher) run(ctx context.Context, out chan<- EmailItem) error {
+	wm, err := w.Store.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load watermark: %w", err)
+	}
+	if wm == nil && !w.Since.IsZero() {
+		wm = &MailWatermark{ReceivedAt: w.Since}
+	}
+	if wm != nil && wm.Seen == nil {
+		wm.Seen = make(map[string]time.Time)
+	}
+
+	delay := w.Interval
+	for {
+		items, err := w.poll(ctx, wm)
+		if err != nil {
+			var apiErr *watcherAPIError
+			if errors.As(err, &apiErr) && !isTransientStatus(apiErr.status) {
+				return err
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if w.OnError != nil {
+				w.OnError(err)
+			}
+			delay = min(delay*2, w.MaxBackoff)
+		} else {
+			delay = w.Interval
+			if wm == nil {
+				// First run: start after the newest mail already there
+				wm = &MailWatermark{Seen: make(map[string]time.Time)}
+				w.advance(wm, items)
+				items = nil
+				if err := w.Store.Save(ctx, wm); err != nil {
+					return fmt.Errorf("failed to save watermark: %w", err)
+				}
+			}
+			for _, item := range items {
+				select {
+				case out <- item:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				w.advance(wm, []EmailItem{item})
+				if err := w.Store.Save(ctx, wm); err != nil {
+					return fmt.Errorf("failed to save watermark: %w", err)
+				}
+			}
+		}
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// watcherAPIError is a failed list request, kept apart from transport
+// errors so its status decides whether to retry
+type watcherAPIError struct {
+	status  int
+	message string
+}
+
+func (e *watcherAPIError) Error() string {
+	return "failed to list emails: " + e.message
+}
+
+// poll returns the emails after the watermark, oldest first. With no
+// watermark it returns the newest page.
+func (w *MailWatcher) poll(ctx context.Context, wm *MailWatermark) ([]EmailItem, error) {
+	params := GetMailRequest{}
+	if w.Filter != nil {
+		params = *w.Filter
+	}
+	if params.Limit == nil {
+		params.Limit = Int(50)
+	}
+	var cutoff time.Time
+	if wm != nil {
+		cutoff = wm.ReceivedAt.Add(-w.Lookback)
+	}
+
+	var items []EmailItem
+	offset := 0
+	for {
+		params.Offset = Int(offset)
+		resp, err := w.client.Mail().List(ctx, &params)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Error != "" {
+			return nil, &watcherAPIError{status: resp.StatusCode, message: resp.Error}
+		}
+		if resp.Data == nil {
+			break
+		}
+		done := wm == nil
+		for _, item := range resp.Data.Emails {
+			if wm != nil && item.ReceivedAt.Before(cutoff) {
+				// The list is newest first, so the rest are older still
+				done = true
+				break
+			}
+			if wm != nil {
+				if _, seen := wm.Seen[item.ID]; seen || item.ReceivedAt.Before(w.Since) {
+					continue
+				}
+			}
+			items = append(items, item)
+		}
+		offset += len(resp.Data.Emails)
+		if done || len(resp.Data.Emails) == 0 || (!resp.Data.Pagination.HasMore && offset >= resp.Data.Pagination.Total) {
+			break
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].ReceivedAt.Before(items[j].ReceivedAt)
+	})
+	return items, nil
+}
+
+// advance records emitted items and forgets IDs that fell out of the
+// lookback window
+func (w *MailWatcher) advance(wm *MailWatermark, items []EmailItem) {
+	for _, item := range items {
+		wm.Seen[item.ID] = item.ReceivedAt
+		if item.ReceivedAt.After(wm.ReceivedAt) {
+			wm.ReceivedAt = item.ReceivedAt
+		}
+	}
+	cutoff := wm.ReceivedAt.Add(-w.Lookback)
+	for id, t := range wm.Seen {
+		if t.Before(cutoff) {
+			delete(wm.Seen, id)
+		}
+	}
+}
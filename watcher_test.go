@@ -0,0 +1,148 @@
+package inboundgo_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+// mailListServer serves /mail from a mutable newest-first list
+type mailListServer struct {
+	mu     sync.Mutex
+	emails []string // JSON objects, newest first
+	fail   []int    // status codes to return on upcoming requests
+}
+
+func (s *mailListServer) add(id string, receivedAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item := fmt.Sprintf(`{"id": %q, "receivedAt": %q}`, id, receivedAt.Format(time.RFC3339))
+	s.emails = append([]string{item}, s.emails...)
+}
+
+func (s *mailListServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	if len(s.fail) > 0 {
+		status := s.fail[0]
+		s.fail = s.fail[1:]
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"error": "status %d"}`, status)
+		return
+	}
+	fmt.Fprintf(w, `{"emails": [%s], "pagination": {"total": %d}}`, strings.Join(s.emails, ","), len(s.emails))
+}
+
+func TestMailWatcher(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	api := &mailListServer{}
+	api.add("old-1", base)
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	store := &inboundgo.FileWatermarkStore{Path: filepath.Join(t.TempDir(), "watermark.json")}
+	watcher := inboundgo.NewMailWatcher(client, store)
+	watcher.Interval = 5 * time.Millisecond
+	var errs []error
+	var errMu sync.Mutex
+	watcher.OnError = func(err error) {
+		errMu.Lock()
+		errs = append(errs, err)
+		errMu.Unlock()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	watchCtx, stop := context.WithCancel(ctx)
+	items := watcher.Watch(watchCtx)
+
+	next := func() string {
+		t.Helper()
+		select {
+		case item := <-items:
+			return item.ID
+		case <-ctx.Done():
+			t.Fatal("Timed out waiting for email")
+		}
+		return ""
+	}
+
+	// Existing mail is not emitted; new mail is, oldest first
+	time.Sleep(20 * time.Millisecond)
+	api.add("new-1", base.Add(time.Minute))
+	api.add("new-2", base.Add(2*time.Minute))
+	if got := next(); got != "new-1" {
+		t.Errorf("Expected new-1, got %s", got)
+	}
+	if got := next(); got != "new-2" {
+		t.Errorf("Expected new-2, got %s", got)
+	}
+
+	// A transient failure is retried, and late-listed mail inside the
+	// lookback window is still emitted once
+	api.mu.Lock()
+	api.fail = []int{http.StatusServiceUnavailable}
+	api.mu.Unlock()
+	api.add("late-1", base.Add(90*time.Second))
+	if got := next(); got != "late-1" {
+		t.Errorf("Expected late-1, got %s", got)
+	}
+	stop()
+	for range items {
+	}
+	errMu.Lock()
+	if len(errs) != 1 {
+		t.Errorf("Expected one retried error, got %v", errs)
+	}
+	errMu.Unlock()
+
+	// A new watcher resumes from the saved watermark
+	wm, err := store.Load(ctx)
+	if err != nil || wm == nil || !wm.ReceivedAt.Equal(base.Add(2*time.Minute)) || len(wm.Seen) != 4 {
+		t.Fatalf("Unexpected saved watermark %+v: %v", wm, err)
+	}
+	api.add("new-3", base.Add(3*time.Minute))
+	resumed := inboundgo.NewMailWatcher(client, store)
+	resumed.Interval = 5 * time.Millisecond
+	watchCtx, stop = context.WithCancel(ctx)
+	items = resumed.Watch(watchCtx)
+	if got := next(); got != "new-3" {
+		t.Errorf("Expected new-3 after resume, got %s", got)
+	}
+	// Wait for the final watermark save before the temp dir is removed
+	stop()
+	for range items {
+	}
+}
+
+func TestMailWatcherStopsOnAuthError(t *testing.T) {
+	api := &mailListServer{fail: []int{http.StatusUnauthorized}}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	client, err := inboundgo.NewClient("test-api-key", server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	watcher := inboundgo.NewMailWatcher(client, nil)
+	watcher.Since = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for range watcher.Watch(context.Background()) {
+		t.Error("Expected no emails")
+	}
+	if err := watcher.Err(); err == nil || !strings.Contains(err.Error(), "status 401") {
+		t.Errorf("Expected auth error, got %v", err)
+	}
+}
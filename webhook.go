@@ -1,11 +1,34 @@
 package inboundgo
 
 import (
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"strings"
 )
 
+// ErrWebhookUnverified is returned when a webhook request does not carry
+// the expected secret
+var ErrWebhookUnverified = errors.New("webhook request could not be verified")
+
+// VerifyWebhookHeader checks that a webhook request carries secret in the
+// named header. Configure the same header on the endpoint with
+// WebhookConfig.Headers so requests from anyone else are rejected. The
+// comparison takes constant time.
+func VerifyWebhookHeader(r *http.Request, name, secret string) error {
+	if secret == "" {
+		return fmt.Errorf("%w: no secret configured", ErrWebhookUnverified)
+	}
+	got := r.Header.Get(name)
+	if subtle.ConstantTimeCompare([]byte(got), []byte(secret)) != 1 {
+		return ErrWebhookUnverified
+	}
+	return nil
+}
+
 // ParseWebhookPayload parses an incoming webhook payload into the WebhookPayload struct
 func ParseWebhookPayload(reader io.Reader) (*WebhookPayload, error) {
 	var payload WebhookPayload
@@ -17,6 +40,16 @@ func ParseWebhookPayload(reader io.Reader) (*WebhookPayload, error) {
 	return &payload, nil
 }
 
+// ParseDeliveryWebhookPayload parses an email.bounced or email.complained
+// webhook payload
+func ParseDeliveryWebhookPayload(reader io.Reader) (*DeliveryWebhookPayload, error) {
+	var payload DeliveryWebhookPayload
+	if err := json.NewDecoder(reader).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("failed to parse delivery webhook payload: %w", err)
+	}
+	return &payload, nil
+}
+
 // GetFromAddress extracts the properly formatted from address from the webhook
 func (w *WebhookPayload) GetFromAddress() string {
 	if w.Email.From != nil && len(w.Email.From.Addresses) > 0 {
@@ -47,10 +80,73 @@ func (w *WebhookPayload) GetToAddress() string {
 	return ""
 }
 
+// GetCcAddresses extracts the properly formatted CC addresses from the
+// webhook
+func (w *WebhookPayload) GetCcAddresses() []string {
+	return groupAddresses(w.Email.ParsedData.Cc)
+}
+
+// GetReplyToAddress extracts the properly formatted Reply-To address from
+// the webhook, or "" if the email has none
+func (w *WebhookPayload) GetReplyToAddress() string {
+	if addresses := groupAddresses(w.Email.ParsedData.ReplyTo); len(addresses) > 0 {
+		return addresses[0]
+	}
+	return ""
+}
+
+// GetAllRecipients returns the bare addresses of every To, CC, and BCC
+// recipient and of the envelope recipient, each once. The envelope
+// recipient is the only trace of a BCC that was not delivered to anyone
+// else.
+func (w *WebhookPayload) GetAllRecipients() []string {
+	var recipients []string
+	seen := make(map[string]bool)
+	add := func(address string) {
+		key := strings.ToLower(strings.TrimSpace(address))
+		if key != "" && !seen[key] {
+			seen[key] = true
+			recipients = append(recipients, strings.TrimSpace(address))
+		}
+	}
+	to := w.Email.ParsedData.To
+	if to == nil {
+		to = w.Email.To
+	}
+	for _, group := range []*WebhookAddressGroup{to, w.Email.ParsedData.Cc, w.Email.ParsedData.Bcc} {
+		if group == nil {
+			continue
+		}
+		for _, addr := range group.Addresses {
+			if addr.Address != nil {
+				add(*addr.Address)
+			}
+		}
+	}
+	add(w.Email.Recipient)
+	return recipients
+}
+
+// GetSenderDomain returns the lowercased domain of the from address, or ""
+// if it has none
+func (w *WebhookPayload) GetSenderDomain() string {
+	if _, domain, ok := splitAddress(w.GetFromAddress()); ok {
+		return domain
+	}
+	return ""
+}
+
 // GetHeaders converts the headers from the webhook format to a standard map[string][]string format
 func (w *WebhookPayload) GetHeaders() map[string][]string {
+	return normalizeHeaders(w.Email.ParsedData.Headers)
+}
+
+// normalizeHeaders converts loosely typed header maps, as returned in webhook
+// payloads and thread messages, to map[string][]string. Values may be
+// strings, string arrays, or objects such as parsed DKIM signatures.
+func normalizeHeaders(raw map[string]any) map[string][]string {
 	headers := make(map[string][]string)
-	for k, v := range w.Email.ParsedData.Headers {
+	for k, v := range raw {
 		switch val := v.(type) {
 		case string:
 			headers[k] = []string{val}
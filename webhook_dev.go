@@ -0,0 +1,219 @@
+package inboundgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WebhookFixture is a recorded webhook delivery, saved as JSON by
+// WebhookRecorder and sent again with ReplayWebhook
+type WebhookFixture struct {
+	Event      string          `json:"event"`
+	ReceivedAt time.Time       `json:"receivedAt"`
+	Headers    http.Header     `json:"headers,omitempty"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// Summary describes the delivery in one line: the sender, recipient, and
+// subject of received mail, or the recipient and reason of bounces and
+// complaints
+func (f *WebhookFixture) Summary() string {
+	switch f.Event {
+	case WebhookEventEmailReceived:
+		var p WebhookPayload
+		if json.Unmarshal(f.Payload, &p) == nil {
+			subject := ""
+			if p.Email.Subject != nil {
+				subject = *p.Email.Subject
+			}
+			return fmt.Sprintf("%s  %s -> %s  %s", f.Event, p.GetFromAddress(), p.Email.Recipient, subject)
+		}
+	case WebhookEventEmailBounced, WebhookEventEmailComplained:
+		var p DeliveryWebhookPayload
+		if json.Unmarshal(f.Payload, &p) == nil {
+			switch {
+			case p.Bounce != nil:
+				return fmt.Sprintf("%s  %s  %s bounce: %s", f.Event, p.Bounce.Recipient, p.Bounce.Type, p.Bounce.Reason)
+			case p.Complaint != nil:
+				feedback := "complaint"
+				if p.Complaint.FeedbackType != nil {
+					feedback = *p.Complaint.FeedbackType
+				}
+				return fmt.Sprintf("%s  %s  %s", f.Event, p.Complaint.Recipient, feedback)
+			}
+		}
+	}
+	return f.Event
+}
+
+// LoadWebhookFixture reads a fixture saved by WebhookRecorder. A file
+// holding just a payload, such as one copied from the webhook docs, is
+// loaded as a fixture with no headers.
+func LoadWebhookFixture(path string) (*WebhookFixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fixture WebhookFixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhookPayload, err)
+	}
+	if len(fixture.Payload) == 0 {
+		fixture = WebhookFixture{Event: fixture.Event, Payload: data}
+	}
+	if fixture.Event == "" {
+		return nil, fmt.Errorf("%w: no event", ErrInvalidWebhookPayload)
+	}
+	return &fixture, nil
+}
+
+// ReplayWebhook POSTs a recorded payload to url with its saved headers, and
+// any in header, for iterating on a webhook handler without waiting for
+// real mail. header can add back secrets that were not saved. The caller
+// must close the response body. client defaults to http.DefaultClient.
+func ReplayWebhook(ctx context.Context, client *http.Client, url string, fixture *WebhookFixture, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(fixture.Payload))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range fixture.Headers {
+		req.Header[name] = append([]string(nil), values...)
+	}
+	for name, values := range header {
+		req.Header[http.CanonicalHeaderKey(name)] = append([]string(nil), values...)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
+
+// unsavedHeaders are request headers that describe the original connection
+// or body encoding rather than the delivery
+var unsavedHeaders = []string{"Authorization", "Connection", "Content-Length", "Content-Type", "Accept-Encoding", "Cookie"}
+
+// WebhookRecorder is a webhook receiver for local development: it verifies
+// each delivery, reports it to OnDelivery, and saves it as a fixture in Dir
+// for tests and for ReplayWebhook. Point an endpoint at it through a tunnel
+// such as ngrok.
+type WebhookRecorder struct {
+	// Verify authenticates requests, e.g. with VerifyWebhookHeader.
+	// Requests are not verified when nil.
+	Verify func(*http.Request) error
+	// Dir is where fixtures are saved. Nothing is saved when it is empty.
+	Dir string
+	// OmitHeaders are not saved, such as the header carrying the webhook
+	// secret. Authorization and cookies are never saved.
+	OmitHeaders []string
+	// OnDelivery is called for each accepted delivery, with the path of its
+	// fixture if one was saved.
+	OnDelivery func(fixture *WebhookFixture, path string)
+	// OnError is called for rejected deliveries and failed saves.
+	OnError func(err error)
+	// MaxBodySize bounds accepted requests (default 32MB).
+	MaxBodySize int64
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+func (rec *WebhookRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if rec.Verify != nil {
+		if err := rec.Verify(r); err != nil {
+			rec.fail(fmt.Errorf("rejected delivery from %s: %w", r.RemoteAddr, err))
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+	data, err := readWebhookBody(w, r, rec.MaxBodySize)
+	if err != nil {
+		rec.fail(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var envelope struct {
+		Event string `json:"event"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil || envelope.Event == "" {
+		err = fmt.Errorf("%w: no event", ErrInvalidWebhookPayload)
+		rec.fail(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	fixture := &WebhookFixture{
+		Event:      envelope.Event,
+		ReceivedAt: rec.now(),
+		Headers:    r.Header.Clone(),
+		Payload:    json.RawMessage(data),
+	}
+	for name := range fixture.Headers {
+		if strings.HasPrefix(name, "Ce-") {
+			delete(fixture.Headers, name)
+		}
+	}
+	for _, name := range append(append([]string(nil), unsavedHeaders...), rec.OmitHeaders...) {
+		fixture.Headers.Del(name)
+	}
+
+	path, err := rec.save(fixture)
+	if err != nil {
+		rec.fail(fmt.Errorf("failed to save fixture: %w", err))
+		http.Error(w, "failed to save fixture", http.StatusInternalServerError)
+		return
+	}
+	if rec.OnDelivery != nil {
+		rec.OnDelivery(fixture, path)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// now returns the current time, later than any returned before so fixture
+// names are unique and sort in arrival order
+func (rec *WebhookRecorder) now() time.Time {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	now := time.Now().UTC()
+	if !now.After(rec.last) {
+		now = rec.last.Add(time.Nanosecond)
+	}
+	rec.last = now
+	return now
+}
+
+// save writes fixture to Dir and returns its path
+func (rec *WebhookRecorder) save(fixture *WebhookFixture) (string, error) {
+	if rec.Dir == "" {
+		return "", nil
+	}
+	if err := os.MkdirAll(rec.Dir, 0o755); err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	name := fixture.ReceivedAt.Format("20060102T150405.000000000Z") + "-" + fixture.Event + ".json"
+	path := filepath.Join(rec.Dir, name)
+	return path, os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+func (rec *WebhookRecorder) fail(err error) {
+	if rec.OnError != nil {
+		rec.OnError(err)
+	}
+}
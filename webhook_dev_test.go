@@ -0,0 +1,104 @@
+package inboundgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+const devPayload = `{"event": "email.received", "timestamp": "2025-01-16T10:00:00Z",
+	"email": {"id": "email_1", "from": {"text": "Jane <jane@example.com>", "addresses": [{"name": "Jane", "address": "jane@example.com"}]},
+	"recipient": "support@example.com", "subject": "Help"}}`
+
+func TestWebhookRecorderAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	var saved []string
+	var rejected int
+	rec := &inboundgo.WebhookRecorder{
+		Verify:      func(r *http.Request) error { return inboundgo.VerifyWebhookHeader(r, "X-Webhook-Secret", "s3cret") },
+		Dir:         dir,
+		OmitHeaders: []string{"X-Webhook-Secret"},
+		OnDelivery:  func(f *inboundgo.WebhookFixture, path string) { saved = append(saved, path) },
+		OnError:     func(err error) { rejected++ },
+	}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	post := func(secret string) int {
+		req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(devPayload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Webhook-Secret", secret)
+		req.Header.Set("X-Inbound-Delivery", "dlv_1")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("POST failed: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	if status := post("wrong"); status != http.StatusUnauthorized || rejected != 1 {
+		t.Errorf("Expected the delivery to be rejected, got %d", status)
+	}
+	if status := post("s3cret"); status != http.StatusNoContent || len(saved) != 1 {
+		t.Fatalf("Expected the delivery to be saved, got %d %v", status, saved)
+	}
+	if filepath.Dir(saved[0]) != dir || !strings.HasSuffix(saved[0], "-email.received.json") {
+		t.Errorf("Unexpected fixture path %s", saved[0])
+	}
+	data, _ := os.ReadFile(saved[0])
+	if strings.Contains(string(data), "s3cret") {
+		t.Errorf("Fixture contains the secret:\n%s", data)
+	}
+
+	fixture, err := inboundgo.LoadWebhookFixture(saved[0])
+	if err != nil {
+		t.Fatalf("LoadWebhookFixture failed: %v", err)
+	}
+	if summary := fixture.Summary(); summary != "email.received  Jane <jane@example.com> -> support@example.com  Help" {
+		t.Errorf("Unexpected summary %q", summary)
+	}
+
+	// Replay into a real handler, adding the secret back
+	var got *inboundgo.WebhookPayload
+	handler := inboundgo.NewWebhookHandler()
+	handler.Verify = func(r *http.Request) error {
+		if r.Header.Get("X-Inbound-Delivery") != "dlv_1" {
+			t.Errorf("Saved header was not replayed")
+		}
+		return inboundgo.VerifyWebhookHeader(r, "X-Webhook-Secret", "s3cret")
+	}
+	handler.OnEmailReceived(func(ctx context.Context, p *inboundgo.WebhookPayload) error {
+		got = p
+		return nil
+	})
+	target := httptest.NewServer(handler)
+	defer target.Close()
+
+	resp, err := inboundgo.ReplayWebhook(context.Background(), nil, target.URL, fixture, http.Header{"X-Webhook-Secret": {"s3cret"}})
+	if err != nil {
+		t.Fatalf("ReplayWebhook failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent || got == nil || got.Email.ID != "email_1" {
+		t.Errorf("Unexpected replay result %d %+v", resp.StatusCode, got)
+	}
+}
+
+func TestLoadWebhookFixturePayloadOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "payload.json")
+	os.WriteFile(path, []byte(devPayload), 0o644)
+
+	fixture, err := inboundgo.LoadWebhookFixture(path)
+	if err != nil {
+		t.Fatalf("LoadWebhookFixture failed: %v", err)
+	}
+	if fixture.Event != "email.received" || string(fixture.Payload) != devPayload {
+		t.Errorf("Unexpected fixture %+v", fixture)
+	}
+}
@@ -0,0 +1,169 @@
+package inboundgo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// Webhook event names
+const (
+	WebhookEventEmailReceived   = "email.received"
+	WebhookEventEmailBounced    = "email.bounced"
+	WebhookEventEmailComplained = "email.complained"
+)
+
+// ErrInvalidWebhookPayload is returned by WebhookHandler.Dispatch for
+// payloads that cannot be decoded; retrying them will not help
+var ErrInvalidWebhookPayload = errors.New("invalid webhook payload")
+
+// WebhookHandler dispatches webhook events to handlers registered by event
+// type. It serves HTTP webhooks directly, and Dispatch lets other transports,
+// such as an SNS subscription or SQS queue, feed it the same payloads.
+type WebhookHandler struct {
+	// Verify authenticates HTTP requests, e.g. with VerifyWebhookHeader.
+	// Requests are not verified when nil.
+	Verify func(*http.Request) error
+	// MaxBodySize bounds accepted HTTP requests (default 32MB).
+	MaxBodySize int64
+
+	received   []func(context.Context, *WebhookPayload) error
+	bounced    []func(context.Context, *DeliveryWebhookPayload) error
+	complained []func(context.Context, *DeliveryWebhookPayload) error
+	other      []func(ctx context.Context, event string, data []byte) error
+}
+
+// NewWebhookHandler creates a handler with no event handlers registered
+func NewWebhookHandler() *WebhookHandler {
+	return &WebhookHandler{}
+}
+
+// OnEmailReceived registers fn for email.received events
+func (h *WebhookHandler) OnEmailReceived(fn func(ctx context.Context, payload *WebhookPayload) error) {
+	h.received = append(h.received, fn)
+}
+
+// OnBounce registers fn for email.bounced events
+func (h *WebhookHandler) OnBounce(fn func(ctx context.Context, payload *DeliveryWebhookPayload) error) {
+	h.bounced = append(h.bounced, fn)
+}
+
+// OnComplaint registers fn for email.complained events
+func (h *WebhookHandler) OnComplaint(fn func(ctx context.Context, payload *DeliveryWebhookPayload) error) {
+	h.complained = append(h.complained, fn)
+}
+
+// OnOther registers fn for events without a typed handler method, with the
+// raw payload
+func (h *WebhookHandler) OnOther(fn func(ctx context.Context, event string, data []byte) error) {
+	h.other = append(h.other, fn)
+}
+
+// Dispatch decodes a webhook payload and runs the handlers registered for
+// its event, in order, stopping at the first error. Events with no
+// handlers are ignored. Malformed payloads return an error wrapping
+// ErrInvalidWebhookPayload.
+func (h *WebhookHandler) Dispatch(ctx context.Context, data []byte) error {
+	var envelope struct {
+		Event string `json:"event"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil || envelope.Event == "" {
+		return fmt.Errorf("%w: no event", ErrInvalidWebhookPayload)
+	}
+
+	switch envelope.Event {
+	case WebhookEventEmailReceived:
+		if len(h.received) == 0 {
+			return nil
+		}
+		var payload WebhookPayload
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidWebhookPayload, err)
+		}
+		for _, fn := range h.received {
+			if err := fn(ctx, &payload); err != nil {
+				return err
+			}
+		}
+	case WebhookEventEmailBounced, WebhookEventEmailComplained:
+		handlers := h.bounced
+		if envelope.Event == WebhookEventEmailComplained {
+			handlers = h.complained
+		}
+		if len(handlers) == 0 {
+			return nil
+		}
+		var payload DeliveryWebhookPayload
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidWebhookPayload, err)
+		}
+		for _, fn := range handlers {
+			if err := fn(ctx, &payload); err != nil {
+				return err
+			}
+		}
+	default:
+		for _, fn := range h.other {
+			if err := fn(ctx, envelope.Event, data); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ServeHTTP verifies and dispatches a webhook request. CloudEvents-wrapped
+// deliveries are unwrapped. It responds 204 on success, 401 to unverified
+// requests, 400 to malformed payloads, and 500 when a handler fails so the
+// webhook is retried.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.Verify != nil {
+		if err := h.Verify(r); err != nil {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+	data, err := readWebhookBody(w, r, h.MaxBodySize)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Dispatch(r.Context(), data); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidWebhookPayload) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// readWebhookBody reads the payload of a webhook request of at most limit
+// bytes (default 32MB), unwrapping CloudEvents-wrapped deliveries
+func readWebhookBody(w http.ResponseWriter, r *http.Request, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		limit = 32 << 20
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, limit)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == cloudEventsContentType || r.Header.Get("Ce-Specversion") != "" {
+		ce, err := ParseCloudEventRequest(r)
+		if err != nil {
+			return nil, err
+		}
+		return ce.DataBytes()
+	}
+	return io.ReadAll(r.Body)
+}
@@ -0,0 +1,108 @@
+package inboundgo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	inboundgo "github.com/inboundemail/inbound-golang-sdk"
+)
+
+func TestWebhookHandlerDispatch(t *testing.T) {
+	var got []string
+	h := inboundgo.NewWebhookHandler()
+	h.OnEmailReceived(func(ctx context.Context, p *inboundgo.WebhookPayload) error {
+		got = append(got, "received:"+p.Email.ID)
+		return nil
+	})
+	h.OnBounce(func(ctx context.Context, p *inboundgo.DeliveryWebhookPayload) error {
+		got = append(got, "bounced:"+p.Bounce.Recipient)
+		return nil
+	})
+	h.OnComplaint(func(ctx context.Context, p *inboundgo.DeliveryWebhookPayload) error {
+		got = append(got, "complained:"+p.Complaint.Recipient)
+		return nil
+	})
+	h.OnOther(func(ctx context.Context, event string, data []byte) error {
+		got = append(got, "other:"+event)
+		return nil
+	})
+
+	ctx := context.Background()
+	payloads := []string{
+		`{"event": "email.received", "email": {"id": "email-1"}}`,
+		`{"event": "email.bounced", "bounce": {"recipient": "a@example.com"}}`,
+		`{"event": "email.complained", "complaint": {"recipient": "b@example.com"}}`,
+		`{"event": "domain.verified"}`,
+	}
+	for _, p := range payloads {
+		if err := h.Dispatch(ctx, []byte(p)); err != nil {
+			t.Fatalf("Dispatch(%s) failed: %v", p, err)
+		}
+	}
+	want := "received:email-1,bounced:a@example.com,complained:b@example.com,other:domain.verified"
+	if strings.Join(got, ",") != want {
+		t.Errorf("Expected %s, got %s", want, strings.Join(got, ","))
+	}
+
+	if err := h.Dispatch(ctx, []byte(`{"email": {}}`)); !errors.Is(err, inboundgo.ErrInvalidWebhookPayload) {
+		t.Errorf("Expected ErrInvalidWebhookPayload, got %v", err)
+	}
+}
+
+func TestWebhookHandlerServeHTTP(t *testing.T) {
+	h := inboundgo.NewWebhookHandler()
+	h.Verify = func(r *http.Request) error {
+		return inboundgo.VerifyWebhookHeader(r, "X-Webhook-Secret", "s3cret")
+	}
+	var ids []string
+	h.OnEmailReceived(func(ctx context.Context, p *inboundgo.WebhookPayload) error {
+		if p.Email.ID == "fail" {
+			return errors.New("handler failed")
+		}
+		ids = append(ids, p.Email.ID)
+		return nil
+	})
+
+	serve := func(body string, header map[string]string) int {
+		req := httptest.NewRequest("POST", "/webhook", strings.NewReader(body))
+		req.Header.Set("X-Webhook-Secret", "s3cret")
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := serve(`{"event": "email.received", "email": {"id": "email-1"}}`, nil); code != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d", code)
+	}
+	if code := serve(`{"event": "email.received", "email": {"id": "email-1"}}`, map[string]string{"X-Webhook-Secret": "wrong"}); code != http.StatusUnauthorized {
+		t.Errorf("Expected 401, got %d", code)
+	}
+	if code := serve(`not json`, nil); code != http.StatusBadRequest {
+		t.Errorf("Expected 400, got %d", code)
+	}
+	if code := serve(`{"event": "email.received", "email": {"id": "fail"}}`, nil); code != http.StatusInternalServerError {
+		t.Errorf("Expected 500, got %d", code)
+	}
+
+	// CloudEvents-wrapped delivery in binary mode
+	code := serve(`{"event": "email.received", "email": {"id": "email-2"}}`, map[string]string{
+		"Content-Type":   "application/json",
+		"Ce-Specversion": "1.0",
+		"Ce-Id":          "1",
+		"Ce-Source":      "https://inbound.new",
+		"Ce-Type":        "new.inbound.email.received",
+	})
+	if code != http.StatusNoContent {
+		t.Errorf("Expected 204 for CloudEvent, got %d", code)
+	}
+	if strings.Join(ids, ",") != "email-1,email-2" {
+		t.Errorf("Unexpected dispatched IDs %v", ids)
+	}
+}
@@ -1,6 +1,8 @@
 package inboundgo
 
 import (
+	"errors"
+	"net/http/httptest"
 	"strings"
 	"testing"
 )
@@ -278,3 +280,69 @@ func TestGetAddressesEmpty(t *testing.T) {
 		t.Errorf("Expected empty to address, got '%s'", toAddr)
 	}
 }
+
+func TestGetRecipientHelpers(t *testing.T) {
+	payload := `{
+  "event": "email.received",
+  "timestamp": "2025-09-16T16:47:50.163Z",
+  "email": {
+    "recipient": "archive@yourdomain.com",
+    "from": {
+      "text": "Jane <Jane@Example.COM>",
+      "addresses": [{"name": "Jane", "address": "Jane@Example.COM"}]
+    },
+    "to": {
+      "text": "support@yourdomain.com",
+      "addresses": [{"name": null, "address": "support@yourdomain.com"}]
+    },
+    "parsedData": {
+      "cc": {
+        "text": "Bob <bob@example.com>, Support@yourdomain.com",
+        "addresses": [{"name": "Bob", "address": "bob@example.com"}, {"name": null, "address": "Support@yourdomain.com"}]
+      },
+      "replyTo": {
+        "text": "Help Desk <help@example.com>",
+        "addresses": [{"name": "Help Desk", "address": "help@example.com"}]
+      },
+      "headers": {}
+    }
+  }
+}`
+
+	webhook, err := ParseWebhookPayload(strings.NewReader(payload))
+	if err != nil {
+		t.Fatalf("Failed to parse webhook payload: %v", err)
+	}
+
+	if cc := webhook.GetCcAddresses(); len(cc) != 2 || cc[0] != "Bob <bob@example.com>" || cc[1] != "Support@yourdomain.com" {
+		t.Errorf("Unexpected CC addresses %v", cc)
+	}
+	if replyTo := webhook.GetReplyToAddress(); replyTo != "Help Desk <help@example.com>" {
+		t.Errorf("Unexpected reply-to address %q", replyTo)
+	}
+	want := []string{"support@yourdomain.com", "bob@example.com", "archive@yourdomain.com"}
+	if got := webhook.GetAllRecipients(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected recipients %v, got %v", want, got)
+	}
+	if domain := webhook.GetSenderDomain(); domain != "example.com" {
+		t.Errorf("Unexpected sender domain %q", domain)
+	}
+}
+
+func TestVerifyWebhookHeader(t *testing.T) {
+	req := httptest.NewRequest("POST", "/webhook", nil)
+	req.Header.Set("X-Webhook-Secret", "s3cret")
+
+	if err := VerifyWebhookHeader(req, "X-Webhook-Secret", "s3cret"); err != nil {
+		t.Errorf("Expected matching secret to verify, got %v", err)
+	}
+	if err := VerifyWebhookHeader(req, "X-Webhook-Secret", "other"); !errors.Is(err, ErrWebhookUnverified) {
+		t.Errorf("Expected ErrWebhookUnverified for wrong secret, got %v", err)
+	}
+	if err := VerifyWebhookHeader(req, "X-Missing", "s3cret"); !errors.Is(err, ErrWebhookUnverified) {
+		t.Errorf("Expected ErrWebhookUnverified for missing header, got %v", err)
+	}
+	if err := VerifyWebhookHeader(req, "X-Webhook-Secret", ""); !errors.Is(err, ErrWebhookUnverified) {
+		t.Errorf("Expected ErrWebhookUnverified without a configured secret, got %v", err)
+	}
+}